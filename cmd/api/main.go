@@ -1,16 +1,22 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"fund-management-api/config"
 	"fund-management-api/controllers"
 	"fund-management-api/middleware"
 	"fund-management-api/monitor"
 	"fund-management-api/routes"
+	"fund-management-api/services"
+	"fund-management-api/storage"
 	"log"
+	"net/http"
 	"os"
-	"path/filepath"
+	"os/signal"
 	"strconv"
+	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -44,6 +50,11 @@ func main() {
 	// Initialize database
 	config.InitDB()
 
+	// Select the file storage backend (STORAGE_BACKEND); a bad S3 setup fails here
+	if err := storage.Init(); err != nil {
+		log.Fatal("Failed to initialize file storage: ", err)
+	}
+
 	// Set Gin mode
 	ginMode := os.Getenv("GIN_MODE")
 	if ginMode == "release" {
@@ -72,6 +83,12 @@ func main() {
 	// Add CORS middleware
 	router.Use(middleware.CORSMiddleware())
 
+	// Reject oversized bodies with 413 (MAX_REQUEST_BODY_MB, MAX_MULTIPART_BODY_MB for uploads)
+	router.Use(middleware.BodySizeLimitMiddleware())
+
+	// Read-only maintenance mode (MAINTENANCE_MODE / admin toggle): blocks writes with 503
+	router.Use(middleware.MaintenanceMiddleware())
+
 	// Optional: Add rate limiting (uncomment in production)
 	// router.Use(middleware.RateLimitMiddleware())
 
@@ -97,10 +114,10 @@ func main() {
 	//   - fund_forms       : blank fund application forms for download
 	//   - import_templates : import templates for download
 	// Personal-data folders (users, merge_submissions) are NOT here -> signed URL only.
-	router.Static("/uploads/email_assets", filepath.Join(uploadPath, "email_assets"))
-	router.Static("/uploads/announcements", filepath.Join(uploadPath, "announcements"))
-	router.Static("/uploads/fund_forms", filepath.Join(uploadPath, "fund_forms"))
-	router.Static("/uploads/import_templates", filepath.Join(uploadPath, "import_templates"))
+	routes.MountPublicUploadFolder(router, "/uploads/email_assets", uploadPath, "email_assets")
+	routes.MountPublicUploadFolder(router, "/uploads/announcements", uploadPath, "announcements")
+	routes.MountPublicUploadFolder(router, "/uploads/fund_forms", uploadPath, "fund_forms")
+	routes.MountPublicUploadFolder(router, "/uploads/import_templates", uploadPath, "import_templates")
 
 	// PROD ACCESS: a reverse proxy that only forwards /api/* to this backend cannot
 	// reach the /uploads/* mounts above, so expose the SAME public folders under
@@ -109,16 +126,33 @@ func main() {
 	// public folders are mounted (personal-data folders are never exposed here).
 	// To move them back behind signed URLs later: delete these four mounts and set
 	// SIGN_PUBLIC_DOCS = true in the frontend (app/lib/file_access.js).
-	router.Static("/api/v1/public/email_assets", filepath.Join(uploadPath, "email_assets"))
-	router.Static("/api/v1/public/announcements", filepath.Join(uploadPath, "announcements"))
-	router.Static("/api/v1/public/fund_forms", filepath.Join(uploadPath, "fund_forms"))
-	router.Static("/api/v1/public/import_templates", filepath.Join(uploadPath, "import_templates"))
+	routes.MountPublicUploadFolder(router, "/api/v1/public/email_assets", uploadPath, "email_assets")
+	routes.MountPublicUploadFolder(router, "/api/v1/public/announcements", uploadPath, "announcements")
+	routes.MountPublicUploadFolder(router, "/api/v1/public/fund_forms", uploadPath, "fund_forms")
+	routes.MountPublicUploadFolder(router, "/api/v1/public/import_templates", uploadPath, "import_templates")
 
 	// Create upload directory if not exists
 	if err := os.MkdirAll(uploadPath, os.ModePerm); err != nil {
 		log.Printf("Warning: Failed to create upload directory: %v", err)
 	}
 
+	// Validate external import credentials without blocking startup; misconfiguration
+	// is logged up front instead of surfacing on the first imported user.
+	go func() {
+		if err := services.ValidateScholarEnvironment(); err != nil {
+			log.Printf("[Startup] Scholar import misconfigured: %v", err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := services.NewScopusIngestService(nil, nil).ValidateCredentials(ctx); err != nil {
+			log.Printf("[Startup] Scopus import misconfigured: %v", err)
+		}
+	}()
+
+	// Form generation: DOCX/PDF request forms are produced by in-process workers
+	// reading persisted jobs (FORM_JOB_WORKERS, FORM_JOB_QUEUE_SIZE, FORM_JOB_MAX_ATTEMPTS)
+	controllers.StartFormJobWorkers()
+
 	// MOU: background scheduler ส่งอีเมลแจ้งเตือน MOU ใกล้หมดอายุ ทำงานทุก NOTIFICATION_INTERVAL_MINUTES (default 1440)
 	go func() {
 		interval := 1440
@@ -145,6 +179,34 @@ func main() {
 		}
 	}()
 
+	// Budget alerts: re-check remaining budgets every BUDGET_ALERT_INTERVAL_MINUTES (default 1440),
+	// catching allocation changes that happen outside the approval flow
+	go func() {
+		interval := 1440
+		if v := os.Getenv("BUDGET_ALERT_INTERVAL_MINUTES"); v != "" {
+			if n, err := strconv.Atoi(v); err == nil && n > 0 {
+				interval = n
+			}
+		}
+		ticker := time.NewTicker(time.Duration(interval) * time.Minute)
+		defer ticker.Stop()
+
+		log.Printf("[Scheduler] Starting budget alert check (interval: %d min)", interval)
+		for {
+			if sent, err := controllers.CheckBudgetAlerts(); err != nil {
+				log.Printf("[Scheduler] budget alert check failed: %v", err)
+			} else if sent > 0 {
+				log.Printf("[Scheduler] sent %d budget alert(s)", sent)
+			}
+			<-ticker.C
+		}
+	}()
+
+	// Publication imports: optional in-process replacement for cron, enabled by
+	// SCHOLAR_IMPORT_CRON / SCOPUS_IMPORT_CRON (intervals SCHOLAR_IMPORT_INTERVAL /
+	// SCOPUS_IMPORT_INTERVAL, default 24h)
+	importScheduler := services.StartImportScheduler(nil)
+
 	// Start server
 	port := os.Getenv("SERVER_PORT")
 	if port == "" {
@@ -171,23 +233,57 @@ func main() {
 		httpsPort = "8443"
 	}
 
-	if certFile == "" || keyFile == "" {
+	httpServer := &http.Server{Addr: httpAddr, Handler: router}
+	servers := []*http.Server{httpServer}
+	var httpsServer *http.Server
+	if certFile != "" && keyFile != "" {
+		httpsServer = &http.Server{Addr: fmt.Sprintf(":%s", httpsPort), Handler: router}
+		servers = append(servers, httpsServer)
+	}
+
+	// On SIGINT/SIGTERM close live log streams so open SSE connections end, stop
+	// accepting requests and let in-flight ones finish, then let a running
+	// scheduled import stop at its next user boundary
+	shutdownDone := make(chan struct{})
+	go func() {
+		stop := make(chan os.Signal, 1)
+		signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+		sig := <-stop
+		log.Printf("Received %s, shutting down", sig)
+		monitor.StopLogStreams()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		for _, server := range servers {
+			if err := server.Shutdown(ctx); err != nil {
+				log.Printf("Server %s did not shut down cleanly: %v", server.Addr, err)
+			}
+		}
+
+		if !importScheduler.Stop(30 * time.Second) {
+			log.Printf("[Scheduler] scheduled import still running at shutdown; its run will be left unfinished")
+		}
+		close(shutdownDone)
+	}()
+
+	if httpsServer == nil {
 		log.Printf("TLS_CERT_FILE or TLS_KEY_FILE not set. HTTPS disabled; running HTTP only.")
-		if err := router.Run(httpAddr); err != nil {
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			log.Fatal("Failed to start HTTP server:", err)
 		}
+		<-shutdownDone
 		return
 	}
 
-	httpsAddr := fmt.Sprintf(":%s", httpsPort)
 	go func() {
-		if err := router.Run(httpAddr); err != nil {
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			log.Printf("HTTP server stopped: %v", err)
 		}
 	}()
 
 	log.Printf("HTTPS API starting on port %s", httpsPort)
-	if err := router.RunTLS(httpsAddr, certFile, keyFile); err != nil {
+	if err := httpsServer.ListenAndServeTLS(certFile, keyFile); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		log.Fatal("Failed to start HTTPS server:", err)
 	}
+	<-shutdownDone
 }
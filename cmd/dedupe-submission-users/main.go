@@ -0,0 +1,40 @@
+// Command dedupe-submission-users removes duplicate submission_users rows and
+// adds missing applicant rows. Run it once before migration 053, which adds
+// the (submission_id, user_id) unique key; the migration backfills applicant
+// rows itself but cannot remove duplicates.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"fund-management-api/config"
+	"fund-management-api/services"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using environment variables")
+	}
+
+	var dryRun bool
+	flag.BoolVar(&dryRun, "dry-run", false, "report what would change without writing to the database")
+	flag.Parse()
+
+	config.InitDB()
+
+	summary, err := services.DedupeSubmissionUsers(config.DB, dryRun)
+	if err != nil {
+		log.Fatalf("submission_users cleanup failed: %v", err)
+	}
+
+	fmt.Printf("Duplicate user/submission pairs: %d\n", summary.DuplicateGroups)
+	fmt.Printf("Duplicate rows removed: %d %v\n", summary.RowsDeleted, summary.DeletedIDs)
+	fmt.Printf("Missing applicant rows added: %d\n", summary.ApplicantsInserted)
+	if dryRun {
+		fmt.Println("Dry run complete. No database changes were made.")
+	}
+}
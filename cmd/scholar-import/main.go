@@ -10,6 +10,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"fund-management-api/config"
 	"fund-management-api/services"
@@ -32,19 +33,34 @@ func main() {
 		dryRun     bool
 		trigger    string
 		lockName   string
+		sinceRaw   string
+		force      bool
 	)
 
 	flag.StringVar(&userIDsRaw, "user-ids", "", "comma-separated list of user IDs to import (optional)")
 	flag.IntVar(&limit, "limit", 0, "maximum number of users to process (optional)")
 	flag.BoolVar(&dryRun, "dry-run", false, "fetch data without writing to the database")
 	flag.StringVar(&trigger, "trigger", "cli", "trigger source label stored in scholar_import_runs")
-	flag.StringVar(&lockName, "lock-name", "scholar_import_job", "MySQL advisory lock name (empty to disable)")
+	flag.StringVar(&lockName, "lock-name", services.ScholarImportLockName, "MySQL advisory lock name (empty to disable)")
+	flag.StringVar(&sinceRaw, "since", os.Getenv("SCHOLAR_IMPORT_MIN_INTERVAL"), "incremental mode: skip users imported within this interval (e.g. 72h, 7d) and unchanged publications")
+	flag.BoolVar(&force, "force", false, "full import of every user, ignoring --since")
 	flag.Parse()
 
 	if limit < 0 {
 		log.Fatal("limit must be greater than or equal to 0")
 	}
 
+	var minInterval time.Duration
+	incremental := false
+	if strings.TrimSpace(sinceRaw) != "" && !force {
+		d, err := services.ParseInterval(sinceRaw)
+		if err != nil || d <= 0 {
+			log.Fatalf("invalid --since '%s': use a positive duration such as 72h or 7d", sinceRaw)
+		}
+		minInterval = d
+		incremental = true
+	}
+
 	var userIDs []uint
 	if strings.TrimSpace(userIDsRaw) != "" {
 		parts := strings.Split(userIDsRaw, ",")
@@ -61,6 +77,10 @@ func main() {
 		}
 	}
 
+	if err := services.ValidateScholarEnvironment(); err != nil {
+		log.Fatalf("scholar import is not configured: %v", err)
+	}
+
 	job := services.NewScholarImportJobService(nil)
 	summary, err := job.RunForAll(context.Background(), &services.ScholarImportAllInput{
 		UserIDs:       userIDs,
@@ -69,6 +89,8 @@ func main() {
 		LockName:      lockName,
 		DryRun:        dryRun,
 		RecordRun:     !dryRun,
+		Incremental:   incremental,
+		MinInterval:   minInterval,
 	})
 	if err != nil {
 		if errors.Is(err, services.ErrScholarImportAlreadyRunning) {
@@ -77,13 +99,18 @@ func main() {
 		log.Fatalf("scholar import failed: %v", err)
 	}
 
-	fmt.Printf("Users processed: %d (errors: %d)\n", summary.UsersProcessed, summary.UsersWithErrors)
-	fmt.Printf("Publications fetched: %d, created: %d, updated: %d, failed: %d\n",
+	fmt.Printf("Users processed: %d (errors: %d, skipped: %d)\n", summary.UsersProcessed, summary.UsersWithErrors, summary.UsersSkipped)
+	fmt.Printf("Publications fetched: %d, created: %d, updated: %d, unchanged: %d, merged: %d, failed: %d\n",
 		summary.PublicationsFetched,
 		summary.PublicationsCreated,
 		summary.PublicationsUpdated,
+		summary.PublicationsUnchanged,
+		summary.PublicationsMerged,
 		summary.PublicationsFailed,
 	)
+	if incremental {
+		fmt.Printf("Incremental mode: users imported within %s were skipped\n", minInterval)
+	}
 
 	if dryRun {
 		fmt.Println("Dry run complete. No database changes were made.")
@@ -8,6 +8,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"fund-management-api/config"
 	"fund-management-api/services"
@@ -42,6 +43,14 @@ func main() {
 	}
 
 	job := services.NewScopusIngestJobService(nil)
+
+	checkCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	err = job.ValidateCredentials(checkCtx)
+	cancel()
+	if err != nil {
+		log.Fatalf("scopus ingest is not configured: %v", err)
+	}
+
 	summary, err := job.RunForAll(context.Background(), &services.ScopusIngestAllInput{
 		UserIDs: userIDs,
 		Limit:   limit,
@@ -50,11 +59,12 @@ func main() {
 		log.Fatalf("scopus ingest failed: %v", err)
 	}
 
-	fmt.Printf("Users processed: %d (errors: %d)\n", summary.UsersProcessed, summary.UsersWithErrors)
-	fmt.Printf("Documents fetched: %d, created: %d, updated: %d, failed: %d\n",
+	fmt.Printf("Users processed: %d (errors: %d, API retries: %d)\n", summary.UsersProcessed, summary.UsersWithErrors, summary.Retries)
+	fmt.Printf("Documents fetched: %d, created: %d, updated: %d, merged: %d, failed: %d\n",
 		summary.DocumentsFetched,
 		summary.DocumentsCreated,
 		summary.DocumentsUpdated,
+		summary.PublicationsMerged,
 		summary.DocumentsFailed,
 	)
 	fmt.Printf("Authors created: %d, updated: %d\n", summary.AuthorsCreated, summary.AuthorsUpdated)
@@ -1,10 +1,16 @@
 package config
 
 import (
+	"errors"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // LogWriter is the writer used for application and database logs.
@@ -15,6 +21,78 @@ func LogFilePath() string {
 	return filepath.Join("logs", "fund-api.log")
 }
 
+// rotatedLogNamePattern matches the active log and its numbered rotations
+// (fund-api.log, fund-api.log.1, fund-api.log.2, ...).
+var rotatedLogNamePattern = regexp.MustCompile(`^fund-api\.log(\.([0-9]{1,4}))?$`)
+
+// ErrInvalidLogFile is returned when a requested log name is not a known log file.
+var ErrInvalidLogFile = errors.New("invalid log file")
+
+// LogFileInfo describes an available (possibly rotated) log file.
+type LogFileInfo struct {
+	Name       string    `json:"name"`
+	Size       int64     `json:"size"`
+	ModifiedAt time.Time `json:"modified_at"`
+	Current    bool      `json:"current"`
+}
+
+// ListLogFiles enumerates the active log and its rotations in the logs directory,
+// newest first (fund-api.log, then .1, .2, ...).
+func ListLogFiles() ([]LogFileInfo, error) {
+	entries, err := os.ReadDir(filepath.Dir(LogFilePath()))
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]LogFileInfo, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !rotatedLogNamePattern.MatchString(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		files = append(files, LogFileInfo{
+			Name:       entry.Name(),
+			Size:       info.Size(),
+			ModifiedAt: info.ModTime(),
+			Current:    entry.Name() == filepath.Base(LogFilePath()),
+		})
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return logRotationIndex(files[i].Name) < logRotationIndex(files[j].Name)
+	})
+	return files, nil
+}
+
+// ResolveLogFilePath maps a log file name to its path inside the logs directory.
+// An empty name resolves to the active log. Only names matching the rotated log
+// pattern are accepted, so no path separators or traversal can reach os.Open.
+func ResolveLogFilePath(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return LogFilePath(), nil
+	}
+	if !rotatedLogNamePattern.MatchString(name) {
+		return "", ErrInvalidLogFile
+	}
+	return filepath.Join(filepath.Dir(LogFilePath()), name), nil
+}
+
+func logRotationIndex(name string) int {
+	match := rotatedLogNamePattern.FindStringSubmatch(name)
+	if len(match) < 3 || match[2] == "" {
+		return 0
+	}
+	index, err := strconv.Atoi(match[2])
+	if err != nil {
+		return 0
+	}
+	return index
+}
+
 // InitLogging prepares the log file and configures the standard logger output.
 func InitLogging() (*os.File, io.Writer) {
 	logPath := filepath.Dir(LogFilePath())
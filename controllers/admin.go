@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -314,3 +315,335 @@ func calculateDirectorySize(dirPath string) int64 {
 	})
 	return size
 }
+
+type adminFileListItem struct {
+	FileID               int       `json:"file_id"`
+	OriginalName         string    `json:"original_name"`
+	StoredPath           string    `json:"stored_path"`
+	FolderType           string    `json:"folder_type"`
+	FileSize             int64     `json:"file_size"`
+	MimeType             string    `json:"mime_type"`
+	UploadedBy           int       `json:"uploaded_by"`
+	UploaderName         string    `json:"uploader_name"`
+	UploaderEmail        string    `json:"uploader_email"`
+	UploadedAt           time.Time `json:"uploaded_at"`
+	SubmissionID         *int      `json:"submission_id,omitempty"`
+	AttachedSubmissionID *int      `json:"attached_submission_id,omitempty"`
+	IsAttached           bool      `json:"is_attached"`
+}
+
+// AdminListFiles lists file_uploads system-wide for storage management.
+// Query: folder_type, uploaded_by, search, sort=size|uploaded_at, order=asc|desc, page, limit
+func AdminListFiles(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if page < 1 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 500 {
+		limit = 500
+	}
+	offset := (page - 1) * limit
+
+	query := config.DB.Table("file_uploads fu").
+		Joins("LEFT JOIN users u ON u.user_id = fu.uploaded_by").
+		Where("fu.delete_at IS NULL")
+
+	if folderType := strings.TrimSpace(c.Query("folder_type")); folderType != "" {
+		query = query.Where("fu.folder_type = ?", folderType)
+	}
+	if uploadedBy, err := strconv.Atoi(c.Query("uploaded_by")); err == nil && uploadedBy > 0 {
+		query = query.Where("fu.uploaded_by = ?", uploadedBy)
+	}
+	if search := strings.TrimSpace(c.Query("search")); search != "" {
+		like := "%" + search + "%"
+		query = query.Where("fu.original_name LIKE ? OR fu.stored_path LIKE ?", like, like)
+	}
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		InternalError(c, "admin files: count", err)
+		return
+	}
+
+	direction := "DESC"
+	if strings.EqualFold(c.Query("order"), "asc") {
+		direction = "ASC"
+	}
+	orderBy := "fu.file_size " + direction
+	if c.DefaultQuery("sort", "size") == "uploaded_at" {
+		orderBy = "fu.uploaded_at " + direction
+	}
+
+	var rows []struct {
+		FileID               int
+		OriginalName         string
+		StoredPath           string
+		FolderType           string
+		FileSize             int64
+		MimeType             string
+		UploadedBy           int
+		UploaderName         string
+		UploaderEmail        string
+		UploadedAt           time.Time
+		SubmissionID         *int
+		AttachedSubmissionID *int
+	}
+	if err := query.
+		Select(`fu.file_id, fu.original_name, fu.stored_path, fu.folder_type, fu.file_size, fu.mime_type,
+			fu.uploaded_by, TRIM(CONCAT(COALESCE(u.user_fname,''),' ',COALESCE(u.user_lname,''))) AS uploader_name,
+			COALESCE(u.email,'') AS uploader_email, fu.uploaded_at, fu.submission_id,
+			(SELECT MIN(sd.submission_id) FROM submission_documents sd WHERE sd.file_id = fu.file_id) AS attached_submission_id`).
+		Order(orderBy).
+		Order("fu.file_id DESC").
+		Offset(offset).Limit(limit).
+		Scan(&rows).Error; err != nil {
+		InternalError(c, "admin files: list", err)
+		return
+	}
+
+	items := make([]adminFileListItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, adminFileListItem{
+			FileID:               row.FileID,
+			OriginalName:         row.OriginalName,
+			StoredPath:           row.StoredPath,
+			FolderType:           row.FolderType,
+			FileSize:             row.FileSize,
+			MimeType:             row.MimeType,
+			UploadedBy:           row.UploadedBy,
+			UploaderName:         strings.TrimSpace(row.UploaderName),
+			UploaderEmail:        row.UploaderEmail,
+			UploadedAt:           row.UploadedAt,
+			SubmissionID:         row.SubmissionID,
+			AttachedSubmissionID: row.AttachedSubmissionID,
+			IsAttached:           row.AttachedSubmissionID != nil,
+		})
+	}
+
+	totalPages := (totalCount + int64(limit) - 1) / int64(limit)
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"files":   items,
+		"pagination": gin.H{
+			"current_page": page,
+			"per_page":     limit,
+			"total_count":  totalCount,
+			"total_pages":  totalPages,
+			"has_next":     page < int(totalPages),
+			"has_prev":     page > 1,
+		},
+	})
+}
+
+type adminFileSubmissionItem struct {
+	SubmissionID        int        `json:"submission_id"`
+	SubmissionNumber    string     `json:"submission_number"`
+	SubmissionType      string     `json:"submission_type"`
+	StatusID            int        `json:"status_id"`
+	StatusName          string     `json:"status_name"`
+	OwnerID             int        `json:"owner_id"`
+	OwnerName           string     `json:"owner_name"`
+	OwnerEmail          string     `json:"owner_email"`
+	DocumentID          int        `json:"document_id"`
+	DocumentTypeName    string     `json:"document_type_name"`
+	AttachedAt          time.Time  `json:"attached_at"`
+	SubmissionDeletedAt *time.Time `json:"submission_deleted_at,omitempty"`
+}
+
+// AdminGetFileSubmissions lists the submissions whose documents point at a file,
+// i.e. the references that make DeleteFile refuse to delete it. Soft-deleted
+// submissions are included because their documents still hold the reference.
+// GET /admin/files/:id/submissions
+func AdminGetFileSubmissions(c *gin.Context) {
+	fileID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || fileID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file id"})
+		return
+	}
+
+	var file models.FileUpload
+	if err := config.DB.Where("file_id = ?", fileID).First(&file).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		return
+	}
+
+	var rows []struct {
+		SubmissionID     int
+		SubmissionNumber string
+		SubmissionType   string
+		StatusID         int
+		StatusName       *string
+		OwnerID          int
+		OwnerName        string
+		OwnerEmail       string
+		DocumentID       int
+		DocumentTypeName *string
+		AttachedAt       time.Time
+		DeletedAt        *time.Time
+	}
+	if err := config.DB.Table("submission_documents sd").
+		Select(`s.submission_id, s.submission_number, s.submission_type, s.status_id, st.status_name,
+			s.user_id AS owner_id, TRIM(CONCAT(COALESCE(u.user_fname,''),' ',COALESCE(u.user_lname,''))) AS owner_name,
+			COALESCE(u.email,'') AS owner_email, sd.document_id, dt.document_type_name,
+			sd.created_at AS attached_at, s.deleted_at`).
+		Joins("JOIN submissions s ON s.submission_id = sd.submission_id").
+		Joins("LEFT JOIN users u ON u.user_id = s.user_id").
+		Joins("LEFT JOIN application_status st ON st.application_status_id = s.status_id").
+		Joins("LEFT JOIN document_types dt ON dt.document_type_id = sd.document_type_id").
+		Where("sd.file_id = ?", fileID).
+		Order("s.submission_id DESC").
+		Order("sd.document_id ASC").
+		Scan(&rows).Error; err != nil {
+		InternalError(c, "admin file submissions", err)
+		return
+	}
+
+	deletable, refCount, err := fileDeletable(config.DB, &file)
+	if err != nil {
+		InternalError(c, "admin file submissions: reference count", err)
+		return
+	}
+
+	items := make([]adminFileSubmissionItem, 0, len(rows))
+	submissionIDs := make(map[int]struct{}, len(rows))
+	for _, row := range rows {
+		item := adminFileSubmissionItem{
+			SubmissionID:        row.SubmissionID,
+			SubmissionNumber:    row.SubmissionNumber,
+			SubmissionType:      row.SubmissionType,
+			StatusID:            row.StatusID,
+			OwnerID:             row.OwnerID,
+			OwnerName:           strings.TrimSpace(row.OwnerName),
+			OwnerEmail:          row.OwnerEmail,
+			DocumentID:          row.DocumentID,
+			AttachedAt:          row.AttachedAt,
+			SubmissionDeletedAt: row.DeletedAt,
+		}
+		if row.StatusName != nil {
+			item.StatusName = *row.StatusName
+		}
+		if row.DocumentTypeName != nil {
+			item.DocumentTypeName = *row.DocumentTypeName
+		}
+		items = append(items, item)
+		submissionIDs[row.SubmissionID] = struct{}{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"file": gin.H{
+			"file_id":       file.FileID,
+			"original_name": file.OriginalName,
+			"uploaded_by":   file.UploadedBy,
+			"deleted":       file.DeleteAt != nil,
+		},
+		"submissions":      items,
+		"reference_count":  refCount,
+		"submission_count": len(submissionIDs),
+		"deletable":        deletable,
+	})
+}
+
+// AdminStorageByUser reports storage used per uploader (file_uploads, excluding
+// soft-deleted rows), largest first. Query: page, limit
+func AdminStorageByUser(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 500 {
+		limit = 500
+	}
+	offset := (page - 1) * limit
+
+	usageSubQuery := config.DB.Table("file_uploads fu").
+		Select("fu.uploaded_by AS user_id, COUNT(*) AS file_count, COALESCE(SUM(fu.file_size),0) AS total_bytes, MAX(fu.uploaded_at) AS last_uploaded_at").
+		Where("fu.delete_at IS NULL").
+		Group("fu.uploaded_by")
+
+	var totalCount int64
+	if err := config.DB.Table("(?) AS usage_by_user", usageSubQuery).Count(&totalCount).Error; err != nil {
+		InternalError(c, "storage by user: count", err)
+		return
+	}
+
+	var totals struct {
+		FileCount  int64
+		TotalBytes int64
+	}
+	if err := config.DB.Table("file_uploads").
+		Select("COUNT(*) AS file_count, COALESCE(SUM(file_size),0) AS total_bytes").
+		Where("delete_at IS NULL").
+		Scan(&totals).Error; err != nil {
+		InternalError(c, "storage by user: totals", err)
+		return
+	}
+
+	var rows []struct {
+		UserID         int
+		UserName       string
+		Email          string
+		FileCount      int64
+		TotalBytes     int64
+		LastUploadedAt *time.Time
+	}
+	if err := config.DB.Table("(?) AS usage_by_user", usageSubQuery).
+		Select(`usage_by_user.user_id,
+            TRIM(CONCAT(COALESCE(u.user_fname,''),' ',COALESCE(u.user_lname,''))) AS user_name,
+            COALESCE(u.email,'') AS email,
+            usage_by_user.file_count,
+            usage_by_user.total_bytes,
+            usage_by_user.last_uploaded_at`).
+		Joins("LEFT JOIN users u ON u.user_id = usage_by_user.user_id").
+		Order("usage_by_user.total_bytes DESC").
+		Order("usage_by_user.user_id ASC").
+		Offset(offset).Limit(limit).
+		Scan(&rows).Error; err != nil {
+		InternalError(c, "storage by user: list", err)
+		return
+	}
+
+	users := make([]gin.H, 0, len(rows))
+	for _, row := range rows {
+		share := 0.0
+		if totals.TotalBytes > 0 {
+			share = float64(row.TotalBytes) / float64(totals.TotalBytes) * 100
+		}
+		users = append(users, gin.H{
+			"user_id":          row.UserID,
+			"user_name":        strings.TrimSpace(row.UserName),
+			"email":            row.Email,
+			"file_count":       row.FileCount,
+			"total_bytes":      row.TotalBytes,
+			"size_mb":          fmt.Sprintf("%.2f", float64(row.TotalBytes)/(1024*1024)),
+			"share_percent":    share,
+			"last_uploaded_at": row.LastUploadedAt,
+		})
+	}
+
+	totalPages := (totalCount + int64(limit) - 1) / int64(limit)
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"users":   users,
+		"totals": gin.H{
+			"file_count":  totals.FileCount,
+			"total_bytes": totals.TotalBytes,
+		},
+		"pagination": gin.H{
+			"current_page": page,
+			"per_page":     limit,
+			"total_count":  totalCount,
+			"total_pages":  totalPages,
+			"has_next":     page < int(totalPages),
+			"has_prev":     page > 1,
+		},
+	})
+}
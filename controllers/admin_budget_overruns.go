@@ -0,0 +1,174 @@
+package controllers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"fund-management-api/config"
+	"fund-management-api/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type budgetApproval struct {
+	SubmissionID     int        `json:"submission_id"`
+	SubmissionNumber string     `json:"submission_number"`
+	SubmissionType   string     `json:"submission_type"`
+	UserID           int        `json:"user_id"`
+	ApplicantName    string     `json:"applicant_name"`
+	SubcategoryID    int        `json:"-"`
+	ApprovedAmount   float64    `json:"approved_amount"`
+	ApprovedAt       *time.Time `json:"approved_at"`
+}
+
+type budgetOverrun struct {
+	budgetApproval
+	CumulativeBefore float64 `json:"cumulative_before"`
+	CumulativeAfter  float64 `json:"cumulative_after"`
+	OverrunAmount    float64 `json:"overrun_amount"`
+}
+
+// findBudgetOverruns walks approvals in decision order keeping a running total
+// and returns every approval that left the total above allocated, with the part
+// of its amount that lies beyond the allocation.
+func findBudgetOverruns(allocated float64, approvals []budgetApproval) ([]budgetOverrun, float64) {
+	overruns := make([]budgetOverrun, 0)
+	running := 0.0
+	for _, approval := range approvals {
+		before := running
+		running += approval.ApprovedAmount
+		if running <= allocated {
+			continue
+		}
+		base := before
+		if base < allocated {
+			base = allocated
+		}
+		overruns = append(overruns, budgetOverrun{
+			budgetApproval:   approval,
+			CumulativeBefore: before,
+			CumulativeAfter:  running,
+			OverrunAmount:    running - base,
+		})
+	}
+	return overruns, running
+}
+
+// GetAdminBudgetOverruns lists, per subcategory, the approved submissions that
+// pushed cumulative approved amounts past the subcategory's overall allocation.
+// GET /admin/budgets/overruns?year_id=
+func GetAdminBudgetOverruns(c *gin.Context) {
+	var yearID int
+	if raw := strings.TrimSpace(c.Query("year_id")); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid year_id"})
+			return
+		}
+		yearID = parsed
+	}
+
+	approvedIDs, err := utils.GetStatusIDsByCodes(utils.StatusCodeApproved, utils.StatusCodeAdminClosed)
+	if err != nil {
+		InternalError(c, "budget overruns: resolve approved statuses", err)
+		return
+	}
+
+	query := config.DB.Table("submissions s").
+		Select(`s.submission_id, s.submission_number, s.submission_type, s.user_id, s.subcategory_id,
+			TRIM(CONCAT(COALESCE(u.user_fname,''),' ',COALESCE(u.user_lname,''))) AS applicant_name,
+			CASE WHEN s.submission_type = 'fund_application' THEN COALESCE(fad.approved_amount,0)
+			     WHEN s.submission_type = 'publication_reward' THEN COALESCE(prd.total_approve_amount, prd.reward_approve_amount, prd.reward_amount, 0)
+			     ELSE 0 END AS approved_amount,
+			COALESCE(s.admin_approved_at, s.approved_at) AS approved_at`).
+		Joins("LEFT JOIN users u ON u.user_id = s.user_id").
+		Joins("LEFT JOIN fund_application_details fad ON fad.submission_id = s.submission_id").
+		Joins("LEFT JOIN publication_reward_details prd ON prd.submission_id = s.submission_id").
+		Where("s.submission_type IN ? AND s.deleted_at IS NULL AND s.subcategory_id IS NOT NULL", []string{"fund_application", "publication_reward"}).
+		Where("s.status_id IN ?", ensureIDs(approvedIDs))
+	if yearID > 0 {
+		query = query.Where("s.year_id = ?", yearID)
+	}
+
+	var approvals []budgetApproval
+	if err := query.
+		Order("s.subcategory_id ASC, COALESCE(s.admin_approved_at, s.approved_at) IS NULL, COALESCE(s.admin_approved_at, s.approved_at) ASC, s.submission_id ASC").
+		Scan(&approvals).Error; err != nil {
+		InternalError(c, "budget overruns: load approvals", err)
+		return
+	}
+
+	bySubcategory := make(map[int][]budgetApproval)
+	subcategoryIDs := make([]int, 0)
+	for _, approval := range approvals {
+		if _, seen := bySubcategory[approval.SubcategoryID]; !seen {
+			subcategoryIDs = append(subcategoryIDs, approval.SubcategoryID)
+		}
+		bySubcategory[approval.SubcategoryID] = append(bySubcategory[approval.SubcategoryID], approval)
+	}
+
+	type subcategoryBudgetRow struct {
+		SubcategoryID   int
+		SubcategoryName string
+		CategoryName    string
+		AllocatedAmount float64
+	}
+	budgets := make(map[int]subcategoryBudgetRow, len(subcategoryIDs))
+	if len(subcategoryIDs) > 0 {
+		var rows []subcategoryBudgetRow
+		if err := config.DB.Table("fund_subcategories fsc").
+			Select("fsc.subcategory_id, fsc.subcategory_name, fc.category_name, COALESCE(sb.allocated_amount,0) AS allocated_amount").
+			Joins("LEFT JOIN fund_categories fc ON fc.category_id = fsc.category_id").
+			Joins("JOIN subcategory_budgets sb ON sb.subcategory_id = fsc.subcategory_id AND sb.record_scope = 'overall' AND sb.delete_at IS NULL").
+			Where("fsc.subcategory_id IN ?", subcategoryIDs).
+			Scan(&rows).Error; err != nil {
+			InternalError(c, "budget overruns: load allocations", err)
+			return
+		}
+		for _, row := range rows {
+			budgets[row.SubcategoryID] = row
+		}
+	}
+
+	results := make([]gin.H, 0)
+	totalOverrun := 0.0
+	for _, subcategoryID := range subcategoryIDs {
+		budget, ok := budgets[subcategoryID]
+		// Without an overall allocation there is no line to cross.
+		if !ok || budget.AllocatedAmount <= 0 {
+			continue
+		}
+
+		overruns, totalApproved := findBudgetOverruns(budget.AllocatedAmount, bySubcategory[subcategoryID])
+		if len(overruns) == 0 {
+			continue
+		}
+
+		overrunAmount := totalApproved - budget.AllocatedAmount
+		totalOverrun += overrunAmount
+		results = append(results, gin.H{
+			"subcategory_id":   subcategoryID,
+			"subcategory_name": budget.SubcategoryName,
+			"category_name":    budget.CategoryName,
+			"allocated_amount": budget.AllocatedAmount,
+			"total_approved":   totalApproved,
+			"overrun_amount":   overrunAmount,
+			"submissions":      overruns,
+		})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i]["overrun_amount"].(float64) > results[j]["overrun_amount"].(float64)
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":       true,
+		"year_id":       yearID,
+		"subcategories": results,
+		"total_count":   len(results),
+		"total_overrun": totalOverrun,
+	})
+}
@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"fund-management-api/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+type adminCategoryCount struct {
+	CategoryID           int     `json:"category_id"`
+	CategoryName         string  `json:"category_name"`
+	TotalApplications    int64   `json:"total_applications"`
+	ApprovedApplications int64   `json:"approved_applications"`
+	ApprovedAmount       float64 `json:"approved_amount"`
+}
+
+// GetAdminCategoryCounts returns each category's submission count, approved
+// count and approved amount for a year: the distribution part of the
+// dashboard's category budgets without loading budgets or subcategories.
+// Drafts are excluded as on the dashboard. Without year_id every year is counted.
+// GET /admin/categories/counts?year_id=
+func GetAdminCategoryCounts(c *gin.Context) {
+	yearID := 0
+	if raw := strings.TrimSpace(c.Query("year_id")); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid year_id"})
+			return
+		}
+		yearID = parsed
+	}
+
+	filter := dashboardFilter{IncludeAll: yearID == 0}
+	if yearID > 0 {
+		filter.YearIDs = []int{yearID}
+	}
+	statusSets := resolveAdminDashboardStatusSets(&filter)
+	approvedIDs := ensureIDs(statusSets.Approved)
+
+	var categoryRows []struct {
+		CategoryID   int
+		CategoryName string
+	}
+	categoryQuery := config.DB.Table("fund_categories fc").
+		Select("fc.category_id, fc.category_name").
+		Where("fc.delete_at IS NULL")
+	if yearID > 0 {
+		categoryQuery = categoryQuery.Where("fc.year_id = ?", yearID)
+	}
+	if err := categoryQuery.Scan(&categoryRows).Error; err != nil {
+		InternalError(c, "category counts: categories", err)
+		return
+	}
+
+	var countRows []struct {
+		CategoryID           *int
+		TotalApplications    int64
+		ApprovedApplications int64
+		ApprovedAmount       float64
+	}
+	countQuery := config.DB.Table("submissions s").
+		Select(`s.category_id,
+            COUNT(*) AS total_applications,
+            SUM(CASE WHEN s.status_id IN ? THEN 1 ELSE 0 END) AS approved_applications,
+            SUM(CASE WHEN s.status_id IN ? THEN CASE
+                     WHEN s.submission_type = 'fund_application' THEN COALESCE(fad.approved_amount,0)
+                     WHEN s.submission_type = 'publication_reward' THEN COALESCE(prd.total_approve_amount, prd.reward_approve_amount, prd.reward_amount, 0)
+                     ELSE 0 END ELSE 0 END) AS approved_amount`, approvedIDs, approvedIDs).
+		Joins("LEFT JOIN fund_application_details fad ON fad.submission_id = s.submission_id").
+		Joins("LEFT JOIN publication_reward_details prd ON prd.submission_id = s.submission_id").
+		Where("s.submission_type IN ? AND s.deleted_at IS NULL", []string{"fund_application", "publication_reward"})
+	countQuery = applyFilterToSubmissions(countQuery, "s", filter)
+	if err := countQuery.Group("s.category_id").Scan(&countRows).Error; err != nil {
+		InternalError(c, "category counts: submissions", err)
+		return
+	}
+
+	byCategory := make(map[int]*adminCategoryCount, len(categoryRows))
+	items := make([]*adminCategoryCount, 0, len(categoryRows))
+	for _, row := range categoryRows {
+		item := &adminCategoryCount{CategoryID: row.CategoryID, CategoryName: row.CategoryName}
+		byCategory[row.CategoryID] = item
+		items = append(items, item)
+	}
+
+	var total adminCategoryCount
+	for _, row := range countRows {
+		categoryID := 0
+		if row.CategoryID != nil {
+			categoryID = *row.CategoryID
+		}
+		item := byCategory[categoryID]
+		if item == nil {
+			item = &adminCategoryCount{CategoryID: categoryID, CategoryName: "ไม่ระบุ"}
+			byCategory[categoryID] = item
+			items = append(items, item)
+		}
+		item.TotalApplications += row.TotalApplications
+		item.ApprovedApplications += row.ApprovedApplications
+		item.ApprovedAmount += row.ApprovedAmount
+
+		total.TotalApplications += row.TotalApplications
+		total.ApprovedApplications += row.ApprovedApplications
+		total.ApprovedAmount += row.ApprovedAmount
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].TotalApplications != items[j].TotalApplications {
+			return items[i].TotalApplications > items[j].TotalApplications
+		}
+		return items[i].CategoryID < items[j].CategoryID
+	})
+	for _, item := range items {
+		item.ApprovedAmount = roundAmount(item.ApprovedAmount)
+	}
+
+	response := gin.H{
+		"success":    true,
+		"categories": items,
+		"totals": gin.H{
+			"total_applications":    total.TotalApplications,
+			"approved_applications": total.ApprovedApplications,
+			"approved_amount":       roundAmount(total.ApprovedAmount),
+		},
+	}
+	if yearID > 0 {
+		response["year_id"] = yearID
+	}
+	c.JSON(http.StatusOK, response)
+}
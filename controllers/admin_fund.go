@@ -4,12 +4,15 @@ package controllers
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"fund-management-api/config"
 	"fund-management-api/models"
+	"fund-management-api/utils"
 	"io"
 	"log"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
@@ -38,6 +41,45 @@ func nullStringToInterface(value sql.NullString) interface{} {
 	return nil
 }
 
+// adminListOrder builds the ORDER BY clause for the admin list endpoints from
+// the sort/dir query parameters. Only keys in columns are accepted, so user
+// input never reaches the SQL; without sort the endpoint's default order is
+// used. An unknown sort key or dir answers 400 listing the allowed values and
+// returns false. The default is appended as a tie-breaker to keep paging stable.
+func adminListOrder(c *gin.Context, columns map[string]string, defaultOrder string) (string, bool) {
+	sortKey := strings.ToLower(strings.TrimSpace(c.Query("sort")))
+	if sortKey == "" {
+		return defaultOrder, true
+	}
+	column, ok := columns[sortKey]
+	if !ok {
+		allowed := make([]string, 0, len(columns))
+		for key := range columns {
+			allowed = append(allowed, key)
+		}
+		sort.Strings(allowed)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":        fmt.Sprintf("Invalid sort %q", sortKey),
+			"allowed_sort": allowed,
+		})
+		return "", false
+	}
+
+	dir := "ASC"
+	switch strings.ToLower(strings.TrimSpace(c.Query("dir"))) {
+	case "", "asc":
+	case "desc":
+		dir = "DESC"
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":       "Invalid dir, use asc or desc",
+			"allowed_dir": []string{"asc", "desc"},
+		})
+		return "", false
+	}
+	return column + " " + dir + ", " + defaultOrder, true
+}
+
 // ===================== FUND CATEGORIES MANAGEMENT =====================
 
 // GetAllCategories - Admin can view all categories
@@ -57,7 +99,17 @@ func GetAllCategories(c *gin.Context) {
 		query = query.Where("year_id = ?", yearID)
 	}
 
-	if err := query.Order("category_id DESC").Find(&categories).Error; err != nil {
+	order, ok := adminListOrder(c, map[string]string{
+		"name":       "category_name",
+		"created_at": "create_at",
+		"updated_at": "update_at",
+		"status":     "status",
+	}, "category_id DESC")
+	if !ok {
+		return
+	}
+
+	if err := query.Order(order).Find(&categories).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch categories"})
 		return
 	}
@@ -387,6 +439,142 @@ func ToggleCategoryStatus(c *gin.Context) {
 	})
 }
 
+// errBulkCategoryStatusRejected rolls back a bulk status change that has
+// failed items.
+var errBulkCategoryStatusRejected = errors.New("bulk category status: some items failed")
+
+// categoryStatusResult is the outcome of one category in BulkUpdateCategoryStatus.
+type categoryStatusResult struct {
+	CategoryID            int    `json:"category_id"`
+	Success               bool   `json:"success"`
+	PreviousStatus        string `json:"previous_status,omitempty"`
+	NewStatus             string `json:"new_status,omitempty"`
+	CascadedSubcategories int64  `json:"cascaded_subcategories"`
+	Error                 string `json:"error,omitempty"`
+}
+
+// maxBulkCategoryStatus bounds one BulkUpdateCategoryStatus request.
+const maxBulkCategoryStatus = 500
+
+// BulkUpdateCategoryStatus sets up to maxBulkCategoryStatus categories to the
+// same status in one transaction. With cascade_subcategories, disabling a category also disables
+// its subcategories. Any failed item rolls back the whole batch; the response
+// lists each category's result either way.
+// POST /admin/categories/bulk-status
+func BulkUpdateCategoryStatus(c *gin.Context) {
+	// Check if user is admin
+	roleID, _ := c.Get("roleID")
+	if roleID.(int) != 3 {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+		return
+	}
+
+	type BulkStatusRequest struct {
+		CategoryIDs          []int  `json:"category_ids" binding:"required"`
+		Status               string `json:"status" binding:"required"`
+		CascadeSubcategories bool   `json:"cascade_subcategories"`
+	}
+
+	var req BulkStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	status := strings.ToLower(strings.TrimSpace(req.Status))
+	if status != "active" && status != "disable" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be active or disable"})
+		return
+	}
+	if len(req.CategoryIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No category_ids provided"})
+		return
+	}
+	if len(req.CategoryIDs) > maxBulkCategoryStatus {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("At most %d categories can be updated at once", maxBulkCategoryStatus)})
+		return
+	}
+
+	categoryIDs := make([]int, 0, len(req.CategoryIDs))
+	seen := make(map[int]bool, len(req.CategoryIDs))
+	for _, id := range req.CategoryIDs {
+		if !seen[id] {
+			seen[id] = true
+			categoryIDs = append(categoryIDs, id)
+		}
+	}
+
+	results := make([]categoryStatusResult, 0, len(categoryIDs))
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		failed := false
+		now := time.Now()
+		for _, id := range categoryIDs {
+			result := categoryStatusResult{CategoryID: id}
+
+			var category models.FundCategory
+			if err := tx.Where("category_id = ? AND delete_at IS NULL", id).First(&category).Error; err != nil {
+				if !errors.Is(err, gorm.ErrRecordNotFound) {
+					return err
+				}
+				result.Error = "Category not found"
+				results = append(results, result)
+				failed = true
+				continue
+			}
+
+			result.PreviousStatus = category.Status
+			result.NewStatus = status
+			if err := tx.Model(&category).Updates(map[string]interface{}{
+				"status":    status,
+				"update_at": &now,
+			}).Error; err != nil {
+				return err
+			}
+
+			if status == "disable" && req.CascadeSubcategories {
+				update := tx.Model(&models.FundSubcategory{}).
+					Where("category_id = ? AND delete_at IS NULL AND status <> ?", id, status).
+					Updates(map[string]interface{}{"status": status, "update_at": &now})
+				if update.Error != nil {
+					return update.Error
+				}
+				result.CascadedSubcategories = update.RowsAffected
+			}
+
+			result.Success = true
+			results = append(results, result)
+		}
+		if failed {
+			return errBulkCategoryStatusRejected
+		}
+		return nil
+	})
+
+	if errors.Is(err, errBulkCategoryStatusRejected) {
+		for i := range results {
+			results[i].Success = false
+			results[i].CascadedSubcategories = 0
+		}
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"success": false,
+			"error":   "Some categories could not be updated; no changes were applied",
+			"results": results,
+		})
+		return
+	}
+	if err != nil {
+		InternalError(c, "bulk category status", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":         true,
+		"message":         fmt.Sprintf("Category status changed to %s", status),
+		"results":         results,
+		"total_processed": len(results),
+	})
+}
+
 // ===================== FUND SUBCATEGORIES MANAGEMENT =====================
 
 // GetAllSubcategories - Admin can view all subcategories
@@ -441,7 +629,17 @@ func GetAllSubcategories(c *gin.Context) {
 		args = append(args, categoryID)
 	}
 
-	baseQuery += " ORDER BY fs.subcategory_id DESC"
+	order, ok := adminListOrder(c, map[string]string{
+		"name":          "fs.subcategory_name",
+		"category_name": "fc.category_name",
+		"created_at":    "fs.create_at",
+		"updated_at":    "fs.update_at",
+		"status":        "fs.status",
+	}, "fs.subcategory_id DESC")
+	if !ok {
+		return
+	}
+	baseQuery += " ORDER BY " + order
 
 	// Execute query
 	rows, err := config.DB.Raw(baseQuery, args...).Rows()
@@ -541,6 +739,8 @@ func CreateSubcategory(c *gin.Context) {
 		FundCondition   string   `json:"fund_condition"`
 		TargetRoles     []string `json:"target_roles"`
 		Comment         string   `json:"comment"`
+
+		EnforceBudgetLimits bool `json:"enforce_budget_limits"`
 	}
 
 	var req CreateSubcategoryRequest
@@ -549,6 +749,10 @@ func CreateSubcategory(c *gin.Context) {
 		return
 	}
 
+	if rejectOversizedText(c, "fund_subcategories", utils.TextField{Column: "fund_condition", Value: req.FundCondition}) {
+		return
+	}
+
 	// Validate category exists
 	var category models.FundCategory
 	if err := config.DB.Where("category_id = ? AND delete_at IS NULL", req.CategoryID).
@@ -609,6 +813,8 @@ func CreateSubcategory(c *gin.Context) {
 		Comment:         comment,
 		CreateAt:        &now,
 		UpdateAt:        &now,
+
+		EnforceBudgetLimits: req.EnforceBudgetLimits,
 	}
 
 	if err := config.DB.Create(&subcategory).Error; err != nil {
@@ -642,6 +848,8 @@ func UpdateSubcategory(c *gin.Context) {
 		TargetRoles     []string `json:"target_roles"`
 		Status          string   `json:"status"`
 		Comment         string   `json:"comment"`
+
+		EnforceBudgetLimits *bool `json:"enforce_budget_limits"`
 	}
 
 	var req UpdateSubcategoryRequest
@@ -650,6 +858,10 @@ func UpdateSubcategory(c *gin.Context) {
 		return
 	}
 
+	if rejectOversizedText(c, "fund_subcategories", utils.TextField{Column: "fund_condition", Value: req.FundCondition}) {
+		return
+	}
+
 	// Find subcategory
 	var subcategory models.FundSubcategory
 	if err := config.DB.Where("subcategory_id = ? AND delete_at IS NULL", subcategoryID).
@@ -721,6 +933,9 @@ func UpdateSubcategory(c *gin.Context) {
 	if req.Comment != "" {
 		updates["comment"] = req.Comment
 	}
+	if req.EnforceBudgetLimits != nil {
+		updates["enforce_budget_limits"] = *req.EnforceBudgetLimits
+	}
 
 	// Handle target_roles
 	if req.TargetRoles != nil {
@@ -916,7 +1131,11 @@ func ToggleSubcategoryStatus(c *gin.Context) {
 
 // ===================== BULK OPERATIONS =====================
 
-// BulkUpdateSubcategoryRoles - Admin bulk updates target_roles for multiple subcategories
+// maxBulkSubcategoryRoles bounds one BulkUpdateSubcategoryRoles request.
+const maxBulkSubcategoryRoles = 500
+
+// BulkUpdateSubcategoryRoles - Admin bulk updates target_roles for up to
+// maxBulkSubcategoryRoles subcategories
 func BulkUpdateSubcategoryRoles(c *gin.Context) {
 	// Check if user is admin
 	roleID, _ := c.Get("roleID")
@@ -944,6 +1163,10 @@ func BulkUpdateSubcategoryRoles(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "No updates provided"})
 		return
 	}
+	if len(req.Updates) > maxBulkSubcategoryRoles {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("At most %d subcategories can be updated at once", maxBulkSubcategoryRoles)})
+		return
+	}
 
 	// Process bulk updates
 	successCount := 0
@@ -1010,8 +1233,18 @@ func GetAllYears(c *gin.Context) {
 
 	var years []models.Year
 
+	order, ok := adminListOrder(c, map[string]string{
+		"name":       "year",
+		"year":       "year",
+		"created_at": "create_at",
+		"status":     "status",
+	}, "year_id DESC")
+	if !ok {
+		return
+	}
+
 	// Get all years (including inactive ones for admin)
-	if err := config.DB.Where("delete_at IS NULL").Order("year_id DESC").Find(&years).Error; err != nil {
+	if err := config.DB.Where("delete_at IS NULL").Order(order).Find(&years).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch years"})
 		return
 	}
@@ -1417,7 +1650,19 @@ func GetAllSubcategoryBudgets(c *gin.Context) {
 		args = append(args, recordScope)
 	}
 
-	baseQuery += " ORDER BY sb.subcategory_budget_id DESC"
+	order, ok := adminListOrder(c, map[string]string{
+		"name":             "fs.subcategory_name",
+		"category_name":    "fc.category_name",
+		"allocated_amount": "sb.allocated_amount",
+		"remaining_budget": "sb.remaining_budget",
+		"created_at":       "sb.create_at",
+		"updated_at":       "sb.update_at",
+		"status":           "sb.status",
+	}, "sb.subcategory_budget_id DESC")
+	if !ok {
+		return
+	}
+	baseQuery += " ORDER BY " + order
 
 	// Execute query
 	rows, err := config.DB.Raw(baseQuery, args...).Rows()
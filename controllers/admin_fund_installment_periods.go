@@ -18,6 +18,8 @@ import (
 
 var activeInstallmentStatuses = []string{"active", "enabled", "open", "current"}
 
+var errInstallmentTargetNotEmpty = errors.New("target year already has installment periods")
+
 type fundSelection struct {
 	Level         string
 	Keyword       string
@@ -54,6 +56,7 @@ type adminFundInstallmentPeriodResponse struct {
 	YearID              int     `json:"year_id"`
 	InstallmentNumber   int     `json:"installment_number"`
 	CutoffDate          string  `json:"cutoff_date"`
+	GraceDays           int     `json:"grace_days"`
 	Name                *string `json:"name,omitempty"`
 	Status              string  `json:"status"`
 	Remark              *string `json:"remark,omitempty"`
@@ -83,6 +86,7 @@ type adminFundInstallmentPeriodRequest struct {
 	YearID            *int    `json:"year_id"`
 	InstallmentNumber *int    `json:"installment_number"`
 	CutoffDate        *string `json:"cutoff_date"`
+	GraceDays         *int    `json:"grace_days"`
 	Name              *string `json:"name"`
 	Status            *string `json:"status"`
 	Remark            *string `json:"remark"`
@@ -243,6 +247,11 @@ func AdminCreateFundInstallmentPeriod(c *gin.Context) {
 		return
 	}
 
+	if req.GraceDays != nil && *req.GraceDays < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "grace_days must not be negative"})
+		return
+	}
+
 	normalizedStatus, statusErr := normalizeInstallmentStatus(req.Status)
 	if statusErr != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": statusErr.Error()})
@@ -272,6 +281,9 @@ func AdminCreateFundInstallmentPeriod(c *gin.Context) {
 		InstallmentNumber: *req.InstallmentNumber,
 		CutoffDate:        cutoffDate,
 	}
+	if req.GraceDays != nil {
+		period.GraceDays = *req.GraceDays
+	}
 
 	if req.Name != nil {
 		name := strings.TrimSpace(*req.Name)
@@ -391,6 +403,15 @@ func AdminUpdateFundInstallmentPeriod(c *gin.Context) {
 		period.CutoffDate = parsed
 	}
 
+	if req.GraceDays != nil {
+		if *req.GraceDays < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "grace_days must not be negative"})
+			return
+		}
+		updates["grace_days"] = *req.GraceDays
+		period.GraceDays = *req.GraceDays
+	}
+
 	normalizedStatus, statusErr := normalizeInstallmentStatus(req.Status)
 	if statusErr != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": statusErr.Error()})
@@ -740,6 +761,7 @@ func AdminCopyFundInstallmentPeriods(c *gin.Context) {
 			YearID:            targetYear.YearID,
 			InstallmentNumber: period.InstallmentNumber,
 			CutoffDate:        cutoff,
+			GraceDays:         period.GraceDays,
 			CreatedAt:         currentTime,
 			UpdatedAt:         currentTime,
 		}
@@ -813,6 +835,113 @@ func AdminCopyFundInstallmentPeriods(c *gin.Context) {
 	})
 }
 
+// AdminCloneYearInstallmentPeriods copies every installment period of a source year
+// (all fund selections) into a target year whose schedule is still empty. Cutoff
+// dates are shifted by one year unless offset_years/offset_days are supplied.
+func AdminCloneYearInstallmentPeriods(c *gin.Context) {
+	var req struct {
+		SourceYearID int  `json:"source_year_id" binding:"required"`
+		TargetYearID int  `json:"target_year_id" binding:"required"`
+		OffsetYears  *int `json:"offset_years"`
+		OffsetDays   int  `json:"offset_days"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "source_year_id and target_year_id are required"})
+		return
+	}
+	if req.SourceYearID <= 0 || req.TargetYearID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "source_year_id and target_year_id must be greater than 0"})
+		return
+	}
+	if req.SourceYearID == req.TargetYearID {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "source and target year must differ"})
+		return
+	}
+
+	offsetYears := 1
+	if req.OffsetYears != nil {
+		offsetYears = *req.OffsetYears
+	}
+
+	for _, yearID := range []int{req.SourceYearID, req.TargetYearID} {
+		if err := ensureYearExists(yearID); err != nil {
+			respondYearLookupError(c, err)
+			return
+		}
+	}
+
+	createdCount := 0
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		if err := tx.Model(&models.FundInstallmentPeriod{}).
+			Where("year_id = ? AND deleted_at IS NULL", req.TargetYearID).
+			Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return errInstallmentTargetNotEmpty
+		}
+
+		var sourcePeriods []models.FundInstallmentPeriod
+		if err := tx.Where("year_id = ? AND deleted_at IS NULL", req.SourceYearID).
+			Order("fund_level ASC, fund_keyword ASC, installment_number ASC").
+			Find(&sourcePeriods).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for _, period := range sourcePeriods {
+			selection := selectionFromPeriod(period)
+			if err := purgeSoftDeletedInstallmentPeriods(tx, selection, req.TargetYearID, period.InstallmentNumber); err != nil {
+				return err
+			}
+
+			cutoff := period.CutoffDate
+			if !cutoff.IsZero() {
+				cutoff = cutoff.AddDate(offsetYears, 0, req.OffsetDays)
+			}
+
+			newPeriod := models.FundInstallmentPeriod{
+				FundLevel:         period.FundLevel,
+				FundKeyword:       period.FundKeyword,
+				FundParentKeyword: period.FundParentKeyword,
+				YearID:            req.TargetYearID,
+				InstallmentNumber: period.InstallmentNumber,
+				CutoffDate:        cutoff,
+				GraceDays:         period.GraceDays,
+				Name:              period.Name,
+				Status:            period.Status,
+				Remark:            period.Remark,
+				CreatedAt:         now,
+				UpdatedAt:         now,
+			}
+			if err := tx.Create(&newPeriod).Error; err != nil {
+				return err
+			}
+			createdCount++
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, errInstallmentTargetNotEmpty) {
+			c.JSON(http.StatusConflict, gin.H{"success": false, "error": err.Error()})
+			return
+		}
+		InternalError(c, "fund_installment_periods", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":        true,
+		"message":        fmt.Sprintf("copied %d installment periods", createdCount),
+		"source_year_id": req.SourceYearID,
+		"target_year_id": req.TargetYearID,
+		"offset_years":   offsetYears,
+		"offset_days":    req.OffsetDays,
+		"created":        createdCount,
+	})
+}
+
 func newAdminFundInstallmentPeriodResponse(period models.FundInstallmentPeriod) adminFundInstallmentPeriodResponse {
 	cutoff := ""
 	if !period.CutoffDate.IsZero() {
@@ -848,6 +977,7 @@ func newAdminFundInstallmentPeriodResponse(period models.FundInstallmentPeriod)
 		YearID:              period.YearID,
 		InstallmentNumber:   period.InstallmentNumber,
 		CutoffDate:          cutoff,
+		GraceDays:           period.GraceDays,
 		Name:                period.Name,
 		Status:              status,
 		Remark:              period.Remark,
@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAdminListOrder(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	columns := map[string]string{"name": "category_name", "status": "status"}
+
+	for _, tc := range []struct {
+		query     string
+		wantOrder string
+		wantBody  string
+	}{
+		{"", "category_id DESC", ""},
+		{"?sort=Name", "category_name ASC, category_id DESC", ""},
+		{"?sort=status&dir=DESC", "status DESC, category_id DESC", ""},
+		{"?sort=amount", "", `"allowed_sort":["name","status"]`},
+		{"?sort=name&dir=sideways", "", `"allowed_dir":["asc","desc"]`},
+	} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/admin/categories"+tc.query, nil)
+
+		order, ok := adminListOrder(c, columns, "category_id DESC")
+		if tc.wantBody == "" {
+			if !ok || order != tc.wantOrder {
+				t.Errorf("%q: got (%q, %v), want (%q, true)", tc.query, order, ok, tc.wantOrder)
+			}
+			continue
+		}
+		if ok {
+			t.Errorf("%q: expected the request to be rejected, got order %q", tc.query, order)
+			continue
+		}
+		if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), tc.wantBody) {
+			t.Errorf("%q: got %d %s, want 400 containing %s", tc.query, w.Code, w.Body.String(), tc.wantBody)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"fund-management-api/config"
 	"fund-management-api/models"
+	"fund-management-api/storage"
 	"fund-management-api/utils"
 	"io"
 	"net/http"
@@ -45,19 +46,36 @@ func AdminImportUsers(c *gin.Context) {
 	}
 
 	uploadDir := "uploads/import_runs/users"
-	if err := os.MkdirAll(uploadDir, 0755); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "ไม่สามารถสร้างโฟลเดอร์อัปโหลดได้"})
+	store := storage.Default()
+	ctx := c.Request.Context()
+	safeName, err := uniqueStorageFilename(ctx, store, uploadDir, header.Filename)
+	if err != nil {
+		InternalError(c, "user import: unique name", err)
 		return
 	}
 
-	safeName := utils.GenerateUniqueFilename(uploadDir, header.Filename)
-	dstPath := filepath.Join(uploadDir, safeName)
-	if err := c.SaveUploadedFile(header, dstPath); err != nil {
+	key, err := saveUploadedFileToStorage(ctx, store, header, filepath.Join(uploadDir, safeName), ct)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "ไม่สามารถบันทึกไฟล์นำเข้าได้"})
 		return
 	}
 
-	rows, err := readXLSXRows(dstPath)
+	// The XLSX reader needs a file on disk; non-local backends are copied
+	// into a scratch directory that is removed afterwards.
+	workDir, err := os.MkdirTemp("", "user-import-*")
+	if err != nil {
+		InternalError(c, "user import: work dir", err)
+		return
+	}
+	defer os.RemoveAll(workDir)
+
+	localPath, err := localCopyOfStoredFile(ctx, store, key, workDir)
+	if err != nil {
+		InternalError(c, "user import: read stored file", err)
+		return
+	}
+
+	rows, err := readXLSXRows(localPath)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "อ่านข้อมูลจากไฟล์ไม่สำเร็จ"})
 		return
@@ -219,16 +237,17 @@ func AdminImportLegacySubmissions(c *gin.Context) {
 		return
 	}
 
+	// Kept for offline processing, so it goes to shared storage.
 	uploadDir := "uploads/import_runs/legacy_submissions"
-	if err := os.MkdirAll(uploadDir, 0755); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "ไม่สามารถสร้างโฟลเดอร์อัปโหลดได้"})
+	store := storage.Default()
+	ctx := c.Request.Context()
+	safeName, err := uniqueStorageFilename(ctx, store, uploadDir, header.Filename)
+	if err != nil {
+		InternalError(c, "legacy submission import: unique name", err)
 		return
 	}
 
-	safeName := utils.GenerateUniqueFilename(uploadDir, header.Filename)
-	dstPath := filepath.Join(uploadDir, safeName)
-
-	if err := c.SaveUploadedFile(header, dstPath); err != nil {
+	if _, err := saveUploadedFileToStorage(ctx, store, header, filepath.Join(uploadDir, safeName), ct); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "ไม่สามารถบันทึกไฟล์นำเข้าได้"})
 		return
 	}
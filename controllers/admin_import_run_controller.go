@@ -0,0 +1,322 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"fund-management-api/config"
+	"fund-management-api/models"
+	"fund-management-api/services"
+	"fund-management-api/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// importRunStatus normalises the per-table run status to running/completed/failed.
+func importRunStatus(status string) string {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "running":
+		return "running"
+	case "success", "completed":
+		return "completed"
+	default:
+		return strings.ToLower(strings.TrimSpace(status))
+	}
+}
+
+func importRunDuration(startedAt time.Time, finishedAt *time.Time) float64 {
+	end := time.Now()
+	if finishedAt != nil {
+		end = *finishedAt
+	}
+	return end.Sub(startedAt).Seconds()
+}
+
+// importRunErrorSummaryLength caps the error text shown per run in the list;
+// the detail endpoint returns it in full.
+const importRunErrorSummaryLength = 200
+
+// importRunListItem is one scholar or Scopus run in the combined run history.
+// Fetched/created/updated/failed are publications for scholar runs and
+// documents for Scopus runs.
+type importRunListItem struct {
+	Source          string     `json:"source"`
+	ID              uint64     `json:"id"`
+	TriggerSource   *string    `json:"trigger_source"`
+	Status          string     `json:"status"`
+	RawStatus       string     `json:"raw_status"`
+	StartedAt       time.Time  `json:"started_at"`
+	FinishedAt      *time.Time `json:"finished_at"`
+	DurationSeconds float64    `json:"duration_seconds"`
+	Fetched         int        `json:"fetched"`
+	Created         int        `json:"created"`
+	Updated         int        `json:"updated"`
+	Merged          int        `json:"merged"`
+	Failed          int        `json:"failed"`
+	ErrorMessage    *string    `json:"error_summary"`
+}
+
+// importRunErrorSummary shortens a run's error message for the run list.
+func importRunErrorSummary(message string) string {
+	runes := []rune(strings.TrimSpace(message))
+	if len(runes) <= importRunErrorSummaryLength {
+		return string(runes)
+	}
+	return string(runes[:importRunErrorSummaryLength]) + "…"
+}
+
+// importRunHistoryQuery selects the scholar and/or Scopus runs started in
+// [from, to) as one result set of importRunListItem columns. An empty source
+// includes both.
+func importRunHistoryQuery(db *gorm.DB, source string, from, to *time.Time) *gorm.DB {
+	filter := func(q *gorm.DB) *gorm.DB {
+		if from != nil {
+			q = q.Where("started_at >= ?", *from)
+		}
+		if to != nil {
+			q = q.Where("started_at < ?", *to)
+		}
+		return q
+	}
+
+	scholar := filter(db.Model(&models.ScholarImportRun{}).
+		Select(`'scholar' AS source, id, trigger_source, status AS raw_status, started_at, finished_at,
+			publications_fetched AS fetched, publications_created AS created, publications_updated AS updated,
+			publications_merged AS merged, publications_failed AS failed, error_message`))
+	scopus := filter(db.Model(&models.ScopusBatchImportRun{}).
+		Select(`'scopus' AS source, id, NULL AS trigger_source, status AS raw_status, started_at, finished_at,
+			documents_fetched AS fetched, documents_created AS created, documents_updated AS updated,
+			publications_merged AS merged, documents_failed AS failed, error_message`))
+
+	switch source {
+	case services.ImportRunSourceScholar:
+		return db.Table("(?) AS runs", scholar)
+	case services.ImportRunSourceScopus:
+		return db.Table("(?) AS runs", scopus)
+	default:
+		return db.Table("(? UNION ALL ?) AS runs", scholar, scopus)
+	}
+}
+
+// parseImportRunDateRange reads date_from/date_to (YYYY-MM-DD, inclusive) as
+// a half-open range in the app's time zone.
+func parseImportRunDateRange(dateFrom, dateTo string) (from, to *time.Time, err error) {
+	if dateFrom = strings.TrimSpace(dateFrom); dateFrom != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", dateFrom, utils.AppLocation())
+		if err != nil {
+			return nil, nil, errors.New("date_from must be YYYY-MM-DD")
+		}
+		from = &parsed
+	}
+	if dateTo = strings.TrimSpace(dateTo); dateTo != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", dateTo, utils.AppLocation())
+		if err != nil {
+			return nil, nil, errors.New("date_to must be YYYY-MM-DD")
+		}
+		end := parsed.AddDate(0, 0, 1)
+		to = &end
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, nil, errors.New("date_from must not be after date_to")
+	}
+	return from, to, nil
+}
+
+// AdminListImportRuns lists recent scholar and Scopus batch import runs, newest
+// first, with their counts, duration and a shortened error message.
+// GET /api/v1/admin/import-runs?source=scholar|scopus&date_from=&date_to=&page=&per_page=
+func AdminListImportRuns(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	perPage, _ := strconv.Atoi(c.DefaultQuery("per_page", "20"))
+
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+
+	source := strings.ToLower(strings.TrimSpace(c.Query("source")))
+	switch source {
+	case "", services.ImportRunSourceScholar, services.ImportRunSourceScopus:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "source must be scholar or scopus"})
+		return
+	}
+	from, to, err := parseImportRunDateRange(c.Query("date_from"), c.Query("date_to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": err.Error()})
+		return
+	}
+
+	var total int64
+	if err := importRunHistoryQuery(config.DB, source, from, to).Count(&total).Error; err != nil {
+		InternalError(c, "import_run", err)
+		return
+	}
+
+	runs := []importRunListItem{}
+	offset := (page - 1) * perPage
+	if err := importRunHistoryQuery(config.DB, source, from, to).
+		Order("started_at DESC, source ASC, id DESC").
+		Offset(offset).Limit(perPage).
+		Scan(&runs).Error; err != nil {
+		InternalError(c, "import_run", err)
+		return
+	}
+	for i := range runs {
+		runs[i].Status = importRunStatus(runs[i].RawStatus)
+		runs[i].DurationSeconds = importRunDuration(runs[i].StartedAt, runs[i].FinishedAt)
+		if msg := runs[i].ErrorMessage; msg != nil {
+			summary := importRunErrorSummary(*msg)
+			runs[i].ErrorMessage = &summary
+		}
+	}
+
+	pagination := gin.H{
+		"current_page": page,
+		"per_page":     perPage,
+		"total_count":  total,
+		"total_pages":  int((total + int64(perPage) - 1) / int64(perPage)),
+		"has_next":     int64(offset+perPage) < total,
+		"has_prev":     page > 1,
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": runs, "pagination": pagination})
+}
+
+// GET /api/v1/admin/import-runs/:id?source=scholar|scopus
+func AdminGetImportRun(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "invalid run id"})
+		return
+	}
+
+	source := strings.ToLower(strings.TrimSpace(c.DefaultQuery("source", "scholar")))
+	switch source {
+	case "scholar":
+		var run models.ScholarImportRun
+		if err := config.DB.Where("id = ?", id).First(&run).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "import run not found"})
+				return
+			}
+			InternalError(c, "import_run", err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"success": true, "data": gin.H{
+			"id":               run.ID,
+			"source":           source,
+			"status":           importRunStatus(run.Status),
+			"raw_status":       run.Status,
+			"trigger_source":   run.TriggerSource,
+			"started_at":       run.StartedAt,
+			"finished_at":      run.FinishedAt,
+			"duration_seconds": importRunDuration(run.StartedAt, run.FinishedAt),
+			"error_message":    run.ErrorMessage,
+			"counters": gin.H{
+				"users_processed":      run.UsersProcessed,
+				"users_with_errors":    run.UsersWithErrors,
+				"publications_fetched": run.PublicationsFetched,
+				"publications_created": run.PublicationsCreated,
+				"publications_updated": run.PublicationsUpdated,
+				"publications_merged":  run.PublicationsMerged,
+				"publications_failed":  run.PublicationsFailed,
+			},
+		}})
+	case "scopus":
+		var run models.ScopusBatchImportRun
+		if err := config.DB.Where("id = ?", id).First(&run).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "import run not found"})
+				return
+			}
+			InternalError(c, "import_run", err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"success": true, "data": gin.H{
+			"id":               run.ID,
+			"source":           source,
+			"status":           importRunStatus(run.Status),
+			"raw_status":       run.Status,
+			"started_at":       run.StartedAt,
+			"finished_at":      run.FinishedAt,
+			"duration_seconds": importRunDuration(run.StartedAt, run.FinishedAt),
+			"error_message":    run.ErrorMessage,
+			"counters": gin.H{
+				"users_processed":      run.UsersProcessed,
+				"users_with_errors":    run.UsersWithErrors,
+				"documents_fetched":    run.DocumentsFetched,
+				"documents_created":    run.DocumentsCreated,
+				"documents_updated":    run.DocumentsUpdated,
+				"documents_failed":     run.DocumentsFailed,
+				"publications_merged":  run.PublicationsMerged,
+				"authors_created":      run.AuthorsCreated,
+				"authors_updated":      run.AuthorsUpdated,
+				"affiliations_created": run.AffiliationsCreated,
+				"affiliations_updated": run.AffiliationsUpdated,
+				"links_inserted":       run.LinksInserted,
+				"links_updated":        run.LinksUpdated,
+			},
+		}})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "source must be scholar or scopus"})
+	}
+}
+
+// POST /api/v1/admin/import-runs/:id/cancel?source=scholar|scopus
+// The job stops at the next user boundary and marks the run cancelled.
+func AdminCancelImportRun(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "invalid run id"})
+		return
+	}
+
+	source := strings.ToLower(strings.TrimSpace(c.DefaultQuery("source", services.ImportRunSourceScholar)))
+	var status string
+	switch source {
+	case services.ImportRunSourceScholar:
+		var run models.ScholarImportRun
+		err = config.DB.Select("id, status").Where("id = ?", id).First(&run).Error
+		status = run.Status
+	case services.ImportRunSourceScopus:
+		var run models.ScopusBatchImportRun
+		err = config.DB.Select("id, status").Where("id = ?", id).First(&run).Error
+		status = run.Status
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "source must be scholar or scopus"})
+		return
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "import run not found"})
+			return
+		}
+		InternalError(c, "import_run", err)
+		return
+	}
+
+	if importRunStatus(status) != "running" {
+		c.JSON(http.StatusConflict, gin.H{"success": false, "error": "import run is not running", "status": importRunStatus(status)})
+		return
+	}
+
+	if !services.CancelImportRun(source, id) {
+		// Runs started by a CLI or another API instance cannot be signalled from here.
+		c.JSON(http.StatusConflict, gin.H{"success": false, "error": "import run is not active on this server"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"message": "cancellation requested; the run stops after the current user",
+	})
+}
@@ -0,0 +1,36 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestParseImportRunDateRange(t *testing.T) {
+	from, to, err := parseImportRunDateRange("2026-10-01", "2026-10-15")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := to.Sub(*from).Hours(); got != 15*24 {
+		t.Fatalf("expected date_to to include the whole day, got a %v hour range", got)
+	}
+
+	if from, to, err := parseImportRunDateRange("", ""); err != nil || from != nil || to != nil {
+		t.Fatalf("expected an open range, got %v %v %v", from, to, err)
+	}
+	for _, tc := range [][2]string{{"2026/10/01", ""}, {"", "yesterday"}, {"2026-10-16", "2026-10-15"}} {
+		if _, _, err := parseImportRunDateRange(tc[0], tc[1]); err == nil {
+			t.Fatalf("expected %q..%q to be rejected", tc[0], tc[1])
+		}
+	}
+}
+
+func TestImportRunErrorSummary(t *testing.T) {
+	if got := importRunErrorSummary("  quota exceeded \n"); got != "quota exceeded" {
+		t.Fatalf("unexpected summary %q", got)
+	}
+	long := importRunErrorSummary(strings.Repeat("ก", importRunErrorSummaryLength+50))
+	if n := utf8.RuneCountInString(long); n != importRunErrorSummaryLength+1 || !strings.HasSuffix(long, "…") {
+		t.Fatalf("expected a %d rune summary ending in an ellipsis, got %d runes", importRunErrorSummaryLength+1, n)
+	}
+}
@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"fund-management-api/config"
+	"fund-management-api/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type installmentRecomputeChange struct {
+	SubmissionID     int    `json:"submission_id"`
+	SubmissionNumber string `json:"submission_number"`
+	OldInstallment   *int   `json:"old_installment"`
+	NewInstallment   *int   `json:"new_installment"`
+}
+
+// AdminRecomputeSubmissionInstallments re-resolves installment_number_at_submit for
+// every submitted submission of a year against the current installment periods.
+// Runs as a dry run unless dry_run=false is passed explicitly.
+func AdminRecomputeSubmissionInstallments(c *gin.Context) {
+	yearID, err := strconv.Atoi(strings.TrimSpace(c.Query("year_id")))
+	if err != nil || yearID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "year_id is required"})
+		return
+	}
+
+	dryRun := true
+	if raw := strings.TrimSpace(c.Query("dry_run")); raw != "" {
+		parsed, parseErr := strconv.ParseBool(raw)
+		if parseErr != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "dry_run must be true or false"})
+			return
+		}
+		dryRun = parsed
+	}
+
+	adminID := c.GetInt("userID")
+
+	var submissions []models.Submission
+	if err := config.DB.
+		Where("year_id = ? AND deleted_at IS NULL AND submitted_at IS NOT NULL", yearID).
+		Order("submission_id ASC").
+		Find(&submissions).Error; err != nil {
+		InternalError(c, "recompute installments: load submissions", err)
+		return
+	}
+
+	changes := make([]installmentRecomputeChange, 0)
+	for _, submission := range submissions {
+		resolved, err := determineSubmissionInstallmentNumber(config.DB, submission, *submission.SubmittedAt)
+		if err != nil {
+			InternalError(c, "recompute installments: resolve installment", err)
+			return
+		}
+		if resolved == nil || sameInstallmentNumber(submission.InstallmentNumberAtSubmit, resolved) {
+			continue
+		}
+		changes = append(changes, installmentRecomputeChange{
+			SubmissionID:     submission.SubmissionID,
+			SubmissionNumber: submission.SubmissionNumber,
+			OldInstallment:   submission.InstallmentNumberAtSubmit,
+			NewInstallment:   resolved,
+		})
+	}
+
+	if !dryRun && len(changes) > 0 {
+		now := time.Now()
+		userAgent := c.GetHeader("User-Agent")
+		err := config.DB.Transaction(func(tx *gorm.DB) error {
+			for i := range changes {
+				change := changes[i]
+				if err := tx.Model(&models.Submission{}).
+					Where("submission_id = ?", change.SubmissionID).
+					Updates(map[string]interface{}{
+						"installment_number_at_submit": *change.NewInstallment,
+						"updated_at":                   now,
+					}).Error; err != nil {
+					return err
+				}
+
+				oldJSON, _ := json.Marshal(map[string]interface{}{"installment_number_at_submit": change.OldInstallment})
+				newJSON, _ := json.Marshal(map[string]interface{}{"installment_number_at_submit": change.NewInstallment})
+				oldValues := string(oldJSON)
+				newValues := string(newJSON)
+				changedFields := "installment_number_at_submit"
+				desc := fmt.Sprintf("Recomputed installment for submission %s", change.SubmissionNumber)
+				if err := tx.Create(&models.AuditLog{
+					UserID:        adminID,
+					Action:        "update",
+					EntityType:    "submission",
+					EntityID:      &change.SubmissionID,
+					EntityNumber:  &change.SubmissionNumber,
+					ChangedFields: &changedFields,
+					OldValues:     &oldValues,
+					NewValues:     &newValues,
+					Description:   &desc,
+					IPAddress:     c.ClientIP(),
+					UserAgent:     &userAgent,
+					CreatedAt:     now,
+				}).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			InternalError(c, "recompute installments: apply changes", err)
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":       true,
+		"dry_run":       dryRun,
+		"year_id":       yearID,
+		"scanned_count": len(submissions),
+		"changed_count": len(changes),
+		"changes":       changes,
+	})
+}
+
+func sameInstallmentNumber(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
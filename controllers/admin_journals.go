@@ -0,0 +1,454 @@
+package controllers
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"fund-management-api/config"
+	"fund-management-api/models"
+	"fund-management-api/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// maxJournalImportErrors caps the per-row errors echoed back by the CSV import.
+const maxJournalImportErrors = 200
+
+var journalQuartiles = map[string]bool{"Q1": true, "Q2": true, "Q3": true, "Q4": true}
+
+func requireAdminForJournals(c *gin.Context) bool {
+	if c.GetInt("roleID") != 3 {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+		return false
+	}
+	return true
+}
+
+type journalRequest struct {
+	JournalName string `json:"journal_name" binding:"required"`
+	ISSN        string `json:"issn"`
+	EISSN       string `json:"eissn"`
+	Quartile    string `json:"quartile"`
+	Indexing    string `json:"indexing"`
+	MetricYear  *int   `json:"metric_year"`
+	Source      string `json:"source"`
+}
+
+// validateJournalRequest trims and normalises the request in place. ISSNs are
+// stored as NNNN-NNNN and quartiles upper-cased.
+func validateJournalRequest(req *journalRequest) error {
+	req.JournalName = strings.TrimSpace(req.JournalName)
+	if req.JournalName == "" {
+		return errors.New("journal_name is required")
+	}
+	for _, issn := range []*string{&req.ISSN, &req.EISSN} {
+		raw := strings.TrimSpace(*issn)
+		if raw == "" {
+			*issn = ""
+			continue
+		}
+		normalized := utils.NormalizeISSN(raw)
+		if normalized == "" {
+			return fmt.Errorf("invalid ISSN %q", raw)
+		}
+		*issn = normalized
+	}
+	req.Quartile = strings.ToUpper(strings.TrimSpace(req.Quartile))
+	if req.Quartile != "" && !journalQuartiles[req.Quartile] {
+		return errors.New("quartile must be one of Q1, Q2, Q3, Q4")
+	}
+	req.Indexing = strings.TrimSpace(req.Indexing)
+	req.Source = strings.TrimSpace(req.Source)
+	if req.MetricYear != nil && (*req.MetricYear < 1900 || *req.MetricYear > 3000) {
+		return errors.New("metric_year is out of range")
+	}
+	return nil
+}
+
+func (req journalRequest) columns() map[string]interface{} {
+	return map[string]interface{}{
+		"journal_name": req.JournalName,
+		"issn":         optionalString(req.ISSN),
+		"eissn":        optionalString(req.EISSN),
+		"quartile":     optionalString(req.Quartile),
+		"indexing":     optionalString(req.Indexing),
+		"metric_year":  req.MetricYear,
+		"source":       optionalString(req.Source),
+	}
+}
+
+// findJournalEntry returns the live row for the same journal and metric year,
+// matched by either ISSN or, when the request has none, by exact name.
+func findJournalEntry(db *gorm.DB, req journalRequest, excludeID int) (*models.Journal, error) {
+	query := db.Where("delete_at IS NULL")
+	if req.MetricYear != nil {
+		query = query.Where("metric_year = ?", *req.MetricYear)
+	} else {
+		query = query.Where("metric_year IS NULL")
+	}
+
+	var issns []string
+	for _, issn := range []string{req.ISSN, req.EISSN} {
+		if issn != "" {
+			issns = append(issns, issn)
+		}
+	}
+	if len(issns) > 0 {
+		query = query.Where("(issn IN ? OR eissn IN ?)", issns, issns)
+	} else {
+		query = query.Where("journal_name = ?", req.JournalName)
+	}
+	if excludeID > 0 {
+		query = query.Where("journal_id <> ?", excludeID)
+	}
+
+	var journal models.Journal
+	if err := query.Order("journal_id ASC").First(&journal).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &journal, nil
+}
+
+// GetAdminJournals lists the journal reference table.
+// GET /admin/journals?q=&quartile=&metric_year=&page=&limit=
+func GetAdminJournals(c *gin.Context) {
+	if !requireAdminForJournals(c) {
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if page < 1 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 500 {
+		limit = 500
+	}
+	offset := (page - 1) * limit
+
+	query := config.DB.Model(&models.Journal{}).Where("delete_at IS NULL")
+	if q := strings.TrimSpace(c.Query("q")); q != "" {
+		like := "%" + q + "%"
+		if issn := utils.NormalizeISSN(q); issn != "" {
+			query = query.Where("(journal_name LIKE ? OR issn = ? OR eissn = ?)", like, issn, issn)
+		} else {
+			query = query.Where("journal_name LIKE ?", like)
+		}
+	}
+	if quartile := strings.ToUpper(strings.TrimSpace(c.Query("quartile"))); quartile != "" {
+		query = query.Where("quartile = ?", quartile)
+	}
+	if raw := strings.TrimSpace(c.Query("metric_year")); raw != "" {
+		year, err := strconv.Atoi(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid metric_year"})
+			return
+		}
+		query = query.Where("metric_year = ?", year)
+	}
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		InternalError(c, "admin journals: count", err)
+		return
+	}
+
+	var journals []models.Journal
+	if err := query.Order("journal_name ASC").Order("metric_year DESC").
+		Offset(offset).Limit(limit).
+		Find(&journals).Error; err != nil {
+		InternalError(c, "admin journals: list", err)
+		return
+	}
+
+	totalPages := (totalCount + int64(limit) - 1) / int64(limit)
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"journals": journals,
+		"pagination": gin.H{
+			"current_page": page,
+			"per_page":     limit,
+			"total_count":  totalCount,
+			"total_pages":  totalPages,
+			"has_next":     page < int(totalPages),
+			"has_prev":     page > 1,
+		},
+	})
+}
+
+// CreateAdminJournal adds a journal to the reference table.
+// POST /admin/journals
+func CreateAdminJournal(c *gin.Context) {
+	if !requireAdminForJournals(c) {
+		return
+	}
+	var req journalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := validateJournalRequest(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Source == "" {
+		req.Source = "manual"
+	}
+
+	existing, err := findJournalEntry(config.DB, req, 0)
+	if err != nil {
+		InternalError(c, "admin journals: check duplicate", err)
+		return
+	}
+	if existing != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "Journal already exists for this metric year", "journal_id": existing.JournalID})
+		return
+	}
+
+	now := time.Now()
+	journal := models.Journal{
+		JournalName: req.JournalName,
+		ISSN:        optionalString(req.ISSN),
+		EISSN:       optionalString(req.EISSN),
+		Quartile:    optionalString(req.Quartile),
+		Indexing:    optionalString(req.Indexing),
+		MetricYear:  req.MetricYear,
+		Source:      optionalString(req.Source),
+		CreateAt:    now,
+		UpdateAt:    now,
+	}
+	if err := config.DB.Create(&journal).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create journal"})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"success": true, "journal": journal})
+}
+
+// UpdateAdminJournal replaces a journal entry.
+// PUT /admin/journals/:id
+func UpdateAdminJournal(c *gin.Context) {
+	if !requireAdminForJournals(c) {
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid journal id"})
+		return
+	}
+	var req journalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := validateJournalRequest(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var journal models.Journal
+	if err := config.DB.Where("journal_id = ? AND delete_at IS NULL", id).First(&journal).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Journal not found"})
+		return
+	}
+	duplicate, err := findJournalEntry(config.DB, req, id)
+	if err != nil {
+		InternalError(c, "admin journals: check duplicate", err)
+		return
+	}
+	if duplicate != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "Journal already exists for this metric year", "journal_id": duplicate.JournalID})
+		return
+	}
+
+	updates := req.columns()
+	updates["update_at"] = time.Now()
+	if err := config.DB.Model(&journal).Updates(updates).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update journal"})
+		return
+	}
+	config.DB.First(&journal, id)
+	c.JSON(http.StatusOK, gin.H{"success": true, "journal": journal})
+}
+
+// DeleteAdminJournal soft-deletes a journal entry.
+// DELETE /admin/journals/:id
+func DeleteAdminJournal(c *gin.Context) {
+	if !requireAdminForJournals(c) {
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid journal id"})
+		return
+	}
+
+	now := time.Now()
+	result := config.DB.Model(&models.Journal{}).
+		Where("journal_id = ? AND delete_at IS NULL", id).
+		Updates(map[string]interface{}{"delete_at": now, "update_at": now})
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete journal"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Journal not found"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Journal deleted"})
+}
+
+type journalImportRowError struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+// ImportAdminJournalsCSV loads journals from an uploaded CSV (multipart field
+// "file"). The header row must contain journal_name; issn, eissn, quartile,
+// indexing, metric_year and source are optional. A row that matches an existing
+// journal for the same metric year updates it, otherwise a new row is created.
+// Invalid rows are reported and skipped.
+// POST /admin/journals/import
+func ImportAdminJournalsCSV(c *gin.Context) {
+	if !requireAdminForJournals(c) {
+		return
+	}
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to open file"})
+		return
+	}
+	defer file.Close()
+
+	defaultSource := strings.TrimSpace(c.PostForm("source"))
+	if defaultSource == "" {
+		defaultSource = "import"
+	}
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	headerRow, err := reader.Read()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read CSV header"})
+		return
+	}
+	if len(headerRow) > 0 {
+		headerRow[0] = strings.TrimPrefix(headerRow[0], "\ufeff")
+	}
+	headers := normalizeHeaders(headerRow)
+	if _, ok := headers["journal_name"]; !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "CSV header must include journal_name"})
+		return
+	}
+
+	created, updated, skipped := 0, 0, 0
+	rowErrors := []journalImportRowError{}
+	addError := func(row int, err error) {
+		skipped++
+		if len(rowErrors) < maxJournalImportErrors {
+			rowErrors = append(rowErrors, journalImportRowError{Row: row, Error: err.Error()})
+		}
+	}
+
+	rowNumber := 1
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		rowNumber++
+		if err != nil {
+			addError(rowNumber, err)
+			continue
+		}
+
+		values := readRow(headers, record)
+		req := journalRequest{
+			JournalName: values["journal_name"],
+			ISSN:        values["issn"],
+			EISSN:       values["eissn"],
+			Quartile:    values["quartile"],
+			Indexing:    values["indexing"],
+			Source:      values["source"],
+		}
+		if strings.TrimSpace(req.JournalName) == "" && strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		if raw := strings.TrimSpace(values["metric_year"]); raw != "" {
+			year, err := strconv.Atoi(raw)
+			if err != nil {
+				addError(rowNumber, fmt.Errorf("invalid metric_year %q", raw))
+				continue
+			}
+			req.MetricYear = &year
+		}
+		if err := validateJournalRequest(&req); err != nil {
+			addError(rowNumber, err)
+			continue
+		}
+		if req.Source == "" {
+			req.Source = defaultSource
+		}
+
+		existing, err := findJournalEntry(config.DB, req, 0)
+		if err != nil {
+			InternalError(c, "admin journals: import lookup", err)
+			return
+		}
+		now := time.Now()
+		if existing != nil {
+			updates := req.columns()
+			updates["update_at"] = now
+			if err := config.DB.Model(existing).Updates(updates).Error; err != nil {
+				addError(rowNumber, err)
+				continue
+			}
+			updated++
+			continue
+		}
+
+		journal := models.Journal{
+			JournalName: req.JournalName,
+			ISSN:        optionalString(req.ISSN),
+			EISSN:       optionalString(req.EISSN),
+			Quartile:    optionalString(req.Quartile),
+			Indexing:    optionalString(req.Indexing),
+			MetricYear:  req.MetricYear,
+			Source:      optionalString(req.Source),
+			CreateAt:    now,
+			UpdateAt:    now,
+		}
+		if err := config.DB.Create(&journal).Error; err != nil {
+			addError(rowNumber, err)
+			continue
+		}
+		created++
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"created": created,
+		"updated": updated,
+		"skipped": skipped,
+		"errors":  rowErrors,
+	})
+}
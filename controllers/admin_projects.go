@@ -1,13 +1,13 @@
 package controllers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"mime/multipart"
 	"net/http"
-	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -18,6 +18,7 @@ import (
 
 	"fund-management-api/config"
 	"fund-management-api/models"
+	"fund-management-api/storage"
 	"fund-management-api/utils"
 
 	"github.com/gin-gonic/gin"
@@ -1973,29 +1974,37 @@ func saveProjectAttachment(c *gin.Context, tx *gorm.DB, projectID uint, file *mu
 		return nil, nil
 	}
 
-	uploadRoot := getUploadRoot()
-	projectFolder := filepath.Join(uploadRoot, "projects", fmt.Sprintf("%d", projectID))
-	if err := utils.EnsureDirectoryExists(projectFolder); err != nil {
-		return nil, err
-	}
+	store := storage.Default()
+	ctx := c.Request.Context()
+	projectFolder := filepath.Join(getUploadRoot(), "projects", fmt.Sprintf("%d", projectID))
 
 	originalName := strings.TrimSpace(file.Filename)
 	if originalName == "" {
 		originalName = file.Filename
 	}
-	storedName := utils.GenerateUniqueFilename(projectFolder, originalName)
-	destination := filepath.Join(projectFolder, storedName)
-
-	if err := c.SaveUploadedFile(file, destination); err != nil {
+	storedName, err := uniqueStorageFilename(ctx, store, projectFolder, originalName)
+	if err != nil {
 		return nil, err
 	}
 
+	// Project attachments have always recorded the path relative to the upload
+	// root, which is the key on every backend.
 	relativePath := filepath.ToSlash(filepath.Join("projects", fmt.Sprintf("%d", projectID), storedName))
 	mimeType := file.Header.Get("Content-Type")
 	if mimeType == "" {
 		mimeType = utils.GetMimeTypeFromExtension(filepath.Ext(storedName))
 	}
 
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	err = store.Save(ctx, relativePath, src, file.Size, mimeType)
+	src.Close()
+	if err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
 	attachment := models.ProjectAttachment{
 		ProjectID:    projectID,
@@ -2014,7 +2023,7 @@ func saveProjectAttachment(c *gin.Context, tx *gorm.DB, projectID uint, file *mu
 	}
 
 	if err := tx.Create(&attachment).Error; err != nil {
-		_ = os.Remove(destination)
+		_ = store.Delete(ctx, relativePath)
 		return nil, err
 	}
 
@@ -2030,13 +2039,12 @@ func deleteOtherProjectAttachments(tx *gorm.DB, projectID uint, keepFileID uint)
 		return nil
 	}
 
-	uploadRoot := getUploadRoot()
+	store := storage.Default()
 	for _, att := range existing {
 		if att.StoredPath == "" {
 			continue
 		}
-		fullPath := filepath.Join(uploadRoot, filepath.FromSlash(att.StoredPath))
-		if err := os.Remove(fullPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		if err := store.Delete(context.Background(), att.StoredPath); err != nil {
 			return err
 		}
 	}
@@ -2061,26 +2069,21 @@ func removeProjectAttachmentFile(att *models.ProjectAttachment) {
 	if att == nil || att.StoredPath == "" {
 		return
 	}
-	uploadRoot := getUploadRoot()
-	fullPath := filepath.Join(uploadRoot, filepath.FromSlash(att.StoredPath))
-	if err := os.Remove(fullPath); err != nil && !errors.Is(err, os.ErrNotExist) {
-		// Ignore cleanup errors to avoid masking the original failure
-	}
+	// Ignore cleanup errors to avoid masking the original failure
+	_ = storage.Default().Delete(context.Background(), att.StoredPath)
 }
 
 func removeProjectAttachmentFiles(attachments []models.ProjectAttachment) {
 	if len(attachments) == 0 {
 		return
 	}
-	uploadRoot := getUploadRoot()
+	store := storage.Default()
 	for _, att := range attachments {
 		if att.StoredPath == "" {
 			continue
 		}
-		fullPath := filepath.Join(uploadRoot, filepath.FromSlash(att.StoredPath))
-		if err := os.Remove(fullPath); err != nil && !errors.Is(err, os.ErrNotExist) {
-			// Ignore cleanup errors
-		}
+		// Ignore cleanup errors
+		_ = store.Delete(context.Background(), att.StoredPath)
 	}
 }
 
@@ -2144,8 +2147,10 @@ func DownloadProjectAttachment(c *gin.Context) {
 		return
 	}
 
-	if _, err := os.Stat(fullPath); err != nil {
-		if errors.Is(err, os.ErrNotExist) {
+	store := storage.Default()
+	info, err := store.Stat(c.Request.Context(), storedPath)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotExist) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Attachment not found"})
 			return
 		}
@@ -2166,10 +2171,9 @@ func DownloadProjectAttachment(c *gin.Context) {
 		displayName = filepath.Base(fullPath)
 	}
 
-	encodedName := url.PathEscape(displayName)
-	c.Header("Content-Type", mimeType)
-	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=\"%s\"; filename*=UTF-8''%s", displayName, encodedName))
-	c.File(fullPath)
+	if err := sendStoredFile(c, store, storedPath, info, mimeType, utils.ContentDisposition("inline", displayName)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read attachment"})
+	}
 }
 
 // ensureProjectTypeExists checks if a project type exists
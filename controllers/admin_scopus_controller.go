@@ -101,6 +101,10 @@ func AdminImportScopusForAll(c *gin.Context) {
 				log.Printf("scopus batch import skipped: job already running")
 				return
 			}
+			if errors.Is(err, services.ErrImportRunCancelled) {
+				log.Printf("scopus batch import cancelled by admin")
+				return
+			}
 			log.Printf("scopus batch import job failed: %v", err)
 		}
 	}()
@@ -10,6 +10,7 @@ import (
 
 	"fund-management-api/config"
 	"fund-management-api/models"
+	"fund-management-api/storage"
 	"fund-management-api/utils"
 	"log"
 	"math"
@@ -567,6 +568,26 @@ func ApproveSubmission(c *gin.Context) {
 		return
 	}
 
+	var refConflicts []announceReferenceConflict
+	if policy := announceReferencePolicy(); policy != policyOff {
+		refConflicts, err = findAnnounceReferenceConflicts(tx, req.AnnounceReferenceNumber, submission.YearID, []int{submissionID})
+		if err != nil {
+			tx.Rollback()
+			InternalError(c, "approve submission: announce reference check", err)
+			return
+		}
+		if len(refConflicts) > 0 && policy == policyBlock {
+			tx.Rollback()
+			c.JSON(http.StatusConflict, gin.H{
+				"success":   false,
+				"error":     "Announce reference number is already used by submission " + refConflicts[0].SubmissionNumber,
+				"code":      "DUPLICATE_ANNOUNCE_REFERENCE",
+				"conflicts": refConflicts,
+			})
+			return
+		}
+	}
+
 	now := time.Now()
 	var adminID *int
 	if uid, ok := userID.(int); ok {
@@ -596,15 +617,24 @@ func ApproveSubmission(c *gin.Context) {
 		updates["admin_comment"] = approvalComment
 	}
 
-	if err := tx.Model(&models.Submission{}).
-		Where("submission_id = ?", submissionID).
-		Updates(updates).Error; err != nil {
+	// Conditional on the status read above so a concurrent approval of the same
+	// submission cannot take its budget twice.
+	statusUpdate := tx.Model(&models.Submission{}).
+		Where("submission_id = ? AND status_id = ?", submissionID, submission.StatusID).
+		Updates(updates)
+	if statusUpdate.Error != nil {
 		tx.Rollback()
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update submission status"})
 		return
 	}
+	if statusUpdate.RowsAffected == 0 {
+		tx.Rollback()
+		c.JSON(http.StatusConflict, gin.H{"error": "Submission status changed concurrently"})
+		return
+	}
 
 	// Update type-specific approval details
+	approvedTotal := 0.0
 	if submission.SubmissionType == "publication_reward" {
 		var d models.PublicationRewardDetail
 		if submission.PublicationRewardDetail != nil {
@@ -633,6 +663,7 @@ func ApproveSubmission(c *gin.Context) {
 		if trimmed := strings.TrimSpace(req.AnnounceReferenceNumber); trimmed != "" {
 			d.AnnounceReferenceNumber = trimmed
 		}
+		approvedTotal = d.TotalApproveAmount
 
 		if d.DetailID == 0 {
 			if err := tx.Create(&d).Error; err != nil {
@@ -653,6 +684,8 @@ func ApproveSubmission(c *gin.Context) {
 			approvedAmount = *req.ApprovedAmount
 		}
 
+		approvedTotal = approvedAmount
+
 		announceRef := strings.TrimSpace(req.AnnounceReferenceNumber)
 		updates := map[string]interface{}{"approved_amount": approvedAmount}
 		if announceRef != "" {
@@ -668,21 +701,232 @@ func ApproveSubmission(c *gin.Context) {
 		}
 	}
 
+	if err := reserveSubcategoryBudget(tx, submission.SubcategoryID, approvedTotal); err != nil {
+		tx.Rollback()
+		var shortfall *budgetShortfallError
+		if errors.As(err, &shortfall) {
+			c.JSON(http.StatusConflict, shortfall.response())
+			return
+		}
+		InternalError(c, "approve submission: reserve budget", err)
+		return
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Transaction failed"})
 		return
 	}
 
+	checkBudgetAlertsAfterApproval(submission.SubcategoryID)
+
 	var out models.Submission
 	_ = config.DB.Preload("PublicationRewardDetail").
 		Where("submission_id = ?", submissionID).
 		First(&out).Error
 
-	c.JSON(http.StatusOK, gin.H{
+	response := gin.H{
 		"success":    true,
 		"message":    "Submission approved successfully",
 		"submission": out,
+	}
+	if len(refConflicts) > 0 {
+		response["announce_reference_conflicts"] = refConflicts
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// maxBulkAnnounceSubmissions bounds one BulkAnnounceSubmissions request.
+const maxBulkAnnounceSubmissions = 500
+
+// BulkAnnounceSubmissions assigns one announce_reference_number to a batch of
+// approved submissions, as issued by a single official announcement. Every
+// submission in the set is checked individually; only approved ones are updated.
+// A batch holds at most maxBulkAnnounceSubmissions distinct submissions.
+func BulkAnnounceSubmissions(c *gin.Context) {
+	var req struct {
+		SubmissionIDs           []int  `json:"submission_ids"`
+		AnnounceReferenceNumber string `json:"announce_reference_number"`
+		AutoGenerate            bool   `json:"auto_generate"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid request data"})
+		return
+	}
+
+	submissionIDs := make([]int, 0, len(req.SubmissionIDs))
+	seen := make(map[int]struct{}, len(req.SubmissionIDs))
+	for _, id := range req.SubmissionIDs {
+		if id <= 0 {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		submissionIDs = append(submissionIDs, id)
+	}
+	if len(submissionIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "submission_ids is required"})
+		return
+	}
+	if len(submissionIDs) > maxBulkAnnounceSubmissions {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   fmt.Sprintf("At most %d submissions can be announced per request", maxBulkAnnounceSubmissions),
+		})
+		return
+	}
+
+	announceRef := strings.TrimSpace(req.AnnounceReferenceNumber)
+	if announceRef == "" && !req.AutoGenerate {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "announce_reference_number is required unless auto_generate is set"})
+		return
+	}
+
+	approvedStatusID, err := utils.GetStatusIDByCode(utils.StatusCodeApproved)
+	if err != nil {
+		InternalError(c, "bulk announce: resolve approved status", err)
+		return
+	}
+
+	type bulkAnnounceResult struct {
+		SubmissionID     int    `json:"submission_id"`
+		SubmissionNumber string `json:"submission_number,omitempty"`
+		Success          bool   `json:"success"`
+		Error            string `json:"error,omitempty"`
+	}
+	results := make([]bulkAnnounceResult, 0, len(submissionIDs))
+	updated := 0
+	policy := announceReferencePolicy()
+	var refConflicts []announceReferenceConflict
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if announceRef == "" {
+			generated, genErr := generateAnnounceReferenceNumber(tx)
+			if genErr != nil {
+				return genErr
+			}
+			announceRef = generated
+		}
+
+		var submissions []models.Submission
+		if err := tx.Where("submission_id IN ? AND deleted_at IS NULL", submissionIDs).
+			Find(&submissions).Error; err != nil {
+			return err
+		}
+		byID := make(map[int]models.Submission, len(submissions))
+		for _, submission := range submissions {
+			byID[submission.SubmissionID] = submission
+		}
+
+		// The batch shares one reference by design; only other submissions of
+		// the same year count as conflicts.
+		if policy != policyOff {
+			checkedYears := make(map[int]struct{})
+			for _, submission := range submissions {
+				if _, done := checkedYears[submission.YearID]; done {
+					continue
+				}
+				checkedYears[submission.YearID] = struct{}{}
+				conflicts, err := findAnnounceReferenceConflicts(tx, announceRef, submission.YearID, submissionIDs)
+				if err != nil {
+					return err
+				}
+				refConflicts = append(refConflicts, conflicts...)
+			}
+			if len(refConflicts) > 0 && policy == policyBlock {
+				return errAnnounceReferenceInUse
+			}
+		}
+
+		for _, id := range submissionIDs {
+			submission, ok := byID[id]
+			if !ok {
+				results = append(results, bulkAnnounceResult{SubmissionID: id, Error: "Submission not found"})
+				continue
+			}
+			result := bulkAnnounceResult{SubmissionID: id, SubmissionNumber: submission.SubmissionNumber}
+			if submission.StatusID != approvedStatusID {
+				result.Error = "Submission is not approved"
+				results = append(results, result)
+				continue
+			}
+			if submission.SubmissionType != "fund_application" && submission.SubmissionType != "publication_reward" {
+				result.Error = "Submission type does not carry an announcement reference"
+				results = append(results, result)
+				continue
+			}
+			if err := updateSubmissionAnnounceReference(tx, submission.SubmissionType, id, announceRef); err != nil {
+				return fmt.Errorf("update announce reference for submission %d: %w", id, err)
+			}
+			if err := tx.Model(&models.Submission{}).
+				Where("submission_id = ?", id).
+				Update("updated_at", time.Now()).Error; err != nil {
+				return fmt.Errorf("touch submission %d: %w", id, err)
+			}
+			result.Success = true
+			results = append(results, result)
+			updated++
+		}
+		return nil
 	})
+	if errors.Is(err, errAnnounceReferenceInUse) {
+		c.JSON(http.StatusConflict, gin.H{
+			"success":   false,
+			"error":     "Announce reference number is already used by submission " + refConflicts[0].SubmissionNumber,
+			"code":      "DUPLICATE_ANNOUNCE_REFERENCE",
+			"conflicts": refConflicts,
+		})
+		return
+	}
+	if err != nil {
+		InternalError(c, "bulk announce", err)
+		return
+	}
+
+	response := gin.H{
+		"success":                   true,
+		"announce_reference_number": announceRef,
+		"updated_count":             updated,
+		"failed_count":              len(results) - updated,
+		"results":                   results,
+	}
+	if len(refConflicts) > 0 {
+		response["announce_reference_conflicts"] = refConflicts
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// announceReferencePrefix is the submission_sequences prefix of generated
+// announcement references.
+const announceReferencePrefix = "ANN"
+
+// generateAnnounceReferenceNumber builds the next ANN-BEYYYY-NNNN reference
+// from the ANN row of submission_sequences, locked until tx ends so concurrent
+// bulk announcements never share a number. A missing row is seeded from the
+// highest reference already issued for the BE year on either detail table.
+func generateAnnounceReferenceNumber(tx *gorm.DB) (string, error) {
+	now := time.Now()
+	beYear := getCurrentBEYearStr()
+	pattern := fmt.Sprintf("%s-%s-%%", announceReferencePrefix, beYear)
+
+	if err := tx.Exec(`
+		INSERT IGNORE INTO submission_sequences (prefix, be_year, installment, last_number, updated_at)
+		SELECT ?, ?, ?, COALESCE(MAX(CAST(SUBSTRING_INDEX(ref, '-', -1) AS UNSIGNED)), 0), ?
+		FROM (
+			SELECT announce_reference_number AS ref FROM fund_application_details WHERE announce_reference_number LIKE ?
+			UNION
+			SELECT announce_reference_number AS ref FROM publication_reward_details WHERE announce_reference_number LIKE ?
+		) refs
+	`, announceReferencePrefix, beYear, 0, now, pattern, pattern).Error; err != nil {
+		return "", err
+	}
+
+	next, err := incrementSubmissionSequence(tx, announceReferencePrefix, beYear, 0, now)
+	if err != nil {
+		return "", err
+	}
+	return formatSubmissionNumber(announceReferencePrefix, beYear, 0, next), nil
 }
 
 // RejectSubmission marks a submission as rejected with audit logging.
@@ -997,6 +1241,8 @@ func CreateResearchFundEvent(c *gin.Context) {
 
 	var createdEvent models.ResearchFundAdminEvent
 	var savedPaths []string
+	store := storage.Default()
+	ctx := c.Request.Context()
 
 	shouldApplyStatusChange := false
 	var submissionStatusUpdates map[string]any
@@ -1132,17 +1378,15 @@ func CreateResearchFundEvent(c *gin.Context) {
 		savedPaths = make([]string, 0, len(files))
 
 		for _, fh := range files {
-			filename := utils.GenerateUniqueFilename(eventFolderPath, fh.Filename)
-			destPath := filepath.Join(eventFolderPath, filename)
-			if err := c.SaveUploadedFile(fh, destPath); err != nil {
+			filename, err := uniqueStorageFilename(ctx, store, eventFolderPath, fh.Filename)
+			if err != nil {
 				return err
 			}
-			savedPaths = append(savedPaths, destPath)
-
-			stat, err := os.Stat(destPath)
+			destPath, err := saveUploadedFileToStorage(ctx, store, fh, filepath.Join(eventFolderPath, filename), fh.Header.Get("Content-Type"))
 			if err != nil {
 				return err
 			}
+			savedPaths = append(savedPaths, destPath)
 
 			metadataBytes, _ := json.Marshal(map[string]any{
 				"submission_id": submission.SubmissionID,
@@ -1154,7 +1398,7 @@ func CreateResearchFundEvent(c *gin.Context) {
 				StoredPath:   destPath,
 				FolderType:   models.FileFolderTypeAdminEvent,
 				Metadata:     string(metadataBytes),
-				FileSize:     stat.Size(),
+				FileSize:     fh.Size,
 				MimeType:     fh.Header.Get("Content-Type"),
 				UploadedBy:   userID,
 				UploadedAt:   now,
@@ -1180,7 +1424,7 @@ func CreateResearchFundEvent(c *gin.Context) {
 
 	if err != nil {
 		for _, path := range savedPaths {
-			_ = os.Remove(path)
+			_ = store.Delete(ctx, path)
 		}
 
 		switch {
@@ -256,6 +256,13 @@ func AdminLegacyCreateSubmission(c *gin.Context) {
 		if err := applyLegacySubmissionFields(&submission, payload.Submission, true, clearSet); err != nil {
 			return err
 		}
+		if submission.SubmissionNumber == "" {
+			number, err := generateSubmissionNumber(tx, submission)
+			if err != nil {
+				return err
+			}
+			submission.SubmissionNumber = number
+		}
 		if err := validateLegacySubmission(tx, &submission); err != nil {
 			return err
 		}
@@ -274,6 +281,9 @@ func AdminLegacyCreateSubmission(c *gin.Context) {
 				return err
 			}
 		}
+		if err := ensureApplicantSubmissionUser(tx, submission.SubmissionID, submission.UserID); err != nil {
+			return err
+		}
 
 		record, err := loadLegacySubmissionRecord(tx, submission.SubmissionID)
 		if err != nil {
@@ -344,6 +354,9 @@ func AdminLegacyUpdateSubmission(c *gin.Context) {
 				return err
 			}
 		}
+		if err := ensureApplicantSubmissionUser(tx, submission.SubmissionID, submission.UserID); err != nil {
+			return err
+		}
 
 		record, err := loadLegacySubmissionRecord(tx, submission.SubmissionID)
 		if err != nil {
@@ -494,17 +507,12 @@ func applyLegacySubmissionFields(submission *models.Submission, input adminLegac
 	}
 	submission.SubmissionType = submissionType
 
+	// A new submission without a number gets one from the sequence when it is
+	// inserted; see AdminLegacyCreateSubmission.
 	if input.SubmissionNumber != nil {
-		candidate := strings.TrimSpace(*input.SubmissionNumber)
-		if candidate == "" {
-			if isCreate {
-				submission.SubmissionNumber = generateSubmissionNumber(submissionType)
-			}
-		} else {
+		if candidate := strings.TrimSpace(*input.SubmissionNumber); candidate != "" {
 			submission.SubmissionNumber = candidate
 		}
-	} else if isCreate && submission.SubmissionNumber == "" {
-		submission.SubmissionNumber = generateSubmissionNumber(submissionType)
 	}
 
 	submission.UserID = input.UserID
@@ -987,10 +995,15 @@ func replaceSubmissionUsers(db *gorm.DB, submissionID int, inputs []adminLegacyU
 		return nil
 	}
 
+	seen := make(map[int]bool, len(inputs))
 	for idx, input := range inputs {
 		if input.UserID <= 0 {
 			return newLegacyValidationError(fmt.Sprintf("users[%d].user_id is required", idx))
 		}
+		if seen[input.UserID] {
+			return newLegacyValidationError(fmt.Sprintf("users[%d]: user %d is listed more than once", idx, input.UserID))
+		}
+		seen[input.UserID] = true
 		if err := ensureActiveUser(db, input.UserID); err != nil {
 			return newLegacyValidationError(fmt.Sprintf("users[%d]: %v", idx, err))
 		}
@@ -1060,7 +1073,7 @@ func parseOptionalTime(value *string) (*time.Time, error) {
 		}
 	}
 	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02 15:04"} {
-		if t, err := time.ParseInLocation(layout, trimmed, time.Local); err == nil {
+		if t, err := time.ParseInLocation(layout, trimmed, utils.AppLocation()); err == nil {
 			return &t, nil
 		}
 	}
@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"fund-management-api/config"
+	"fund-management-api/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type setUserFacultyRequest struct {
+	FacultyID *int `json:"faculty_id"`
+}
+
+// AdminSetUserFaculty assigns the user's faculty/department, which the
+// per-department stats group by. A null faculty_id clears it.
+// PUT /admin/users/:id/faculty {"faculty_id": 6}
+func AdminSetUserFaculty(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || userID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "invalid user_id"})
+		return
+	}
+
+	var payload setUserFacultyRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "invalid request body"})
+		return
+	}
+
+	var faculty *models.Faculty
+	if payload.FacultyID != nil {
+		var found models.Faculty
+		if err := config.DB.Where("id = ? AND is_active = ?", *payload.FacultyID, true).First(&found).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "faculty not found"})
+				return
+			}
+			InternalError(c, "set user faculty: load faculty", err)
+			return
+		}
+		faculty = &found
+	}
+
+	var user models.User
+	if err := config.DB.Select("user_id").Where("user_id = ? AND delete_at IS NULL", userID).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "user not found"})
+			return
+		}
+		InternalError(c, "set user faculty: load user", err)
+		return
+	}
+
+	if err := config.DB.Model(&models.User{}).Where("user_id = ?", userID).
+		Update("faculty_id", payload.FacultyID).Error; err != nil {
+		InternalError(c, "set user faculty", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"user_id":    userID,
+			"faculty_id": payload.FacultyID,
+			"faculty":    faculty,
+		},
+	})
+}
@@ -54,6 +54,7 @@ func AdminImportScholarPublications(c *gin.Context) {
 			"fetched": summary.PublicationsFetched,
 			"created": summary.PublicationsCreated,
 			"updated": summary.PublicationsUpdated,
+			"merged":  summary.PublicationsMerged,
 			"failed":  summary.PublicationsFailed,
 		},
 	})
@@ -85,7 +86,7 @@ func AdminImportScholarForAll(c *gin.Context) {
 		UserIDs:       userIDs,
 		Limit:         limit,
 		TriggerSource: "admin_api",
-		LockName:      "scholar_import_job",
+		LockName:      services.ScholarImportLockName,
 		RecordRun:     true,
 	})
 	if err != nil {
@@ -93,6 +94,10 @@ func AdminImportScholarForAll(c *gin.Context) {
 			c.JSON(http.StatusConflict, gin.H{"success": false, "error": "scholar import already running"})
 			return
 		}
+		if errors.Is(err, services.ErrImportRunCancelled) {
+			c.JSON(http.StatusOK, gin.H{"success": true, "cancelled": true, "summary": summary})
+			return
+		}
 		InternalError(c, "user_publication", err)
 		return
 	}
@@ -100,6 +105,92 @@ func AdminImportScholarForAll(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"success": true, "summary": summary})
 }
 
+// AdminImportScholarForUser refreshes one user's Scholar publications now
+// instead of waiting for the scheduled batch. It runs RunForAll for that user
+// alone under the shared import lock, so while another import holds the lock
+// it returns 409. With dry_run=true nothing is written and the counts say what
+// the import would create, update or merge.
+// POST /api/v1/admin/users/:id/scholar-import?dry_run=true
+func AdminImportScholarForUser(c *gin.Context) {
+	roleID, _ := c.Get("roleID")
+	if roleID.(int) != 3 {
+		c.JSON(http.StatusForbidden, gin.H{"success": false, "error": "Admin access required"})
+		return
+	}
+
+	id64, err := strconv.ParseUint(strings.TrimSpace(c.Param("id")), 10, 64)
+	if err != nil || id64 == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "invalid user_id"})
+		return
+	}
+	userID := uint(id64)
+
+	dryRun := false
+	if raw := strings.TrimSpace(c.Query("dry_run")); raw != "" {
+		parsed, parseErr := strconv.ParseBool(raw)
+		if parseErr != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "dry_run must be true or false"})
+			return
+		}
+		dryRun = parsed
+	}
+
+	var user models.User
+	if err := config.DB.Select("user_id", "scholar_author_id").
+		Where("user_id = ?", userID).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "user not found"})
+			return
+		}
+		InternalError(c, "user_publication", err)
+		return
+	}
+	if user.ScholarAuthorID == nil || strings.TrimSpace(*user.ScholarAuthorID) == "" {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"success": false, "error": "user has no scholar_author_id"})
+		return
+	}
+
+	job := services.NewScholarImportJobService(nil)
+	summary, err := job.RunForAll(c.Request.Context(), &services.ScholarImportAllInput{
+		UserIDs:       []uint{userID},
+		TriggerSource: "admin_user",
+		LockName:      services.ScholarImportLockName,
+		DryRun:        dryRun,
+		RecordRun:     !dryRun,
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrScholarImportAlreadyRunning) {
+			c.JSON(http.StatusConflict, gin.H{"success": false, "error": "scholar import already running"})
+			return
+		}
+		if errors.Is(err, services.ErrImportRunCancelled) {
+			c.JSON(http.StatusOK, gin.H{"success": true, "cancelled": true, "dry_run": dryRun})
+			return
+		}
+		InternalError(c, "user_publication", err)
+		return
+	}
+
+	result := gin.H{
+		"fetched":   summary.PublicationsFetched,
+		"created":   summary.PublicationsCreated,
+		"updated":   summary.PublicationsUpdated,
+		"unchanged": summary.PublicationsUnchanged,
+		"merged":    summary.PublicationsMerged,
+		"failed":    summary.PublicationsFailed,
+	}
+	if summary.UsersWithErrors > 0 {
+		reason := "scholar import failed"
+		if len(summary.Decisions) > 0 && summary.Decisions[0].Reason != "" {
+			reason = summary.Decisions[0].Reason
+		}
+		c.JSON(http.StatusBadGateway, gin.H{"success": false, "error": reason, "dry_run": dryRun, "summary": result})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "dry_run": dryRun, "user_id": userID, "summary": result})
+}
+
 type AdminUserLite struct {
 	UserID          uint    `json:"user_id"`
 	Name            string  `json:"name"`
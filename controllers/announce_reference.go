@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// errAnnounceReferenceInUse aborts a bulk announcement blocked by the policy.
+var errAnnounceReferenceInUse = errors.New("announce reference number already in use")
+
+type announceReferenceConflict struct {
+	SubmissionID            int    `json:"submission_id"`
+	SubmissionNumber        string `json:"submission_number"`
+	SubmissionType          string `json:"submission_type"`
+	YearID                  int    `json:"year_id"`
+	AnnounceReferenceNumber string `json:"announce_reference_number"`
+}
+
+// announceReferencePolicy controls what happens when an announce reference is
+// already used by another submission of the same year
+// (ANNOUNCE_REF_UNIQUENESS_POLICY=warn|block|off, default warn).
+func announceReferencePolicy() string {
+	return envPolicy("ANNOUNCE_REF_UNIQUENESS_POLICY", policyWarn)
+}
+
+// findAnnounceReferenceConflicts lists the live submissions of the year whose
+// fund or publication detail already carries ref, leaving out excludeIDs (the
+// submissions being stamped). An empty ref never conflicts.
+func findAnnounceReferenceConflicts(db *gorm.DB, ref string, yearID int, excludeIDs []int) ([]announceReferenceConflict, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, nil
+	}
+
+	conflicts := make([]announceReferenceConflict, 0)
+	if err := db.Raw(`
+		SELECT s.submission_id, s.submission_number, s.submission_type, s.year_id, refs.ref AS announce_reference_number
+		FROM (
+			SELECT submission_id, TRIM(announce_reference_number) AS ref FROM fund_application_details
+			WHERE TRIM(announce_reference_number) = ?
+			UNION
+			SELECT submission_id, TRIM(announce_reference_number) AS ref FROM publication_reward_details
+			WHERE TRIM(announce_reference_number) = ?
+		) refs
+		JOIN submissions s ON s.submission_id = refs.submission_id
+		WHERE s.year_id = ? AND s.deleted_at IS NULL AND s.submission_id NOT IN ?
+		ORDER BY s.submission_id ASC
+	`, ref, ref, yearID, ensureIDs(excludeIDs)).Scan(&conflicts).Error; err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
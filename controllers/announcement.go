@@ -2,14 +2,14 @@
 package controllers
 
 import (
-	"fmt"
+	"errors"
 	"fund-management-api/config"
 	"fund-management-api/models"
+	"fund-management-api/storage"
 	"fund-management-api/utils"
 	"log"
 	"mime"
 	"net/http"
-	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -238,6 +238,8 @@ func CreateAnnouncement(c *gin.Context) {
 	}
 
 	// ไฟล์ (จำเป็น)
+	store := storage.Default()
+	ctx := c.Request.Context()
 	file, header, err := c.Request.FormFile("file")
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "File is required"})
@@ -258,20 +260,12 @@ func CreateAnnouncement(c *gin.Context) {
 
 	// ==== จุดสำคัญ: โฟลเดอร์ + ชื่อไฟล์ ====
 	uploadDir := "uploads/announcements" // ไม่แยกปี/เดือน
-	if err := os.MkdirAll(uploadDir, 0755); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create upload directory"})
-		return
-	}
 	// ใช้ชื่อไฟล์เดิม (sanitize กันอักขระต้องห้าม) — ไม่มี timestamp
 	safeName := utils.SanitizeFilename(header.Filename)
-	dstPath := filepath.Join(uploadDir, safeName)
-
-	// ถ้าไฟล์ปลายทางมีอยู่แล้ว: เขียนทับ (ลบไฟล์เก่าก่อนเพื่อความชัวร์)
-	if _, statErr := os.Stat(dstPath); statErr == nil {
-		_ = os.Remove(dstPath)
-	}
 
-	if err := c.SaveUploadedFile(header, dstPath); err != nil {
+	// Save replaces an existing file with the same name
+	dstPath, err := saveUploadedFileToStorage(ctx, store, header, filepath.Join(uploadDir, safeName), ct)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
 		return
 	}
@@ -298,7 +292,7 @@ func CreateAnnouncement(c *gin.Context) {
 	}
 
 	if err := config.DB.Create(&ann).Error; err != nil {
-		_ = os.Remove(dstPath)
+		_ = store.Delete(ctx, dstPath)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create announcement"})
 		return
 	}
@@ -338,6 +332,8 @@ func UpdateAnnouncement(c *gin.Context) {
 	}
 
 	// ---- (อัปโหลดไฟล์ใหม่ถ้ามี) เหมือนเดิม ----
+	store := storage.Default()
+	ctx := c.Request.Context()
 	file, header, err := c.Request.FormFile("file")
 	var newFilePath string
 	var oldFilePath string
@@ -357,18 +353,11 @@ func UpdateAnnouncement(c *gin.Context) {
 
 		// ==== จุดสำคัญ: โฟลเดอร์ + ชื่อไฟล์ ====
 		uploadDir := "uploads/announcements" // ไม่แยกปี/เดือน
-		if err := os.MkdirAll(uploadDir, 0755); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create upload directory"})
-			return
-		}
 		safeName := utils.SanitizeFilename(header.Filename)
-		dstPath := filepath.Join(uploadDir, safeName)
 
-		// ถ้าไฟล์ปลายทางมีอยู่แล้ว ให้ลบทิ้งเพื่อเขียนทับ
-		if _, statErr := os.Stat(dstPath); statErr == nil {
-			_ = os.Remove(dstPath)
-		}
-		if err := c.SaveUploadedFile(header, dstPath); err != nil {
+		// Save replaces an existing file with the same name
+		dstPath, err := saveUploadedFileToStorage(ctx, store, header, filepath.Join(uploadDir, safeName), ct)
+		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
 			return
 		}
@@ -421,14 +410,14 @@ func UpdateAnnouncement(c *gin.Context) {
 
 	if err := config.DB.Save(&announcement).Error; err != nil {
 		if newFilePath != "" {
-			os.Remove(newFilePath)
+			_ = store.Delete(ctx, newFilePath)
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update announcement"})
 		return
 	}
 
 	if oldFilePath != "" && newFilePath != "" && oldFilePath != newFilePath {
-		_ = os.Remove(oldFilePath)
+		_ = store.Delete(ctx, oldFilePath)
 	}
 
 	config.DB.Preload("Creator").Preload("Year").First(&announcement, announcement.AnnouncementID)
@@ -486,8 +475,14 @@ func DownloadAnnouncementFile(c *gin.Context) {
 	}
 
 	// Check if file exists
-	if _, err := os.Stat(announcement.FilePath); os.IsNotExist(err) {
-		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+	store := storage.Default()
+	info, err := store.Stat(c.Request.Context(), announcement.FilePath)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotExist) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+			return
+		}
+		InternalError(c, "announcement file: stat", err)
 		return
 	}
 
@@ -526,9 +521,10 @@ func DownloadAnnouncementFile(c *gin.Context) {
 	c.Header("Cache-Control", "no-store, no-cache, must-revalidate, max-age=0")
 	c.Header("Pragma", "no-cache")
 	c.Header("Expires", "0")
-	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", announcement.FileName))
-	c.Header("Content-Type", "application/octet-stream")
-	c.File(announcement.FilePath)
+	disposition := utils.ContentDisposition("attachment", announcement.FileName)
+	if err := sendStoredFile(c, store, announcement.FilePath, info, "application/octet-stream", disposition); err != nil {
+		InternalError(c, "announcement file: send", err)
+	}
 }
 
 // ViewAnnouncementFile - ดูไฟล์ประกาศ (inline)
@@ -544,8 +540,14 @@ func ViewAnnouncementFile(c *gin.Context) {
 	}
 
 	// Check if file exists
-	if _, err := os.Stat(announcement.FilePath); os.IsNotExist(err) {
-		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+	store := storage.Default()
+	info, err := store.Stat(c.Request.Context(), announcement.FilePath)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotExist) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+			return
+		}
+		InternalError(c, "announcement file: stat", err)
 		return
 	}
 
@@ -583,11 +585,14 @@ func ViewAnnouncementFile(c *gin.Context) {
 	c.Header("Cache-Control", "no-store, no-cache, must-revalidate, max-age=0")
 	c.Header("Pragma", "no-cache")
 	c.Header("Expires", "0")
+	contentType := "application/octet-stream"
 	if announcement.MimeType != nil {
-		c.Header("Content-Type", *announcement.MimeType)
+		contentType = *announcement.MimeType
+	}
+	disposition := utils.ContentDisposition("inline", announcement.FileName)
+	if err := sendStoredFile(c, store, announcement.FilePath, info, contentType, disposition); err != nil {
+		InternalError(c, "announcement file: send", err)
 	}
-	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=\"%s\"", announcement.FileName))
-	c.File(announcement.FilePath)
 }
 
 // ===== FUND FORM CONTROLLERS =====
@@ -764,6 +769,8 @@ func CreateFundForm(c *gin.Context) {
 	}
 
 	// 4) รับไฟล์ (บังคับ)
+	store := storage.Default()
+	ctx := c.Request.Context()
 	file, header, err := c.Request.FormFile("file")
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "File is required"})
@@ -783,18 +790,11 @@ func CreateFundForm(c *gin.Context) {
 
 	// 5) โฟลเดอร์ปลายทาง + ชื่อไฟล์เดิม (sanitize) — ไม่มี timestamp
 	uploadDir := "uploads/fund_forms"
-	if err := os.MkdirAll(uploadDir, 0755); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create upload directory"})
-		return
-	}
 	safeName := utils.SanitizeFilename(header.Filename)
-	dstPath := filepath.Join(uploadDir, safeName)
 
-	// ถ้ามีไฟล์ชื่อซ้ำ ให้ลบทิ้งก่อนเพื่อเขียนทับ
-	if _, statErr := os.Stat(dstPath); statErr == nil {
-		_ = os.Remove(dstPath)
-	}
-	if err := c.SaveUploadedFile(header, dstPath); err != nil {
+	// Save replaces an existing file with the same name
+	dstPath, err := saveUploadedFileToStorage(ctx, store, header, filepath.Join(uploadDir, safeName), ct)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
 		return
 	}
@@ -819,7 +819,7 @@ func CreateFundForm(c *gin.Context) {
 		UpdateAt:     now,
 	}
 	if err := config.DB.Create(&form).Error; err != nil {
-		_ = os.Remove(dstPath)
+		_ = store.Delete(ctx, dstPath)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create fund form"})
 		return
 	}
@@ -879,6 +879,8 @@ func UpdateFundForm(c *gin.Context) {
 	}
 
 	// 3) (ถ้ามีไฟล์ใหม่) → เซฟลง uploads/fund_forms ด้วยชื่อเดิม
+	store := storage.Default()
+	ctx := c.Request.Context()
 	file, header, err := c.Request.FormFile("file")
 	var newPath, oldPath string
 	if err == nil {
@@ -895,17 +897,11 @@ func UpdateFundForm(c *gin.Context) {
 		}
 
 		uploadDir := "uploads/fund_forms"
-		if err := os.MkdirAll(uploadDir, 0755); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create upload directory"})
-			return
-		}
 		safeName := utils.SanitizeFilename(header.Filename)
-		dstPath := filepath.Join(uploadDir, safeName)
 
-		if _, statErr := os.Stat(dstPath); statErr == nil {
-			_ = os.Remove(dstPath)
-		}
-		if err := c.SaveUploadedFile(header, dstPath); err != nil {
+		// Save replaces an existing file with the same name
+		dstPath, err := saveUploadedFileToStorage(ctx, store, header, filepath.Join(uploadDir, safeName), ct)
+		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
 			return
 		}
@@ -948,13 +944,13 @@ func UpdateFundForm(c *gin.Context) {
 
 	if err := config.DB.Save(&form).Error; err != nil {
 		if newPath != "" {
-			_ = os.Remove(newPath)
+			_ = store.Delete(ctx, newPath)
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update fund form"})
 		return
 	}
 	if oldPath != "" && newPath != "" && oldPath != newPath {
-		_ = os.Remove(oldPath)
+		_ = store.Delete(ctx, oldPath)
 	}
 
 	config.DB.Preload("Creator").Preload("Year").First(&form, form.FormID)
@@ -1008,8 +1004,14 @@ func DownloadFundForm(c *gin.Context) {
 	}
 
 	// Check if file exists
-	if _, err := os.Stat(form.FilePath); os.IsNotExist(err) {
-		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+	store := storage.Default()
+	info, err := store.Stat(c.Request.Context(), form.FilePath)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotExist) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+			return
+		}
+		InternalError(c, "fund form file: stat", err)
 		return
 	}
 	formID := form.FormID
@@ -1041,9 +1043,10 @@ func DownloadFundForm(c *gin.Context) {
 	}()
 
 	// Set headers for download
-	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", form.FileName))
-	c.Header("Content-Type", "application/octet-stream")
-	c.File(form.FilePath)
+	disposition := utils.ContentDisposition("attachment", form.FileName)
+	if err := sendStoredFile(c, store, form.FilePath, info, "application/octet-stream", disposition); err != nil {
+		InternalError(c, "fund form file: send", err)
+	}
 }
 
 // ViewFundForm - ดูแบบฟอร์ม (inline)
@@ -1059,17 +1062,26 @@ func ViewFundForm(c *gin.Context) {
 	}
 
 	// Check if file exists
-	if _, err := os.Stat(form.FilePath); os.IsNotExist(err) {
-		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+	store := storage.Default()
+	info, err := store.Stat(c.Request.Context(), form.FilePath)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotExist) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+			return
+		}
+		InternalError(c, "fund form file: stat", err)
 		return
 	}
 
 	// Set headers for inline viewing
+	contentType := "application/octet-stream"
 	if form.MimeType != nil {
-		c.Header("Content-Type", *form.MimeType)
+		contentType = *form.MimeType
+	}
+	disposition := utils.ContentDisposition("inline", form.FileName)
+	if err := sendStoredFile(c, store, form.FilePath, info, contentType, disposition); err != nil {
+		InternalError(c, "fund form file: send", err)
 	}
-	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=\"%s\"", form.FileName))
-	c.File(form.FilePath)
 }
 
 // ===== HELPER FUNCTIONS =====
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"fund-management-api/config"
 	"fund-management-api/models"
+	"fund-management-api/utils"
 	"net/http"
 	"strings"
 	"time"
@@ -136,6 +137,13 @@ func CreateApplication(c *gin.Context) {
 		return
 	}
 
+	if rejectOversizedText(c, "fund_application_details",
+		utils.TextField{Column: "project_title", Value: req.ProjectTitle},
+		utils.TextField{Column: "project_description", Value: req.ProjectDescription},
+	) {
+		return
+	}
+
 	userID, _ := c.Get("userID")
 
 	// Check if subcategory exists and has budget
@@ -275,6 +283,13 @@ func UpdateApplication(c *gin.Context) {
 		return
 	}
 
+	if rejectOversizedText(c, "fund_application_details",
+		utils.TextField{Column: "project_title", Value: req.ProjectTitle},
+		utils.TextField{Column: "project_description", Value: req.ProjectDescription},
+	) {
+		return
+	}
+
 	// Find application
 	var application models.FundApplication
 	if err := config.DB.Where("application_id = ? AND user_id = ? AND delete_at IS NULL", id, userID).
@@ -1050,6 +1065,10 @@ func CreateSubcategoryWithRoles(c *gin.Context) {
 		return
 	}
 
+	if rejectOversizedText(c, "fund_subcategories", utils.TextField{Column: "fund_condition", Value: req.FundCondition}) {
+		return
+	}
+
 	// Convert target_roles to JSON string
 	var targetRolesJSON *string
 	if len(req.TargetRoles) > 0 {
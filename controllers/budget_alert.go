@@ -0,0 +1,259 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"fund-management-api/config"
+	"fund-management-api/models"
+	"fund-management-api/utils"
+
+	"gorm.io/gorm"
+)
+
+const defaultBudgetAlertThresholdPercent = 10.0
+
+// budgetAlertMu serialises checks so an approval and the scheduled run cannot
+// both open an alert for the same dip.
+var budgetAlertMu sync.Mutex
+
+// budgetAlertThresholdPercent is the remaining-budget share (of allocated) at or
+// below which admins are emailed. BUDGET_ALERT_THRESHOLD_PERCENT=0 disables alerts.
+func budgetAlertThresholdPercent() float64 {
+	raw := strings.TrimSpace(os.Getenv("BUDGET_ALERT_THRESHOLD_PERCENT"))
+	if raw == "" {
+		return defaultBudgetAlertThresholdPercent
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil || value < 0 {
+		return defaultBudgetAlertThresholdPercent
+	}
+	if value > 100 {
+		return 100
+	}
+	return value
+}
+
+type budgetAlertUsage struct {
+	SubcategoryID   int
+	SubcategoryName string
+	CategoryName    string
+	YearLabel       string
+	AllocatedAmount float64
+	ApprovedAmount  float64
+}
+
+func (u budgetAlertUsage) remaining() float64 {
+	return u.AllocatedAmount - u.ApprovedAmount
+}
+
+func (u budgetAlertUsage) remainingPercent() float64 {
+	if u.AllocatedAmount <= 0 {
+		return 0
+	}
+	return u.remaining() / u.AllocatedAmount * 100
+}
+
+// loadBudgetAlertUsage compares each active subcategory's overall allocation
+// with the amounts approved against it. No IDs means every subcategory.
+func loadBudgetAlertUsage(db *gorm.DB, subcategoryIDs []int) ([]budgetAlertUsage, error) {
+	approvedIDs, err := utils.GetStatusIDsByCodes(utils.StatusCodeApproved, utils.StatusCodeAdminClosed)
+	if err != nil {
+		return nil, err
+	}
+
+	approvedTotals := db.Table("submissions s").
+		Select(`s.subcategory_id,
+			SUM(CASE WHEN s.submission_type = 'fund_application' THEN COALESCE(fad.approved_amount,0)
+			         WHEN s.submission_type = 'publication_reward' THEN COALESCE(prd.total_approve_amount, prd.reward_approve_amount, prd.reward_amount, 0)
+			         ELSE 0 END) AS approved_amount`).
+		Joins("LEFT JOIN fund_application_details fad ON fad.submission_id = s.submission_id").
+		Joins("LEFT JOIN publication_reward_details prd ON prd.submission_id = s.submission_id").
+		Where("s.deleted_at IS NULL AND s.subcategory_id IS NOT NULL").
+		Where("s.status_id IN ?", ensureIDs(approvedIDs)).
+		Group("s.subcategory_id")
+
+	query := db.Table("fund_subcategories fsc").
+		Select(`fsc.subcategory_id, fsc.subcategory_name, fc.category_name, y.year AS year_label,
+			sb.allocated_amount, COALESCE(approved.approved_amount, 0) AS approved_amount`).
+		Joins("JOIN subcategory_budgets sb ON sb.subcategory_id = fsc.subcategory_id AND sb.record_scope = 'overall' AND sb.delete_at IS NULL AND sb.status = 'active'").
+		Joins("LEFT JOIN fund_categories fc ON fc.category_id = fsc.category_id").
+		Joins("LEFT JOIN years y ON y.year_id = fc.year_id").
+		Joins("LEFT JOIN (?) approved ON approved.subcategory_id = fsc.subcategory_id", approvedTotals).
+		Where("fsc.delete_at IS NULL AND fsc.status = 'active' AND sb.allocated_amount > 0")
+	if len(subcategoryIDs) > 0 {
+		query = query.Where("fsc.subcategory_id IN ?", subcategoryIDs)
+	}
+
+	var rows []budgetAlertUsage
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// CheckBudgetAlerts emails admins when a subcategory's remaining budget drops to
+// the configured threshold. An open alert suppresses repeats until the budget
+// recovers above the threshold (e.g. the allocation is raised), which resolves it.
+func CheckBudgetAlerts(subcategoryIDs ...int) (sent int, err error) {
+	threshold := budgetAlertThresholdPercent()
+	if threshold <= 0 {
+		return 0, nil
+	}
+
+	budgetAlertMu.Lock()
+	defer budgetAlertMu.Unlock()
+
+	db := config.DB
+	usages, err := loadBudgetAlertUsage(db, subcategoryIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	var recipients []string
+	recipientsLoaded := false
+
+	for _, usage := range usages {
+		var open models.BudgetAlert
+		hasOpen := true
+		if err := db.Where("subcategory_id = ? AND resolved_at IS NULL", usage.SubcategoryID).
+			Order("alert_id DESC").
+			First(&open).Error; err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return sent, err
+			}
+			hasOpen = false
+		}
+
+		percent := usage.remainingPercent()
+		if percent > threshold {
+			if hasOpen {
+				now := time.Now()
+				if err := db.Model(&models.BudgetAlert{}).
+					Where("alert_id = ?", open.AlertID).
+					Update("resolved_at", now).Error; err != nil {
+					return sent, err
+				}
+			}
+			continue
+		}
+		if hasOpen {
+			continue
+		}
+
+		if !recipientsLoaded {
+			recipients = budgetAlertRecipients(db)
+			recipientsLoaded = true
+		}
+
+		joined := strings.Join(recipients, ",")
+		alert := models.BudgetAlert{
+			SubcategoryID:    usage.SubcategoryID,
+			ThresholdPercent: threshold,
+			AllocatedAmount:  usage.AllocatedAmount,
+			RemainingAmount:  usage.remaining(),
+			RemainingPercent: percent,
+			SentAt:           time.Now(),
+		}
+		if joined != "" {
+			alert.Recipients = &joined
+		}
+		// Record before sending so a slow or failing SMTP server cannot cause repeats.
+		if err := db.Create(&alert).Error; err != nil {
+			return sent, err
+		}
+
+		if len(recipients) == 0 {
+			log.Printf("[budgetAlert] subcategory %d at %.2f%% remaining but no admin email is configured", usage.SubcategoryID, percent)
+			continue
+		}
+		subject, html := buildBudgetAlertEmail(usage, threshold)
+		if err := config.SendMail(recipients, subject, html); err != nil {
+			log.Printf("[budgetAlert] failed to send alert for subcategory %d: %v", usage.SubcategoryID, err)
+			continue
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+// checkBudgetAlertsAfterApproval runs the check for one subcategory without
+// holding up the approval response.
+func checkBudgetAlertsAfterApproval(subcategoryID *int) {
+	if subcategoryID == nil || *subcategoryID <= 0 {
+		return
+	}
+	id := *subcategoryID
+	go func() {
+		if _, err := CheckBudgetAlerts(id); err != nil {
+			log.Printf("[budgetAlert] check after approval failed for subcategory %d: %v", id, err)
+		}
+	}()
+}
+
+// budgetAlertRecipients returns admin addresses, preferring the notification
+// email, plus any extra addresses in BUDGET_ALERT_EMAILS.
+func budgetAlertRecipients(db *gorm.DB) []string {
+	seen := map[string]bool{}
+	recipients := make([]string, 0)
+	add := func(addr string) {
+		addr = strings.TrimSpace(addr)
+		key := strings.ToLower(addr)
+		if addr == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		recipients = append(recipients, addr)
+	}
+
+	var admins []models.User
+	if err := db.Select("user_id, email, email_notification").
+		Where("role_id = ? AND delete_at IS NULL", 3).
+		Find(&admins).Error; err != nil {
+		log.Printf("[budgetAlert] failed to load admin recipients: %v", err)
+	}
+	for _, admin := range admins {
+		if admin.EmailNotification != nil && strings.TrimSpace(*admin.EmailNotification) != "" {
+			add(*admin.EmailNotification)
+			continue
+		}
+		add(admin.Email)
+	}
+
+	for _, addr := range strings.Split(os.Getenv("BUDGET_ALERT_EMAILS"), ",") {
+		add(addr)
+	}
+	return recipients
+}
+
+func buildBudgetAlertEmail(usage budgetAlertUsage, threshold float64) (string, string) {
+	name := strings.TrimSpace(usage.SubcategoryName)
+	if name == "" {
+		name = fmt.Sprintf("#%d", usage.SubcategoryID)
+	}
+	subject := fmt.Sprintf("แจ้งเตือนงบประมาณใกล้หมด: %s", name)
+
+	paragraphs := []string{
+		"เรียน ผู้ดูแลระบบ",
+		fmt.Sprintf("งบประมาณของทุน <strong>%s</strong> คงเหลือ %.2f%% ของวงเงินที่จัดสรร ซึ่งต่ำกว่าเกณฑ์แจ้งเตือน %.2f%%", name, usage.remainingPercent(), threshold),
+		"กรุณาตรวจสอบและพิจารณาปรับวงเงินหรือการอนุมัติคำร้องที่เกี่ยวข้อง",
+	}
+
+	meta := []emailMetaItem{
+		{Label: "ทุน", Value: name},
+		{Label: "หมวดทุน", Value: usage.CategoryName},
+		{Label: "ปีงบประมาณ", Value: usage.YearLabel},
+		{Label: "วงเงินที่จัดสรร", Value: formatAmount(usage.AllocatedAmount) + " บาท"},
+		{Label: "อนุมัติแล้ว", Value: formatAmount(usage.ApprovedAmount) + " บาท"},
+		{Label: "คงเหลือ", Value: formatAmount(usage.remaining()) + " บาท"},
+	}
+
+	return subject, buildEmailTemplate(subject, paragraphs, meta, "", "", "")
+}
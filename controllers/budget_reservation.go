@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"fund-management-api/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// budgetShortfallError reports an approval that the subcategory's remaining
+// budget cannot cover.
+type budgetShortfallError struct {
+	SubcategoryID int
+	Remaining     float64
+	Requested     float64
+}
+
+func (e *budgetShortfallError) Error() string {
+	return fmt.Sprintf("approval of %.2f exceeds the remaining budget of %.2f for subcategory %d",
+		e.Requested, e.Remaining, e.SubcategoryID)
+}
+
+// Shortfall is the amount missing from the remaining budget.
+func (e *budgetShortfallError) Shortfall() float64 {
+	return roundAmount(e.Requested - e.Remaining)
+}
+
+func (e *budgetShortfallError) response() gin.H {
+	return gin.H{
+		"success":          false,
+		"error":            "Approved amount exceeds the remaining budget",
+		"code":             "BUDGET_SHORTFALL",
+		"subcategory_id":   e.SubcategoryID,
+		"remaining_budget": roundAmount(e.Remaining),
+		"approved_amount":  roundAmount(e.Requested),
+		"shortfall":        e.Shortfall(),
+	}
+}
+
+// reserveSubcategoryBudget takes amount out of the subcategory's active overall
+// budget inside tx. The budget row is read with SELECT ... FOR UPDATE, so
+// concurrent approvals of the same subcategory queue on the row and the second
+// one sees the first one's decrement. It returns *budgetShortfallError when the
+// approval would make remaining_budget negative. Subcategories without an
+// overall budget are not guarded.
+func reserveSubcategoryBudget(tx *gorm.DB, subcategoryID *int, amount float64) error {
+	if subcategoryID == nil || *subcategoryID <= 0 || amount <= 0 {
+		return nil
+	}
+
+	var budget models.SubcategoryBudget
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("subcategory_id = ? AND status = 'active' AND delete_at IS NULL AND record_scope = 'overall'", *subcategoryID).
+		First(&budget).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if budget.RemainingBudget-amount < -publicationAmountTolerance {
+		return &budgetShortfallError{
+			SubcategoryID: *subcategoryID,
+			Remaining:     budget.RemainingBudget,
+			Requested:     amount,
+		}
+	}
+
+	return tx.Exec(
+		"UPDATE subcategory_budgets SET remaining_budget = remaining_budget - ?, used_amount = used_amount + ?, update_at = ? WHERE subcategory_budget_id = ?",
+		amount, amount, time.Now(), budget.SubcategoryBudgetID,
+	).Error
+}
+
+// submissionApprovedAmount returns the amount recorded as approved on the
+// submission's type-specific details.
+func submissionApprovedAmount(tx *gorm.DB, submission *models.Submission) (float64, error) {
+	var total struct {
+		Amount *float64 `gorm:"column:amount"`
+	}
+	var err error
+	switch submission.SubmissionType {
+	case "publication_reward":
+		err = tx.Table("publication_reward_details").
+			Select("COALESCE(SUM(total_approve_amount),0) AS amount").
+			Where("submission_id = ?", submission.SubmissionID).
+			Scan(&total).Error
+	case "fund_application":
+		err = tx.Table("fund_application_details").
+			Select("COALESCE(SUM(approved_amount),0) AS amount").
+			Where("submission_id = ?", submission.SubmissionID).
+			Scan(&total).Error
+	default:
+		return 0, nil
+	}
+	if err != nil || total.Amount == nil {
+		return 0, err
+	}
+	return math.Max(*total.Amount, 0), nil
+}
@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// lockingBudgetStore models one overall subcategory_budgets row. A budget read
+// without FOR UPDATE is rejected so the test fails if the guard stops locking.
+type lockingBudgetStore struct {
+	mu        sync.Mutex
+	remaining float64
+	used      float64
+}
+
+func (s *lockingBudgetStore) snapshot() (float64, float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.remaining, s.used
+}
+
+func newLockingBudgetDB(t *testing.T, store *lockingBudgetStore) *gorm.DB {
+	t.Helper()
+
+	return newLockingGormDB(t, 2, lockingSQLHandler{
+		query: func(query string, _ []driver.NamedValue, locked bool) ([]string, []driver.Value, error) {
+			if !strings.Contains(query, "subcategory_budgets") {
+				return nil, nil, fmt.Errorf("unexpected query: %s", query)
+			}
+			if !locked {
+				return nil, nil, fmt.Errorf("budget read without row lock: %s", query)
+			}
+			remaining, used := store.snapshot()
+			return []string{"subcategory_budget_id", "subcategory_id", "record_scope", "allocated_amount", "used_amount", "remaining_budget", "status"},
+				[]driver.Value{int64(11), int64(5), "overall", 1000.0, used, remaining, "active"}, nil
+		},
+		exec: func(query string, args []driver.NamedValue, locked bool) (int64, error) {
+			if !strings.HasPrefix(query, "UPDATE subcategory_budgets SET remaining_budget = remaining_budget - ?") {
+				return 0, fmt.Errorf("unexpected exec: %s", query)
+			}
+			if !locked {
+				return 0, errors.New("budget updated without row lock")
+			}
+			amount := args[0].Value.(float64)
+			store.mu.Lock()
+			store.remaining -= amount
+			store.used += amount
+			store.mu.Unlock()
+			return 1, nil
+		},
+	})
+}
+
+func TestReserveSubcategoryBudget_ConcurrentApprovalsFundOnlyOne(t *testing.T) {
+	store := &lockingBudgetStore{remaining: 1000}
+	db := newLockingBudgetDB(t, store)
+	subcategoryID := 5
+
+	start := make(chan struct{})
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			errs[i] = db.Transaction(func(tx *gorm.DB) error {
+				return reserveSubcategoryBudget(tx, &subcategoryID, 700)
+			})
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	approved, shortfalls := 0, 0
+	for _, err := range errs {
+		var shortfall *budgetShortfallError
+		switch {
+		case err == nil:
+			approved++
+		case errors.As(err, &shortfall):
+			shortfalls++
+			if shortfall.Shortfall() != 400 {
+				t.Fatalf("expected a shortfall of 400, got %v", shortfall.Shortfall())
+			}
+		default:
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if approved != 1 || shortfalls != 1 {
+		t.Fatalf("expected one approval and one shortfall, got %d and %d", approved, shortfalls)
+	}
+
+	remaining, used := store.snapshot()
+	if remaining != 300 || used != 700 {
+		t.Fatalf("expected remaining 300 and used 700, got %v and %v", remaining, used)
+	}
+}
+
+func TestReserveSubcategoryBudget_SkipsWithoutSubcategory(t *testing.T) {
+	store := &lockingBudgetStore{remaining: 0}
+	db := newLockingBudgetDB(t, store)
+
+	if err := reserveSubcategoryBudget(db, nil, 500); err != nil {
+		t.Fatalf("expected no guard without a subcategory, got %v", err)
+	}
+}
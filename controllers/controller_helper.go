@@ -5,6 +5,8 @@ import (
     "log"
     "net/http"
 
+    "fund-management-api/utils"
+
     "github.com/gin-gonic/gin"
 )
 
@@ -26,6 +28,18 @@ func InternalError(c *gin.Context, context string, err error) {
     c.JSON(http.StatusInternalServerError, gin.H{"success": false, "error": detail})
 }
 
+// rejectOversizedText answers 400 with a per-field error list when any value is
+// longer than its column allows (see utils.ValidateTextLengths), so oversized
+// input never reaches the DB. It reports whether the request was rejected.
+func rejectOversizedText(c *gin.Context, table string, fields ...utils.TextField) bool {
+    fieldErrors := utils.ValidateTextLengths(table, fields...)
+    if len(fieldErrors) == 0 {
+        return false
+    }
+    c.JSON(http.StatusBadRequest, utils.NewFieldErrorResponse("FIELD_TOO_LONG", fieldErrors))
+    return true
+}
+
 func parseDeleteParams(c *gin.Context, idErrMsg string) (id uint, editorID int, ok bool) {
     var rawID uint
     if _, err := fmt.Sscanf(c.Param("id"), "%d", &rawID); err != nil || rawID == 0 {
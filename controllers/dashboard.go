@@ -99,6 +99,7 @@ type dashboardFilter struct {
 	CurrentYear         string
 	ActiveInstallment   *int
 	ExcludedStatusIDs   []int
+	CategoryID          *int
 }
 
 type dashboardStatusSets struct {
@@ -198,6 +199,9 @@ func (f dashboardFilter) toMap() map[string]interface{} {
 	if f.SelectedInstallment != nil {
 		result["installment"] = *f.SelectedInstallment
 	}
+	if f.CategoryID != nil {
+		result["category_id"] = *f.CategoryID
+	}
 	return result
 }
 
@@ -223,6 +227,9 @@ func applyFilterToSubmissions(query *gorm.DB, alias string, filter dashboardFilt
 	if len(filter.ExcludedStatusIDs) > 0 {
 		query = query.Where(fmt.Sprintf("%s.status_id NOT IN ?", alias), filter.ExcludedStatusIDs)
 	}
+	if filter.CategoryID != nil {
+		query = query.Where(fmt.Sprintf("%s.category_id = ?", alias), *filter.CategoryID)
+	}
 	return query
 }
 
@@ -532,61 +539,52 @@ func getUserDashboard(userID int) map[string]interface{} {
 	}
 
 	currentYear := time.Now().Format("2006")
+	budgetUsage.UsedBudget = getUserBudgetUsed(userID, currentYear)
+
+	// Year-level budget ceiling removed from schema; only usage totals remain.
+	budgetUsage.YearBudget = 0
+	budgetUsage.RemainingBudget = 0
+
+	stats["budget_usage"] = budgetUsage
+
+	return stats
+}
+
+// getUserBudgetUsed sums the approved fund application and publication reward
+// amounts of a user's submissions in the given year. Nothing is counted when the
+// approved status cannot be resolved.
+func getUserBudgetUsed(userID int, year string) float64 {
+	approvedStatusID, err := utils.GetStatusIDByCode(utils.StatusCodeApproved)
+	if err != nil || approvedStatusID <= 0 {
+		return 0
+	}
+
 	// Approved fund application amounts
+	var fundUsed float64
 	config.DB.Table("fund_application_details fad").
 		Joins("JOIN submissions s ON fad.submission_id = s.submission_id").
 		Joins("JOIN years y ON s.year_id = y.year_id").
-		Where("s.user_id = ? AND y.year = ? AND s.status_id = 2", userID, currentYear).
+		Where("s.user_id = ? AND y.year = ? AND s.status_id = ?", userID, year, approvedStatusID).
 		Select("COALESCE(SUM(fad.approved_amount), 0)").
-		Scan(&budgetUsage.UsedBudget)
+		Scan(&fundUsed)
 
 	// Approved publication reward amounts
 	var rewardUsed float64
 	config.DB.Table("publication_reward_details prd").
 		Joins("JOIN submissions s ON prd.submission_id = s.submission_id").
 		Joins("JOIN years y ON s.year_id = y.year_id").
-		Where("s.user_id = ? AND y.year = ? AND s.status_id = 2", userID, currentYear).
+		Where("s.user_id = ? AND y.year = ? AND s.status_id = ?", userID, year, approvedStatusID).
 		Select("COALESCE(SUM(prd.reward_approve_amount), 0)").
 		Scan(&rewardUsed)
-	budgetUsage.UsedBudget += rewardUsed
-
-	// Year-level budget ceiling removed from schema; only usage totals remain.
-	budgetUsage.YearBudget = 0
-	budgetUsage.RemainingBudget = 0
 
-	stats["budget_usage"] = budgetUsage
-
-	return stats
+	return fundUsed + rewardUsed
 }
 
 // getAdminDashboard returns dashboard for admin users
 func getAdminDashboard(filter dashboardFilter, options dashboardFilterOptions) map[string]interface{} {
 	stats := make(map[string]interface{})
 
-	statusSets := dashboardStatusSets{}
-
-	if pendingIDs, err := utils.GetStatusIDsByCodes(utils.StatusCodePending, utils.StatusCodeDeptHeadPending, utils.StatusCodeNeedsMoreInfo); err == nil {
-		statusSets.Pending = append(statusSets.Pending, pendingIDs...)
-	}
-
-	if approvedIDs, err := utils.GetStatusIDsByCodes(utils.StatusCodeApproved, utils.StatusCodeAdminClosed); err == nil {
-		statusSets.Approved = append(statusSets.Approved, approvedIDs...)
-	}
-
-	if rejectedIDs, err := utils.GetStatusIDsByCodes(utils.StatusCodeRejected, utils.StatusCodeDeptHeadNotRecommended); err == nil {
-		statusSets.Rejected = append(statusSets.Rejected, rejectedIDs...)
-	}
-
-	if draftIDs, err := utils.GetStatusIDsByCodes(utils.StatusCodeDraft); err == nil {
-		filter.ExcludedStatusIDs = append(filter.ExcludedStatusIDs, draftIDs...)
-		statusSets.Excluded = append(statusSets.Excluded, draftIDs...)
-	}
-
-	statusSets.Pending = uniqueInts(statusSets.Pending)
-	statusSets.Approved = uniqueInts(statusSets.Approved)
-	statusSets.Rejected = uniqueInts(statusSets.Rejected)
-	statusSets.Excluded = uniqueInts(statusSets.Excluded)
-	filter.ExcludedStatusIDs = uniqueInts(filter.ExcludedStatusIDs)
+	statusSets := resolveAdminDashboardStatusSets(&filter)
 
 	stats["overview"] = buildAdminOverview(filter, statusSets)
 	stats["category_budgets"] = buildAdminCategoryBudgets(filter, statusSets)
@@ -626,26 +624,129 @@ func getAdminDashboard(filter dashboardFilter, options dashboardFilterOptions) m
 	return stats
 }
 
-// getMonthlyStats returns monthly statistics for a user
-func getMonthlyStats(userID int, months int) []map[string]interface{} {
-	var monthlyData []map[string]interface{}
+// GetAdminFinancialOverview returns only the financial_overview section of the admin
+// dashboard. Accepts the same scope/year/installment params as /dashboard/stats.
+func GetAdminFinancialOverview(c *gin.Context) {
+	filter, _ := resolveDashboardFilter(c.Query("scope"), c.Query("year"), c.Query("installment"))
+	statusSets := resolveAdminDashboardStatusSets(&filter)
+
+	overview := buildAdminFinancialOverview(filter, statusSets)
+	if overview == nil {
+		overview = map[string]interface{}{}
+	}
 
+	c.JSON(http.StatusOK, gin.H{
+		"success":            true,
+		"financial_overview": overview,
+		"applied_filter":     filter.toMap(),
+	})
+}
+
+// resolveAdminDashboardStatusSets groups status IDs used by the admin dashboard and
+// excludes drafts from the filter.
+func resolveAdminDashboardStatusSets(filter *dashboardFilter) dashboardStatusSets {
+	statusSets := dashboardStatusSets{}
+
+	if pendingIDs, err := utils.GetStatusIDsByCodes(utils.StatusCodePending, utils.StatusCodeDeptHeadPending, utils.StatusCodeNeedsMoreInfo); err == nil {
+		statusSets.Pending = append(statusSets.Pending, pendingIDs...)
+	}
+
+	if approvedIDs, err := utils.GetStatusIDsByCodes(utils.StatusCodeApproved, utils.StatusCodeAdminClosed); err == nil {
+		statusSets.Approved = append(statusSets.Approved, approvedIDs...)
+	}
+
+	if rejectedIDs, err := utils.GetStatusIDsByCodes(utils.StatusCodeRejected, utils.StatusCodeDeptHeadNotRecommended); err == nil {
+		statusSets.Rejected = append(statusSets.Rejected, rejectedIDs...)
+	}
+
+	if draftIDs, err := utils.GetStatusIDsByCodes(utils.StatusCodeDraft); err == nil {
+		filter.ExcludedStatusIDs = append(filter.ExcludedStatusIDs, draftIDs...)
+		statusSets.Excluded = append(statusSets.Excluded, draftIDs...)
+	}
+
+	statusSets.Pending = uniqueInts(statusSets.Pending)
+	statusSets.Approved = uniqueInts(statusSets.Approved)
+	statusSets.Rejected = uniqueInts(statusSets.Rejected)
+	statusSets.Excluded = uniqueInts(statusSets.Excluded)
+	filter.ExcludedStatusIDs = uniqueInts(filter.ExcludedStatusIDs)
+
+	return statusSets
+}
+
+// monthWindow is a half-open calendar-month range [Start, End).
+type monthWindow struct {
+	Start time.Time
+	End   time.Time
+}
+
+// monthlyStatsWindows returns the last months calendar months up to and
+// including now's month, oldest first. Windows are anchored on the first of the
+// month so that AddDate never normalises e.g. 31 November into December.
+func monthlyStatsWindows(now time.Time, months int) []monthWindow {
+	if months <= 0 {
+		return nil
+	}
+	current := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	windows := make([]monthWindow, 0, months)
 	for i := months - 1; i >= 0; i-- {
-		monthStart := time.Now().AddDate(0, -i, 0).Format("2006-01")
-		monthEnd := time.Now().AddDate(0, -i+1, 0).Format("2006-01")
+		start := current.AddDate(0, -i, 0)
+		windows = append(windows, monthWindow{Start: start, End: start.AddDate(0, 1, 0)})
+	}
+	return windows
+}
 
-		stats := make(map[string]interface{})
-		config.DB.Table("fund_applications").
-			Select(`COUNT(*) as applications,
-				                COUNT(CASE WHEN application_status_id = 2 THEN 1 END) as approved,
-                                COUNT(CASE WHEN application_status_id = 3 THEN 1 END) as rejected,
-                                COALESCE(SUM(CASE WHEN application_status_id = 2 THEN approved_amount ELSE 0 END), 0) as approved_amount`).
-			Where("user_id = ? AND submitted_at >= ? AND submitted_at < ? AND delete_at IS NULL",
-				userID, monthStart+"-01", monthEnd+"-01").
-			Scan(&stats)
+// getMonthlyStats returns monthly statistics for a user's fund applications and
+// publication rewards, bucketed by submitted date (created date for drafts that
+// were never submitted). Drafts are not counted.
+func getMonthlyStats(userID int, months int) []map[string]interface{} {
+	var monthlyData []map[string]interface{}
+
+	// -1 matches no row, so an unresolvable status counts as zero.
+	approvedStatusID, err := utils.GetStatusIDByCode(utils.StatusCodeApproved)
+	if err != nil || approvedStatusID <= 0 {
+		approvedStatusID = -1
+	}
+	rejectedStatusID, err := utils.GetStatusIDByCode(utils.StatusCodeRejected)
+	if err != nil || rejectedStatusID <= 0 {
+		rejectedStatusID = -1
+	}
+	draftStatusID, err := utils.GetStatusIDByCode(utils.StatusCodeDraft)
+	if err != nil || draftStatusID <= 0 {
+		draftStatusID = -1
+	}
 
-		stats["month"] = monthStart
-		monthlyData = append(monthlyData, stats)
+	dateExpr := submissionDateExpression
+	for _, window := range monthlyStatsWindows(time.Now(), months) {
+		var row struct {
+			Applications   int64
+			Approved       int64
+			Rejected       int64
+			ApprovedAmount float64
+		}
+		config.DB.Table("submissions s").
+			Select(`COUNT(*) AS applications,
+                COUNT(CASE WHEN s.status_id = ? THEN 1 END) AS approved,
+                COUNT(CASE WHEN s.status_id = ? THEN 1 END) AS rejected,
+                COALESCE(SUM(CASE WHEN s.status_id = ? THEN
+                        CASE WHEN s.submission_type = 'fund_application' THEN COALESCE(fad.approved_amount,0)
+                             WHEN s.submission_type = 'publication_reward' THEN COALESCE(prd.total_approve_amount, prd.reward_approve_amount, 0)
+                             ELSE 0 END
+                     ELSE 0 END), 0) AS approved_amount`,
+				approvedStatusID, rejectedStatusID, approvedStatusID).
+			Joins("LEFT JOIN fund_application_details fad ON s.submission_id = fad.submission_id").
+			Joins("LEFT JOIN publication_reward_details prd ON s.submission_id = prd.submission_id").
+			Where("s.user_id = ? AND s.submission_type IN ? AND s.status_id <> ? AND s.deleted_at IS NULL",
+				userID, []string{"fund_application", "publication_reward"}, draftStatusID).
+			Where(fmt.Sprintf("%s >= ? AND %s < ?", dateExpr, dateExpr), window.Start, window.End).
+			Scan(&row)
+
+		monthlyData = append(monthlyData, map[string]interface{}{
+			"month":           window.Start.Format("2006-01"),
+			"applications":    row.Applications,
+			"approved":        row.Approved,
+			"rejected":        row.Rejected,
+			"approved_amount": row.ApprovedAmount,
+		})
 	}
 
 	return monthlyData
@@ -715,12 +816,23 @@ func buildAdminOverview(filter dashboardFilter, statuses dashboardStatusSets) ma
 		overview["approval_rate"] = 0.0
 	}
 
+	// users soft-deletes via delete_at (not deleted_at like submissions).
 	var totalUsers int64
-	config.DB.Table("users").
-		Where("delete_at IS NULL").
+	config.DB.Table("users u").
+		Where("u.delete_at IS NULL").
 		Count(&totalUsers)
 	overview["total_users"] = totalUsers
 
+	// total_users ignores the dashboard filter; active_users counts applicants
+	// who submitted at least once within the filtered scope.
+	var activeUsers int64
+	activeUsersQuery := config.DB.Table("submissions s").
+		Joins("JOIN users u ON u.user_id = s.user_id AND u.delete_at IS NULL").
+		Where("s.submission_type IN ? AND s.deleted_at IS NULL AND s.submitted_at IS NOT NULL", submissionTypes)
+	activeUsersQuery = applyFilterToSubmissions(activeUsersQuery, "s", filter)
+	activeUsersQuery.Distinct("s.user_id").Count(&activeUsers)
+	overview["active_users"] = activeUsers
+
 	type amountSummary struct {
 		Requested float64
 		Approved  float64
@@ -1149,7 +1261,30 @@ func buildAdminPendingApplications(filter dashboardFilter, statuses dashboardSta
 	return pendingApplications
 }
 
-func buildAdminQuotaSummary(filter dashboardFilter, statuses dashboardStatusSets, rawViewRows []map[string]interface{}) []map[string]interface{} {
+// quotaSummaryRow is one user's usage of one subcategory in one year.
+type quotaSummaryRow struct {
+	Year              string
+	YearID            int
+	UserID            int
+	UserName          string
+	CategoryID        int
+	CategoryName      string
+	SubcategoryID     int
+	SubcategoryName   string
+	AllocatedAmount   float64
+	UsedAmount        float64
+	RemainingBudget   float64
+	MaxGrants         float64
+	UsedGrants        float64
+	RemainingGrants   float64
+	MaxAmountPerYear  float64
+	MaxAmountPerGrant float64
+}
+
+// collectAdminQuotaSummaryRows combines the usage view with approved
+// submissions and returns every user/subcategory quota row in the filter,
+// highest usage first.
+func collectAdminQuotaSummaryRows(filter dashboardFilter, statuses dashboardStatusSets, rawViewRows []map[string]interface{}) []quotaSummaryRow {
 	logQuotaUsageViewData(filter, rawViewRows)
 
 	viewUsage := fetchUsageAggregatesFromView(filter)
@@ -1298,25 +1433,6 @@ func buildAdminQuotaSummary(filter dashboardFilter, statuses dashboardStatusSets
 		}
 	}
 
-	type quotaSummaryRow struct {
-		Year              string
-		YearID            int
-		UserID            int
-		UserName          string
-		CategoryID        int
-		CategoryName      string
-		SubcategoryID     int
-		SubcategoryName   string
-		AllocatedAmount   float64
-		UsedAmount        float64
-		RemainingBudget   float64
-		MaxGrants         float64
-		UsedGrants        float64
-		RemainingGrants   float64
-		MaxAmountPerYear  float64
-		MaxAmountPerGrant float64
-	}
-
 	summaryRows := make([]quotaSummaryRow, 0, len(usageByKey))
 	for key, usage := range usageByKey {
 		if usage.YearID == 0 || usage.SubcategoryID == 0 || usage.UserID == 0 {
@@ -1393,6 +1509,14 @@ func buildAdminQuotaSummary(filter dashboardFilter, statuses dashboardStatusSets
 		return summaryRows[i].UsedAmount > summaryRows[j].UsedAmount
 	})
 
+	return summaryRows
+}
+
+func buildAdminQuotaSummary(filter dashboardFilter, statuses dashboardStatusSets, rawViewRows []map[string]interface{}) []map[string]interface{} {
+	summaryRows := collectAdminQuotaSummaryRows(filter, statuses, rawViewRows)
+	if summaryRows == nil {
+		return nil
+	}
 	if len(summaryRows) > 100 {
 		summaryRows = summaryRows[:100]
 	}
@@ -2014,6 +2138,7 @@ func buildAdminUpcomingInstallments(filter dashboardFilter) []map[string]interfa
 	}
 
 	now := time.Now()
+	holidays := utils.PublicHolidays()
 	openIndex := -1
 	for idx, row := range rows {
 		if !row.CutoffDate.Before(now) {
@@ -2030,7 +2155,8 @@ func buildAdminUpcomingInstallments(filter dashboardFilter) []map[string]interfa
 			periodLabel = fmt.Sprintf("รอบที่ %d", row.InstallmentNumber)
 		}
 
-		remainingDays := int(math.Ceil(cutoff.Sub(now).Hours() / 24))
+		calendarDaysRemaining := int(math.Ceil(cutoff.Sub(now).Hours() / 24))
+		remainingDays := utils.CountBusinessDays(now, cutoff, holidays)
 		status := "closed"
 
 		if openIndex == -1 {
@@ -2047,14 +2173,15 @@ func buildAdminUpcomingInstallments(filter dashboardFilter) []map[string]interfa
 		}
 
 		periods = append(periods, map[string]interface{}{
-			"installment":     row.InstallmentNumber,
-			"name":            periodLabel,
-			"cutoff_date":     row.CutoffDate.Format("2006-01-02"),
-			"year":            row.Year,
-			"fund_keyword":    row.FundKeyword,
-			"days_remaining":  remainingDays,
-			"status":          status,
-			"cutoff_datetime": row.CutoffDate.Format(time.RFC3339),
+			"installment":             row.InstallmentNumber,
+			"name":                    periodLabel,
+			"cutoff_date":             row.CutoffDate.Format("2006-01-02"),
+			"year":                    row.Year,
+			"fund_keyword":            row.FundKeyword,
+			"days_remaining":          remainingDays,
+			"calendar_days_remaining": calendarDaysRemaining,
+			"status":                  status,
+			"cutoff_datetime":         row.CutoffDate.Format(time.RFC3339),
 		})
 	}
 
@@ -2065,7 +2192,57 @@ func buildAdminUpcomingInstallments(filter dashboardFilter) []map[string]interfa
 	return periods
 }
 
-func buildAdminActivityFeed(filter dashboardFilter) []map[string]interface{} {
+const (
+	defaultActivityFeedLimit = 12
+	defaultTopUsersLimit     = 8
+	maxDashboardListLimit    = 100
+)
+
+// parseDashboardListLimit reads the optional limit query parameter, capped at
+// maxDashboardListLimit. It responds 400 and returns false when limit is invalid.
+func parseDashboardListLimit(c *gin.Context, fallback int) (int, bool) {
+	raw := strings.TrimSpace(c.Query("limit"))
+	if raw == "" {
+		return fallback, true
+	}
+	parsed, err := strconv.Atoi(raw)
+	if err != nil || parsed <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid limit"})
+		return 0, false
+	}
+	if parsed > maxDashboardListLimit {
+		parsed = maxDashboardListLimit
+	}
+	return parsed, true
+}
+
+// GetDashboardActivity returns the admin activity feed (recent audit log entries)
+// within the dashboard scope. Accepts the same scope/year/installment parameters
+// as GetDashboardStats plus limit (default 12, max 100).
+// GET /dashboard/activity
+func GetDashboardActivity(c *gin.Context) {
+	limit, ok := parseDashboardListLimit(c, defaultActivityFeedLimit)
+	if !ok {
+		return
+	}
+
+	filter, _ := resolveDashboardFilter(c.Query("scope"), c.Query("year"), c.Query("installment"))
+
+	feed, err := buildAdminActivityFeed(filter, limit)
+	if err != nil {
+		InternalError(c, "dashboard activity", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":        true,
+		"activity":       feed,
+		"limit":          limit,
+		"applied_filter": filter.toMap(),
+	})
+}
+
+func buildAdminActivityFeed(filter dashboardFilter, limit int) ([]map[string]interface{}, error) {
 	var rows []struct {
 		LogID        int
 		CreatedAt    time.Time
@@ -2089,9 +2266,11 @@ func buildAdminActivityFeed(filter dashboardFilter) []map[string]interface{} {
 		query = query.Where("s.submission_id IS NULL OR s.year_id IN ?", filter.YearIDs)
 	}
 
-	query.Order("al.created_at DESC").
-		Limit(12).
-		Scan(&rows)
+	if err := query.Order("al.created_at DESC").
+		Limit(limit).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
 
 	feed := make([]map[string]interface{}, 0, len(rows))
 	for _, row := range rows {
@@ -2107,10 +2286,37 @@ func buildAdminActivityFeed(filter dashboardFilter) []map[string]interface{} {
 		})
 	}
 
-	return feed
+	return feed, nil
 }
 
-func buildAdminTopUsers(filter dashboardFilter, statuses dashboardStatusSets) []map[string]interface{} {
+// GetDashboardTopUsers returns the most active users with their submission and
+// approval counts within the dashboard scope. Accepts scope/year/installment and
+// limit (default 8, max 100).
+// GET /dashboard/top-users
+func GetDashboardTopUsers(c *gin.Context) {
+	limit, ok := parseDashboardListLimit(c, defaultTopUsersLimit)
+	if !ok {
+		return
+	}
+
+	filter, _ := resolveDashboardFilter(c.Query("scope"), c.Query("year"), c.Query("installment"))
+	statuses := resolveAdminDashboardStatusSets(&filter)
+
+	users, err := buildAdminTopUsers(filter, statuses, limit)
+	if err != nil {
+		InternalError(c, "dashboard top users", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":        true,
+		"top_users":      users,
+		"limit":          limit,
+		"applied_filter": filter.toMap(),
+	})
+}
+
+func buildAdminTopUsers(filter dashboardFilter, statuses dashboardStatusSets, limit int) ([]map[string]interface{}, error) {
 	submissionTypes := []string{"fund_application", "publication_reward"}
 	approvedIDs := ensureIDs(statuses.Approved)
 
@@ -2133,7 +2339,7 @@ func buildAdminTopUsers(filter dashboardFilter, statuses dashboardStatusSets) []
 		ApprovedCount   int64
 	}
 
-	config.DB.Table("v_user_activity_summary vus").
+	if err := config.DB.Table("v_user_activity_summary vus").
 		Select(`vus.user_id,
             vus.user_name,
             COALESCE(vus.login_count,0) AS login_count,
@@ -2146,8 +2352,10 @@ func buildAdminTopUsers(filter dashboardFilter, statuses dashboardStatusSets) []
             COALESCE(subs.approved_count,0) AS approved_count`).
 		Joins("LEFT JOIN (?) AS subs ON subs.user_id = vus.user_id", submissionsSubQuery).
 		Order("total_actions DESC").
-		Limit(8).
-		Scan(&rows)
+		Limit(limit).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
 
 	summaries := make([]map[string]interface{}, 0, len(rows))
 	for _, row := range rows {
@@ -2170,29 +2378,116 @@ func buildAdminTopUsers(filter dashboardFilter, statuses dashboardStatusSets) []
 		})
 	}
 
-	return summaries
+	return summaries, nil
 }
 
+// trendGranularities lists the trend views in the order the dashboard builds them.
+var trendGranularities = []string{"monthly", "yearly", "quarterly", "installment"}
+
 func buildSystemTrendBreakdown(filter dashboardFilter, statuses dashboardStatusSets) map[string][]map[string]interface{} {
 	breakdown := make(map[string][]map[string]interface{})
 
-	if monthly := buildMonthlyTrend(filter, statuses); len(monthly) > 0 {
-		breakdown["monthly"] = monthly
+	for _, granularity := range trendGranularities {
+		if trend := buildTrendByGranularity(granularity, filter, statuses); len(trend) > 0 {
+			breakdown[granularity] = trend
+		}
 	}
 
-	if yearly := buildYearlyTrend(filter, statuses); len(yearly) > 0 {
-		breakdown["yearly"] = yearly
+	return breakdown
+}
+
+// buildTrendByGranularity computes a single trend view; unknown granularities return nil.
+func buildTrendByGranularity(granularity string, filter dashboardFilter, statuses dashboardStatusSets) []map[string]interface{} {
+	switch granularity {
+	case "monthly":
+		return buildMonthlyTrend(filter, statuses)
+	case "yearly":
+		return buildYearlyTrend(filter, statuses)
+	case "quarterly":
+		return buildQuarterlyTrend(filter, statuses)
+	case "installment":
+		return buildInstallmentTrend(filter, statuses)
+	}
+	return nil
+}
+
+// parseTrendGranularity reads ?granularity= (default monthly) and writes a 400
+// when it is not one of trendGranularities.
+func parseTrendGranularity(c *gin.Context) (string, bool) {
+	granularity := strings.ToLower(strings.TrimSpace(c.DefaultQuery("granularity", "monthly")))
+	for _, allowed := range trendGranularities {
+		if granularity == allowed {
+			return granularity, true
+		}
 	}
+	c.JSON(http.StatusBadRequest, gin.H{
+		"success": false,
+		"error":   "granularity must be one of: " + strings.Join(trendGranularities, ", "),
+	})
+	return "", false
+}
 
-	if quarterly := buildQuarterlyTrend(filter, statuses); len(quarterly) > 0 {
-		breakdown["quarterly"] = quarterly
+// GetAdminTrends returns one trend view (?granularity=monthly|yearly|quarterly|installment)
+// for the dashboard scope/year/installment params.
+func GetAdminTrends(c *gin.Context) {
+	granularity, ok := parseTrendGranularity(c)
+	if !ok {
+		return
 	}
 
-	if installments := buildInstallmentTrend(filter, statuses); len(installments) > 0 {
-		breakdown["installment"] = installments
+	filter, _ := resolveDashboardFilter(c.Query("scope"), c.Query("year"), c.Query("installment"))
+	statusSets := resolveAdminDashboardStatusSets(&filter)
+
+	trend := buildTrendByGranularity(granularity, filter, statusSets)
+	if trend == nil {
+		trend = []map[string]interface{}{}
 	}
 
-	return breakdown
+	c.JSON(http.StatusOK, gin.H{
+		"success":        true,
+		"granularity":    granularity,
+		"trend":          trend,
+		"applied_filter": filter.toMap(),
+	})
+}
+
+// GetAdminCategoryTrend returns one trend view restricted to a single fund
+// category. scope defaults to "all" so the whole history of the category is shown.
+// GET /admin/categories/:id/trend?granularity=&scope=&year=&installment=
+func GetAdminCategoryTrend(c *gin.Context) {
+	categoryID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || categoryID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid category id"})
+		return
+	}
+	granularity, ok := parseTrendGranularity(c)
+	if !ok {
+		return
+	}
+
+	var category models.FundCategory
+	if err := config.DB.Where("category_id = ? AND delete_at IS NULL", categoryID).First(&category).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "Category not found"})
+		return
+	}
+
+	filter, _ := resolveDashboardFilter(c.DefaultQuery("scope", "all"), c.Query("year"), c.Query("installment"))
+	statusSets := resolveAdminDashboardStatusSets(&filter)
+	filter.CategoryID = &categoryID
+
+	trend := buildTrendByGranularity(granularity, filter, statusSets)
+	if trend == nil {
+		trend = []map[string]interface{}{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":        true,
+		"category_id":    category.CategoryID,
+		"category_name":  category.CategoryName,
+		"granularity":    granularity,
+		"trend":          trend,
+		"applied_filter": filter.toMap(),
+	})
 }
 
 func parseThaiYear(year string) (int, bool) {
@@ -2250,6 +2545,28 @@ func monthPeriodsForFilter(filter dashboardFilter) []string {
 	return periods
 }
 
+// mergeTrendPeriods returns the generated periods plus any periods that only appear
+// in the data, sorted. Unparseable filter years yield no generated periods; rows
+// returned by the query must still be shown rather than silently dropped.
+func mergeTrendPeriods(generated []string, dataPeriods []string) []string {
+	seen := make(map[string]struct{}, len(generated)+len(dataPeriods))
+	merged := make([]string, 0, len(generated)+len(dataPeriods))
+	for _, list := range [][]string{generated, dataPeriods} {
+		for _, period := range list {
+			if period == "" {
+				continue
+			}
+			if _, exists := seen[period]; exists {
+				continue
+			}
+			seen[period] = struct{}{}
+			merged = append(merged, period)
+		}
+	}
+	sort.Strings(merged)
+	return merged
+}
+
 func buildMonthlyTrend(filter dashboardFilter, statuses dashboardStatusSets) []map[string]interface{} {
 	submissionTypes := []string{"fund_application", "publication_reward"}
 	approvedIDs := ensureIDs(statuses.Approved)
@@ -2309,32 +2626,28 @@ func buildMonthlyTrend(filter dashboardFilter, statuses dashboardStatusSets) []m
 	})
 
 	for _, row := range rows {
-		dataByPeriod[row.Period] = struct {
-			ThaiYear       *string
-			FundTotal      float64
-			RewardTotal    float64
-			FundApproved   float64
-			RewardApproved float64
-			TotalRequested float64
-			TotalApproved  float64
-		}{
-			ThaiYear:       row.ThaiYear,
-			FundTotal:      row.FundTotal,
-			RewardTotal:    row.RewardTotal,
-			FundApproved:   row.FundApproved,
-			RewardApproved: row.RewardApproved,
-			TotalRequested: row.TotalRequested,
-			TotalApproved:  row.TotalApproved,
-		}
-	}
-
-	periods := monthPeriodsForFilter(filter)
-	if len(periods) == 0 {
-		for period := range dataByPeriod {
-			periods = append(periods, period)
-		}
-		sort.Strings(periods)
+		if row.Period == "" {
+			continue
+		}
+		// A month can span two fiscal years, so accumulate rather than overwrite.
+		data := dataByPeriod[row.Period]
+		if data.ThaiYear == nil {
+			data.ThaiYear = row.ThaiYear
+		}
+		data.FundTotal += row.FundTotal
+		data.RewardTotal += row.RewardTotal
+		data.FundApproved += row.FundApproved
+		data.RewardApproved += row.RewardApproved
+		data.TotalRequested += row.TotalRequested
+		data.TotalApproved += row.TotalApproved
+		dataByPeriod[row.Period] = data
+	}
+
+	dataPeriods := make([]string, 0, len(dataByPeriod))
+	for period := range dataByPeriod {
+		dataPeriods = append(dataPeriods, period)
 	}
+	periods := mergeTrendPeriods(monthPeriodsForFilter(filter), dataPeriods)
 
 	results := make([]map[string]interface{}, 0, len(periods))
 	for _, period := range periods {
@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+
+	"fund-management-api/config"
+	"fund-management-api/utils"
+)
+
+func runUserBudgetUsed(t *testing.T, steps []*queryStep) float64 {
+	t.Helper()
+
+	utils.ResetStatusCache()
+	defer utils.ResetStatusCache()
+
+	db, state, cleanup := newScriptedGormDB(t, steps)
+	defer cleanup()
+
+	previous := config.DB
+	config.DB = db
+	defer func() { config.DB = previous }()
+
+	used := getUserBudgetUsed(42, "2568")
+	if err := state.verifyComplete(); err != nil {
+		t.Fatal(err)
+	}
+	return used
+}
+
+func TestGetUserBudgetUsed_UsesResolvedApprovedStatus(t *testing.T) {
+	// Approved is seeded as ID 7 so a hard-coded status_id = 2 would count nothing.
+	used := runUserBudgetUsed(t, []*queryStep{
+		{
+			kind:    stepQuery,
+			pattern: regexp.MustCompile("FROM `application_status` WHERE status_code = \\?"),
+			columns: []string{"application_status_id", "status_code", "status_name"},
+			rows:    [][]driver.Value{{int64(7), utils.StatusCodeApproved, "อนุมัติ"}},
+		},
+		{
+			kind:    stepQuery,
+			pattern: regexp.MustCompile(`FROM fund_application_details fad .*s\.status_id = \?`),
+			args:    []driver.Value{int64(42), "2568", int64(7)},
+			columns: []string{"total"},
+			rows:    [][]driver.Value{{1500.0}},
+		},
+		{
+			kind:    stepQuery,
+			pattern: regexp.MustCompile(`FROM publication_reward_details prd .*s\.status_id = \?`),
+			args:    []driver.Value{int64(42), "2568", int64(7)},
+			columns: []string{"total"},
+			rows:    [][]driver.Value{{500.0}},
+		},
+	})
+
+	if used != 2000 {
+		t.Fatalf("expected approved usage of 2000, got %v", used)
+	}
+}
+
+func TestGetUserBudgetUsed_UnresolvedStatusCountsNothing(t *testing.T) {
+	used := runUserBudgetUsed(t, []*queryStep{
+		{
+			kind:    stepQuery,
+			pattern: regexp.MustCompile("FROM `application_status` WHERE status_code = \\?"),
+			err:     errors.New("connection lost"),
+		},
+	})
+
+	if used != 0 {
+		t.Fatalf("expected no usage without an approved status, got %v", used)
+	}
+}
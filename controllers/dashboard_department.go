@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"net/http"
+
+	"fund-management-api/config"
+	"fund-management-api/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type departmentStatsRow struct {
+	DepartmentID     *int    `json:"department_id"`
+	DepartmentName   string  `json:"department_name"`
+	DepartmentNameEn *string `json:"department_name_en,omitempty"`
+	SubmissionCount  int     `json:"submission_count"`
+	ApprovedCount    int     `json:"approved_count"`
+	ApprovedAmount   float64 `json:"approved_amount"`
+}
+
+// GetAdminDepartmentStats returns submission counts, approved counts and
+// approved amounts per applicant department within the dashboard scope (same
+// scope/year/installment query parameters as /dashboard/stats). Departments
+// are the faculties lookup (models.Faculty) assigned through
+// PUT /admin/users/:id/faculty; applicants without one are grouped under a
+// null department_id.
+func GetAdminDepartmentStats(c *gin.Context) {
+	filter, _ := resolveDashboardFilter(c.Query("scope"), c.Query("year"), c.Query("installment"))
+
+	approvedIDs, err := utils.GetStatusIDsByCodes(utils.StatusCodeApproved, utils.StatusCodeAdminClosed)
+	if err != nil {
+		InternalError(c, "department stats: resolve approved statuses", err)
+		return
+	}
+	approved := ensureIDs(approvedIDs)
+
+	query := config.DB.Table("submissions s").
+		Select(`f.id AS department_id, COALESCE(f.name_th, '') AS department_name, f.name_en AS department_name_en,
+			COUNT(*) AS submission_count,
+			SUM(CASE WHEN s.status_id IN ? THEN 1 ELSE 0 END) AS approved_count,
+			COALESCE(SUM(CASE WHEN s.status_id IN ? THEN
+				CASE WHEN s.submission_type = 'fund_application' THEN COALESCE(fad.approved_amount,0)
+				     WHEN s.submission_type = 'publication_reward' THEN COALESCE(prd.total_approve_amount, prd.reward_approve_amount, prd.reward_amount, 0)
+				     ELSE 0 END
+			ELSE 0 END), 0) AS approved_amount`, approved, approved).
+		Joins("JOIN users u ON u.user_id = s.user_id").
+		Joins("LEFT JOIN faculties f ON f.id = u.faculty_id").
+		Joins("LEFT JOIN fund_application_details fad ON fad.submission_id = s.submission_id").
+		Joins("LEFT JOIN publication_reward_details prd ON prd.submission_id = s.submission_id").
+		Where("s.submission_type IN ? AND s.deleted_at IS NULL", []string{"fund_application", "publication_reward"})
+	query = applyFilterToSubmissions(query, "s", filter)
+
+	var rows []departmentStatsRow
+	if err := query.
+		Group("f.id, f.name_th, f.name_en").
+		Order("approved_amount DESC, submission_count DESC").
+		Scan(&rows).Error; err != nil {
+		InternalError(c, "department stats: aggregate submissions", err)
+		return
+	}
+	if rows == nil {
+		rows = []departmentStatsRow{}
+	}
+
+	submissionTotal, approvedTotal, amountTotal := 0, 0, 0.0
+	for _, row := range rows {
+		submissionTotal += row.SubmissionCount
+		approvedTotal += row.ApprovedCount
+		amountTotal += row.ApprovedAmount
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":     true,
+		"departments": rows,
+		"totals": gin.H{
+			"submission_count": submissionTotal,
+			"approved_count":   approvedTotal,
+			"approved_amount":  amountTotal,
+		},
+		"applied_filter": filter.toMap(),
+	})
+}
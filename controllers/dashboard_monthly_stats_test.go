@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMonthlyStatsWindows_EndOfMonthIsContiguous(t *testing.T) {
+	now := time.Date(2025, time.January, 31, 15, 30, 0, 0, time.UTC)
+
+	windows := monthlyStatsWindows(now, 6)
+	if len(windows) != 6 {
+		t.Fatalf("expected 6 windows, got %d", len(windows))
+	}
+
+	want := []string{"2024-08", "2024-09", "2024-10", "2024-11", "2024-12", "2025-01"}
+	seen := make(map[string]struct{}, len(windows))
+	for i, window := range windows {
+		month := window.Start.Format("2006-01")
+		if month != want[i] {
+			t.Fatalf("window %d: expected %s, got %s", i, want[i], month)
+		}
+		if _, dup := seen[month]; dup {
+			t.Fatalf("month %s appears twice", month)
+		}
+		seen[month] = struct{}{}
+
+		if window.Start.Day() != 1 || window.End.Day() != 1 {
+			t.Fatalf("window %d is not anchored on the first: %v - %v", i, window.Start, window.End)
+		}
+		if !window.End.Equal(window.Start.AddDate(0, 1, 0)) {
+			t.Fatalf("window %d does not span one month: %v - %v", i, window.Start, window.End)
+		}
+		if i > 0 && !windows[i-1].End.Equal(window.Start) {
+			t.Fatalf("window %d starts at %v but the previous one ends at %v", i, window.Start, windows[i-1].End)
+		}
+	}
+
+	if !windows[len(windows)-1].End.After(now) {
+		t.Fatalf("last window must contain now, ends at %v", windows[len(windows)-1].End)
+	}
+}
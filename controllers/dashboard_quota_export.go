@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"encoding/csv"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"fund-management-api/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+var quotaSummaryExportHeader = []string{
+	"year", "user", "category", "subcategory",
+	"allocated", "used", "remaining",
+	"max_grants", "used_grants", "remaining_grants",
+}
+
+// quotaSummaryExportFilename names the export after the applied filter, e.g.
+// quota_summary_installment_2568_2.xlsx.
+func quotaSummaryExportFilename(filter dashboardFilter, format string) string {
+	parts := []string{"quota_summary", filter.Scope}
+	if filter.SelectedYear != "" {
+		parts = append(parts, filter.SelectedYear)
+	}
+	if filter.SelectedInstallment != nil {
+		parts = append(parts, strconv.Itoa(*filter.SelectedInstallment))
+	}
+	return strings.Join(parts, "_") + "." + format
+}
+
+func quotaSummaryCSVRecord(row quotaSummaryRow) []string {
+	amount := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+	count := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	return []string{
+		row.Year, row.UserName, row.CategoryName, row.SubcategoryName,
+		amount(row.AllocatedAmount), amount(row.UsedAmount), amount(row.RemainingBudget),
+		count(row.MaxGrants), count(row.UsedGrants), count(row.RemainingGrants),
+	}
+}
+
+func quotaSummaryXLSXRow(row quotaSummaryRow) []xlsxCell {
+	return []xlsxCell{
+		{Value: row.Year}, {Value: row.UserName}, {Value: row.CategoryName}, {Value: row.SubcategoryName},
+		{Value: row.AllocatedAmount, Style: xlsxStyleMoney},
+		{Value: row.UsedAmount, Style: xlsxStyleMoney},
+		{Value: row.RemainingBudget, Style: xlsxStyleMoney},
+		{Value: row.MaxGrants}, {Value: row.UsedGrants}, {Value: row.RemainingGrants},
+	}
+}
+
+// writeQuotaSummaryCSV streams rows as CSV with a BOM so Excel detects UTF-8.
+func writeQuotaSummaryCSV(w io.Writer, rows []quotaSummaryRow) error {
+	if _, err := io.WriteString(w, "\xEF\xBB\xBF"); err != nil {
+		return err
+	}
+	writer := csv.NewWriter(w)
+	writer.Write(quotaSummaryExportHeader)
+	for _, row := range rows {
+		writer.Write(quotaSummaryCSVRecord(row))
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeQuotaSummaryXLSX(w io.Writer, rows []quotaSummaryRow) error {
+	sw, err := newXLSXStreamWriter(w, "Quota Summary", []float64{10, 28, 32, 40, 16, 16, 16, 12, 12, 16})
+	if err != nil {
+		return err
+	}
+	header := make([]xlsxCell, len(quotaSummaryExportHeader))
+	for i, title := range quotaSummaryExportHeader {
+		header[i] = xlsxCell{Value: title, Style: xlsxStyleBold}
+	}
+	if err := sw.WriteRow(header); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := sw.WriteRow(quotaSummaryXLSXRow(row)); err != nil {
+			return err
+		}
+	}
+	return sw.Close()
+}
+
+// ExportAdminQuotaSummary downloads the full admin quota summary (every row,
+// not just the dashboard's top 100) as CSV or XLSX, using the same
+// scope/year/installment parameters and aggregation as /dashboard/stats.
+// GET /api/v1/dashboard/quota-summary/export?format=csv|xlsx
+func ExportAdminQuotaSummary(c *gin.Context) {
+	format := strings.ToLower(strings.TrimSpace(c.DefaultQuery("format", "csv")))
+	if format != "csv" && format != "xlsx" {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "format must be csv or xlsx"})
+		return
+	}
+
+	filter, _ := resolveDashboardFilter(c.Query("scope"), c.Query("year"), c.Query("installment"))
+	statusSets := resolveAdminDashboardStatusSets(&filter)
+	rows := collectAdminQuotaSummaryRows(filter, statusSets, collectQuotaUsageViewRows(filter))
+
+	c.Header("Content-Disposition", utils.ContentDisposition("attachment", quotaSummaryExportFilename(filter, format)))
+	write := writeQuotaSummaryCSV
+	if format == "xlsx" {
+		c.Header("Content-Type", xlsxContentType)
+		write = writeQuotaSummaryXLSX
+	} else {
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+	}
+	c.Status(http.StatusOK)
+
+	// The response is already streaming, so a write error (usually the client
+	// going away) can only be logged.
+	if err := write(c.Writer, rows); err != nil {
+		log.Printf("quota summary export: %v", err)
+	}
+}
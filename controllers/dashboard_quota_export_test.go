@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestQuotaSummaryExportFilename(t *testing.T) {
+	installment := 2
+	cases := []struct {
+		filter dashboardFilter
+		format string
+		want   string
+	}{
+		{dashboardFilter{Scope: "all"}, "csv", "quota_summary_all.csv"},
+		{dashboardFilter{Scope: "year", SelectedYear: "2568"}, "xlsx", "quota_summary_year_2568.xlsx"},
+		{dashboardFilter{Scope: "installment", SelectedYear: "2568", SelectedInstallment: &installment}, "csv", "quota_summary_installment_2568_2.csv"},
+	}
+	for _, tc := range cases {
+		if got := quotaSummaryExportFilename(tc.filter, tc.format); got != tc.want {
+			t.Fatalf("quotaSummaryExportFilename(%+v) = %q, want %q", tc.filter, got, tc.want)
+		}
+	}
+}
+
+var quotaSummaryExportRows = []quotaSummaryRow{{
+	Year:            "2568",
+	UserName:        "สมชาย ใจดี",
+	CategoryName:    "ทุนวิจัย",
+	SubcategoryName: "ทุนพัฒนาบทความ, ระดับนานาชาติ",
+	AllocatedAmount: 50000,
+	UsedAmount:      12500.5,
+	RemainingBudget: 37499.5,
+	MaxGrants:       3,
+	UsedGrants:      1,
+	RemainingGrants: 2,
+}}
+
+func TestWriteQuotaSummaryCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeQuotaSummaryCSV(&buf, quotaSummaryExportRows); err != nil {
+		t.Fatalf("writeQuotaSummaryCSV: %v", err)
+	}
+
+	want := "\xEF\xBB\xBF" +
+		"year,user,category,subcategory,allocated,used,remaining,max_grants,used_grants,remaining_grants\n" +
+		"2568,สมชาย ใจดี,ทุนวิจัย,\"ทุนพัฒนาบทความ, ระดับนานาชาติ\",50000.00,12500.50,37499.50,3,1,2\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected CSV:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWriteQuotaSummaryXLSX_ReadsBack(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeQuotaSummaryXLSX(&buf, quotaSummaryExportRows); err != nil {
+		t.Fatalf("writeQuotaSummaryXLSX: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "quota.xlsx")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := readXLSXRows(path)
+	if err != nil {
+		t.Fatalf("readXLSXRows: %v", err)
+	}
+
+	want := [][]string{
+		quotaSummaryExportHeader,
+		{"2568", "สมชาย ใจดี", "ทุนวิจัย", "ทุนพัฒนาบทความ, ระดับนานาชาติ", "50000", "12500.5", "37499.5", "3", "1", "2"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("expected %d rows, got %d: %v", len(want), len(rows), rows)
+	}
+	for i := range want {
+		if strings.Join(rows[i], "|") != strings.Join(want[i], "|") {
+			t.Fatalf("row %d: expected %v, got %v", i, want[i], rows[i])
+		}
+	}
+}
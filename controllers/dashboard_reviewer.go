@@ -0,0 +1,167 @@
+package controllers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+
+	"fund-management-api/config"
+	"fund-management-api/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	reviewerStageDeptHead = "dept_head"
+	reviewerStageAdmin    = "admin"
+)
+
+// reviewerDecisionCount is one reviewer's decisions at one review stage.
+type reviewerDecisionCount struct {
+	ReviewerID    int
+	Stage         string
+	ApprovedCount int
+	RejectedCount int
+}
+
+type reviewerStageStats struct {
+	ApprovedCount int     `json:"approved_count"`
+	RejectedCount int     `json:"rejected_count"`
+	DecisionCount int     `json:"decision_count"`
+	ApprovalRate  float64 `json:"approval_rate"`
+}
+
+type reviewerStats struct {
+	ReviewerID   int    `json:"reviewer_id"`
+	ReviewerName string `json:"reviewer_name"`
+	reviewerStageStats
+	ByStage map[string]reviewerStageStats `json:"by_stage"`
+}
+
+// reviewerDecisionsQuery lists every recorded decision in the filter as
+// (reviewer_id, stage, decision) rows: the department head's recommendation or
+// rejection and the admin's approval or rejection. Admin decisions fall back to
+// the legacy approved_by/rejected_by columns.
+func reviewerDecisionsQuery(db *gorm.DB, filter dashboardFilter) *gorm.DB {
+	part := func(actorColumn, stage, decision string) *gorm.DB {
+		query := db.Table("submissions s").
+			Select(fmt.Sprintf("%s AS reviewer_id, '%s' AS stage, '%s' AS decision", actorColumn, stage, decision)).
+			Where(actorColumn+" IS NOT NULL").
+			Where("s.submission_type IN ? AND s.deleted_at IS NULL", []string{"fund_application", "publication_reward"})
+		return applyFilterToSubmissions(query, "s", filter)
+	}
+
+	return db.Table("(? UNION ALL ? UNION ALL ? UNION ALL ?) AS d",
+		part("s.head_approved_by", reviewerStageDeptHead, "approved"),
+		part("s.head_rejected_by", reviewerStageDeptHead, "rejected"),
+		part("COALESCE(s.admin_approved_by, s.approved_by)", reviewerStageAdmin, "approved"),
+		part("COALESCE(s.admin_rejected_by, s.rejected_by)", reviewerStageAdmin, "rejected"),
+	).
+		Select(`reviewer_id, stage,
+			SUM(CASE WHEN decision = 'approved' THEN 1 ELSE 0 END) AS approved_count,
+			SUM(CASE WHEN decision = 'rejected' THEN 1 ELSE 0 END) AS rejected_count`).
+		Group("reviewer_id, stage")
+}
+
+func newReviewerStageStats(approved, rejected int) reviewerStageStats {
+	stats := reviewerStageStats{
+		ApprovedCount: approved,
+		RejectedCount: rejected,
+		DecisionCount: approved + rejected,
+	}
+	if stats.DecisionCount > 0 {
+		stats.ApprovalRate = math.Round(float64(approved)/float64(stats.DecisionCount)*10000) / 100
+	}
+	return stats
+}
+
+// groupReviewerStats folds per-stage counts into one entry per reviewer,
+// busiest reviewer first.
+func groupReviewerStats(counts []reviewerDecisionCount, names map[int]string) []reviewerStats {
+	byReviewer := make(map[int]*reviewerStats)
+	order := []int{}
+	for _, count := range counts {
+		stats, ok := byReviewer[count.ReviewerID]
+		if !ok {
+			stats = &reviewerStats{
+				ReviewerID:   count.ReviewerID,
+				ReviewerName: names[count.ReviewerID],
+				ByStage:      map[string]reviewerStageStats{},
+			}
+			byReviewer[count.ReviewerID] = stats
+			order = append(order, count.ReviewerID)
+		}
+		stage := stats.ByStage[count.Stage]
+		stats.ByStage[count.Stage] = newReviewerStageStats(stage.ApprovedCount+count.ApprovedCount, stage.RejectedCount+count.RejectedCount)
+		stats.reviewerStageStats = newReviewerStageStats(stats.ApprovedCount+count.ApprovedCount, stats.RejectedCount+count.RejectedCount)
+	}
+
+	result := make([]reviewerStats, 0, len(order))
+	for _, id := range order {
+		result = append(result, *byReviewer[id])
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].DecisionCount != result[j].DecisionCount {
+			return result[i].DecisionCount > result[j].DecisionCount
+		}
+		return result[i].ReviewerID < result[j].ReviewerID
+	})
+	return result
+}
+
+// GetAdminReviewerStats returns each reviewer's approve/reject counts and
+// approval rate (percent) within the dashboard scope (same
+// scope/year/installment query parameters as /dashboard/stats), split by
+// review stage (dept_head, admin). Decisions come from the reviewer columns on
+// submissions, so a decision cleared by a revision request is not counted.
+// GET /api/v1/admin/stats/by-reviewer
+func GetAdminReviewerStats(c *gin.Context) {
+	roleID, _ := c.Get("roleID")
+	if roleID.(int) != 3 {
+		c.JSON(http.StatusForbidden, gin.H{"success": false, "error": "Admin access required"})
+		return
+	}
+
+	filter, _ := resolveDashboardFilter(c.Query("scope"), c.Query("year"), c.Query("installment"))
+
+	var counts []reviewerDecisionCount
+	if err := reviewerDecisionsQuery(config.DB, filter).Scan(&counts).Error; err != nil {
+		InternalError(c, "reviewer stats: aggregate decisions", err)
+		return
+	}
+
+	reviewerIDs := make([]int, 0, len(counts))
+	for _, count := range counts {
+		reviewerIDs = append(reviewerIDs, count.ReviewerID)
+	}
+	names := make(map[int]string, len(reviewerIDs))
+	if len(reviewerIDs) > 0 {
+		var users []models.User
+		if err := config.DB.
+			Select("user_id", "user_fname", "user_lname", "email").
+			Where("user_id IN ?", uniqueInts(reviewerIDs)).
+			Find(&users).Error; err != nil {
+			InternalError(c, "reviewer stats: load reviewers", err)
+			return
+		}
+		for i := range users {
+			names[users[i].UserID] = formatUserFullName(&users[i])
+		}
+	}
+
+	reviewers := groupReviewerStats(counts, names)
+	approvedTotal, rejectedTotal := 0, 0
+	for _, reviewer := range reviewers {
+		approvedTotal += reviewer.ApprovedCount
+		rejectedTotal += reviewer.RejectedCount
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":        true,
+		"reviewers":      reviewers,
+		"totals":         newReviewerStageStats(approvedTotal, rejectedTotal),
+		"applied_filter": filter.toMap(),
+	})
+}
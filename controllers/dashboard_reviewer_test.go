@@ -0,0 +1,40 @@
+package controllers
+
+import "testing"
+
+func TestGroupReviewerStats(t *testing.T) {
+	reviewers := groupReviewerStats([]reviewerDecisionCount{
+		{ReviewerID: 7, Stage: reviewerStageDeptHead, ApprovedCount: 3, RejectedCount: 1},
+		{ReviewerID: 9, Stage: reviewerStageAdmin, ApprovedCount: 1, RejectedCount: 0},
+		{ReviewerID: 7, Stage: reviewerStageAdmin, ApprovedCount: 0, RejectedCount: 2},
+	}, map[int]string{7: "Head Reviewer"})
+
+	if len(reviewers) != 2 {
+		t.Fatalf("expected 2 reviewers, got %d", len(reviewers))
+	}
+	first := reviewers[0]
+	if first.ReviewerID != 7 || first.ReviewerName != "Head Reviewer" {
+		t.Fatalf("expected the busiest reviewer first, got %+v", first)
+	}
+	if first.DecisionCount != 6 || first.ApprovedCount != 3 || first.ApprovalRate != 50 {
+		t.Fatalf("unexpected overall stats %+v", first.reviewerStageStats)
+	}
+	if head := first.ByStage[reviewerStageDeptHead]; head.DecisionCount != 4 || head.ApprovalRate != 75 {
+		t.Fatalf("unexpected dept head stats %+v", head)
+	}
+	if admin := first.ByStage[reviewerStageAdmin]; admin.RejectedCount != 2 || admin.ApprovalRate != 0 {
+		t.Fatalf("unexpected admin stats %+v", admin)
+	}
+	if reviewers[1].ApprovalRate != 100 {
+		t.Fatalf("expected a 100%% approval rate, got %v", reviewers[1].ApprovalRate)
+	}
+}
+
+func TestNewReviewerStageStatsRoundsRate(t *testing.T) {
+	if got := newReviewerStageStats(1, 2).ApprovalRate; got != 33.33 {
+		t.Fatalf("expected 33.33, got %v", got)
+	}
+	if got := newReviewerStageStats(0, 0).ApprovalRate; got != 0 {
+		t.Fatalf("expected 0 without decisions, got %v", got)
+	}
+}
@@ -0,0 +1,121 @@
+package controllers
+
+import (
+	"database/sql/driver"
+	"regexp"
+	"testing"
+
+	"fund-management-api/config"
+)
+
+var monthlyTrendColumns = []string{
+	"period", "thai_year", "fund_total", "reward_total", "fund_approved",
+	"reward_approved", "total_requested", "total_approved",
+}
+
+func runMonthlyTrend(t *testing.T, filter dashboardFilter, rows [][]driver.Value) []map[string]interface{} {
+	t.Helper()
+
+	steps := []*queryStep{
+		{
+			kind:    stepQuery,
+			pattern: regexp.MustCompile(`(?s)SELECT DATE_FORMAT\(.*FROM submissions s.*GROUP BY`),
+			columns: monthlyTrendColumns,
+			rows:    rows,
+		},
+	}
+
+	db, state, cleanup := newScriptedGormDB(t, steps)
+	defer cleanup()
+
+	previous := config.DB
+	config.DB = db
+	defer func() { config.DB = previous }()
+
+	trend := buildMonthlyTrend(filter, dashboardStatusSets{Approved: []int{1}})
+	if err := state.verifyComplete(); err != nil {
+		t.Fatal(err)
+	}
+	return trend
+}
+
+func trendPeriod(trend []map[string]interface{}, period string) map[string]interface{} {
+	for _, entry := range trend {
+		if entry["period"] == period {
+			return entry
+		}
+	}
+	return nil
+}
+
+func TestBuildMonthlyTrend_MalformedYearsFallBackToData(t *testing.T) {
+	filter := dashboardFilter{Scope: "year", Years: []string{"ปีงบ", "25x7"}, YearIDs: []int{3}}
+	if periods := monthPeriodsForFilter(filter); len(periods) != 0 {
+		t.Fatalf("expected no generated periods for malformed years, got %v", periods)
+	}
+
+	trend := runMonthlyTrend(t, filter, [][]driver.Value{
+		{"2024-03", "2567", 2.0, 1.0, 1.0, 0.0, 5000.0, 2000.0},
+		{"2024-01", "2567", 1.0, 0.0, 0.0, 0.0, 1000.0, 0.0},
+	})
+
+	if len(trend) != 2 {
+		t.Fatalf("expected 2 periods derived from data, got %d: %v", len(trend), trend)
+	}
+	if trend[0]["period"] != "2024-01" || trend[1]["period"] != "2024-03" {
+		t.Fatalf("expected periods sorted ascending, got %v then %v", trend[0]["period"], trend[1]["period"])
+	}
+	if got := trend[1]["total_applications"]; got != 3.0 {
+		t.Fatalf("expected 3 applications in 2024-03, got %v", got)
+	}
+}
+
+func TestBuildMonthlyTrend_MixedYearsKeepsDataOutsideGeneratedRange(t *testing.T) {
+	// "2567" parses to 2024; "bad" is dropped. Fiscal-year data from late 2023
+	// falls outside the generated 2024-01..2024-12 range and must still appear.
+	filter := dashboardFilter{Scope: "year", Years: []string{"2567", "bad"}, YearIDs: []int{3}}
+
+	trend := runMonthlyTrend(t, filter, [][]driver.Value{
+		{"2023-11", "2567", 1.0, 0.0, 1.0, 0.0, 1500.0, 1500.0},
+		{"2024-02", "2567", 0.0, 2.0, 0.0, 1.0, 3000.0, 1000.0},
+	})
+
+	if len(trend) != 13 {
+		t.Fatalf("expected 12 generated months plus 1 data-only month, got %d", len(trend))
+	}
+	if trend[0]["period"] != "2023-11" {
+		t.Fatalf("expected data-only period first, got %v", trend[0]["period"])
+	}
+	if entry := trendPeriod(trend, "2024-02"); entry == nil || entry["reward_total"] != 2.0 {
+		t.Fatalf("expected 2024-02 reward_total 2, got %v", entry)
+	}
+}
+
+func TestBuildMonthlyTrend_AccumulatesSamePeriodAcrossYears(t *testing.T) {
+	filter := dashboardFilter{Scope: "year", Years: []string{"???"}, YearIDs: []int{3, 4}}
+
+	trend := runMonthlyTrend(t, filter, [][]driver.Value{
+		{"2024-10", "2567", 1.0, 0.0, 0.0, 0.0, 100.0, 0.0},
+		{"2024-10", "2568", 2.0, 1.0, 1.0, 1.0, 200.0, 150.0},
+	})
+
+	if len(trend) != 1 {
+		t.Fatalf("expected a single period, got %d", len(trend))
+	}
+	if got := trend[0]["total_applications"]; got != 4.0 {
+		t.Fatalf("expected rows for the same month to be summed to 4, got %v", got)
+	}
+}
+
+func TestMergeTrendPeriods(t *testing.T) {
+	merged := mergeTrendPeriods([]string{"2024-02", "2024-01"}, []string{"2024-01", "2023-12", ""})
+	want := []string{"2023-12", "2024-01", "2024-02"}
+	if len(merged) != len(want) {
+		t.Fatalf("expected %v, got %v", want, merged)
+	}
+	for i := range want {
+		if merged[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, merged)
+		}
+	}
+}
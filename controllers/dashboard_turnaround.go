@@ -0,0 +1,150 @@
+package controllers
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	"fund-management-api/config"
+	"fund-management-api/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type turnaroundRow struct {
+	StatusID    int
+	SubmittedAt time.Time
+	DecidedAt   time.Time
+}
+
+type turnaroundSummary struct {
+	Count               int     `json:"count"`
+	ApprovedCount       int     `json:"approved_count"`
+	RejectedCount       int     `json:"rejected_count"`
+	AverageDays         float64 `json:"average_days"`
+	MedianDays          float64 `json:"median_days"`
+	MinDays             float64 `json:"min_days"`
+	MaxDays             float64 `json:"max_days"`
+	AverageBusinessDays float64 `json:"average_business_days"`
+	MedianBusinessDays  float64 `json:"median_business_days"`
+}
+
+// GetAdminTurnaroundStats returns average/median days from submitted_at to the
+// approval or rejection timestamp for decided submissions in the dashboard scope
+// (same scope/year/installment query parameters as /dashboard/stats).
+func GetAdminTurnaroundStats(c *gin.Context) {
+	filter, _ := resolveDashboardFilter(c.Query("scope"), c.Query("year"), c.Query("installment"))
+
+	approvedIDs, err := utils.GetStatusIDsByCodes(utils.StatusCodeApproved, utils.StatusCodeAdminClosed)
+	if err != nil {
+		InternalError(c, "turnaround stats: resolve approved statuses", err)
+		return
+	}
+	rejectedIDs, err := utils.GetStatusIDsByCodes(utils.StatusCodeRejected, utils.StatusCodeDeptHeadNotRecommended)
+	if err != nil {
+		InternalError(c, "turnaround stats: resolve rejected statuses", err)
+		return
+	}
+
+	decidedIDs := uniqueInts(append(append([]int{}, approvedIDs...), rejectedIDs...))
+	approvedSet := make(map[int]bool, len(approvedIDs))
+	for _, id := range approvedIDs {
+		approvedSet[id] = true
+	}
+
+	query := config.DB.Table("submissions s").
+		Select(`s.submission_type, s.status_id, s.submitted_at,
+			CASE WHEN s.status_id IN ? THEN COALESCE(s.admin_approved_at, s.approved_at)
+			     ELSE COALESCE(s.admin_rejected_at, s.rejected_at, s.head_rejected_at) END AS decided_at`, ensureIDs(approvedIDs)).
+		Where("s.submission_type IN ? AND s.deleted_at IS NULL AND s.submitted_at IS NOT NULL", []string{"fund_application", "publication_reward"}).
+		Where("s.status_id IN ?", ensureIDs(decidedIDs))
+	query = applyFilterToSubmissions(query, "s", filter)
+
+	var rows []struct {
+		SubmissionType string
+		StatusID       int
+		SubmittedAt    *time.Time
+		DecidedAt      *time.Time
+	}
+	if err := query.Scan(&rows).Error; err != nil {
+		InternalError(c, "turnaround stats: load submissions", err)
+		return
+	}
+
+	overall := make([]turnaroundRow, 0, len(rows))
+	byType := make(map[string][]turnaroundRow)
+	for _, row := range rows {
+		if row.SubmittedAt == nil || row.DecidedAt == nil || row.DecidedAt.Before(*row.SubmittedAt) {
+			continue
+		}
+		item := turnaroundRow{
+			StatusID:    row.StatusID,
+			SubmittedAt: *row.SubmittedAt,
+			DecidedAt:   *row.DecidedAt,
+		}
+		overall = append(overall, item)
+		byType[row.SubmissionType] = append(byType[row.SubmissionType], item)
+	}
+
+	holidays := utils.PublicHolidays()
+	byTypeSummary := make(map[string]turnaroundSummary, len(byType))
+	for submissionType, items := range byType {
+		byTypeSummary[submissionType] = summarizeTurnaround(items, approvedSet, holidays)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":            true,
+		"overall":            summarizeTurnaround(overall, approvedSet, holidays),
+		"by_submission_type": byTypeSummary,
+		"applied_filter":     filter.toMap(),
+	})
+}
+
+func summarizeTurnaround(rows []turnaroundRow, approvedSet map[int]bool, holidays map[string]bool) turnaroundSummary {
+	summary := turnaroundSummary{Count: len(rows)}
+	if len(rows) == 0 {
+		return summary
+	}
+
+	days := make([]float64, 0, len(rows))
+	businessDays := make([]float64, 0, len(rows))
+	total := 0.0
+	businessTotal := 0.0
+	for _, row := range rows {
+		if approvedSet[row.StatusID] {
+			summary.ApprovedCount++
+		} else {
+			summary.RejectedCount++
+		}
+		d := row.DecidedAt.Sub(row.SubmittedAt).Hours() / 24
+		days = append(days, d)
+		total += d
+
+		b := float64(utils.CountBusinessDays(row.SubmittedAt, row.DecidedAt, holidays))
+		businessDays = append(businessDays, b)
+		businessTotal += b
+	}
+
+	summary.AverageDays = roundTurnaroundDays(total / float64(len(days)))
+	summary.MedianDays = roundTurnaroundDays(medianTurnaroundDays(days))
+	summary.MinDays = roundTurnaroundDays(days[0])
+	summary.MaxDays = roundTurnaroundDays(days[len(days)-1])
+	summary.AverageBusinessDays = roundTurnaroundDays(businessTotal / float64(len(businessDays)))
+	summary.MedianBusinessDays = roundTurnaroundDays(medianTurnaroundDays(businessDays))
+	return summary
+}
+
+// medianTurnaroundDays sorts values in place and returns their median.
+func medianTurnaroundDays(values []float64) float64 {
+	sort.Float64s(values)
+	mid := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[mid-1] + values[mid]) / 2
+	}
+	return values[mid]
+}
+
+func roundTurnaroundDays(value float64) float64 {
+	return math.Round(value*100) / 100
+}
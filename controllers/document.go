@@ -2,9 +2,10 @@ package controllers
 
 import (
 	"encoding/json"
-	"fmt"
+	"errors"
 	"fund-management-api/config"
 	"fund-management-api/models"
+	"fund-management-api/storage"
 	"fund-management-api/utils"
 	"net/http"
 	"os"
@@ -103,10 +104,14 @@ func UploadDocument(c *gin.Context) {
 		return
 	}
 
-	// Validate file size
-	maxSize := int64(10 * 1024 * 1024) // 10MB
-	if file.Size > maxSize {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "File size exceeds 10MB limit"})
+	// Validate file size against the document type's limit (or MAX_UPLOAD_SIZE_MB)
+	limitMB, err := resolveUploadSizeLimitMB(config.DB, documentTypeID)
+	if err != nil {
+		InternalError(c, "upload document: size limit", err)
+		return
+	}
+	if file.Size > int64(limitMB)*1024*1024 {
+		respondUploadTooLarge(c, limitMB)
 		return
 	}
 
@@ -160,11 +165,17 @@ func UploadDocument(c *gin.Context) {
 	}
 
 	// Use original filename with safety checks
-	safeFilename := utils.GenerateUniqueFilename(submissionFolderPath, file.Filename)
-	fullPath := filepath.Join(submissionFolderPath, safeFilename)
+	store := storage.Default()
+	ctx := c.Request.Context()
+	safeFilename, err := uniqueStorageFilename(ctx, store, submissionFolderPath, file.Filename)
+	if err != nil {
+		InternalError(c, "upload document: unique name", err)
+		return
+	}
 
 	// Save file
-	if err := c.SaveUploadedFile(file, fullPath); err != nil {
+	fullPath, err := saveUploadedFileToStorage(ctx, store, file, filepath.Join(submissionFolderPath, safeFilename), file.Header.Get("Content-Type"))
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
 		return
 	}
@@ -187,7 +198,7 @@ func UploadDocument(c *gin.Context) {
 
 	if err := createFileUploadRecord(config.DB, &fileUpload); err != nil {
 		// Delete uploaded file if database save fails
-		os.Remove(fullPath)
+		_ = store.Delete(ctx, fullPath)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file info"})
 		return
 	}
@@ -207,7 +218,7 @@ func UploadDocument(c *gin.Context) {
 
 	if err := config.DB.Create(&document).Error; err != nil {
 		// Delete uploaded file and FileUpload record if document creation fails
-		os.Remove(fullPath)
+		_ = store.Delete(ctx, fullPath)
 		config.DB.Delete(&fileUpload)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save document record"})
 		return
@@ -280,28 +291,39 @@ func DownloadDocument(c *gin.Context) {
 		uploadPath = "./uploads"
 	}
 
-	// Try to find file in year/month subdirectory
+	// Try to find file in year/month subdirectory, then the upload root
+	store := storage.Default()
+	ctx := c.Request.Context()
 	uploadTime := document.UploadedAt
-	subDir := filepath.Join(uploadPath, uploadTime.Format("2006/01"))
-	fullPath := filepath.Join(subDir, document.StoredFilename)
-
-	// Check if file exists
-	if _, err := os.Stat(fullPath); os.IsNotExist(err) {
-		// Try root upload directory
-		fullPath = filepath.Join(uploadPath, document.StoredFilename)
-		if _, err := os.Stat(fullPath); os.IsNotExist(err) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+	var key string
+	var info storage.ObjectInfo
+	for _, candidate := range []string{
+		filepath.Join(uploadPath, uploadTime.Format("2006/01"), document.StoredFilename),
+		filepath.Join(uploadPath, document.StoredFilename),
+	} {
+		candidateKey := storageKey(store, candidate)
+		candidateInfo, err := store.Stat(ctx, candidateKey)
+		if err == nil {
+			key, info = candidateKey, candidateInfo
+			break
+		}
+		if !errors.Is(err, storage.ErrNotExist) {
+			InternalError(c, "download document: stat", err)
 			return
 		}
 	}
+	if key == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		return
+	}
 
 	// Set headers for download
 	c.Header("Content-Description", "File Transfer")
 	c.Header("Content-Transfer-Encoding", "binary")
-	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", document.OriginalFilename))
-	c.Header("Content-Type", "application/octet-stream")
-
-	c.File(fullPath)
+	disposition := utils.ContentDisposition("attachment", document.OriginalFilename)
+	if err := sendStoredFile(c, store, key, info, "application/octet-stream", disposition); err != nil {
+		InternalError(c, "download document: send", err)
+	}
 }
 
 // DeleteDocument soft deletes a document
@@ -391,6 +413,7 @@ func GetDocumentTypes(c *gin.Context) {
 			"document_type_id":   dt.DocumentTypeID,
 			"document_type_name": dt.DocumentTypeName,
 			"fund_type_mode":     meta.FundTypeMode,
+			"max_upload_size_mb": dt.MaxUploadSizeMB,
 		}
 
 		if dt.FundTypes != nil {
@@ -438,6 +461,7 @@ func GetDocumentTypesAdmin(c *gin.Context) {
 			"create_at":          dt.CreateAt,
 			"update_at":          dt.UpdateAt,
 			"fund_type_mode":     meta.FundTypeMode,
+			"max_upload_size_mb": dt.MaxUploadSizeMB,
 		}
 
 		if dt.FundTypes != nil {
@@ -473,12 +497,17 @@ func UpdateDocumentType(c *gin.Context) {
 		Multiple         *bool     `json:"multiple"`
 		DocumentOrder    *int      `json:"document_order"`
 		FundTypes        *[]string `json:"fund_types"`
+		MaxUploadSizeMB  *int      `json:"max_upload_size_mb"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := validateDocumentTypeUploadSize(req.MaxUploadSizeMB); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	// Check if document type exists
 	var documentType models.DocumentType
@@ -523,6 +552,14 @@ func UpdateDocumentType(c *gin.Context) {
 		}
 	}
 
+	if req.MaxUploadSizeMB != nil {
+		if *req.MaxUploadSizeMB == 0 {
+			updates["max_upload_size_mb"] = nil
+		} else {
+			updates["max_upload_size_mb"] = *req.MaxUploadSizeMB
+		}
+	}
+
 	updates["update_at"] = time.Now()
 
 	if err := config.DB.Model(&documentType).Updates(updates).Error; err != nil {
@@ -553,12 +590,17 @@ func CreateDocumentType(c *gin.Context) {
 		Multiple         bool     `json:"multiple"`
 		DocumentOrder    int      `json:"document_order"`
 		FundTypes        []string `json:"fund_types"`
+		MaxUploadSizeMB  *int     `json:"max_upload_size_mb"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := validateDocumentTypeUploadSize(req.MaxUploadSizeMB); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	// Create document type
 	documentType := models.DocumentType{
@@ -570,6 +612,9 @@ func CreateDocumentType(c *gin.Context) {
 		CreateAt:         time.Now(),
 		UpdateAt:         time.Now(),
 	}
+	if req.MaxUploadSizeMB != nil && *req.MaxUploadSizeMB > 0 {
+		documentType.MaxUploadSizeMB = req.MaxUploadSizeMB
+	}
 
 	// Handle fund_types JSON
 	if len(req.FundTypes) > 0 {
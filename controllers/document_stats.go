@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"fund-management-api/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+type documentTypeStat struct {
+	DocumentTypeID   int    `json:"document_type_id"`
+	DocumentTypeName string `json:"document_type_name"`
+	DocumentTypeCode string `json:"document_type_code"`
+	TotalCount       int64  `json:"total_count"`
+	VerifiedCount    int64  `json:"verified_count"`
+	UnverifiedCount  int64  `json:"unverified_count"`
+	TotalBytes       int64  `json:"total_bytes"`
+}
+
+// GetAdminDocumentStats returns document counts and storage per document type
+// across submissions that have not been deleted, with verified/unverified
+// splits. submission_type narrows the counts to one submission type.
+// GET /admin/documents/stats?submission_type=
+func GetAdminDocumentStats(c *gin.Context) {
+	query := config.DB.Table("submission_documents sd").
+		Joins("JOIN submissions s ON s.submission_id = sd.submission_id").
+		Joins("LEFT JOIN document_types dt ON dt.document_type_id = sd.document_type_id").
+		Joins("LEFT JOIN file_uploads fu ON fu.file_id = sd.file_id").
+		Where("s.deleted_at IS NULL")
+	if submissionType := strings.TrimSpace(c.Query("submission_type")); submissionType != "" {
+		query = query.Where("s.submission_type = ?", submissionType)
+	}
+
+	stats := make([]documentTypeStat, 0)
+	if err := query.
+		Select(`sd.document_type_id,
+			COALESCE(dt.document_type_name,'') AS document_type_name,
+			COALESCE(dt.code,'') AS document_type_code,
+			COUNT(*) AS total_count,
+			COALESCE(SUM(CASE WHEN sd.is_verified = 1 THEN 1 ELSE 0 END),0) AS verified_count,
+			COALESCE(SUM(CASE WHEN sd.is_verified = 1 THEN 0 ELSE 1 END),0) AS unverified_count,
+			COALESCE(SUM(fu.file_size),0) AS total_bytes`).
+		Group("sd.document_type_id, dt.document_type_name, dt.code").
+		Order("total_count DESC, sd.document_type_id ASC").
+		Scan(&stats).Error; err != nil {
+		InternalError(c, "document stats", err)
+		return
+	}
+
+	var totals documentTypeStat
+	for _, stat := range stats {
+		totals.TotalCount += stat.TotalCount
+		totals.VerifiedCount += stat.VerifiedCount
+		totals.UnverifiedCount += stat.UnverifiedCount
+		totals.TotalBytes += stat.TotalBytes
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":        true,
+		"document_types": stats,
+		"totals": gin.H{
+			"total_count":      totals.TotalCount,
+			"verified_count":   totals.VerifiedCount,
+			"unverified_count": totals.UnverifiedCount,
+			"total_bytes":      totals.TotalBytes,
+		},
+	})
+}
@@ -0,0 +1,175 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"fund-management-api/config"
+	"fund-management-api/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type verificationQueueItem struct {
+	DocumentID       int       `json:"document_id"`
+	SubmissionID     int       `json:"submission_id"`
+	SubmissionNumber string    `json:"submission_number"`
+	SubmissionType   string    `json:"submission_type"`
+	ApplicantID      int       `json:"applicant_id"`
+	ApplicantName    string    `json:"applicant_name"`
+	DocumentTypeID   int       `json:"document_type_id"`
+	DocumentTypeName string    `json:"document_type_name"`
+	DocumentTypeCode string    `json:"document_type_code"`
+	OriginalName     string    `json:"original_name"`
+	FileID           int       `json:"file_id"`
+	FileSize         int64     `json:"file_size"`
+	MimeType         string    `json:"mime_type"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// GetAdminDocumentVerificationQueue lists unverified documents of submitted
+// submissions, oldest first so reviewers clear the backlog in order.
+// document_type accepts a document type id or code; date_from/date_to
+// (YYYY-MM-DD) filter on when the document was attached.
+// GET /admin/documents/verification-queue?submission_type=&document_type=&date_from=&date_to=&page=&limit=
+func GetAdminDocumentVerificationQueue(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if page < 1 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 200 {
+		limit = 200
+	}
+	offset := (page - 1) * limit
+
+	from, to, err := parseSubmissionDateRange(c, "date_from", "date_to")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": err.Error()})
+		return
+	}
+
+	query := config.DB.Table("submission_documents sd").
+		Joins("JOIN submissions s ON s.submission_id = sd.submission_id").
+		Joins("LEFT JOIN document_types dt ON dt.document_type_id = sd.document_type_id").
+		Joins("LEFT JOIN file_uploads fu ON fu.file_id = sd.file_id").
+		Joins("LEFT JOIN users u ON u.user_id = s.user_id").
+		Where("sd.is_verified = ?", false).
+		Where("s.deleted_at IS NULL AND s.submitted_at IS NOT NULL")
+
+	if submissionType := strings.TrimSpace(c.Query("submission_type")); submissionType != "" {
+		query = query.Where("s.submission_type = ?", submissionType)
+	}
+	if documentType := strings.TrimSpace(c.Query("document_type")); documentType != "" {
+		if id, err := strconv.Atoi(documentType); err == nil {
+			query = query.Where("sd.document_type_id = ?", id)
+		} else {
+			query = query.Where("dt.code = ?", documentType)
+		}
+	}
+	if from != nil {
+		query = query.Where("sd.created_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("sd.created_at < ?", *to)
+	}
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		InternalError(c, "verification queue: count", err)
+		return
+	}
+
+	items := make([]verificationQueueItem, 0)
+	if err := query.
+		Select(`sd.document_id, sd.submission_id, s.submission_number, s.submission_type,
+			s.user_id AS applicant_id,
+			TRIM(CONCAT(COALESCE(u.user_fname,''),' ',COALESCE(u.user_lname,''))) AS applicant_name,
+			sd.document_type_id, COALESCE(dt.document_type_name,'') AS document_type_name,
+			COALESCE(dt.code,'') AS document_type_code,
+			COALESCE(NULLIF(sd.original_name,''), fu.original_name, '') AS original_name,
+			sd.file_id, COALESCE(fu.file_size,0) AS file_size, COALESCE(fu.mime_type,'') AS mime_type,
+			sd.created_at`).
+		Order("sd.created_at ASC").
+		Order("sd.document_id ASC").
+		Offset(offset).Limit(limit).
+		Scan(&items).Error; err != nil {
+		InternalError(c, "verification queue: list", err)
+		return
+	}
+
+	totalPages := (totalCount + int64(limit) - 1) / int64(limit)
+	c.JSON(http.StatusOK, gin.H{
+		"success":   true,
+		"documents": items,
+		"pagination": gin.H{
+			"current_page": page,
+			"per_page":     limit,
+			"total_count":  totalCount,
+			"total_pages":  totalPages,
+			"has_next":     page < int(totalPages),
+			"has_prev":     page > 1,
+		},
+	})
+}
+
+// VerifySubmissionDocument marks a submission document as verified (or clears
+// the mark with {"verified": false}), taking it off the verification queue.
+// PUT /admin/documents/:id/verify
+func VerifySubmissionDocument(c *gin.Context) {
+	documentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || documentID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid document id"})
+		return
+	}
+
+	req := struct {
+		Verified *bool `json:"verified"`
+	}{}
+	if err := c.ShouldBindJSON(&req); err != nil && err.Error() != "EOF" {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid request data"})
+		return
+	}
+	verified := req.Verified == nil || *req.Verified
+
+	var document models.SubmissionDocument
+	if err := config.DB.Where("document_id = ?", documentID).First(&document).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "Document not found"})
+			return
+		}
+		InternalError(c, "verify document", err)
+		return
+	}
+
+	updates := map[string]interface{}{"is_verified": verified}
+	if verified {
+		now := time.Now()
+		userID := c.GetInt("userID")
+		updates["verified_by"] = userID
+		updates["verified_at"] = now
+		document.VerifiedBy = &userID
+		document.VerifiedAt = &now
+	} else {
+		updates["verified_by"] = nil
+		updates["verified_at"] = nil
+		document.VerifiedBy = nil
+		document.VerifiedAt = nil
+	}
+	if err := config.DB.Model(&models.SubmissionDocument{}).
+		Where("document_id = ?", documentID).
+		Updates(updates).Error; err != nil {
+		InternalError(c, "verify document: update", err)
+		return
+	}
+	document.IsVerified = verified
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "document": document})
+}
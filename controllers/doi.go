@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"fund-management-api/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+const doiResolveTimeout = 5 * time.Second
+
+// doiResolveClient does not follow redirects: doi.org answers a registered DOI
+// with a redirect to the publisher and an unknown one with 404.
+var doiResolveClient = &http.Client{
+	Timeout: doiResolveTimeout,
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// resolveDOI asks doi.org whether the DOI is registered. ok is false when the
+// lookup itself failed (network error, timeout or unexpected status).
+func resolveDOI(ctx context.Context, doi string) (resolvable bool, ok bool) {
+	ctx, cancel := context.WithTimeout(ctx, doiResolveTimeout)
+	defer cancel()
+
+	target := "https://doi.org/" + (&url.URL{Path: doi}).EscapedPath()
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, target, nil)
+	if err != nil {
+		return false, false
+	}
+	resp, err := doiResolveClient.Do(req)
+	if err != nil {
+		return false, false
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 300 && resp.StatusCode < 400:
+		return true, true
+	case resp.StatusCode == http.StatusNotFound:
+		return false, true
+	}
+	return false, false
+}
+
+// ValidateDOI normalises a DOI and checks its format. With resolve=true it also
+// asks doi.org, reporting resolvable=null when doi.org could not be reached.
+// GET /doi/validate?doi=&resolve=
+func ValidateDOI(c *gin.Context) {
+	raw := strings.TrimSpace(c.Query("doi"))
+	if raw == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "doi is required"})
+		return
+	}
+
+	normalized := utils.NormalizeDOI(raw)
+	valid := utils.IsValidDOI(normalized)
+
+	response := gin.H{
+		"success":    true,
+		"input":      raw,
+		"doi":        normalized,
+		"valid":      valid,
+		"url":        nil,
+		"resolvable": nil,
+	}
+	if valid {
+		response["url"] = "https://doi.org/" + normalized
+	} else {
+		response["message"] = "DOI must look like 10.xxxx/suffix"
+	}
+
+	if valid && strings.EqualFold(strings.TrimSpace(c.Query("resolve")), "true") {
+		if resolvable, ok := resolveDOI(c.Request.Context(), normalized); ok {
+			response["resolvable"] = resolvable
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
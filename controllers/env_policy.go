@@ -0,0 +1,23 @@
+package controllers
+
+import (
+	"os"
+	"strings"
+)
+
+// Values of the warn|block|off policies configured through the environment.
+const (
+	policyWarn  = "warn"
+	policyBlock = "block"
+	policyOff   = "off"
+)
+
+// envPolicy reads a warn|block|off policy from the environment variable key,
+// falling back to def when it is unset or not one of those values.
+func envPolicy(key, def string) string {
+	switch value := strings.ToLower(strings.TrimSpace(os.Getenv(key))); value {
+	case policyWarn, policyBlock, policyOff:
+		return value
+	}
+	return def
+}
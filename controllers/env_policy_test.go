@@ -0,0 +1,21 @@
+package controllers
+
+import "testing"
+
+func TestEnvPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		value, def, want string
+	}{
+		{"", policyWarn, policyWarn},
+		{"", policyBlock, policyBlock},
+		{"bogus", policyWarn, policyWarn},
+		{" Block ", policyWarn, policyBlock},
+		{"OFF", policyBlock, policyOff},
+		{"warn", policyBlock, policyWarn},
+	} {
+		t.Setenv("TEST_ENV_POLICY", tc.value)
+		if got := envPolicy("TEST_ENV_POLICY", tc.def); got != tc.want {
+			t.Errorf("envPolicy(%q, default %s) = %s, want %s", tc.value, tc.def, got, tc.want)
+		}
+	}
+}
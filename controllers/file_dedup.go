@@ -0,0 +1,78 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"fund-management-api/config"
+	"fund-management-api/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var fileHashPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// uploadDedupEnabled reports whether UploadFile should reuse an identical file
+// the user already uploaded. The form/query field "dedup" wins over the
+// FILE_UPLOAD_DEDUP default.
+func uploadDedupEnabled(c *gin.Context) bool {
+	value := c.PostForm("dedup")
+	if value == "" {
+		value = c.Query("dedup")
+	}
+	if value == "" {
+		value = os.Getenv("FILE_UPLOAD_DEDUP")
+	}
+	enabled, err := strconv.ParseBool(strings.TrimSpace(value))
+	return err == nil && enabled
+}
+
+// findUserFileByHash returns the newest non-deleted file of userID with the
+// given SHA-256 hash. Lookups are always scoped to the owner so a hash never
+// reveals another user's file. folderType narrows the match when not empty.
+func findUserFileByHash(db *gorm.DB, userID int, hash, folderType string) (*models.FileUpload, error) {
+	query := db.Where("uploaded_by = ? AND file_hash = ? AND delete_at IS NULL", userID, hash)
+	if folderType != "" {
+		query = query.Where("folder_type = ?", folderType)
+	}
+
+	var file models.FileUpload
+	if err := query.Order("uploaded_at DESC, file_id DESC").First(&file).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &file, nil
+}
+
+// GetFileByHash lets the frontend check for an identical upload before sending
+// the file. Only the caller's own non-deleted files are searched.
+// GET /files/by-hash/:hash
+func GetFileByHash(c *gin.Context) {
+	hash := strings.ToLower(strings.TrimSpace(c.Param("hash")))
+	if !fileHashPattern.MatchString(hash) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file hash"})
+		return
+	}
+
+	file, err := findUserFileByHash(config.DB, c.GetInt("userID"), hash, "")
+	if err != nil {
+		InternalError(c, "file by hash", err)
+		return
+	}
+	if file == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"file":    file,
+	})
+}
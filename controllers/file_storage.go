@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"fund-management-api/storage"
+	"fund-management-api/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// storageKey converts a path under UPLOAD_PATH into the key stored in
+// file_uploads.stored_path. The local backend keeps recording the path itself
+// so existing rows and path-based readers stay valid; other backends record the
+// slash-separated path relative to UPLOAD_PATH.
+func storageKey(store storage.Storage, path string) string {
+	if storage.IsLocal(store) {
+		return path
+	}
+	root := filepath.Clean(getUploadRoot())
+	if rel, err := filepath.Rel(root, filepath.Clean(path)); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(path)
+}
+
+// uniqueStorageFilename is utils.GenerateUniqueFilename for any backend: it
+// appends _1, _2, ... until dir/name is free.
+func uniqueStorageFilename(ctx context.Context, store storage.Storage, dir, name string) (string, error) {
+	if storage.IsLocal(store) {
+		return utils.GenerateUniqueFilename(dir, name), nil
+	}
+
+	filename := utils.SanitizeForFilename(name)
+	ext := filepath.Ext(filename)
+	base := strings.TrimSuffix(filename, ext)
+	for counter := 0; ; counter++ {
+		candidate := filename
+		if counter > 0 {
+			candidate = fmt.Sprintf("%s_%d%s", base, counter, ext)
+		}
+		exists, err := storage.Exists(ctx, store, storageKey(store, filepath.Join(dir, candidate)))
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return candidate, nil
+		}
+	}
+}
+
+// localCopyOfStoredFile returns a file path for key that tools needing a file
+// (LibreOffice, pdfunite) can read. Local objects are used in place; others
+// are downloaded into workDir.
+func localCopyOfStoredFile(ctx context.Context, store storage.Storage, key, workDir string) (string, error) {
+	if local, ok := store.(storage.LocalPather); ok {
+		return local.LocalPath(key)
+	}
+
+	r, err := store.Open(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	out, err := os.CreateTemp(workDir, "object-*"+filepath.Ext(key))
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(out, r); err != nil {
+		out.Close()
+		return "", err
+	}
+	return out.Name(), out.Close()
+}
+
+// saveFileToStorage uploads the file at path to key.
+func saveFileToStorage(ctx context.Context, store storage.Storage, path, key, contentType string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return store.Save(ctx, key, f, info.Size(), contentType)
+}
+
+// saveUploadedFileToStorage stores an uploaded form file at path (under
+// UPLOAD_PATH) and returns the key to record.
+func saveUploadedFileToStorage(ctx context.Context, store storage.Storage, file *multipart.FileHeader, path, contentType string) (string, error) {
+	src, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	key := storageKey(store, path)
+	return key, store.Save(ctx, key, src, file.Size, contentType)
+}
+
+// saveBytesToStorage stores data at path (under UPLOAD_PATH) and returns the
+// key to record.
+func saveBytesToStorage(ctx context.Context, store storage.Storage, path string, data []byte, contentType string) (string, error) {
+	key := storageKey(store, path)
+	return key, store.Save(ctx, key, bytes.NewReader(data), int64(len(data)), contentType)
+}
+
+// sendStoredFile writes the object at key as the response. Local files go
+// through c.File so range requests and modification times keep working.
+func sendStoredFile(c *gin.Context, store storage.Storage, key string, info storage.ObjectInfo, contentType, disposition string) error {
+	if local, ok := store.(storage.LocalPather); ok {
+		path, err := local.LocalPath(key)
+		if err != nil {
+			return err
+		}
+		c.Header("Content-Disposition", disposition)
+		c.Header("Content-Type", contentType)
+		c.File(path)
+		return nil
+	}
+
+	r, err := store.Open(c.Request.Context(), key)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	c.DataFromReader(http.StatusOK, info.Size, contentType, r, map[string]string{"Content-Disposition": disposition})
+	return nil
+}
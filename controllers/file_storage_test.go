@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"fund-management-api/models"
+	"fund-management-api/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// memoryStorage is an object-store backend without local paths, standing in
+// for S3 in handler tests.
+type memoryStorage struct {
+	objects map[string][]byte
+}
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{objects: make(map[string][]byte)}
+}
+
+func (m *memoryStorage) Save(_ context.Context, key string, r io.Reader, _ int64, _ string) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	m.objects[key] = data
+	return nil
+}
+
+func (m *memoryStorage) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	data, ok := m.objects[key]
+	if !ok {
+		return nil, storage.ErrNotExist
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *memoryStorage) Delete(_ context.Context, key string) error {
+	delete(m.objects, key)
+	return nil
+}
+
+func (m *memoryStorage) Stat(_ context.Context, key string) (storage.ObjectInfo, error) {
+	data, ok := m.objects[key]
+	if !ok {
+		return storage.ObjectInfo{}, storage.ErrNotExist
+	}
+	return storage.ObjectInfo{Key: key, Size: int64(len(data)), ModTime: time.Unix(0, 0)}, nil
+}
+
+func (m *memoryStorage) URL(context.Context, string, time.Duration) (string, error) {
+	return "", nil
+}
+
+func TestSaveBytesToStorage_UsesKeysRelativeToUploadRoot(t *testing.T) {
+	t.Setenv("UPLOAD_PATH", "./uploads")
+	store := newMemoryStorage()
+
+	key, err := saveBytesToStorage(context.Background(), store, filepath.Join("uploads", "users", "7", "form.pdf"), []byte("%PDF-"), "application/pdf")
+	if err != nil {
+		t.Fatalf("saveBytesToStorage: %v", err)
+	}
+	if key != "users/7/form.pdf" {
+		t.Fatalf("expected key users/7/form.pdf, got %q", key)
+	}
+	if string(store.objects[key]) != "%PDF-" {
+		t.Fatalf("unexpected stored content %q", store.objects[key])
+	}
+}
+
+func TestSendStoredFile_StreamsFromObjectStore(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := newMemoryStorage()
+	store.objects["users/7/form.pdf"] = []byte("%PDF-1.4 body")
+	info, _ := store.Stat(context.Background(), "users/7/form.pdf")
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	if err := sendStoredFile(c, store, "users/7/form.pdf", info, "application/pdf", `attachment; filename="form.pdf"`); err != nil {
+		t.Fatalf("sendStoredFile: %v", err)
+	}
+
+	if w.Code != http.StatusOK || w.Body.String() != "%PDF-1.4 body" {
+		t.Fatalf("unexpected response %d %q", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Content-Disposition"); got != `attachment; filename="form.pdf"` {
+		t.Fatalf("unexpected Content-Disposition %q", got)
+	}
+}
+
+func TestSubmissionArchive_ReadsFromObjectStore(t *testing.T) {
+	store := newMemoryStorage()
+	store.objects["users/7/a.pdf"] = []byte("first")
+	documents := []models.SubmissionDocument{
+		{DocumentID: 1, DisplayOrder: 1, OriginalName: "a.pdf", File: models.FileUpload{FileID: 10, StoredPath: "users/7/a.pdf"}},
+		{DocumentID: 2, DisplayOrder: 2, OriginalName: "missing.pdf", File: models.FileUpload{FileID: 11, StoredPath: "users/7/missing.pdf"}},
+	}
+
+	ctx := context.Background()
+	entries := submissionArchiveEntries(ctx, store, documents)
+	if len(entries) != 1 || entries[0].Name != "01_a.pdf" {
+		t.Fatalf("expected only the stored document, got %+v", entries)
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	if err := writeSubmissionArchiveEntry(ctx, store, zw, entries[0]); err != nil {
+		t.Fatalf("writeSubmissionArchiveEntry: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := zr.File[0].Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(r)
+	r.Close()
+	if string(body) != "first" {
+		t.Fatalf("unexpected archived content %q", body)
+	}
+}
@@ -0,0 +1,332 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"fund-management-api/config"
+	"fund-management-api/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	formJobType = "publication_reward_form"
+
+	defaultFormJobWorkers     = 2
+	defaultFormJobQueueSize   = 100
+	defaultFormJobMaxAttempts = 3
+	formJobPollInterval       = 30 * time.Second
+	defaultFormJobLeaseMins   = 15
+)
+
+var (
+	formJobCh      chan int
+	formJobWorkers int
+	formJobOnce    sync.Once
+)
+
+func formJobEnvInt(key string, fallback int) int {
+	if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			return n
+		}
+	}
+	return fallback
+}
+
+// StartFormJobWorkers starts the form-generation workers (FORM_JOB_WORKERS,
+// default 2) fed by a bounded channel (FORM_JOB_QUEUE_SIZE, default 100). A
+// poller feeds due jobs from the table so nothing is lost when the channel is
+// full or a retry is scheduled for later, and queues again jobs whose lease
+// expired because the instance running them stopped.
+func StartFormJobWorkers() {
+	formJobOnce.Do(func() {
+		formJobWorkers = formJobEnvInt("FORM_JOB_WORKERS", defaultFormJobWorkers)
+		formJobCh = make(chan int, formJobEnvInt("FORM_JOB_QUEUE_SIZE", defaultFormJobQueueSize))
+
+		for i := 0; i < formJobWorkers; i++ {
+			go func() {
+				for jobID := range formJobCh {
+					processFormJob(jobID)
+				}
+			}()
+		}
+
+		go func() {
+			ticker := time.NewTicker(formJobPollInterval)
+			defer ticker.Stop()
+			for {
+				requeueExpiredFormJobs()
+				dispatchDueFormJobs()
+				<-ticker.C
+			}
+		}()
+
+		log.Printf("[formJobs] started %d worker(s), queue size %d", formJobWorkers, cap(formJobCh))
+	})
+}
+
+// dispatchFormJob hands a job to the workers without blocking; when the
+// channel is full the poller picks the job up later.
+func dispatchFormJob(jobID int) {
+	if formJobCh == nil {
+		return
+	}
+	select {
+	case formJobCh <- jobID:
+	default:
+	}
+}
+
+// formJobLease is how long a job may stay running before another instance
+// assumes its worker died (FORM_JOB_LEASE_MINUTES, default 15). It must exceed
+// the longest form generation, or a live job is run twice.
+func formJobLease() time.Duration {
+	return time.Duration(formJobEnvInt("FORM_JOB_LEASE_MINUTES", defaultFormJobLeaseMins)) * time.Minute
+}
+
+// requeueExpiredFormJobs queues again running jobs whose lease has expired.
+// Jobs still within their lease may belong to another live API instance and
+// are left alone.
+func requeueExpiredFormJobs() {
+	now := time.Now()
+	result := config.DB.Model(&models.BackgroundJob{}).
+		Where("job_type = ? AND status = ? AND (started_at IS NULL OR started_at < ?)",
+			formJobType, models.BackgroundJobRunning, now.Add(-formJobLease())).
+		Updates(map[string]interface{}{"status": models.BackgroundJobQueued, "run_after": now})
+	if result.Error != nil {
+		log.Printf("[formJobs] failed to requeue expired jobs: %v", result.Error)
+		return
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("[formJobs] requeued %d job(s) whose lease expired", result.RowsAffected)
+	}
+}
+
+func dispatchDueFormJobs() {
+	free := cap(formJobCh) - len(formJobCh)
+	if free <= 0 {
+		return
+	}
+	var jobIDs []int
+	if err := config.DB.Model(&models.BackgroundJob{}).
+		Where("job_type = ? AND status = ? AND run_after <= ?", formJobType, models.BackgroundJobQueued, time.Now()).
+		Order("run_after ASC, job_id ASC").
+		Limit(free).
+		Pluck("job_id", &jobIDs).Error; err != nil {
+		log.Printf("[formJobs] failed to load due jobs: %v", err)
+		return
+	}
+	for _, jobID := range jobIDs {
+		dispatchFormJob(jobID)
+	}
+}
+
+// EnqueueFormGeneration persists a form-generation job for the submission and
+// dispatches it. A job that is still queued or running is reused.
+func EnqueueFormGeneration(submissionID int) (*models.BackgroundJob, error) {
+	var job models.BackgroundJob
+	err := config.DB.Where("job_type = ? AND submission_id = ? AND status IN ?", formJobType, submissionID,
+		[]string{models.BackgroundJobQueued, models.BackgroundJobRunning}).
+		Order("job_id DESC").
+		First(&job).Error
+	if err == nil {
+		return &job, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	now := time.Now()
+	job = models.BackgroundJob{
+		JobType:      formJobType,
+		SubmissionID: &submissionID,
+		Status:       models.BackgroundJobQueued,
+		MaxAttempts:  formJobEnvInt("FORM_JOB_MAX_ATTEMPTS", defaultFormJobMaxAttempts),
+		RunAfter:     now,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := config.DB.Create(&job).Error; err != nil {
+		return nil, err
+	}
+	if err := config.DB.Model(&models.Submission{}).
+		Where("submission_id = ?", submissionID).
+		Updates(map[string]interface{}{"form_generation_status": formGenerationPending, "form_generation_error": gorm.Expr("NULL")}).Error; err != nil {
+		log.Printf("[formJobs] failed to mark submission %d pending: %v", submissionID, err)
+	}
+
+	dispatchFormJob(job.JobID)
+	return &job, nil
+}
+
+// processFormJob claims a queued job and runs it. The conditional update makes
+// the claim exclusive when the same ID reaches the channel twice.
+func processFormJob(jobID int) {
+	now := time.Now()
+	claim := config.DB.Model(&models.BackgroundJob{}).
+		Where("job_id = ? AND status = ?", jobID, models.BackgroundJobQueued).
+		Updates(map[string]interface{}{
+			"status":     models.BackgroundJobRunning,
+			"attempts":   gorm.Expr("attempts + 1"),
+			"started_at": now,
+		})
+	if claim.Error != nil {
+		log.Printf("[formJobs] failed to claim job %d: %v", jobID, claim.Error)
+		return
+	}
+	if claim.RowsAffected == 0 {
+		return
+	}
+
+	var job models.BackgroundJob
+	if err := config.DB.First(&job, jobID).Error; err != nil {
+		log.Printf("[formJobs] failed to load job %d: %v", jobID, err)
+		return
+	}
+
+	runErr := runFormJob(&job)
+	finished := time.Now()
+	updates := map[string]interface{}{
+		"status":      models.BackgroundJobSucceeded,
+		"last_error":  gorm.Expr("NULL"),
+		"finished_at": finished,
+	}
+	if runErr != nil {
+		message := runErr.Error()
+		updates["last_error"] = message
+		if job.Attempts < job.MaxAttempts {
+			// Back off 1, 2, 4... minutes between attempts.
+			updates["status"] = models.BackgroundJobQueued
+			updates["run_after"] = finished.Add(time.Duration(1<<(job.Attempts-1)) * time.Minute)
+			updates["finished_at"] = gorm.Expr("NULL")
+			if job.SubmissionID != nil {
+				config.DB.Model(&models.Submission{}).
+					Where("submission_id = ?", *job.SubmissionID).
+					Update("form_generation_status", formGenerationPending)
+			}
+		} else {
+			updates["status"] = models.BackgroundJobFailed
+		}
+	}
+	if err := config.DB.Model(&models.BackgroundJob{}).Where("job_id = ?", jobID).Updates(updates).Error; err != nil {
+		log.Printf("[formJobs] failed to record result of job %d: %v", jobID, err)
+	}
+}
+
+func runFormJob(job *models.BackgroundJob) error {
+	if job.SubmissionID == nil {
+		return fmt.Errorf("job %d has no submission", job.JobID)
+	}
+	var submission models.Submission
+	if err := config.DB.Preload("User").
+		Where("submission_id = ? AND deleted_at IS NULL", *job.SubmissionID).
+		First(&submission).Error; err != nil {
+		return fmt.Errorf("failed to load submission %d: %w", *job.SubmissionID, err)
+	}
+	return runSubmissionFormGeneration(&submission)
+}
+
+// GetAdminJobQueue reports queue depth per status and the most recent failed
+// jobs. GET /admin/jobs?limit=
+func GetAdminJobQueue(c *gin.Context) {
+	limit := 50
+	if raw := strings.TrimSpace(c.Query("limit")); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil && n > 0 && n <= 500 {
+			limit = n
+		}
+	}
+
+	var counts []struct {
+		Status string
+		Total  int
+	}
+	if err := config.DB.Model(&models.BackgroundJob{}).
+		Select("status, COUNT(*) AS total").
+		Group("status").
+		Scan(&counts).Error; err != nil {
+		InternalError(c, "job queue: count jobs", err)
+		return
+	}
+	byStatus := gin.H{
+		models.BackgroundJobQueued:    0,
+		models.BackgroundJobRunning:   0,
+		models.BackgroundJobSucceeded: 0,
+		models.BackgroundJobFailed:    0,
+	}
+	for _, row := range counts {
+		byStatus[row.Status] = row.Total
+	}
+
+	var failed []models.BackgroundJob
+	if err := config.DB.Where("status = ?", models.BackgroundJobFailed).
+		Order("updated_at DESC, job_id DESC").
+		Limit(limit).
+		Find(&failed).Error; err != nil {
+		InternalError(c, "job queue: load failed jobs", err)
+		return
+	}
+
+	inMemory := 0
+	capacity := 0
+	if formJobCh != nil {
+		inMemory = len(formJobCh)
+		capacity = cap(formJobCh)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":     true,
+		"counts":      byStatus,
+		"depth":       byStatus[models.BackgroundJobQueued].(int) + byStatus[models.BackgroundJobRunning].(int),
+		"workers":     formJobWorkers,
+		"buffered":    inMemory,
+		"capacity":    capacity,
+		"failed_jobs": failed,
+	})
+}
+
+// RetryAdminJob puts a failed job back in the queue with a fresh attempt budget.
+// POST /admin/jobs/:id/retry
+func RetryAdminJob(c *gin.Context) {
+	jobID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || jobID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid job id"})
+		return
+	}
+
+	result := config.DB.Model(&models.BackgroundJob{}).
+		Where("job_id = ? AND status = ?", jobID, models.BackgroundJobFailed).
+		Updates(map[string]interface{}{
+			"status":      models.BackgroundJobQueued,
+			"attempts":    0,
+			"run_after":   time.Now(),
+			"finished_at": gorm.Expr("NULL"),
+		})
+	if result.Error != nil {
+		InternalError(c, "job queue: retry job", result.Error)
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "Failed job not found"})
+		return
+	}
+
+	var job models.BackgroundJob
+	if err := config.DB.First(&job, jobID).Error; err == nil && job.SubmissionID != nil {
+		config.DB.Model(&models.Submission{}).
+			Where("submission_id = ?", *job.SubmissionID).
+			Update("form_generation_status", formGenerationPending)
+	}
+
+	dispatchFormJob(jobID)
+	c.JSON(http.StatusOK, gin.H{"success": true, "job_id": jobID})
+}
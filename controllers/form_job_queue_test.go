@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"fund-management-api/config"
+)
+
+func TestRequeueExpiredFormJobs_OnlyTouchesJobsPastTheirLease(t *testing.T) {
+	t.Setenv("FORM_JOB_LEASE_MINUTES", "10")
+
+	var statements []string
+	var cutoff time.Time
+	db := newLockingGormDB(t, 1, lockingSQLHandler{
+		exec: func(query string, args []driver.NamedValue, _ bool) (int64, error) {
+			statements = append(statements, query)
+			for _, arg := range args {
+				if ts, ok := arg.Value.(time.Time); ok && (cutoff.IsZero() || ts.Before(cutoff)) {
+					cutoff = ts
+				}
+			}
+			return 0, nil
+		},
+	})
+	previous := config.DB
+	config.DB = db
+	defer func() { config.DB = previous }()
+
+	before := time.Now()
+	requeueExpiredFormJobs()
+
+	if len(statements) != 1 || !strings.Contains(statements[0], "started_at IS NULL OR started_at <") {
+		t.Fatalf("expected one lease-bounded update, got %v", statements)
+	}
+	// The earliest time argument is the started_at cutoff; the other is run_after.
+	if lease := before.Sub(cutoff); lease < 9*time.Minute || lease > 11*time.Minute {
+		t.Fatalf("expected a 10 minute lease cutoff, got %v before now", lease)
+	}
+}
@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"fund-management-api/models"
+	"fund-management-api/utils"
+
+	"gorm.io/gorm"
+)
+
+const fundApplicationFormDocumentCode = "fund_application_form_docx"
+const fundApplicationFormPdfDocumentCode = "fund_application_form_pdf"
+
+// buildFundApplicationReplacements maps a fund application, its applicant and
+// the system configuration onto the placeholders of
+// templates/fund_application_template.docx.
+func buildFundApplicationReplacements(submission *models.Submission, detail *models.FundApplicationDetail, sysConfig *systemConfigSnapshot, documents []models.SubmissionDocument) (map[string]string, error) {
+	if submission == nil {
+		return nil, fmt.Errorf("submission is required")
+	}
+	if submission.User == nil {
+		return nil, fmt.Errorf("submission missing applicant information")
+	}
+	if detail == nil {
+		return nil, fmt.Errorf("fund application detail is required")
+	}
+	if sysConfig == nil {
+		sysConfig = &systemConfigSnapshot{}
+	}
+
+	documentDate := time.Now()
+	switch {
+	case submission.SubmittedAt != nil:
+		documentDate = *submission.SubmittedAt
+	case !submission.CreatedAt.IsZero():
+		documentDate = submission.CreatedAt
+	}
+
+	installment := formatNullableInt(sysConfig.Installment)
+	if submission.InstallmentNumberAtSubmit != nil {
+		installment = formatInstallmentNumber(submission.InstallmentNumberAtSubmit)
+	}
+
+	fundName := ""
+	switch {
+	case submission.Subcategory != nil:
+		fundName = strings.TrimSpace(submission.Subcategory.SubcategoryName)
+	case detail.Subcategory != nil:
+		fundName = strings.TrimSpace(detail.Subcategory.SubcategoryName)
+	}
+	categoryName := ""
+	if submission.Category != nil {
+		categoryName = strings.TrimSpace(submission.Category.CategoryName)
+	}
+
+	replacements := map[string]string{
+		"{{date_th}}":               utils.FormatThaiDate(documentDate),
+		"{{submission_number}}":     strings.TrimSpace(submission.SubmissionNumber),
+		"{{applicant_name}}":        buildApplicantName(submission.User),
+		"{{date_of_employment}}":    resolveApplicantEmploymentDate(submission.User),
+		"{{position}}":              resolveApplicantPosition(submission.User),
+		"{{installment}}":           installment,
+		"{{kku_report_year}}":       formatNullableString(sysConfig.KkuReportYear),
+		"{{category_name}}":         categoryName,
+		"{{fund_name}}":             fundName,
+		"{{project_title}}":         strings.TrimSpace(detail.ProjectTitle),
+		"{{project_description}}":   strings.TrimSpace(detail.ProjectDescription),
+		"{{requested_amount}}":      formatAmount(detail.RequestedAmount),
+		"{{requested_amount_text}}": utils.BahtText(detail.RequestedAmount),
+		"{{document_line}}":         buildDocumentLine(documents),
+	}
+
+	endOfContractContent, err := fetchEndOfContractContent()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load end of contract content: %w", err)
+	}
+	replacements["{{end_of_contract}}"] = endOfContractContent
+
+	return replacements, nil
+}
+
+// generateFundApplicationForms renders the fund application form as DOCX and
+// PDF and attaches both to the submission, replacing any earlier ones.
+func generateFundApplicationForms(tx *gorm.DB, submission *models.Submission, now time.Time) error {
+	applicant := submission.User
+	if applicant == nil {
+		applicant = &models.User{}
+	}
+	if err := tx.Preload("Position").Where("user_id = ?", submission.UserID).First(applicant).Error; err != nil {
+		return fmt.Errorf("failed to load applicant: %w", err)
+	}
+	submission.User = applicant
+
+	if submission.Category == nil && submission.CategoryID != nil {
+		var category models.FundCategory
+		if err := tx.Where("category_id = ?", *submission.CategoryID).First(&category).Error; err == nil {
+			submission.Category = &category
+		}
+	}
+
+	var detail models.FundApplicationDetail
+	if err := tx.Preload("Subcategory").
+		Where("submission_id = ?", submission.SubmissionID).
+		First(&detail).Error; err != nil {
+		return fmt.Errorf("failed to load fund application detail: %w", err)
+	}
+
+	sysConfig, err := fetchLatestSystemConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load system configuration: %w", err)
+	}
+
+	if err := resequenceSubmissionDocumentsByDocumentType(tx, submission.SubmissionID); err != nil {
+		return fmt.Errorf("failed to resequence submission documents: %w", err)
+	}
+
+	documents, err := fetchSubmissionDocuments(tx, submission.SubmissionID)
+	if err != nil {
+		return fmt.Errorf("failed to load submission documents: %w", err)
+	}
+
+	replacements, err := buildFundApplicationReplacements(submission, &detail, sysConfig, documents)
+	if err != nil {
+		return err
+	}
+
+	docType, err := ensureGeneratedFormDocumentType(tx, fundApplicationFormDocumentCode, "แบบฟอร์มใบสมัครขอใช้เงินกองทุน (DOCX)", "fund_application")
+	if err != nil {
+		return fmt.Errorf("failed to prepare document type: %w", err)
+	}
+
+	pdfDocType, err := ensureGeneratedFormDocumentType(tx, fundApplicationFormPdfDocumentCode, "แบบฟอร์มใบสมัครขอใช้เงินกองทุน (PDF)", "fund_application")
+	if err != nil {
+		return fmt.Errorf("failed to prepare pdf document type: %w", err)
+	}
+
+	return attachGeneratedFormDocuments(tx, submission, documents, generatedFormSpec{
+		TemplatePath: filepath.Join("templates", "fund_application_template.docx"),
+		FileSuffix:   "fund_application_form",
+		DocxType:     docType,
+		PdfType:      pdfDocType,
+	}, replacements, now)
+}
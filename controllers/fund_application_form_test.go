@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"fund-management-api/config"
+	"fund-management-api/models"
+	"fund-management-api/utils"
+
+	"gorm.io/gorm"
+)
+
+func TestBuildFundApplicationReplacements(t *testing.T) {
+	db := newLockingGormDB(t, 1, lockingSQLHandler{
+		query: func(query string, _ []driver.NamedValue, _ bool) ([]string, []driver.Value, error) {
+			if strings.Contains(query, "end_of_contract") {
+				return []string{"content"}, []driver.Value{"ข้าพเจ้าขอรับรองว่าข้อมูลถูกต้อง"}, nil
+			}
+			return nil, nil, fmt.Errorf("unexpected query: %s", query)
+		},
+	})
+	previous := config.DB
+	config.DB = db
+	defer func() { config.DB = previous }()
+
+	prefix, position := "ดร.", "อาจารย์"
+	employed := time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)
+	submitted := time.Date(2026, time.October, 15, 10, 0, 0, 0, time.UTC)
+	installment := 2
+	submission := &models.Submission{
+		SubmissionNumber:          "FA-2569-0001",
+		SubmittedAt:               &submitted,
+		InstallmentNumberAtSubmit: &installment,
+		User:                      &models.User{Prefix: &prefix, UserFname: "สมชาย", UserLname: "ใจดี", PositionTitle: &position, DateOfEmployment: &employed},
+		Category:                  &models.FundCategory{CategoryName: "ทุนส่งเสริมการวิจัย"},
+		Subcategory:               &models.FundSubcategory{SubcategoryName: "ทุนวิจัยเริ่มต้น"},
+	}
+	detail := &models.FundApplicationDetail{
+		ProjectTitle:       "  Rice disease detection  ",
+		ProjectDescription: "Field study",
+		RequestedAmount:    50000,
+	}
+	sysConfig := &systemConfigSnapshot{
+		Installment:   sql.NullInt64{Int64: 1, Valid: true},
+		KkuReportYear: sql.NullString{String: "2569", Valid: true},
+	}
+
+	got, err := buildFundApplicationReplacements(submission, detail, sysConfig, nil)
+	if err != nil {
+		t.Fatalf("buildFundApplicationReplacements: %v", err)
+	}
+	want := map[string]string{
+		"{{date_th}}":               utils.FormatThaiDate(submitted),
+		"{{submission_number}}":     "FA-2569-0001",
+		"{{applicant_name}}":        "ดร. สมชาย ใจดี",
+		"{{date_of_employment}}":    utils.FormatThaiCalendarDate(employed),
+		"{{position}}":              "อาจารย์",
+		"{{installment}}":           "2",
+		"{{kku_report_year}}":       "2569",
+		"{{category_name}}":         "ทุนส่งเสริมการวิจัย",
+		"{{fund_name}}":             "ทุนวิจัยเริ่มต้น",
+		"{{project_title}}":         "Rice disease detection",
+		"{{project_description}}":   "Field study",
+		"{{requested_amount}}":      formatAmount(50000),
+		"{{requested_amount_text}}": utils.BahtText(50000),
+		"{{document_line}}":         "",
+		"{{end_of_contract}}":       "ข้าพเจ้าขอรับรองว่าข้อมูลถูกต้อง",
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("%s = %q, want %q", key, got[key], value)
+		}
+	}
+
+	// Without an installment at submit the system config's installment is used.
+	submission.InstallmentNumberAtSubmit = nil
+	if got, err := buildFundApplicationReplacements(submission, detail, sysConfig, nil); err != nil || got["{{installment}}"] != "1" {
+		t.Fatalf("expected the configured installment, got %q (%v)", got["{{installment}}"], err)
+	}
+}
+
+func TestBuildFundApplicationReplacements_RequiresApplicantAndDetail(t *testing.T) {
+	if _, err := buildFundApplicationReplacements(&models.Submission{}, &models.FundApplicationDetail{}, nil, nil); err == nil {
+		t.Fatal("expected an error without applicant information")
+	}
+	if _, err := buildFundApplicationReplacements(&models.Submission{User: &models.User{}}, nil, nil, nil); err == nil {
+		t.Fatal("expected an error without the fund application detail")
+	}
+}
+
+func TestMissingFormSubmissionsQuery_SkipsFundApplicationsBeforeFormGeneration(t *testing.T) {
+	db := newLockingGormDB(t, 1, lockingSQLHandler{})
+	sqlText := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var ids []int
+		return missingFormSubmissionsQuery(tx, []int{4}).Pluck("s.submission_id", &ids)
+	})
+	if !strings.Contains(sqlText, "s.submission_type = 'fund_application' AND s.form_generation_status IS NOT NULL") {
+		t.Fatalf("expected fund applications to require a form generation status, got %s", sqlText)
+	}
+}
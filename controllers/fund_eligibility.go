@@ -0,0 +1,333 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"fund-management-api/config"
+	"fund-management-api/models"
+	"fund-management-api/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Blocking reason codes returned by the can-apply check.
+const (
+	applyBlockSubcategoryUnavailable = "SUBCATEGORY_UNAVAILABLE"
+	applyBlockRoleNotEligible        = "ROLE_NOT_ELIGIBLE"
+	applyBlockUserNotEligible        = "USER_NOT_ELIGIBLE"
+	applyBlockQuotaExhausted         = "QUOTA_EXHAUSTED"
+	applyBlockApplicationLimit       = "APPLICATION_LIMIT_REACHED"
+	applyBlockNoBudget               = "NO_ACTIVE_BUDGET"
+	applyBlockGrantsExhausted        = "NO_REMAINING_GRANTS"
+	applyBlockYearlyAmountReached    = "YEARLY_AMOUNT_REACHED"
+	applyBlockBudgetExhausted        = "BUDGET_EXHAUSTED"
+	applyBlockInstallmentClosed      = "INSTALLMENT_CLOSED"
+)
+
+type applyBlockingReason struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type fundApplyDecision struct {
+	CanApply        bool                  `json:"can_apply"`
+	SubcategoryID   int                   `json:"subcategory_id"`
+	YearID          int                   `json:"year_id"`
+	Reasons         []applyBlockingReason `json:"reasons"`
+	RemainingQuota  *float64              `json:"remaining_quota,omitempty"`
+	RemainingGrants *int                  `json:"remaining_grants,omitempty"`
+	RemainingBudget *float64              `json:"remaining_budget,omitempty"`
+	Installment     *int                  `json:"installment,omitempty"`
+	// EnforceBudgetLimits mirrors the subcategory's enforce_budget_limits flag.
+	EnforceBudgetLimits bool `json:"-"`
+}
+
+func (d *fundApplyDecision) block(code, message string) {
+	d.Reasons = append(d.Reasons, applyBlockingReason{Code: code, Message: message})
+	d.CanApply = false
+}
+
+// evaluateFundApplyEligibility gathers every rule that decides whether a user may
+// submit to a subcategory: target_roles, the user's quota in
+// user_fund_eligibilities, the yearly grant/amount limits on the overall budget,
+// the remaining budget and whether an installment period is still open.
+// yearID may be 0 to use the subcategory's own year.
+func evaluateFundApplyEligibility(db *gorm.DB, userID, roleID, subcategoryID, yearID int, now time.Time) (*fundApplyDecision, error) {
+	if db == nil {
+		db = config.DB
+	}
+	decision := &fundApplyDecision{CanApply: true, SubcategoryID: subcategoryID, YearID: yearID, Reasons: []applyBlockingReason{}}
+
+	var subcategory models.FundSubcategory
+	if err := db.Preload("Category").
+		Where("subcategory_id = ? AND delete_at IS NULL", subcategoryID).
+		First(&subcategory).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			decision.block(applyBlockSubcategoryUnavailable, "Subcategory not found")
+			return decision, nil
+		}
+		return nil, err
+	}
+	if subcategory.Status != "active" || (subcategory.Category.CategoryID != 0 && subcategory.Category.Status != "active") {
+		decision.block(applyBlockSubcategoryUnavailable, "Subcategory is not open for applications")
+	}
+	if decision.YearID == 0 {
+		decision.YearID = subcategory.Category.YearID
+	}
+	decision.EnforceBudgetLimits = subcategory.EnforceBudgetLimits
+
+	if !subcategoryTargetsRole(subcategory.TargetRoles, roleID) {
+		decision.block(applyBlockRoleNotEligible, "Your role is not eligible for this fund")
+	}
+
+	if err := applyUserFundEligibility(db, decision, userID, subcategory.CategoryID); err != nil {
+		return nil, err
+	}
+
+	if err := applyBudgetLimits(db, decision, userID, subcategoryID); err != nil {
+		return nil, err
+	}
+
+	selection, err := resolveSubmissionFundSelection(db, &models.Submission{SubcategoryID: &subcategoryID})
+	if err != nil {
+		return nil, err
+	}
+	periods, err := loadActiveInstallmentPeriods(db, decision.YearID, selection)
+	if err != nil {
+		return nil, err
+	}
+	// Without configured periods submissions are not restricted by installment.
+	if len(periods) > 0 {
+		open := openInstallmentPeriod(periods, now)
+		if open == nil {
+			decision.block(applyBlockInstallmentClosed, "No installment period is currently open")
+		} else {
+			number := open.InstallmentNumber
+			decision.Installment = &number
+		}
+	}
+
+	return decision, nil
+}
+
+// submitBlockingReasons returns the reasons that stop a submission of
+// submissionType. Publication rewards sent after the last installment cutoff
+// are still accepted and assigned to the last installment (see
+// selectInstallmentNumber), so INSTALLMENT_CLOSED only blocks other types.
+// When the subcategory does not enforce its budget limits the grant, yearly
+// amount and remaining budget reasons are dropped too; checkSubmissionBudgetLimits
+// reports them as warnings instead.
+func submitBlockingReasons(decision *fundApplyDecision, submissionType string) []applyBlockingReason {
+	publicationReward := strings.TrimSpace(submissionType) == "publication_reward"
+	reasons := make([]applyBlockingReason, 0, len(decision.Reasons))
+	for _, reason := range decision.Reasons {
+		switch reason.Code {
+		case applyBlockInstallmentClosed:
+			if publicationReward {
+				continue
+			}
+		case applyBlockGrantsExhausted, applyBlockYearlyAmountReached, applyBlockBudgetExhausted:
+			if !decision.EnforceBudgetLimits {
+				continue
+			}
+		}
+		reasons = append(reasons, reason)
+	}
+	return reasons
+}
+
+// subcategoryTargetsRole reports whether target_roles (a JSON array of role IDs)
+// includes roleID. An empty list opens the fund to every role; admins always pass.
+func subcategoryTargetsRole(targetRoles *string, roleID int) bool {
+	if roleID == 3 || targetRoles == nil || strings.TrimSpace(*targetRoles) == "" {
+		return true
+	}
+	var roles []interface{}
+	if err := json.Unmarshal([]byte(*targetRoles), &roles); err != nil || len(roles) == 0 {
+		return true
+	}
+	want := strconv.Itoa(roleID)
+	for _, role := range roles {
+		if strings.TrimSpace(fmt.Sprint(role)) == want {
+			return true
+		}
+	}
+	return false
+}
+
+func applyUserFundEligibility(db *gorm.DB, decision *fundApplyDecision, userID, categoryID int) error {
+	var eligibility struct {
+		RemainingQuota        *float64 `gorm:"column:remaining_quota"`
+		RemainingApplications *int     `gorm:"column:remaining_applications"`
+		IsEligible            *string  `gorm:"column:is_eligible"`
+		RestrictionReason     *string  `gorm:"column:restriction_reason"`
+	}
+	result := db.Table("user_fund_eligibilities").
+		Select("remaining_quota, remaining_applications, is_eligible, restriction_reason").
+		Where("user_id = ? AND year_id = ? AND category_id = ? AND delete_at IS NULL", userID, decision.YearID, categoryID).
+		Order("calculated_at DESC").
+		Limit(1).
+		Scan(&eligibility)
+	if result.Error != nil {
+		return result.Error
+	}
+	// No row means no per-user restriction has been calculated.
+	if result.RowsAffected == 0 {
+		return nil
+	}
+
+	if eligibility.IsEligible != nil {
+		switch strings.ToLower(strings.TrimSpace(*eligibility.IsEligible)) {
+		case "0", "false", "no", "n", "ineligible":
+			message := "You are not eligible for this fund"
+			if eligibility.RestrictionReason != nil && strings.TrimSpace(*eligibility.RestrictionReason) != "" {
+				message = strings.TrimSpace(*eligibility.RestrictionReason)
+			}
+			decision.block(applyBlockUserNotEligible, message)
+		}
+	}
+	if eligibility.RemainingQuota != nil {
+		decision.RemainingQuota = eligibility.RemainingQuota
+		if *eligibility.RemainingQuota <= 0 {
+			decision.block(applyBlockQuotaExhausted, "Your remaining quota for this fund is used up")
+		}
+	}
+	if eligibility.RemainingApplications != nil && *eligibility.RemainingApplications <= 0 {
+		decision.block(applyBlockApplicationLimit, "You have reached the number of applications allowed")
+	}
+	return nil
+}
+
+// subcategoryBudgetUsage is a subcategory's active overall budget with a
+// user's usage of it in one year and the budget left.
+type subcategoryBudgetUsage struct {
+	Overall    models.SubcategoryBudget
+	UsedGrants float64
+	UsedAmount float64
+	Remaining  float64
+}
+
+// loadSubcategoryBudgetUsage reads the active overall budget of a subcategory,
+// the user's usage from v_subcategory_user_usage_total and the remaining budget
+// from v_budget_summary. It returns nil when no active overall budget exists.
+func loadSubcategoryBudgetUsage(db *gorm.DB, subcategoryID, userID, yearID int) (*subcategoryBudgetUsage, error) {
+	usage := &subcategoryBudgetUsage{}
+	if err := db.Where("subcategory_id = ? AND status = 'active' AND delete_at IS NULL AND record_scope = 'overall'", subcategoryID).
+		First(&usage.Overall).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var used struct {
+		UsedGrants float64 `gorm:"column:used_grants"`
+		UsedAmount float64 `gorm:"column:used_amount"`
+	}
+	if err := db.Table("v_subcategory_user_usage_total").
+		Select("COALESCE(SUM(used_grants_total),0) AS used_grants, COALESCE(SUM(used_amount_total),0) AS used_amount").
+		Where("subcategory_id = ? AND user_id = ? AND year_id = ?", subcategoryID, userID, yearID).
+		Scan(&used).Error; err != nil {
+		// Same fallback as CreateApplication when the usage view is unavailable.
+		log.Printf("[fundEligibility] failed to read v_subcategory_user_usage_total: %v", err)
+	}
+	usage.UsedGrants = used.UsedGrants
+	usage.UsedAmount = used.UsedAmount
+
+	usage.Remaining = usage.Overall.RemainingBudget
+	var summary struct {
+		RemainingBudget *float64 `gorm:"column:remaining_budget"`
+	}
+	if err := db.Table("v_budget_summary").
+		Select("remaining_budget").
+		Where("subcategory_id = ?", subcategoryID).
+		Limit(1).
+		Scan(&summary).Error; err == nil && summary.RemainingBudget != nil {
+		usage.Remaining = *summary.RemainingBudget
+	}
+	return usage, nil
+}
+
+// applyBudgetLimits mirrors CreateApplication: max_grants and max_amount_per_year
+// on the overall budget are per-user yearly limits, and the subcategory must
+// still have budget left.
+func applyBudgetLimits(db *gorm.DB, decision *fundApplyDecision, userID, subcategoryID int) error {
+	usage, err := loadSubcategoryBudgetUsage(db, subcategoryID, userID, decision.YearID)
+	if err != nil {
+		return err
+	}
+	if usage == nil {
+		decision.block(applyBlockNoBudget, "No active budget is configured for this fund")
+		return nil
+	}
+	overall := usage.Overall
+
+	if overall.MaxGrants > 0 {
+		remaining := overall.MaxGrants - int(usage.UsedGrants)
+		if remaining < 0 {
+			remaining = 0
+		}
+		decision.RemainingGrants = &remaining
+		if remaining == 0 {
+			decision.block(applyBlockGrantsExhausted, "No remaining grants available for this year")
+		}
+	}
+	if overall.MaxAmountPerYear != nil && *overall.MaxAmountPerYear > 0 && usage.UsedAmount >= *overall.MaxAmountPerYear {
+		decision.block(applyBlockYearlyAmountReached, "Your yearly amount for this fund has been reached")
+	}
+
+	remaining := usage.Remaining
+	decision.RemainingBudget = &remaining
+	if overall.AllocatedAmount > 0 && remaining <= 0 {
+		decision.block(applyBlockBudgetExhausted, "The fund's budget is exhausted")
+	}
+	return nil
+}
+
+// openInstallmentPeriod returns the first period whose cutoff (plus grace days)
+// has not passed at now, or nil when every period is closed.
+func openInstallmentPeriod(periods []models.FundInstallmentPeriod, now time.Time) *models.FundInstallmentPeriod {
+	for i := range periods {
+		if periods[i].CutoffDate.IsZero() {
+			continue
+		}
+		if !now.After(utils.EndOfDayInAppLocation(installmentEffectiveCutoff(periods[i]))) {
+			return &periods[i]
+		}
+	}
+	return nil
+}
+
+// GetFundCanApply answers whether the current user may submit to a subcategory.
+// GET /funds/can-apply?subcategory_id=&year_id=
+func GetFundCanApply(c *gin.Context) {
+	subcategoryID, err := strconv.Atoi(strings.TrimSpace(c.Query("subcategory_id")))
+	if err != nil || subcategoryID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "subcategory_id is required"})
+		return
+	}
+	yearID := 0
+	if raw := strings.TrimSpace(c.Query("year_id")); raw != "" {
+		yearID, err = strconv.Atoi(raw)
+		if err != nil || yearID <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid year_id"})
+			return
+		}
+	}
+
+	decision, err := evaluateFundApplyEligibility(config.DB, c.GetInt("userID"), c.GetInt("roleID"), subcategoryID, yearID, time.Now())
+	if err != nil {
+		InternalError(c, "fund_can_apply", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": decision})
+}
@@ -0,0 +1,36 @@
+package controllers
+
+import "testing"
+
+func TestSubmitBlockingReasons_PublicationRewardIgnoresClosedInstallments(t *testing.T) {
+	decision := &fundApplyDecision{}
+	decision.block(applyBlockInstallmentClosed, "No installment period is currently open")
+	decision.block(applyBlockQuotaExhausted, "Your remaining quota for this fund is used up")
+
+	reasons := submitBlockingReasons(decision, "publication_reward")
+	if len(reasons) != 1 || reasons[0].Code != applyBlockQuotaExhausted {
+		t.Fatalf("expected only the quota reason for a publication reward, got %+v", reasons)
+	}
+
+	if reasons := submitBlockingReasons(decision, "fund_application"); len(reasons) != 2 {
+		t.Fatalf("expected fund applications to keep the installment gate, got %+v", reasons)
+	}
+}
+
+func TestSubmitBlockingReasons_AdvisoryBudgetLimitsDoNotBlock(t *testing.T) {
+	decision := &fundApplyDecision{}
+	decision.block(applyBlockGrantsExhausted, "No remaining grants available for this year")
+	decision.block(applyBlockYearlyAmountReached, "Your yearly amount for this fund has been reached")
+	decision.block(applyBlockBudgetExhausted, "The fund's budget is exhausted")
+	decision.block(applyBlockNoBudget, "No active budget is configured for this fund")
+
+	reasons := submitBlockingReasons(decision, "fund_application")
+	if len(reasons) != 1 || reasons[0].Code != applyBlockNoBudget {
+		t.Fatalf("expected only the missing budget to block when limits are advisory, got %+v", reasons)
+	}
+
+	decision.EnforceBudgetLimits = true
+	if reasons := submitBlockingReasons(decision, "fund_application"); len(reasons) != 4 {
+		t.Fatalf("expected enforced budget limits to block, got %+v", reasons)
+	}
+}
@@ -0,0 +1,163 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"fund-management-api/config"
+	"fund-management-api/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const fundingStatementTemplate = "funding_statement_template.docx"
+
+// fundingStatementItem is one approved submission on a funding statement.
+type fundingStatementItem struct {
+	SubmissionID     int
+	SubmissionNumber string
+	SubmissionType   string
+	Year             string
+	Title            string
+	ApprovedAmount   float64
+}
+
+// fundingStatementYear groups a year's approved submissions with their total.
+type fundingStatementYear struct {
+	Year  string
+	Items []fundingStatementItem
+	Total float64
+}
+
+// loadFundingStatement returns the user's approved fund applications and
+// publication rewards grouped by year, oldest year first. Amounts are the
+// approved amounts the yearly budget usage (getUserBudgetUsed) counts.
+func loadFundingStatement(db *gorm.DB, userID int) ([]fundingStatementYear, error) {
+	approvedStatusID, err := utils.GetStatusIDByCode(utils.StatusCodeApproved)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []fundingStatementItem
+	if err := db.Table("submissions s").
+		Select(`s.submission_id, s.submission_number, s.submission_type, y.year,
+			COALESCE(fad.project_title, prd.paper_title, '') AS title,
+			CASE WHEN s.submission_type = 'fund_application' THEN COALESCE(fad.approved_amount, 0)
+			     ELSE COALESCE(prd.reward_approve_amount, 0) END AS approved_amount`).
+		Joins("JOIN years y ON s.year_id = y.year_id").
+		Joins("LEFT JOIN fund_application_details fad ON fad.submission_id = s.submission_id").
+		Joins("LEFT JOIN publication_reward_details prd ON prd.submission_id = s.submission_id").
+		Where("s.user_id = ? AND s.status_id = ? AND s.deleted_at IS NULL AND s.submission_type IN ?",
+			userID, approvedStatusID, []string{"fund_application", "publication_reward"}).
+		Order("y.year ASC, s.submission_number ASC, s.submission_id ASC").
+		Scan(&items).Error; err != nil {
+		return nil, err
+	}
+
+	return groupFundingStatement(items), nil
+}
+
+// groupFundingStatement splits items, already ordered by year, into per-year
+// groups with subtotals.
+func groupFundingStatement(items []fundingStatementItem) []fundingStatementYear {
+	years := []fundingStatementYear{}
+	for _, item := range items {
+		if len(years) == 0 || years[len(years)-1].Year != item.Year {
+			years = append(years, fundingStatementYear{Year: item.Year})
+		}
+		current := &years[len(years)-1]
+		current.Items = append(current.Items, item)
+		current.Total += item.ApprovedAmount
+	}
+	return years
+}
+
+// buildFundingStatementReplacements maps the grouped statement onto the
+// placeholders of templates/funding_statement_template.docx. Each year is a
+// heading line with its subtotal followed by one line per submission.
+func buildFundingStatementReplacements(applicantName, position string, years []fundingStatementYear, now time.Time) map[string]string {
+	var lines []string
+	count := 0
+	var grandTotal float64
+	for _, year := range years {
+		lines = append(lines, fmt.Sprintf("ปีงบประมาณ %s  รวม %s บาท", year.Year, formatAmount(year.Total)))
+		for _, item := range year.Items {
+			label := "ขอใช้เงินกองทุน"
+			if item.SubmissionType == "publication_reward" {
+				label = "เงินรางวัลการตีพิมพ์"
+			}
+			lines = append(lines, fmt.Sprintf("    %s  %s: %s  %s บาท",
+				strings.TrimSpace(item.SubmissionNumber), label, strings.TrimSpace(item.Title), formatAmount(item.ApprovedAmount)))
+		}
+		count += len(year.Items)
+		grandTotal += year.Total
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "ยังไม่มีรายการที่ได้รับอนุมัติ")
+	}
+
+	return map[string]string{
+		"{{date_th}}":          utils.FormatThaiDate(now),
+		"{{applicant_name}}":   applicantName,
+		"{{position}}":         position,
+		"{{statement_lines}}":  strings.Join(lines, "\n"),
+		"{{submission_count}}": fmt.Sprintf("%d", count),
+		"{{grand_total}}":      formatAmount(grandTotal),
+		"{{grand_total_text}}": utils.BahtText(grandTotal),
+	}
+}
+
+// GetMyFundingStatement downloads the caller's funding statement: every
+// approved submission across years with its approved amount, yearly subtotals
+// and a grand total, rendered as PDF.
+// GET /users/me/statement.pdf
+func GetMyFundingStatement(c *gin.Context) {
+	userID := c.GetInt("userID")
+
+	user, err := loadUserFormProfile(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "User not found"})
+			return
+		}
+		InternalError(c, "funding statement: user", err)
+		return
+	}
+
+	years, err := loadFundingStatement(config.DB, userID)
+	if err != nil {
+		InternalError(c, "funding statement", err)
+		return
+	}
+
+	tmpDir, err := os.MkdirTemp("", "funding-statement-")
+	if err != nil {
+		InternalError(c, "funding statement: temp dir", err)
+		return
+	}
+	defer os.RemoveAll(tmpDir)
+
+	now := time.Now()
+	outputDocx := filepath.Join(tmpDir, "funding_statement.docx")
+	replacements := buildFundingStatementReplacements(buildApplicantName(&user), resolveApplicantPosition(&user), years, now)
+	if err := fillDocxTemplate(filepath.Join("templates", fundingStatementTemplate), outputDocx, replacements); err != nil {
+		InternalError(c, "funding statement: render docx", err)
+		return
+	}
+
+	pdfData, err := convertDocxToPDFBytes(outputDocx)
+	if err != nil {
+		InternalError(c, "funding statement: convert pdf", err)
+		return
+	}
+
+	filename := fmt.Sprintf("funding_statement_%s.pdf", now.In(utils.AppLocation()).Format("20060102"))
+	c.Header("Content-Disposition", utils.ContentDisposition("attachment", filename))
+	c.Data(http.StatusOK, "application/pdf", pdfData)
+}
@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGroupFundingStatement_SubtotalsPerYear(t *testing.T) {
+	years := groupFundingStatement([]fundingStatementItem{
+		{SubmissionNumber: "FA-2567-001", SubmissionType: "fund_application", Year: "2567", ApprovedAmount: 10000},
+		{SubmissionNumber: "PR-2567-002", SubmissionType: "publication_reward", Year: "2567", ApprovedAmount: 2500.5},
+		{SubmissionNumber: "FA-2568-001", SubmissionType: "fund_application", Year: "2568", ApprovedAmount: 4000},
+	})
+	if len(years) != 2 {
+		t.Fatalf("expected 2 years, got %d", len(years))
+	}
+	if years[0].Year != "2567" || len(years[0].Items) != 2 || years[0].Total != 12500.5 {
+		t.Fatalf("unexpected first year: %+v", years[0])
+	}
+	if years[1].Year != "2568" || len(years[1].Items) != 1 || years[1].Total != 4000 {
+		t.Fatalf("unexpected second year: %+v", years[1])
+	}
+}
+
+func TestBuildFundingStatementReplacements_Totals(t *testing.T) {
+	years := groupFundingStatement([]fundingStatementItem{
+		{SubmissionNumber: "FA-2567-001", SubmissionType: "fund_application", Year: "2567", Title: "Project", ApprovedAmount: 10000},
+		{SubmissionNumber: "PR-2568-001", SubmissionType: "publication_reward", Year: "2568", Title: "Paper", ApprovedAmount: 5000},
+	})
+	got := buildFundingStatementReplacements("Name", "Lecturer", years, time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC))
+
+	if got["{{submission_count}}"] != "2" {
+		t.Fatalf("expected 2 submissions, got %q", got["{{submission_count}}"])
+	}
+	if got["{{grand_total}}"] != formatAmount(15000) {
+		t.Fatalf("unexpected grand total %q", got["{{grand_total}}"])
+	}
+	lines := strings.Split(got["{{statement_lines}}"], "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected a heading and an item line per year, got %q", lines)
+	}
+	if !strings.Contains(lines[3], "PR-2568-001") || !strings.Contains(lines[3], "เงินรางวัลการตีพิมพ์") {
+		t.Fatalf("unexpected item line %q", lines[3])
+	}
+}
+
+func TestBuildFundingStatementReplacements_Empty(t *testing.T) {
+	got := buildFundingStatementReplacements("Name", "", nil, time.Now())
+	if got["{{submission_count}}"] != "0" || got["{{statement_lines}}"] == "" {
+		t.Fatalf("unexpected empty statement: %+v", got)
+	}
+}
@@ -3,9 +3,8 @@ package controllers
 import (
 	"fund-management-api/config"
 	"fund-management-api/models"
-	"fund-management-api/utils"
+	"fund-management-api/storage"
 	"net/http"
-	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -129,6 +128,8 @@ func CreateImportTemplateAdmin(c *gin.Context) {
 		return
 	}
 
+	store := storage.Default()
+	ctx := c.Request.Context()
 	file, header, err := c.Request.FormFile("file")
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "File is required"})
@@ -147,15 +148,14 @@ func CreateImportTemplateAdmin(c *gin.Context) {
 	}
 
 	uploadDir := "uploads/import_templates"
-	if err := os.MkdirAll(uploadDir, 0755); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create upload directory"})
+	safeName, err := uniqueStorageFilename(ctx, store, uploadDir, header.Filename)
+	if err != nil {
+		InternalError(c, "import template: unique name", err)
 		return
 	}
 
-	safeName := utils.GenerateUniqueFilename(uploadDir, header.Filename)
-	dstPath := filepath.Join(uploadDir, safeName)
-
-	if err := c.SaveUploadedFile(header, dstPath); err != nil {
+	dstPath, err := saveUploadedFileToStorage(ctx, store, header, filepath.Join(uploadDir, safeName), ct)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
 		return
 	}
@@ -179,7 +179,7 @@ func CreateImportTemplateAdmin(c *gin.Context) {
 	}
 
 	if err := config.DB.Create(&template).Error; err != nil {
-		_ = os.Remove(dstPath)
+		_ = store.Delete(ctx, dstPath)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create import template"})
 		return
 	}
@@ -248,6 +248,8 @@ func UpdateImportTemplateAdmin(c *gin.Context) {
 		}
 	}
 
+	store := storage.Default()
+	ctx := c.Request.Context()
 	file, header, err := c.Request.FormFile("file")
 	var newPath, oldPath string
 	if err == nil {
@@ -264,15 +266,14 @@ func UpdateImportTemplateAdmin(c *gin.Context) {
 		}
 
 		uploadDir := "uploads/import_templates"
-		if err := os.MkdirAll(uploadDir, 0755); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create upload directory"})
+		safeName, err := uniqueStorageFilename(ctx, store, uploadDir, header.Filename)
+		if err != nil {
+			InternalError(c, "import template: unique name", err)
 			return
 		}
 
-		safeName := utils.GenerateUniqueFilename(uploadDir, header.Filename)
-		dstPath := filepath.Join(uploadDir, safeName)
-
-		if err := c.SaveUploadedFile(header, dstPath); err != nil {
+		dstPath, err := saveUploadedFileToStorage(ctx, store, header, filepath.Join(uploadDir, safeName), ct)
+		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
 			return
 		}
@@ -311,13 +312,13 @@ func UpdateImportTemplateAdmin(c *gin.Context) {
 
 	if err := config.DB.Save(&template).Error; err != nil {
 		if newPath != "" {
-			_ = os.Remove(newPath)
+			_ = store.Delete(ctx, newPath)
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update import template"})
 		return
 	}
 	if oldPath != "" && newPath != "" && oldPath != newPath {
-		_ = os.Remove(oldPath)
+		_ = store.Delete(ctx, oldPath)
 	}
 
 	config.DB.Preload("Creator").Preload("Year").First(&template, template.TemplateID)
@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"fund-management-api/models"
+	"fund-management-api/utils"
+)
+
+// useAppTimezone pins APP_TIMEZONE for the test and returns the resolved zone.
+func useAppTimezone(t *testing.T, name string) *time.Location {
+	t.Helper()
+	t.Setenv("APP_TIMEZONE", name)
+	utils.ResetAppLocation()
+	t.Cleanup(utils.ResetAppLocation)
+	return utils.AppLocation()
+}
+
+func gracePeriods(graceDays int) []models.FundInstallmentPeriod {
+	return []models.FundInstallmentPeriod{
+		{InstallmentNumber: 1, CutoffDate: time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC), GraceDays: graceDays},
+		{InstallmentNumber: 2, CutoffDate: time.Date(2026, time.June, 30, 0, 0, 0, 0, time.UTC), GraceDays: graceDays},
+	}
+}
+
+func expectInstallment(t *testing.T, got *int, want int) {
+	t.Helper()
+	if got == nil {
+		t.Fatalf("expected installment %d, got nil", want)
+	}
+	if *got != want {
+		t.Fatalf("expected installment %d, got %d", want, *got)
+	}
+}
+
+// A submission on the cutoff day itself still belongs to that installment,
+// with or without grace.
+func TestSelectInstallmentNumber_ExactlyAtCutoff(t *testing.T) {
+	bangkok := useAppTimezone(t, utils.DefaultAppTimezone)
+	submitted := time.Date(2026, time.March, 31, 23, 59, 59, 0, bangkok)
+
+	expectInstallment(t, selectInstallmentNumber(gracePeriods(0), submitted), 1)
+	expectInstallment(t, selectInstallmentNumber(gracePeriods(3), submitted), 1)
+}
+
+// Without grace (the default), the day after cutoff rolls over to the next installment.
+func TestSelectInstallmentNumber_DefaultGraceIsZero(t *testing.T) {
+	bangkok := useAppTimezone(t, utils.DefaultAppTimezone)
+	submitted := time.Date(2026, time.April, 1, 0, 0, 1, 0, bangkok)
+
+	expectInstallment(t, selectInstallmentNumber(gracePeriods(0), submitted), 2)
+}
+
+func TestSelectInstallmentNumber_WithinGrace(t *testing.T) {
+	bangkok := useAppTimezone(t, utils.DefaultAppTimezone)
+	periods := gracePeriods(3)
+
+	expectInstallment(t, selectInstallmentNumber(periods, time.Date(2026, time.April, 1, 9, 0, 0, 0, bangkok)), 1)
+	expectInstallment(t, selectInstallmentNumber(periods, time.Date(2026, time.April, 3, 23, 59, 59, 0, bangkok)), 1)
+}
+
+func TestSelectInstallmentNumber_PastGrace(t *testing.T) {
+	bangkok := useAppTimezone(t, utils.DefaultAppTimezone)
+	periods := gracePeriods(3)
+
+	expectInstallment(t, selectInstallmentNumber(periods, time.Date(2026, time.April, 4, 0, 0, 0, 0, bangkok)), 2)
+	// Past the last installment's grace, the last installment is still used.
+	expectInstallment(t, selectInstallmentNumber(periods, time.Date(2026, time.July, 10, 0, 0, 0, 0, bangkok)), 2)
+}
+
+// The cutoff day ends at midnight in the application timezone, not UTC: 23:30
+// in Bangkok on the cutoff date (16:30 UTC) is on time, 00:30 the next morning
+// (still the cutoff date in UTC) is late.
+func TestSelectInstallmentNumber_CutoffDayEndsInAppTimezone(t *testing.T) {
+	bangkok := useAppTimezone(t, utils.DefaultAppTimezone)
+	periods := gracePeriods(0)
+
+	expectInstallment(t, selectInstallmentNumber(periods, time.Date(2026, time.March, 31, 23, 30, 0, 0, bangkok)), 1)
+	expectInstallment(t, selectInstallmentNumber(periods, time.Date(2026, time.April, 1, 0, 30, 0, 0, bangkok)), 2)
+}
+
+// The cutoff date keeps its calendar day however the DATE column was loaded.
+func TestSelectInstallmentNumber_CutoffDateReadInServerZone(t *testing.T) {
+	bangkok := useAppTimezone(t, utils.DefaultAppTimezone)
+	pacific := time.FixedZone("PDT", -7*60*60)
+	periods := []models.FundInstallmentPeriod{
+		{InstallmentNumber: 1, CutoffDate: time.Date(2026, time.March, 31, 0, 0, 0, 0, pacific)},
+		{InstallmentNumber: 2, CutoffDate: time.Date(2026, time.June, 30, 0, 0, 0, 0, pacific)},
+	}
+
+	expectInstallment(t, selectInstallmentNumber(periods, time.Date(2026, time.March, 31, 23, 30, 0, 0, bangkok)), 1)
+}
+
+func TestSelectInstallmentNumber_ConfiguredTimezone(t *testing.T) {
+	useAppTimezone(t, "UTC")
+	periods := gracePeriods(0)
+
+	// 23:30 UTC on the cutoff date is 06:30 the next day in Bangkok, but on time in UTC.
+	expectInstallment(t, selectInstallmentNumber(periods, time.Date(2026, time.March, 31, 23, 30, 0, 0, time.UTC)), 1)
+	expectInstallment(t, selectInstallmentNumber(periods, time.Date(2026, time.April, 1, 0, 30, 0, 0, time.UTC)), 2)
+}
+
+func TestAppLocation_InvalidTimezoneFallsBackToDefault(t *testing.T) {
+	loc := useAppTimezone(t, "Not/AZone")
+
+	_, offset := time.Date(2026, time.March, 31, 12, 0, 0, 0, loc).Zone()
+	if offset != 7*60*60 {
+		t.Fatalf("expected the UTC+7 default, got offset %d", offset)
+	}
+}
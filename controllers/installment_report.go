@@ -0,0 +1,176 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"fund-management-api/config"
+	"fund-management-api/models"
+	"fund-management-api/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+const xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+var installmentReportHeaders = []string{
+	"No.", "Submission No.", "Type", "Applicant", "Category", "Subcategory",
+	"Submitted At", "Requested Amount", "Approved Amount", "Status", "Announce Reference",
+}
+
+type installmentStatusTotal struct {
+	Name      string
+	Count     int
+	Requested float64
+	Approved  float64
+}
+
+// submissionAnnounceReference returns the announce reference number stored on
+// the submission's type-specific details.
+func submissionAnnounceReference(submission models.Submission) string {
+	if submission.FundApplicationDetail != nil {
+		return strings.TrimSpace(submission.FundApplicationDetail.AnnounceReferenceNumber)
+	}
+	if submission.PublicationRewardDetail != nil {
+		return strings.TrimSpace(submission.PublicationRewardDetail.AnnounceReferenceNumber)
+	}
+	return ""
+}
+
+// buildInstallmentReportSheet lays out the reconciliation sheet: one row per
+// submission followed by overall and per-status totals.
+func buildInstallmentReportSheet(yearLabel string, installment int, items []adminSubmissionListItem, submissions []models.Submission) xlsxSheet {
+	rows := [][]xlsxCell{
+		{{Value: fmt.Sprintf("Installment %d reconciliation, year %s", installment, yearLabel), Style: xlsxStyleBold}},
+		{},
+	}
+	header := make([]xlsxCell, 0, len(installmentReportHeaders))
+	for _, title := range installmentReportHeaders {
+		header = append(header, xlsxCell{Value: title, Style: xlsxStyleBold})
+	}
+	rows = append(rows, header)
+
+	var totalRequested, totalApproved float64
+	byStatus := map[int]*installmentStatusTotal{}
+	for i, item := range items {
+		submittedAt := ""
+		if item.SubmittedAt != nil {
+			submittedAt = item.SubmittedAt.In(utils.AppLocation()).Format("2006-01-02 15:04")
+		}
+		statusName := ""
+		if item.Status != nil {
+			statusName = item.Status.StatusName
+		}
+		rows = append(rows, []xlsxCell{
+			{Value: i + 1},
+			{Value: item.SubmissionNumber},
+			{Value: item.SubmissionType},
+			{Value: item.ApplicantName},
+			{Value: item.CategoryName},
+			{Value: item.SubcategoryName},
+			{Value: submittedAt},
+			{Value: roundAmount(item.RequestedAmount), Style: xlsxStyleMoney},
+			{Value: roundAmount(item.ApprovedAmount), Style: xlsxStyleMoney},
+			{Value: statusName},
+			{Value: submissionAnnounceReference(submissions[i])},
+		})
+
+		totalRequested += item.RequestedAmount
+		totalApproved += item.ApprovedAmount
+		total := byStatus[item.StatusID]
+		if total == nil {
+			total = &installmentStatusTotal{Name: statusName}
+			byStatus[item.StatusID] = total
+		}
+		total.Count++
+		total.Requested += item.RequestedAmount
+		total.Approved += item.ApprovedAmount
+	}
+
+	rows = append(rows,
+		[]xlsxCell{},
+		[]xlsxCell{
+			{Value: "Total", Style: xlsxStyleBold}, {Value: len(items), Style: xlsxStyleBold},
+			{}, {}, {}, {}, {},
+			{Value: roundAmount(totalRequested), Style: xlsxStyleBoldMoney},
+			{Value: roundAmount(totalApproved), Style: xlsxStyleBoldMoney},
+		},
+		[]xlsxCell{},
+		[]xlsxCell{
+			{Value: "Status", Style: xlsxStyleBold}, {Value: "Submissions", Style: xlsxStyleBold},
+			{}, {}, {}, {}, {},
+			{Value: "Requested Amount", Style: xlsxStyleBold}, {Value: "Approved Amount", Style: xlsxStyleBold},
+		},
+	)
+
+	statusIDs := make([]int, 0, len(byStatus))
+	for id := range byStatus {
+		statusIDs = append(statusIDs, id)
+	}
+	sort.Ints(statusIDs)
+	for _, id := range statusIDs {
+		total := byStatus[id]
+		rows = append(rows, []xlsxCell{
+			{Value: total.Name}, {Value: total.Count},
+			{}, {}, {}, {}, {},
+			{Value: roundAmount(total.Requested), Style: xlsxStyleMoney},
+			{Value: roundAmount(total.Approved), Style: xlsxStyleMoney},
+		})
+	}
+
+	return xlsxSheet{
+		Name:   fmt.Sprintf("Installment %d", installment),
+		Widths: []float64{6, 18, 18, 28, 30, 30, 17, 17, 17, 22, 20},
+		Rows:   rows,
+	}
+}
+
+// GetAdminInstallmentReport downloads the reconciliation workbook of one
+// installment: every submission from the by-installment listing with its
+// applicant, category, requested and approved amounts, status and announce
+// reference, plus totals.
+// GET /admin/installments/:year_id/:installment/report.xlsx
+func GetAdminInstallmentReport(c *gin.Context) {
+	yearID, err := strconv.Atoi(c.Param("year_id"))
+	if err != nil || yearID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid year_id"})
+		return
+	}
+	installment, err := strconv.Atoi(c.Param("installment"))
+	if err != nil || installment <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid installment"})
+		return
+	}
+
+	var submissions []models.Submission
+	if err := submissionsByInstallmentQuery(yearID, installment).
+		Preload("User").Preload("Year").Preload("Status").Preload("Category").Preload("Subcategory").
+		Order("submissions.submitted_at ASC, submissions.submission_id ASC").
+		Find(&submissions).Error; err != nil {
+		InternalError(c, "installment report", err)
+		return
+	}
+	if err := enrichAdminSubmissionListDetails(submissions); err != nil {
+		InternalError(c, "installment report: details", err)
+		return
+	}
+
+	yearLabel := strconv.Itoa(yearID)
+	var year models.Year
+	if err := config.DB.Where("year_id = ?", yearID).First(&year).Error; err == nil && strings.TrimSpace(year.Year) != "" {
+		yearLabel = strings.TrimSpace(year.Year)
+	}
+
+	content, err := writeXLSX(buildInstallmentReportSheet(yearLabel, installment, toAdminSubmissionListItems(submissions), submissions))
+	if err != nil {
+		InternalError(c, "installment report: write xlsx", err)
+		return
+	}
+
+	filename := fmt.Sprintf("installment_%s_%d_report.xlsx", yearLabel, installment)
+	c.Header("Content-Disposition", utils.ContentDisposition("attachment", filename))
+	c.Data(http.StatusOK, xlsxContentType, content)
+}
@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"fund-management-api/config"
+	"fund-management-api/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetJournalQuartile returns the known quartile of a journal looked up by ISSN
+// or name. year selects that metric year when the list has one.
+// GET /journals/quartile?issn=&name=&year=
+func GetJournalQuartile(c *gin.Context) {
+	issn := strings.TrimSpace(c.Query("issn"))
+	name := strings.TrimSpace(c.Query("name"))
+	if issn == "" && name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "issn or name is required"})
+		return
+	}
+
+	year := 0
+	if raw := strings.TrimSpace(c.Query("year")); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid year"})
+			return
+		}
+		year = parsed
+	}
+
+	match, err := services.NewJournalQuartileService(config.DB).Lookup(c.Request.Context(), issn, name, year)
+	if err != nil {
+		InternalError(c, "journal quartile lookup", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"found":   match != nil,
+		"data":    match,
+	})
+}
+
+// prefillJournalQuartile looks up the journal's quartile for a publication and
+// fills quartile when the applicant left it empty. A looked-up value never
+// overrides one that was entered; the caller reports the match as a suggestion.
+// Lookup failures are logged and ignored.
+func prefillJournalQuartile(ctx context.Context, journalName string, year int, quartile *string) (*services.JournalQuartileMatch, bool) {
+	if strings.TrimSpace(journalName) == "" {
+		return nil, false
+	}
+	match, err := services.NewJournalQuartileService(config.DB).Lookup(ctx, "", journalName, year)
+	if err != nil {
+		log.Printf("[journalQuartile] lookup for %q failed: %v", journalName, err)
+		return nil, false
+	}
+	if match == nil {
+		return nil, false
+	}
+	if strings.TrimSpace(*quartile) == "" {
+		*quartile = match.Quartile
+		return match, true
+	}
+	return match, false
+}
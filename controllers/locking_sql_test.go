@@ -0,0 +1,126 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// lockingSQLHandler answers the statements a test expects. locked reports
+// whether the calling connection holds the row lock, so handlers can reject
+// writes that skipped SELECT ... FOR UPDATE.
+type lockingSQLHandler struct {
+	query func(query string, args []driver.NamedValue, locked bool) (columns []string, row []driver.Value, err error)
+	exec  func(query string, args []driver.NamedValue, locked bool) (rowsAffected int64, err error)
+}
+
+// lockingSQLDriver is a fake database/sql driver with one InnoDB-style row
+// lock: a query ending in FOR UPDATE takes it until the transaction ends, so
+// concurrent transactions queue on it the way they would on a real row.
+type lockingSQLDriver struct {
+	rowLock sync.Mutex
+	handler lockingSQLHandler
+}
+
+func (d *lockingSQLDriver) Open(string) (driver.Conn, error) {
+	return &lockingSQLConn{driver: d}, nil
+}
+
+type lockingSQLConn struct {
+	driver  *lockingSQLDriver
+	holding bool
+}
+
+func (c *lockingSQLConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepare not supported")
+}
+func (c *lockingSQLConn) Close() error              { c.release(); return nil }
+func (c *lockingSQLConn) Begin() (driver.Tx, error) { return lockingSQLTx{conn: c}, nil }
+func (c *lockingSQLConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return lockingSQLTx{conn: c}, nil
+}
+
+func (c *lockingSQLConn) release() {
+	if c.holding {
+		c.holding = false
+		c.driver.rowLock.Unlock()
+	}
+}
+
+func (c *lockingSQLConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if c.driver.handler.query == nil {
+		return nil, fmt.Errorf("unexpected query: %s", query)
+	}
+	if strings.HasSuffix(strings.TrimSpace(query), "FOR UPDATE") && !c.holding {
+		c.driver.rowLock.Lock()
+		c.holding = true
+	}
+	columns, row, err := c.driver.handler.query(query, args, c.holding)
+	if err != nil {
+		return nil, err
+	}
+	return &lockingSQLRows{columns: columns, row: row}, nil
+}
+
+func (c *lockingSQLConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if c.driver.handler.exec == nil {
+		return nil, fmt.Errorf("unexpected exec: %s", query)
+	}
+	affected, err := c.driver.handler.exec(strings.TrimSpace(query), args, c.holding)
+	if err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(affected), nil
+}
+
+type lockingSQLTx struct{ conn *lockingSQLConn }
+
+func (tx lockingSQLTx) Commit() error   { tx.conn.release(); return nil }
+func (tx lockingSQLTx) Rollback() error { tx.conn.release(); return nil }
+
+// lockingSQLRows returns a single row, or none when row is nil.
+type lockingSQLRows struct {
+	columns []string
+	row     []driver.Value
+	done    bool
+}
+
+func (r *lockingSQLRows) Columns() []string { return r.columns }
+func (r *lockingSQLRows) Close() error      { return nil }
+func (r *lockingSQLRows) Next(dest []driver.Value) error {
+	if r.done || r.row == nil {
+		return io.EOF
+	}
+	copy(dest, r.row)
+	r.done = true
+	return nil
+}
+
+func newLockingGormDB(t *testing.T, maxOpenConns int, handler lockingSQLHandler) *gorm.DB {
+	t.Helper()
+
+	driverName := fmt.Sprintf("locking_sql_%d", time.Now().UnixNano())
+	sql.Register(driverName, &lockingSQLDriver{handler: handler})
+	sqlDB, err := sql.Open(driverName, "")
+	if err != nil {
+		t.Fatalf("failed to open sql db: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(maxOpenConns)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to create gorm db: %v", err)
+	}
+	return db
+}
@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"net/http"
+
+	"fund-management-api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetMaintenanceMode returns whether the API is currently in read-only maintenance mode.
+func GetMaintenanceMode(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success":     true,
+		"maintenance": middleware.GetMaintenanceState(),
+	})
+}
+
+// UpdateMaintenanceMode turns read-only maintenance mode on or off.
+func UpdateMaintenanceMode(c *gin.Context) {
+	var req struct {
+		Enabled *bool  `json:"enabled"`
+		Message string `json:"message"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || req.Enabled == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "enabled is required"})
+		return
+	}
+
+	var updatedBy *int
+	if uid, ok := c.Get("userID"); ok {
+		if id, ok := uid.(int); ok {
+			updatedBy = &id
+		}
+	}
+
+	state, err := middleware.SetMaintenanceState(*req.Enabled, req.Message, updatedBy)
+	if err != nil {
+		InternalError(c, "update maintenance mode", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":     true,
+		"maintenance": state,
+	})
+}
@@ -0,0 +1,216 @@
+package controllers
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"fund-management-api/models"
+)
+
+const (
+	mergeInputPDF   = "pdf"
+	mergeInputDocx  = "docx"
+	mergeInputImage = "image"
+)
+
+// mergeInput is a submission document ready to merge, with the path of its
+// PDF (the original file or its converted copy).
+type mergeInput struct {
+	Document models.SubmissionDocument
+	Path     string
+}
+
+// orderedMergePaths returns the PDF paths in the order the applicant arranged
+// the documents: display_order, then created_at, then document_id. The merged
+// packet is what reviewers print, so the order must not depend on how the
+// documents were loaded.
+func orderedMergePaths(inputs []mergeInput) []string {
+	sorted := append([]mergeInput(nil), inputs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].Document, sorted[j].Document
+		if a.DisplayOrder != b.DisplayOrder {
+			return a.DisplayOrder < b.DisplayOrder
+		}
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.DocumentID < b.DocumentID
+	})
+
+	paths := make([]string, 0, len(sorted))
+	for _, input := range sorted {
+		paths = append(paths, input.Path)
+	}
+	return paths
+}
+
+// mergeInputKind classifies a submission document for the merged PDF by its
+// MIME type, falling back to the stored and original extensions. It returns
+// "" for files that cannot be merged.
+func mergeInputKind(file models.FileUpload) string {
+	mimeType := strings.ToLower(strings.TrimSpace(file.MimeType))
+	exts := []string{
+		strings.ToLower(filepath.Ext(strings.TrimSpace(file.StoredPath))),
+		strings.ToLower(filepath.Ext(strings.TrimSpace(file.OriginalName))),
+	}
+	hasExt := func(candidates ...string) bool {
+		for _, ext := range exts {
+			for _, candidate := range candidates {
+				if ext == candidate {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	switch {
+	case mimeType == "application/pdf" || hasExt(".pdf"):
+		return mergeInputPDF
+	case mimeType == docxContentType || mimeType == msWordType || hasExt(".docx", ".doc"):
+		return mergeInputDocx
+	case strings.HasPrefix(mimeType, "image/") || hasExt(".jpg", ".jpeg", ".png", ".gif"):
+		return mergeInputImage
+	}
+	return ""
+}
+
+// generatedFormPdfTwins maps the generated application form DOCX codes to the
+// PDF attached beside them at submit time.
+var generatedFormPdfTwins = map[string]string{
+	publicationRewardFormDocumentCode: publicationRewardFormPdfDocumentCode,
+	fundApplicationFormDocumentCode:   fundApplicationFormPdfDocumentCode,
+}
+
+// redundantFormDocxIDs returns the ids of generated form DOCX documents whose
+// PDF twin is also attached, so the merged packet carries the form once. A
+// DOCX without its PDF (the conversion failed at submit) is still merged.
+func redundantFormDocxIDs(documents []models.SubmissionDocument) map[int]bool {
+	attached := make(map[string]bool, len(documents))
+	for _, doc := range documents {
+		attached[doc.DocumentType.Code] = true
+	}
+	redundant := make(map[int]bool)
+	for _, doc := range documents {
+		if twin, ok := generatedFormPdfTwins[doc.DocumentType.Code]; ok && attached[twin] {
+			redundant[doc.DocumentID] = true
+		}
+	}
+	return redundant
+}
+
+// prepareMergeInput returns a PDF path for the document at path, converting
+// DOCX through LibreOffice and images into a single page. Converted files are
+// written to workDir, which the caller removes after merging.
+func prepareMergeInput(kind, path, workDir string, index int) (string, error) {
+	switch kind {
+	case mergeInputPDF:
+		return path, nil
+	case mergeInputDocx:
+		data, err := convertDocxToPDFBytes(path)
+		if err != nil {
+			return "", err
+		}
+		output := filepath.Join(workDir, fmt.Sprintf("document-%03d.pdf", index))
+		if err := os.WriteFile(output, data, 0o600); err != nil {
+			return "", fmt.Errorf("failed to write converted pdf: %w", err)
+		}
+		return output, nil
+	case mergeInputImage:
+		output := filepath.Join(workDir, fmt.Sprintf("image-%03d.pdf", index))
+		if err := imageFileToPDF(path, output); err != nil {
+			return "", err
+		}
+		return output, nil
+	}
+	return "", fmt.Errorf("unsupported document type")
+}
+
+// imageFileToPDF writes the image as a single A4 page, scaled to fit inside
+// the margins and turned landscape for wide images. Pixels are embedded as
+// Flate-compressed RGB; transparency is flattened onto white.
+func imageFileToPDF(imagePath, outputPath string) error {
+	src, err := os.Open(imagePath)
+	if err != nil {
+		return err
+	}
+	img, _, err := image.Decode(src)
+	src.Close()
+	if err != nil {
+		return fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= 0 || height <= 0 {
+		return fmt.Errorf("image has no pixels")
+	}
+
+	var raw bytes.Buffer
+	zw := zlib.NewWriter(&raw)
+	row := make([]byte, 0, width*3)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		row = row[:0]
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			alpha := uint32(c.A)
+			blend := func(v uint8) byte {
+				return byte((uint32(v)*alpha + 255*(255-alpha)) / 255)
+			}
+			row = append(row, blend(c.R), blend(c.G), blend(c.B))
+		}
+		if _, err := zw.Write(row); err != nil {
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	pageW, pageH := 595.28, 841.89
+	if width > height {
+		pageW, pageH = pageH, pageW
+	}
+	const margin = 28.35 // 1 cm
+	scale := (pageW - 2*margin) / float64(width)
+	if s := (pageH - 2*margin) / float64(height); s < scale {
+		scale = s
+	}
+	drawW, drawH := float64(width)*scale, float64(height)*scale
+	offsetX, offsetY := (pageW-drawW)/2, (pageH-drawH)/2
+	content := fmt.Sprintf("q %.2f 0 0 %.2f %.2f %.2f cm /Im0 Do Q", drawW, drawH, offsetX, offsetY)
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /XObject << /Im0 4 0 R >> >> /Contents 5 0 R >>", pageW, pageH),
+		fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /FlateDecode /Length %d >>\nstream\n%s\nendstream", width, height, raw.Len(), raw.Bytes()),
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return os.WriteFile(outputPath, out.Bytes(), 0o600)
+}
@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"fund-management-api/models"
+)
+
+func TestImageFileToPDF_WritesSinglePageWithValidXref(t *testing.T) {
+	dir := t.TempDir()
+	img := image.NewNRGBA(image.Rect(0, 0, 40, 20))
+	for x := 0; x < 40; x++ {
+		img.Set(x, 10, color.NRGBA{R: 200, A: 255})
+	}
+	imagePath := filepath.Join(dir, "scan.png")
+	f, err := os.Create(imagePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	output := filepath.Join(dir, "scan.pdf")
+	if err := imageFileToPDF(imagePath, output); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4")) || !bytes.HasSuffix(data, []byte("%%EOF\n")) {
+		t.Fatal("expected a complete PDF file")
+	}
+	if !bytes.Contains(data, []byte("/Count 1")) || !bytes.Contains(data, []byte("/Width 40 /Height 20")) {
+		t.Fatal("expected one page embedding the 40x20 image")
+	}
+	// A wide image is placed on a landscape page.
+	if !bytes.Contains(data, []byte("/MediaBox [0 0 841.89 595.28]")) {
+		t.Fatal("expected a landscape A4 page")
+	}
+
+	match := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(data)
+	if match == nil {
+		t.Fatal("missing startxref")
+	}
+	xref, _ := strconv.Atoi(string(match[1]))
+	if !bytes.HasPrefix(data[xref:], []byte("xref\n0 6\n")) {
+		t.Fatalf("startxref does not point at the xref table")
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(data[xref:], -1)
+	if len(entries) != 5 {
+		t.Fatalf("expected 5 object offsets, got %d", len(entries))
+	}
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if want := fmt.Sprintf("%d 0 obj", i+1); !bytes.HasPrefix(data[offset:], []byte(want)) {
+			t.Fatalf("xref entry %d does not point at %q", i+1, want)
+		}
+	}
+}
+
+func TestMergeInputKind(t *testing.T) {
+	cases := []struct {
+		file models.FileUpload
+		want string
+	}{
+		{models.FileUpload{MimeType: "application/pdf", StoredPath: "a"}, mergeInputPDF},
+		{models.FileUpload{StoredPath: "form_PR-2568-0001.docx"}, mergeInputDocx},
+		{models.FileUpload{MimeType: "image/jpeg", StoredPath: "scan"}, mergeInputImage},
+		{models.FileUpload{OriginalName: "scan.PNG", StoredPath: "x"}, mergeInputImage},
+		{models.FileUpload{MimeType: xlsxContentType, StoredPath: "sheet.xlsx"}, ""},
+	}
+	for _, tc := range cases {
+		if got := mergeInputKind(tc.file); got != tc.want {
+			t.Fatalf("%+v: expected %q, got %q", tc.file, tc.want, got)
+		}
+	}
+}
+
+func TestOrderedMergePaths_FollowsDisplayOrder(t *testing.T) {
+	base := time.Date(2025, 10, 1, 9, 0, 0, 0, time.UTC)
+	// Inserted (and numbered) in a different order than the applicant arranged.
+	inputs := []mergeInput{
+		{Document: models.SubmissionDocument{DocumentID: 11, DisplayOrder: 3, CreatedAt: base}, Path: "c.pdf"},
+		{Document: models.SubmissionDocument{DocumentID: 12, DisplayOrder: 1, CreatedAt: base.Add(time.Minute)}, Path: "a.pdf"},
+		{Document: models.SubmissionDocument{DocumentID: 13, DisplayOrder: 2, CreatedAt: base.Add(2 * time.Minute)}, Path: "b.pdf"},
+	}
+
+	got := orderedMergePaths(inputs)
+	want := []string{"a.pdf", "b.pdf", "c.pdf"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if inputs[0].Path != "c.pdf" {
+		t.Fatalf("expected inputs to be left unsorted")
+	}
+}
+
+func TestOrderedMergePaths_BreaksTiesByCreatedAt(t *testing.T) {
+	base := time.Date(2025, 10, 1, 9, 0, 0, 0, time.UTC)
+	inputs := []mergeInput{
+		{Document: models.SubmissionDocument{DocumentID: 5, DisplayOrder: 1, CreatedAt: base.Add(time.Hour)}, Path: "later.pdf"},
+		{Document: models.SubmissionDocument{DocumentID: 9, DisplayOrder: 1, CreatedAt: base}, Path: "earlier.pdf"},
+		{Document: models.SubmissionDocument{DocumentID: 2, DisplayOrder: 0, CreatedAt: base.Add(2 * time.Hour)}, Path: "first.pdf"},
+	}
+
+	got := orderedMergePaths(inputs)
+	want := []string{"first.pdf", "earlier.pdf", "later.pdf"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestRedundantFormDocxIDs_SkipsDocxWithPdfTwin(t *testing.T) {
+	doc := func(id int, code string) models.SubmissionDocument {
+		return models.SubmissionDocument{DocumentID: id, DocumentType: models.DocumentType{Code: code}}
+	}
+
+	got := redundantFormDocxIDs([]models.SubmissionDocument{
+		doc(1, publicationRewardFormDocumentCode),
+		doc(2, publicationRewardFormPdfDocumentCode),
+		doc(3, "paper"),
+		doc(4, fundApplicationFormDocumentCode),
+	})
+	if !got[1] {
+		t.Fatalf("expected the publication reward form docx to be skipped, got %v", got)
+	}
+	if got[2] || got[3] {
+		t.Fatalf("expected the pdf form and other documents to be merged, got %v", got)
+	}
+	if got[4] {
+		t.Fatalf("expected a form docx without its pdf twin to be merged, got %v", got)
+	}
+}
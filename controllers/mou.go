@@ -5,13 +5,15 @@ import (
 	"bytes"
 	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"fund-management-api/config"
 	"fund-management-api/models"
+	"fund-management-api/storage"
+	"fund-management-api/utils"
 	"io"
 	"log"
 	"net/http"
-	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -22,6 +24,13 @@ import (
 	"gorm.io/gorm"
 )
 
+// MouUploadMaxFileMB and MouUploadMaxFiles bound the attachments of one MOU or
+// activity create request; the routes size the request body limit from them.
+const (
+	MouUploadMaxFileMB = 50
+	MouUploadMaxFiles  = 10
+)
+
 // mouMu protects updateMouStatusesBasedOnDate and SendPendingMouNotifications from concurrent execution
 var mouMu sync.Mutex
 
@@ -298,9 +307,10 @@ func DownloadMouAttachments(c *gin.Context) {
 	buf := new(bytes.Buffer)
 	zw := zip.NewWriter(buf)
 
+	store := storage.Default()
 	var errs []string
 	for _, att := range attachments {
-		f, err := os.Open(att.FilePath)
+		f, err := store.Open(c.Request.Context(), att.FilePath)
 		if err != nil {
 			errs = append(errs, fmt.Sprintf("%s: %v", att.FileName, err))
 			continue
@@ -330,7 +340,7 @@ func DownloadMouAttachments(c *gin.Context) {
 	}
 
 	zipName := fmt.Sprintf("mou_%d_attachments.zip", mou.ID)
-	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", zipName))
+	c.Header("Content-Disposition", utils.ContentDisposition("attachment", zipName))
 	c.Header("Content-Type", "application/zip")
 	c.Data(http.StatusOK, "application/zip", buf.Bytes())
 }
@@ -351,18 +361,24 @@ func GetMouAttachment(c *gin.Context) {
 		return
 	}
 
-	if _, err := os.Stat(att.FilePath); os.IsNotExist(err) {
-		c.JSON(http.StatusNotFound, gin.H{"error": "File not found on server"})
+	store := storage.Default()
+	info, err := store.Stat(c.Request.Context(), att.FilePath)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotExist) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "File not found on server"})
+			return
+		}
+		InternalError(c, "mou attachment: stat", err)
 		return
 	}
 
+	disposition := utils.ContentDisposition("inline", att.FileName)
 	if download {
-		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", att.FileName))
-	} else {
-		c.Header("Content-Disposition", fmt.Sprintf("inline; filename=\"%s\"", att.FileName))
+		disposition = utils.ContentDisposition("attachment", att.FileName)
+	}
+	if err := sendStoredFile(c, store, att.FilePath, info, att.MimeType, disposition); err != nil {
+		InternalError(c, "mou attachment: send", err)
 	}
-	c.Header("Content-Type", att.MimeType)
-	c.File(att.FilePath)
 }
 
 // CreateMou creates a new MOU record
@@ -631,10 +647,13 @@ func CreateMou(c *gin.Context) {
 		if err == nil {
 			uploadFiles := form.File["files"]
 			var fileErrors []string
+			if len(uploadFiles) > MouUploadMaxFiles {
+				fileErrors = append(fileErrors, fmt.Sprintf("at most %d files can be uploaded at once", MouUploadMaxFiles))
+			}
 			for _, f := range uploadFiles {
-				// Validate file size (max 50MB)
-				if f.Size > 50*1024*1024 {
-					fileErrors = append(fileErrors, fmt.Sprintf("%s: exceeds 50MB limit", f.Filename))
+				// Validate file size (max MouUploadMaxFileMB)
+				if f.Size > MouUploadMaxFileMB*1024*1024 {
+					fileErrors = append(fileErrors, fmt.Sprintf("%s: exceeds %dMB limit", f.Filename, MouUploadMaxFileMB))
 					continue
 				}
 				// Validate file extension
@@ -646,13 +665,11 @@ func CreateMou(c *gin.Context) {
 				}
 			}
 			uploadDir := filepath.Join("uploads", "mou")
-			if err := os.MkdirAll(uploadDir, 0755); err != nil {
-				fileErrors = append(fileErrors, fmt.Sprintf("upload directory: %v", err))
-			}
+			store := storage.Default()
 			if len(fileErrors) == 0 {
 				for _, f := range uploadFiles {
-					savePath := filepath.Join(uploadDir, fmt.Sprintf("%d_%s", mou.ID, f.Filename))
-					if err := c.SaveUploadedFile(f, savePath); err != nil {
+					savePath, err := saveUploadedFileToStorage(c.Request.Context(), store, f, filepath.Join(uploadDir, fmt.Sprintf("%d_%s", mou.ID, f.Filename)), f.Header.Get("Content-Type"))
+					if err != nil {
 						fileErrors = append(fileErrors, fmt.Sprintf("%s: %v", f.Filename, err))
 					} else {
 						attachment := models.MouAttachment{
@@ -1627,9 +1644,12 @@ func CreateMouActivity(c *gin.Context) {
 		if err == nil {
 			uploadFiles := form.File["files"]
 			var fileErrors []string
+			if len(uploadFiles) > MouUploadMaxFiles {
+				fileErrors = append(fileErrors, fmt.Sprintf("at most %d files can be uploaded at once", MouUploadMaxFiles))
+			}
 			for _, f := range uploadFiles {
-				if f.Size > 50*1024*1024 {
-					fileErrors = append(fileErrors, fmt.Sprintf("%s: exceeds 50MB limit", f.Filename))
+				if f.Size > MouUploadMaxFileMB*1024*1024 {
+					fileErrors = append(fileErrors, fmt.Sprintf("%s: exceeds %dMB limit", f.Filename, MouUploadMaxFileMB))
 					continue
 				}
 				ext := strings.ToLower(filepath.Ext(f.Filename))
@@ -1640,13 +1660,11 @@ func CreateMouActivity(c *gin.Context) {
 				}
 			}
 			uploadDir := filepath.Join("uploads", "activity")
-			if err := os.MkdirAll(uploadDir, 0755); err != nil {
-				fileErrors = append(fileErrors, fmt.Sprintf("upload directory: %v", err))
-			}
+			store := storage.Default()
 			if len(fileErrors) == 0 {
 				for _, f := range uploadFiles {
-					savePath := filepath.Join(uploadDir, fmt.Sprintf("%d_%s", activity.ID, f.Filename))
-					if err := c.SaveUploadedFile(f, savePath); err != nil {
+					savePath, err := saveUploadedFileToStorage(c.Request.Context(), store, f, filepath.Join(uploadDir, fmt.Sprintf("%d_%s", activity.ID, f.Filename)), f.Header.Get("Content-Type"))
+					if err != nil {
 						fileErrors = append(fileErrors, fmt.Sprintf("%s: %v", f.Filename, err))
 					} else {
 						attachment := models.MouActivityAttachment{
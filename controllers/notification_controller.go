@@ -19,6 +19,7 @@ import (
 
 	"fund-management-api/config"
 	"fund-management-api/models"
+	"fund-management-api/utils"
 )
 
 /* ==========================
@@ -410,8 +411,7 @@ func formatSubmittedAt(sub submissionLite) string {
 		return "-"
 	}
 
-	loc, _ := time.LoadLocation("Asia/Bangkok")
-	t := sub.SubmittedAt.In(loc)
+	t := sub.SubmittedAt.In(utils.AppLocation())
 	return t.Format("02/01/2006 15:04")
 }
 
@@ -0,0 +1,204 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"fund-management-api/models"
+	"fund-management-api/utils"
+)
+
+const (
+	defaultDraftWatermarkText      = "DRAFT"
+	defaultUnapprovedWatermarkText = "NOT APPROVED"
+)
+
+// watermarkTextSanitizer drops characters the watermark fonts cannot draw.
+// Latin text uses Helvetica; Thai text uses watermarkFontPath.
+var watermarkTextSanitizer = regexp.MustCompile(`[^\p{Thai}A-Za-z0-9 .\-]`)
+
+// watermarkFontPath is the TrueType face embedded for non-Latin watermarks.
+var watermarkFontPath = filepath.Join("templates", "fonts", "THSarabunNew", "THSarabunNew Bold.ttf")
+
+// isLatinWatermarkText reports whether text can be drawn with a standard PDF
+// font.
+func isLatinWatermarkText(text string) bool {
+	for _, r := range text {
+		if r < 0x20 || r > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// pdfWatermarkEnabled reports whether generated and merged PDFs of unapproved
+// submissions should be stamped (PDF_WATERMARK_ENABLED).
+func pdfWatermarkEnabled() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("PDF_WATERMARK_ENABLED"))) {
+	case "1", "true", "yes", "on":
+		return true
+	}
+	return false
+}
+
+func watermarkTextFromEnv(key, fallback string) string {
+	raw := strings.TrimSpace(os.Getenv(key))
+	text := strings.TrimSpace(watermarkTextSanitizer.ReplaceAllString(raw, ""))
+	if text != raw {
+		log.Printf("[pdfWatermark] %s contains characters that cannot be drawn; using %q", key, text)
+	}
+	if text == "" {
+		return fallback
+	}
+	return text
+}
+
+// submissionWatermarkText picks the watermark for a submission's documents:
+// none once it is approved (or closed after approval), DRAFT for drafts and
+// NOT APPROVED for everything still under review or rejected.
+func submissionWatermarkText(submission *models.Submission) string {
+	if !pdfWatermarkEnabled() || submission == nil {
+		return ""
+	}
+
+	approved, err := utils.StatusMatchesCodes(submission.StatusID, utils.StatusCodeApproved, utils.StatusCodeAdminClosed)
+	if err != nil {
+		log.Printf("[pdfWatermark] failed to resolve status %d for submission %d: %v", submission.StatusID, submission.SubmissionID, err)
+	}
+	if approved {
+		return ""
+	}
+
+	if draft, _ := utils.StatusMatchesCodes(submission.StatusID, utils.StatusCodeDraft); draft || submission.SubmittedAt == nil {
+		return draftWatermarkText()
+	}
+	return watermarkTextFromEnv("PDF_WATERMARK_UNAPPROVED_TEXT", defaultUnapprovedWatermarkText)
+}
+
+func draftWatermarkText() string {
+	if !pdfWatermarkEnabled() {
+		return ""
+	}
+	return watermarkTextFromEnv("PDF_WATERMARK_DRAFT_TEXT", defaultDraftWatermarkText)
+}
+
+// watermarkPDFBytes stamps text across every page. Watermarking is best effort:
+// when no tool is available the original document is returned and logged.
+func watermarkPDFBytes(data []byte, text string) []byte {
+	if text == "" || len(data) == 0 {
+		return data
+	}
+
+	tmpDir, err := os.MkdirTemp("", "pdf-watermark-")
+	if err != nil {
+		log.Printf("[pdfWatermark] failed to create temp directory: %v", err)
+		return data
+	}
+	defer os.RemoveAll(tmpDir)
+
+	path := filepath.Join(tmpDir, "document.pdf")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		log.Printf("[pdfWatermark] failed to write temp pdf: %v", err)
+		return data
+	}
+	if err := watermarkPDFFile(path, text); err != nil {
+		log.Printf("[pdfWatermark] %v", err)
+		return data
+	}
+
+	stamped, err := os.ReadFile(path)
+	if err != nil {
+		log.Printf("[pdfWatermark] failed to read watermarked pdf: %v", err)
+		return data
+	}
+	return stamped
+}
+
+// watermarkPDFFile stamps the PDF at path in place, trying pdf-lib first and
+// ghostscript second, mirroring mergePDFs.
+func watermarkPDFFile(path, text string) error {
+	if text == "" {
+		return nil
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve pdf path: %w", err)
+	}
+	stampedPath := absPath + ".watermarked"
+	defer os.Remove(stampedPath)
+
+	var attempts []string
+
+	scriptArgs := []string{stampedPath, absPath, text}
+	if !isLatinWatermarkText(text) {
+		fontPath, err := filepath.Abs(watermarkFontPath)
+		if err != nil {
+			return fmt.Errorf("failed to resolve watermark font path: %w", err)
+		}
+		scriptArgs = append(scriptArgs, fontPath)
+	}
+
+	if nodeBinary, err := resolveNodeBinary(); err == nil {
+		if err := runPdfLibScript(nodeBinary, "watermark_pdf.js", scriptArgs...); err == nil {
+			return os.Rename(stampedPath, absPath)
+		} else {
+			attempts = append(attempts, fmt.Sprintf("node (%v)", err))
+		}
+	} else {
+		attempts = append(attempts, fmt.Sprintf("node (%v)", err))
+	}
+
+	if gsBinary, err := exec.LookPath("gs"); err == nil {
+		if err := watermarkPDFWithGhostscript(gsBinary, absPath, stampedPath, text); err == nil {
+			return os.Rename(stampedPath, absPath)
+		} else {
+			attempts = append(attempts, fmt.Sprintf("gs (%v)", err))
+		}
+	} else {
+		attempts = append(attempts, fmt.Sprintf("gs (%v)", err))
+	}
+
+	return fmt.Errorf("failed to watermark pdf: %s", strings.Join(attempts, "; "))
+}
+
+func watermarkPDFWithGhostscript(gsBinary, inputPath, outputPath, text string) error {
+	// The PostScript program below only has Helvetica; Thai text would come out
+	// as missing glyphs, so leave it to pdf-lib.
+	if !isLatinWatermarkText(text) {
+		return fmt.Errorf("non-Latin watermark text needs pdf-lib")
+	}
+	// EndPage runs before each page is emitted; reason 2 is device deactivation.
+	endPage := fmt.Sprintf("<< /EndPage { exch pop 2 ne { gsave "+
+		"currentpagedevice /PageSize get aload pop 2 div exch 2 div exch translate 45 rotate "+
+		"/Helvetica-Bold findfont 72 scalefont setfont 0.85 setgray "+
+		"(%s) dup stringwidth pop -2 div -24 moveto show grestore true } { false } ifelse } >> setpagedevice",
+		watermarkTextSanitizer.ReplaceAllString(text, ""))
+
+	args := []string{"-q", "-dNOPAUSE", "-dBATCH", "-sDEVICE=pdfwrite", fmt.Sprintf("-sOutputFile=%s", outputPath), "-c", endPage, "-f", inputPath}
+	cmd := exec.Command(gsBinary, args...)
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = strings.TrimSpace(stdout.String())
+		}
+		if msg == "" {
+			msg = err.Error()
+		}
+		return fmt.Errorf("%s", msg)
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package controllers
+
+import "testing"
+
+func TestWatermarkTextFromEnv_KeepsThaiText(t *testing.T) {
+	t.Setenv("PDF_WATERMARK_DRAFT_TEXT", "ร่าง DRAFT")
+	if got := watermarkTextFromEnv("PDF_WATERMARK_DRAFT_TEXT", defaultDraftWatermarkText); got != "ร่าง DRAFT" {
+		t.Fatalf("expected Thai text to be kept, got %q", got)
+	}
+
+	t.Setenv("PDF_WATERMARK_DRAFT_TEXT", "<>")
+	if got := watermarkTextFromEnv("PDF_WATERMARK_DRAFT_TEXT", defaultDraftWatermarkText); got != defaultDraftWatermarkText {
+		t.Fatalf("expected fallback for undrawable text, got %q", got)
+	}
+}
+
+func TestIsLatinWatermarkText(t *testing.T) {
+	if !isLatinWatermarkText("NOT APPROVED") {
+		t.Fatal("expected Latin text to use the standard font")
+	}
+	if isLatinWatermarkText("ยังไม่อนุมัติ") {
+		t.Fatal("expected Thai text to need the embedded font")
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"fund-management-api/config"
 	"fund-management-api/models"
+	"fund-management-api/services"
+	"fund-management-api/storage"
 	"fund-management-api/utils"
 	"net/http"
 	"os"
@@ -407,6 +409,8 @@ func CreatePublicationReward(c *gin.Context) {
 
 	// Process uploaded files
 	var uploadedFiles []models.PublicationDocument
+	store := storage.Default()
+	ctx := c.Request.Context()
 
 	for fieldName, files := range c.Request.MultipartForm.File {
 		if strings.HasPrefix(fieldName, "doc_") {
@@ -427,11 +431,16 @@ func CreatePublicationReward(c *gin.Context) {
 				finalFilename := fmt.Sprintf("pub_%s_%s", documentType, safeOriginalName)
 
 				// Generate unique filename
-				uniqueFilename := utils.GenerateUniqueFilename(submissionFolderPath, finalFilename)
-				dst := filepath.Join(submissionFolderPath, uniqueFilename)
+				uniqueFilename, err := uniqueStorageFilename(ctx, store, submissionFolderPath, finalFilename)
+				if err != nil {
+					tx.Rollback()
+					InternalError(c, "publication reward: unique name", err)
+					return
+				}
 
 				// Save file
-				if err := c.SaveUploadedFile(fileHeader, dst); err != nil {
+				dst, err := saveUploadedFileToStorage(ctx, store, fileHeader, filepath.Join(submissionFolderPath, uniqueFilename), fileHeader.Header.Get("Content-Type"))
+				if err != nil {
 					tx.Rollback()
 					c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
 					return
@@ -454,7 +463,7 @@ func CreatePublicationReward(c *gin.Context) {
 
 				if err := createFileUploadRecord(tx, &fileUpload); err != nil {
 					tx.Rollback()
-					os.Remove(dst)
+					_ = store.Delete(ctx, dst)
 					c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file info"})
 					return
 				}
@@ -474,7 +483,7 @@ func CreatePublicationReward(c *gin.Context) {
 
 				if err := tx.Create(&doc).Error; err != nil {
 					tx.Rollback()
-					os.Remove(dst)
+					_ = store.Delete(ctx, dst)
 					c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save document record"})
 					return
 				}
@@ -816,6 +825,8 @@ func UploadPublicationDocument(c *gin.Context) {
 
 	var uploadedFiles []models.PublicationDocument
 	now := time.Now()
+	store := storage.Default()
+	ctx := c.Request.Context()
 
 	// Process all files
 	for fieldName, files := range form.File {
@@ -838,11 +849,15 @@ func UploadPublicationDocument(c *gin.Context) {
 			finalFilename := fmt.Sprintf("pub_%s_%s", documentType, safeOriginalName)
 
 			// Generate unique filename in submission folder
-			uniqueFilename := utils.GenerateUniqueFilename(submissionFolderPath, finalFilename)
-			dst := filepath.Join(submissionFolderPath, uniqueFilename)
+			uniqueFilename, err := uniqueStorageFilename(ctx, store, submissionFolderPath, finalFilename)
+			if err != nil {
+				InternalError(c, "publication document: unique name", err)
+				return
+			}
 
 			// Save file
-			if err := c.SaveUploadedFile(fileHeader, dst); err != nil {
+			dst, err := saveUploadedFileToStorage(ctx, store, fileHeader, filepath.Join(submissionFolderPath, uniqueFilename), fileHeader.Header.Get("Content-Type"))
+			if err != nil {
 				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
 				return
 			}
@@ -863,7 +878,7 @@ func UploadPublicationDocument(c *gin.Context) {
 
 			if err := createFileUploadRecord(config.DB, &fileUpload); err != nil {
 				// Delete uploaded file if database save fails
-				os.Remove(dst)
+				_ = store.Delete(ctx, dst)
 				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file info"})
 				return
 			}
@@ -885,7 +900,7 @@ func UploadPublicationDocument(c *gin.Context) {
 
 			if err := config.DB.Create(&doc).Error; err != nil {
 				// Delete uploaded file and FileUpload record if document creation fails
-				os.Remove(dst)
+				_ = store.Delete(ctx, dst)
 				config.DB.Delete(&fileUpload)
 				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save document record"})
 				return
@@ -971,13 +986,21 @@ func RejectPublicationReward(c *gin.Context) {
 	})
 }
 
-// UpdatePublicationRewardRates updates reward rates configuration (admin only)
+// maxRewardRateBatch bounds one UpdatePublicationRewardRates request.
+const maxRewardRateBatch = 500
+
+// UpdatePublicationRewardRates updates reward rates configuration (admin only),
+// at most maxRewardRateBatch rates per request.
 func UpdatePublicationRewardRates(c *gin.Context) {
 	var rates []models.PublicationRewardRate
 	if err := c.ShouldBindJSON(&rates); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if len(rates) > maxRewardRateBatch {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("At most %d rates can be updated per request", maxRewardRateBatch)})
+		return
+	}
 
 	// Update rates in transaction
 	tx := config.DB.Begin()
@@ -1000,16 +1023,39 @@ func UpdatePublicationRewardRates(c *gin.Context) {
 	})
 }
 
-// GetPublicationRewardRateLookup returns specific reward amount for calculation
+// GetPublicationRewardRateLookup returns specific reward amount for calculation.
+// Without quartile, issn or journal_name resolves it from the journal list.
 func GetPublicationRewardRateLookup(c *gin.Context) {
 	year := c.Query("year")
 	authorStatus := c.Query("author_status")
 	quartile := c.Query("quartile")
 
+	var journalMatch *services.JournalQuartileMatch
+	if quartile == "" && year != "" {
+		issn := strings.TrimSpace(c.Query("issn"))
+		journalName := strings.TrimSpace(c.Query("journal_name"))
+		if issn != "" || journalName != "" {
+			metricYear, _ := strconv.Atoi(year)
+			match, err := services.NewJournalQuartileService(config.DB).Lookup(c.Request.Context(), issn, journalName, metricYear)
+			if err != nil {
+				InternalError(c, "reward rate lookup: journal quartile", err)
+				return
+			}
+			if match == nil {
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": "Journal quartile not found; pass quartile explicitly",
+				})
+				return
+			}
+			journalMatch = match
+			quartile = match.Quartile
+		}
+	}
+
 	// Validate required parameters
 	if year == "" || authorStatus == "" || quartile == "" {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Missing required parameters: year, author_status, quartile",
+			"error": "Missing required parameters: year, author_status, quartile (or issn/journal_name)",
 		})
 		return
 	}
@@ -1029,6 +1075,7 @@ func GetPublicationRewardRateLookup(c *gin.Context) {
 		"year":          rate.Year,
 		"author_status": rate.AuthorStatus,
 		"quartile":      rate.JournalQuartile,
+		"journal":       journalMatch,
 	})
 }
 
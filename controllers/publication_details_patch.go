@@ -0,0 +1,257 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"fund-management-api/config"
+	"fund-management-api/models"
+	"fund-management-api/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// publicationDetailsPatchRequest mirrors AddPublicationDetails' payload with
+// pointer fields so omitted keys leave the stored value untouched. Approval
+// amounts and the announcement snapshot are not patchable here.
+type publicationDetailsPatchRequest struct {
+	PaperTitle      *string  `json:"article_title"`
+	JournalName     *string  `json:"journal_name"`
+	PublicationDate *string  `json:"publication_date"` // "YYYY-MM-DD"
+	PublicationType *string  `json:"publication_type"`
+	Quartile        *string  `json:"journal_quartile"`
+	ImpactFactor    *float64 `json:"impact_factor"`
+	DOI             *string  `json:"doi"`
+	URL             *string  `json:"url"`
+	PageNumbers     *string  `json:"page_numbers"`
+	VolumeIssue     *string  `json:"volume_issue"`
+	Indexing        *string  `json:"indexing"`
+
+	RewardAmount   *float64 `json:"publication_reward"`
+	RevisionFee    *float64 `json:"revision_fee"`
+	PublicationFee *float64 `json:"publication_fee"`
+	TotalAmount    *float64 `json:"total_amount"`
+
+	AuthorCount    *int    `json:"author_count"`
+	AuthorType     *string `json:"author_status"`
+	AuthorNameList *string `json:"author_name_list"`
+	Signature      *string `json:"signature"`
+
+	AnnounceReferenceNumber *string `json:"announce_reference_number"`
+	HasUniversityFunding    *string `json:"has_university_funding"`
+	FundingReferences       *string `json:"funding_references"`
+	UniversityRankings      *string `json:"university_rankings"`
+
+	ExternalFundings *[]publicationExternalFundingInput `json:"external_fundings"`
+}
+
+// PatchPublicationDetails updates only the publication detail fields present in
+// the body. Validation matches AddPublicationDetails (including ?mode=draft) and
+// is applied to the merged record; external_funding_amount is recomputed when
+// external_fundings is sent, and total_amount is always recomputed.
+func PatchPublicationDetails(c *gin.Context) {
+	submissionID := c.Param("id")
+	userID, _ := c.Get("userID")
+
+	var req publicationDetailsPatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	deref := func(value *string) string {
+		if value == nil {
+			return ""
+		}
+		return strings.TrimSpace(*value)
+	}
+	if rejectOversizedText(c, "publication_reward_details",
+		utils.TextField{Column: "paper_title", Field: "article_title", Value: deref(req.PaperTitle)},
+		utils.TextField{Column: "journal_name", Value: deref(req.JournalName)},
+		utils.TextField{Column: "author_name_list", Value: deref(req.AuthorNameList)},
+		utils.TextField{Column: "signature", Value: deref(req.Signature)},
+	) {
+		return
+	}
+
+	modeParam := strings.ToLower(strings.TrimSpace(c.Query("mode")))
+	allowIncomplete := modeParam == "draft"
+	if !allowIncomplete {
+		switch strings.ToLower(strings.TrimSpace(c.Query("allow_incomplete"))) {
+		case "1", "true", "yes", "on":
+			allowIncomplete = true
+		}
+	}
+
+	var submission models.Submission
+	if err := config.DB.Where("submission_id = ? AND user_id = ?", submissionID, userID).
+		First(&submission).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Submission not found"})
+		return
+	}
+
+	var detail models.PublicationRewardDetail
+	if err := config.DB.Where("submission_id = ?", submission.SubmissionID).First(&detail).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Publication details not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch publication details"})
+		return
+	}
+
+	if req.PublicationDate != nil {
+		raw := strings.TrimSpace(*req.PublicationDate)
+		if raw != "" {
+			parsedDate, err := time.Parse("2006-01-02", raw)
+			if err != nil {
+				if !allowIncomplete {
+					c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid publication date format"})
+					return
+				}
+			} else {
+				detail.PublicationDate = parsedDate
+			}
+		}
+	}
+
+	setString := func(dst *string, value *string) {
+		if value != nil {
+			*dst = *value
+		}
+	}
+	setTrimmed := func(dst *string, value *string) {
+		if value != nil {
+			*dst = strings.TrimSpace(*value)
+		}
+	}
+	setFloat := func(dst *float64, value *float64) {
+		if value != nil {
+			*dst = *value
+		}
+	}
+	setNullable := func(dst **string, value *string) {
+		if value == nil {
+			return
+		}
+		trimmed := strings.TrimSpace(*value)
+		if trimmed == "" {
+			*dst = nil
+			return
+		}
+		*dst = &trimmed
+	}
+
+	setString(&detail.PaperTitle, req.PaperTitle)
+	setString(&detail.JournalName, req.JournalName)
+	setString(&detail.PublicationType, req.PublicationType)
+	setString(&detail.Quartile, req.Quartile)
+	setFloat(&detail.ImpactFactor, req.ImpactFactor)
+	if req.DOI != nil {
+		detail.DOI = utils.NormalizeDOI(*req.DOI)
+	}
+	setString(&detail.URL, req.URL)
+	setString(&detail.PageNumbers, req.PageNumbers)
+	setString(&detail.VolumeIssue, req.VolumeIssue)
+	setString(&detail.Indexing, req.Indexing)
+
+	setFloat(&detail.RewardAmount, req.RewardAmount)
+	setFloat(&detail.RevisionFee, req.RevisionFee)
+	setFloat(&detail.PublicationFee, req.PublicationFee)
+	if req.ExternalFundings != nil {
+		detail.ExternalFundingAmount = sumExternalFundingInputs(*req.ExternalFundings)
+	}
+	totalAdjusted := reconcilePublicationRewardTotal(&detail, req.TotalAmount)
+
+	if req.AuthorCount != nil {
+		detail.AuthorCount = *req.AuthorCount
+	}
+	setTrimmed(&detail.AuthorType, req.AuthorType)
+	setTrimmed(&detail.AuthorNameList, req.AuthorNameList)
+	setTrimmed(&detail.Signature, req.Signature)
+
+	setTrimmed(&detail.AnnounceReferenceNumber, req.AnnounceReferenceNumber)
+	if req.HasUniversityFunding != nil {
+		detail.HasUniversityFunding = strings.TrimSpace(*req.HasUniversityFunding)
+		if detail.HasUniversityFunding == "" {
+			detail.HasUniversityFunding = "no"
+		}
+	}
+	setNullable(&detail.FundingReferences, req.FundingReferences)
+	setNullable(&detail.UniversityRankings, req.UniversityRankings)
+
+	if detail.AuthorNameList == "" && !allowIncomplete {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "author_name_list is required"})
+		return
+	}
+	if detail.Signature == "" && !allowIncomplete {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "signature is required"})
+		return
+	}
+
+	duplicates, ok := checkDuplicatePublicationClaim(c, config.DB, submission.UserID, submission.SubmissionID, detail.DOI, detail.PaperTitle, allowIncomplete)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	detail.UpdateAt = now
+
+	var responseExternalFunds []gin.H
+	if err := config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&detail).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save publication details"})
+			return err
+		}
+
+		if req.ExternalFundings == nil {
+			return nil
+		}
+		funds, failMsg, err := syncPublicationExternalFunds(tx, &detail, *req.ExternalFundings, now)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": failMsg})
+			return err
+		}
+		responseExternalFunds = funds
+		return nil
+	}); err != nil {
+		return
+	}
+
+	if req.ExternalFundings == nil {
+		if err := config.DB.
+			Where("detail_id = ? AND (deleted_at IS NULL OR deleted_at = '0000-00-00 00:00:00')", detail.DetailID).
+			Order("external_fund_id ASC").
+			Find(&detail.ExternalFunds).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load external funding records"})
+			return
+		}
+		responseExternalFunds = make([]gin.H, 0, len(detail.ExternalFunds))
+		for _, fund := range detail.ExternalFunds {
+			responseExternalFunds = append(responseExternalFunds, gin.H{
+				"external_fund_id": fund.ExternalFundID,
+				"fund_name":        fund.FundName,
+				"amount":           fund.Amount,
+			})
+		}
+	}
+
+	clearSubmissionAutosaveAfterSave(submission.SubmissionID)
+
+	response := gin.H{
+		"success":           true,
+		"message":           "Publication details updated successfully",
+		"details":           detail,
+		"external_fundings": responseExternalFunds,
+	}
+	if len(duplicates) > 0 {
+		response["duplicate_warnings"] = duplicates
+	}
+	if totalAdjusted {
+		response["total_amount_adjusted"] = true
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"fund-management-api/models"
+	"fund-management-api/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type publicationDuplicateClaim struct {
+	SubmissionID     int    `json:"submission_id"`
+	SubmissionNumber string `json:"submission_number"`
+	StatusID         int    `json:"status_id"`
+	MatchedOn        string `json:"matched_on"` // doi | title
+}
+
+// publicationDuplicatePolicy controls what happens when a user claims a paper
+// they already claimed (PUBLICATION_DUPLICATE_POLICY=warn|block|off, default warn).
+func publicationDuplicatePolicy() string {
+	return envPolicy("PUBLICATION_DUPLICATE_POLICY", policyWarn)
+}
+
+// findDuplicatePublicationClaims lists the user's other publication rewards that
+// are not rejected and carry the same DOI or, failing that, the same normalised
+// title.
+func findDuplicatePublicationClaims(db *gorm.DB, userID, submissionID int, doi, title string) ([]publicationDuplicateClaim, error) {
+	normalizedDOI := utils.NormalizeDOI(doi)
+	normalizedTitle := utils.NormalizePublicationTitle(title)
+	if normalizedDOI == "" && normalizedTitle == "" {
+		return nil, nil
+	}
+
+	rejectedIDs, err := utils.GetStatusIDsByCodes(utils.StatusCodeRejected)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []struct {
+		SubmissionID     int
+		SubmissionNumber string
+		StatusID         int
+		DOI              string `gorm:"column:doi"`
+		PaperTitle       string
+	}
+	if err := db.Table("submissions s").
+		Select("s.submission_id, s.submission_number, s.status_id, COALESCE(prd.doi, '') AS doi, COALESCE(prd.paper_title, '') AS paper_title").
+		Joins("JOIN publication_reward_details prd ON prd.submission_id = s.submission_id AND (prd.delete_at IS NULL OR prd.delete_at = '0000-00-00 00:00:00')").
+		Where("s.user_id = ? AND s.submission_id <> ? AND s.submission_type = 'publication_reward' AND s.deleted_at IS NULL", userID, submissionID).
+		Where("s.status_id NOT IN ?", ensureIDs(rejectedIDs)).
+		Order("s.submission_id ASC").
+		Scan(&candidates).Error; err != nil {
+		return nil, err
+	}
+
+	duplicates := make([]publicationDuplicateClaim, 0)
+	for _, candidate := range candidates {
+		matchedOn := ""
+		if normalizedDOI != "" && utils.NormalizeDOI(candidate.DOI) == normalizedDOI {
+			matchedOn = "doi"
+		} else if normalizedTitle != "" && utils.NormalizePublicationTitle(candidate.PaperTitle) == normalizedTitle {
+			matchedOn = "title"
+		}
+		if matchedOn == "" {
+			continue
+		}
+		duplicates = append(duplicates, publicationDuplicateClaim{
+			SubmissionID:     candidate.SubmissionID,
+			SubmissionNumber: candidate.SubmissionNumber,
+			StatusID:         candidate.StatusID,
+			MatchedOn:        matchedOn,
+		})
+	}
+	return duplicates, nil
+}
+
+// checkDuplicatePublicationClaim runs the duplicate check for a detail save. It
+// responds 409 and returns false when the policy blocks the save; otherwise it
+// returns the duplicates to report back as a warning. Drafts are never blocked.
+func checkDuplicatePublicationClaim(c *gin.Context, db *gorm.DB, userID, submissionID int, doi, title string, allowIncomplete bool) ([]publicationDuplicateClaim, bool) {
+	policy := publicationDuplicatePolicy()
+	if policy == policyOff {
+		return nil, true
+	}
+
+	duplicates, err := findDuplicatePublicationClaims(db, userID, submissionID, doi, title)
+	if err != nil {
+		InternalError(c, "publication_duplicate", err)
+		return nil, false
+	}
+	if len(duplicates) == 0 {
+		return nil, true
+	}
+
+	if policy == policyBlock && !allowIncomplete {
+		c.JSON(http.StatusConflict, gin.H{
+			"success":    false,
+			"error":      "This publication has already been claimed in submission " + duplicates[0].SubmissionNumber,
+			"code":       "DUPLICATE_PUBLICATION",
+			"duplicates": duplicates,
+		})
+		return nil, false
+	}
+	return duplicates, true
+}
+
+// submissionDuplicatePublicationClaims runs the duplicate check on a publication
+// reward's saved details at submit time. Drafts may be saved over a blocked
+// duplicate (allow_incomplete), so the block policy must hold here too.
+func submissionDuplicatePublicationClaims(db *gorm.DB, submission *models.Submission) ([]publicationDuplicateClaim, error) {
+	if submission == nil || submission.SubmissionType != "publication_reward" || publicationDuplicatePolicy() == policyOff {
+		return nil, nil
+	}
+	var detail models.PublicationRewardDetail
+	if err := db.Select("doi", "paper_title").
+		Where("submission_id = ?", submission.SubmissionID).
+		First(&detail).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return findDuplicatePublicationClaims(db, submission.UserID, submission.SubmissionID, detail.DOI, detail.PaperTitle)
+}
+
+// duplicatePublicationIssue reports the first duplicate claim as a submit check.
+func duplicatePublicationIssue(duplicates []publicationDuplicateClaim) submitCheckIssue {
+	return submitCheckIssue{
+		Code:    "DUPLICATE_PUBLICATION",
+		Field:   "doi",
+		Message: "This publication has already been claimed in submission " + duplicates[0].SubmissionNumber,
+	}
+}
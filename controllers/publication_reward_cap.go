@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"fund-management-api/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// publicationRewardYearlyCap reads system_config.max_publication_rewards_per_year;
+// 0 means no cap.
+func publicationRewardYearlyCap(db *gorm.DB) (int, error) {
+	var limit sql.NullInt64
+	if err := db.Raw(`
+		SELECT max_publication_rewards_per_year
+		FROM system_config
+		ORDER BY config_id DESC
+		LIMIT 1
+	`).Scan(&limit).Error; err != nil {
+		return 0, err
+	}
+	if !limit.Valid || limit.Int64 <= 0 {
+		return 0, nil
+	}
+	return int(limit.Int64), nil
+}
+
+// countPublicationRewardClaims counts the user's publication rewards in
+// the year that are pending or approved, leaving out excludeSubmissionID.
+func countPublicationRewardClaims(db *gorm.DB, userID, yearID, excludeSubmissionID int) (int, error) {
+	var count int64
+	if err := db.Table("submissions s").
+		Joins("JOIN application_status st ON st.application_status_id = s.status_id").
+		Where("s.deleted_at IS NULL").
+		Where("s.user_id = ? AND s.year_id = ? AND s.submission_id <> ?", userID, yearID, excludeSubmissionID).
+		Where("s.submission_type = 'publication_reward'").
+		Where("st.status_code NOT IN ?", []string{utils.StatusCodeRejected, utils.StatusCodeDraft}).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// checkPublicationRewardCap stops submitting another publication reward once
+// the user has reached the yearly cap. Admins are not capped.
+func checkPublicationRewardCap(in submitCheckInput) (submitCheckOutcome, error) {
+	submission := in.Submission
+	if submission.SubmissionType != "publication_reward" || in.RoleID == 3 {
+		return submitCheckOutcome{}, nil
+	}
+
+	limit, err := publicationRewardYearlyCap(in.DB)
+	if err != nil || limit == 0 {
+		return submitCheckOutcome{}, err
+	}
+
+	used, err := countPublicationRewardClaims(in.DB, submission.UserID, submission.YearID, submission.SubmissionID)
+	if err != nil || used < limit {
+		return submitCheckOutcome{}, err
+	}
+
+	message := fmt.Sprintf("You have already claimed %d of %d publication rewards allowed this year", used, limit)
+	return submitCheckOutcome{
+		Errors: []submitCheckIssue{{Code: "PUBLICATION_REWARD_CAP_REACHED", Message: message}},
+		Status: http.StatusBadRequest,
+		Body: gin.H{
+			"error": message,
+			"code":  "PUBLICATION_REWARD_CAP_REACHED",
+			"used":  used,
+			"limit": limit,
+		},
+	}, nil
+}
@@ -3,24 +3,23 @@ package controllers
 import (
 	"archive/zip"
 	"bytes"
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"fund-management-api/config"
 	"fund-management-api/models"
+	"fund-management-api/services"
 	"fund-management-api/utils"
 	"io"
-	"io/fs"
 	"log"
 	"mime/multipart"
 	"net/http"
-	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
-	"runtime"
 	"sort"
 	"strconv"
 	"strings"
@@ -209,11 +208,18 @@ func handlePublicationRewardPreviewSubmission(c *gin.Context) {
 	}
 	replacements["{{end_of_contract}}"] = sanitizeEndOfContractContent(endOfContractContent)
 
-	pdfData, err := generatePublicationRewardPDF(replacements)
+	verificationQR, err := submissionVerificationQRImage(&submission)
+	if err != nil {
+		InternalError(c, "reward_preview", err)
+		return
+	}
+
+	pdfData, err := generatePublicationRewardPDF(replacements, verificationQR)
 	if err != nil {
 		InternalError(c, "reward_preview", err)
 		return
 	}
+	pdfData = watermarkPDFBytes(pdfData, submissionWatermarkText(&submission))
 
 	c.Header("Content-Type", "application/pdf")
 	c.Header("Content-Disposition", "inline; filename=publication_reward_preview.pdf")
@@ -272,6 +278,7 @@ func handlePublicationRewardPreviewForm(c *gin.Context) {
 		InternalError(c, "reward_preview", err)
 		return
 	}
+	merged = watermarkPDFBytes(merged, draftWatermarkText())
 
 	c.Header("Content-Type", "application/pdf")
 	c.Header("Content-Disposition", "inline; filename=publication_reward_preview.pdf")
@@ -509,8 +516,10 @@ func resolveApplicantEmploymentDate(user *models.User) string {
 		return ""
 	}
 
-	if formatted := utils.FormatThaiDatePtr(user.DateOfEmployment); formatted != "" {
-		return formatted
+	if user.DateOfEmployment != nil {
+		if formatted := utils.FormatThaiCalendarDate(*user.DateOfEmployment); formatted != "" {
+			return formatted
+		}
 	}
 
 	if user.UserID == 0 {
@@ -530,7 +539,7 @@ func resolveApplicantEmploymentDate(user *models.User) string {
 	}
 
 	if row.Date.Valid {
-		return utils.FormatThaiDate(row.Date.Time)
+		return utils.FormatThaiCalendarDate(row.Date.Time)
 	}
 
 	return ""
@@ -549,8 +558,10 @@ func lookupEmploymentDateFromUserID(userID int) string {
 		return ""
 	}
 
-	if formatted := utils.FormatThaiDatePtr(user.DateOfEmployment); formatted != "" {
-		return formatted
+	if user.DateOfEmployment != nil {
+		if formatted := utils.FormatThaiCalendarDate(*user.DateOfEmployment); formatted != "" {
+			return formatted
+		}
 	}
 
 	return ""
@@ -605,7 +616,6 @@ func mergePreviewPDFWithAttachments(base []byte, files []*multipart.FileHeader)
 		if !bytes.HasPrefix(data, []byte("%PDF")) {
 			// Skip non-PDF attachments (e.g. the auto-generated .docx form that comes
 			// back with a returned submission) instead of failing the whole preview.
-			// Mirrors MergeSubmissionDocuments, which also skips non-PDF documents.
 			log.Printf("[mergePreview] skipping non-PDF attachment %s", header.Filename)
 			continue
 		}
@@ -758,19 +768,26 @@ func copyPDF(src, dst string) error {
 }
 
 func mergePDFsWithNode(nodeBinary string, inputs []string, outputPath string) error {
-	scriptPath := filepath.Join("scripts", "merge_pdf.js")
+	args := append([]string{outputPath}, inputs...)
+	return runPdfLibScript(nodeBinary, "merge_pdf.js", args...)
+}
+
+// runPdfLibScript runs one of the pdf-lib helpers in scripts/ with the
+// frontend's node_modules on NODE_PATH.
+func runPdfLibScript(nodeBinary, script string, scriptArgs ...string) error {
+	scriptPath := filepath.Join("scripts", script)
 	absScriptPath, err := filepath.Abs(scriptPath)
 	if err != nil {
-		return fmt.Errorf("failed to resolve merge script path: %w", err)
+		return fmt.Errorf("failed to resolve script path %s: %w", script, err)
 	}
 	if _, err := os.Stat(absScriptPath); err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("merge script not found at %s", absScriptPath)
+			return fmt.Errorf("script not found at %s", absScriptPath)
 		}
-		return fmt.Errorf("failed to access merge script: %w", err)
+		return fmt.Errorf("failed to access script %s: %w", script, err)
 	}
 
-	args := append([]string{absScriptPath, outputPath}, inputs...)
+	args := append([]string{absScriptPath}, scriptArgs...)
 
 	repoDir := filepath.Dir(filepath.Dir(absScriptPath))
 	nodeModulesPath := filepath.Join(repoDir, "..", "frontend_project_fund", "node_modules")
@@ -1426,7 +1443,7 @@ func documentUnitForName(name string) string {
 	return "ฉบับ"
 }
 
-func generatePublicationRewardPDF(replacements map[string]string) ([]byte, error) {
+func generatePublicationRewardPDF(replacements map[string]string, images ...docxImage) ([]byte, error) {
 	templatePath := filepath.Join("templates", "publication_reward_template.docx")
 	if _, err := os.Stat(templatePath); err != nil {
 		if os.IsNotExist(err) {
@@ -1442,127 +1459,23 @@ func generatePublicationRewardPDF(replacements map[string]string) ([]byte, error
 	defer os.RemoveAll(tmpDir)
 
 	outputDocx := filepath.Join(tmpDir, "publication_reward_preview.docx")
-	if err := fillDocxTemplate(templatePath, outputDocx, replacements); err != nil {
+	if err := fillDocxTemplate(templatePath, outputDocx, replacements, images...); err != nil {
 		return nil, err
 	}
 
-	fontEnv, err := configureLibreOfficeFonts(tmpDir)
-	if err != nil {
-		return nil, err
-	}
-
-	converter, err := lookupLibreOfficeBinary()
-	if err != nil {
-		return nil, err
-	}
-
-	profileDir := filepath.Join(tmpDir, "lo-profile")
-	if err := os.MkdirAll(profileDir, 0700); err != nil {
-		return nil, fmt.Errorf("failed to prepare libreoffice profile: %w", err)
-	}
-
-	profileURL, err := fileURLFromPath(profileDir)
-	if err != nil {
-		return nil, fmt.Errorf("failed to prepare libreoffice profile: %w", err)
-	}
-
-	profileArg := fmt.Sprintf("-env:UserInstallation=%s", profileURL)
-	filterArg := "pdf:writer_pdf_Export:EmbedStandardFonts=true;EmbedFonts=true"
-
-	args := []string{profileArg, "--headless", "--convert-to", filterArg, "--outdir", tmpDir, outputDocx}
-	cmd := exec.Command(converter, args...)
-	env := append([]string{}, os.Environ()...)
-	if len(fontEnv) > 0 {
-		env = append(env, fontEnv...)
-	}
-	cmd.Env = env
-
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return nil, fmt.Errorf("failed to convert to pdf: %v", strings.TrimSpace(string(output)))
-	}
-
-	outputPDF := filepath.Join(tmpDir, "publication_reward_preview.pdf")
-	data, err := os.ReadFile(outputPDF)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read generated pdf: %w", err)
-	}
-
-	return data, nil
+	return services.ConvertDocxToPDF(context.Background(), outputDocx)
 }
 
-func lookupLibreOfficeBinary() (string, error) {
-	if explicit := strings.TrimSpace(os.Getenv("LIBREOFFICE_PATH")); explicit != "" {
-		if runtime.GOOS == "windows" {
-			explicit = strings.Trim(explicit, "\"")
-		}
-
-		candidate := explicit
-		if !filepath.IsAbs(candidate) {
-			absPath, err := filepath.Abs(candidate)
-			if err != nil {
-				return "", fmt.Errorf("invalid LIBREOFFICE_PATH: %w", err)
-			}
-			candidate = absPath
-		}
-
-		info, err := os.Stat(candidate)
-		if err != nil {
-			return "", fmt.Errorf("invalid LIBREOFFICE_PATH: %w", err)
-		}
-		if info.IsDir() {
-			return "", fmt.Errorf("LIBREOFFICE_PATH must point to the soffice executable, not a directory")
-		}
-
-		return candidate, nil
-	}
-
-	if path, err := exec.LookPath("soffice"); err == nil {
-		return path, nil
-	}
-	if path, err := exec.LookPath("libreoffice"); err == nil {
-		return path, nil
-	}
-	return "", fmt.Errorf("libreoffice (soffice) binary not found in PATH; set LIBREOFFICE_PATH to override")
+// docxImage is a PNG stamped into a generated docx. It replaces the run holding
+// Placeholder when the template has one; otherwise it floats at the bottom-right
+// corner of the first page so existing templates keep their layout.
+type docxImage struct {
+	Placeholder string
+	PNG         []byte
+	SizeEMU     int64
 }
 
-func fileURLFromPath(path string) (string, error) {
-	absPath, err := filepath.Abs(path)
-	if err != nil {
-		return "", err
-	}
-
-	absPath = filepath.ToSlash(absPath)
-
-	if runtime.GOOS == "windows" {
-		if strings.HasPrefix(absPath, "//") {
-			trimmed := strings.TrimPrefix(absPath, "//")
-			parts := strings.SplitN(trimmed, "/", 2)
-			host := parts[0]
-			var uncPath string
-			if len(parts) == 2 {
-				uncPath = "/" + parts[1]
-			}
-
-			u := &url.URL{
-				Scheme: "file",
-				Host:   host,
-				Path:   uncPath,
-			}
-			return u.String(), nil
-		}
-
-		if !strings.HasPrefix(absPath, "/") {
-			absPath = "/" + absPath
-		}
-	} else if !strings.HasPrefix(absPath, "/") {
-		absPath = "/" + absPath
-	}
-
-	u := &url.URL{Scheme: "file", Path: absPath}
-	return u.String(), nil
-}
-
-func fillDocxTemplate(templatePath, outputPath string, replacements map[string]string) error {
+func fillDocxTemplate(templatePath, outputPath string, replacements map[string]string, images ...docxImage) error {
 	reader, err := zip.OpenReader(templatePath)
 	if err != nil {
 		return fmt.Errorf("failed to open template: %w", err)
@@ -1598,6 +1511,17 @@ func fillDocxTemplate(templatePath, outputPath string, replacements map[string]s
 			data = []byte(content)
 		}
 
+		if len(images) > 0 {
+			switch file.Name {
+			case "word/document.xml":
+				data = []byte(embedDocxImages(string(data), images))
+			case "word/_rels/document.xml.rels":
+				data = []byte(addDocxImageRelationships(string(data), images))
+			case "[Content_Types].xml":
+				data = []byte(ensureDocxPNGContentType(string(data)))
+			}
+		}
+
 		header := file.FileHeader
 		writerEntry, err := writer.CreateHeader(&header)
 		if err != nil {
@@ -1611,12 +1535,136 @@ func fillDocxTemplate(templatePath, outputPath string, replacements map[string]s
 		}
 	}
 
+	for i, image := range images {
+		entry, err := writer.Create(docxImageMediaPath(i))
+		if err != nil {
+			writer.Close()
+			return fmt.Errorf("failed to write docx image: %w", err)
+		}
+		if _, err := entry.Write(image.PNG); err != nil {
+			writer.Close()
+			return fmt.Errorf("failed to write docx image: %w", err)
+		}
+	}
+
 	if err := writer.Close(); err != nil {
 		return fmt.Errorf("failed to finalize docx: %w", err)
 	}
 	return nil
 }
 
+const (
+	docxEMUPerMM        = 36000
+	docxPageWidthEMU    = 210 * docxEMUPerMM
+	docxPageHeightEMU   = 297 * docxEMUPerMM
+	docxFloatingInsetMM = 3
+)
+
+func docxImageMediaPath(index int) string {
+	return fmt.Sprintf("word/media/generated_image%d.png", index+1)
+}
+
+func docxImageRelationshipID(index int) string {
+	return fmt.Sprintf("rIdGeneratedImage%d", index+1)
+}
+
+func addDocxImageRelationships(content string, images []docxImage) string {
+	var rels strings.Builder
+	for i := range images {
+		fmt.Fprintf(&rels, `<Relationship Id="%s" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="media/generated_image%d.png"/>`, docxImageRelationshipID(i), i+1)
+	}
+	return strings.Replace(content, "</Relationships>", rels.String()+"</Relationships>", 1)
+}
+
+func ensureDocxPNGContentType(content string) string {
+	if strings.Contains(content, `Extension="png"`) {
+		return content
+	}
+	return strings.Replace(content, "</Types>", `<Default Extension="png" ContentType="image/png"/></Types>`, 1)
+}
+
+func embedDocxImages(content string, images []docxImage) string {
+	content = normalizeDocxPlaceholders(content, docxImagePlaceholders(images))
+
+	for i, image := range images {
+		if image.Placeholder != "" && strings.Contains(content, image.Placeholder) {
+			content = replaceDocxPlaceholderRun(content, image.Placeholder, docxImageRun(i, image, false))
+			continue
+		}
+		content = insertIntoFirstDocxParagraph(content, docxImageRun(i, image, true))
+	}
+	return content
+}
+
+func docxImagePlaceholders(images []docxImage) map[string]string {
+	placeholders := make(map[string]string, len(images))
+	for _, image := range images {
+		if image.Placeholder != "" {
+			placeholders[image.Placeholder] = ""
+		}
+	}
+	return placeholders
+}
+
+// replaceDocxPlaceholderRun swaps the whole <w:r> that holds placeholder for run.
+func replaceDocxPlaceholderRun(content, placeholder, run string) string {
+	idx := strings.Index(content, placeholder)
+	if idx < 0 {
+		return content
+	}
+	start := strings.LastIndex(content[:idx], "<w:r>")
+	if alt := strings.LastIndex(content[:idx], "<w:r "); alt > start {
+		start = alt
+	}
+	end := strings.Index(content[idx:], "</w:r>")
+	if start < 0 || end < 0 {
+		return strings.Replace(content, placeholder, "", 1)
+	}
+	end += idx + len("</w:r>")
+	return content[:start] + run + content[end:]
+}
+
+func insertIntoFirstDocxParagraph(content, run string) string {
+	body := strings.Index(content, "<w:body>")
+	if body < 0 {
+		return content
+	}
+	para := body + len("<w:body>")
+	if !strings.HasPrefix(content[para:], "<w:p>") && !strings.HasPrefix(content[para:], "<w:p ") {
+		return content
+	}
+	pos := para + strings.Index(content[para:], ">") + 1
+	if strings.HasPrefix(content[pos:], "<w:pPr>") {
+		if end := strings.Index(content[pos:], "</w:pPr>"); end >= 0 {
+			pos += end + len("</w:pPr>")
+		}
+	}
+	return content[:pos] + run + content[pos:]
+}
+
+func docxImageRun(index int, image docxImage, floating bool) string {
+	size := image.SizeEMU
+	name := fmt.Sprintf("Generated Image %d", index+1)
+	graphic := fmt.Sprintf(`<wp:docPr id="%d" name="%s"/><wp:cNvGraphicFramePr/>`+
+		`<a:graphic xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"><a:graphicData uri="http://schemas.openxmlformats.org/drawingml/2006/picture">`+
+		`<pic:pic xmlns:pic="http://schemas.openxmlformats.org/drawingml/2006/picture"><pic:nvPicPr><pic:cNvPr id="0" name="%s"/><pic:cNvPicPr/></pic:nvPicPr>`+
+		`<pic:blipFill><a:blip r:embed="%s"/><a:stretch><a:fillRect/></a:stretch></pic:blipFill>`+
+		`<pic:spPr><a:xfrm><a:off x="0" y="0"/><a:ext cx="%d" cy="%d"/></a:xfrm><a:prstGeom prst="rect"><a:avLst/></a:prstGeom></pic:spPr></pic:pic>`+
+		`</a:graphicData></a:graphic>`,
+		9000+index, name, name, docxImageRelationshipID(index), size, size)
+
+	if !floating {
+		return fmt.Sprintf(`<w:r><w:drawing><wp:inline distT="0" distB="0" distL="0" distR="0"><wp:extent cx="%d" cy="%d"/><wp:effectExtent l="0" t="0" r="0" b="0"/>%s</wp:inline></w:drawing></w:r>`,
+			size, size, graphic)
+	}
+
+	inset := int64(docxFloatingInsetMM * docxEMUPerMM)
+	return fmt.Sprintf(`<w:r><w:drawing><wp:anchor distT="0" distB="0" distL="0" distR="0" simplePos="0" relativeHeight="251659264" behindDoc="0" locked="1" layoutInCell="1" allowOverlap="1">`+
+		`<wp:simplePos x="0" y="0"/><wp:positionH relativeFrom="page"><wp:posOffset>%d</wp:posOffset></wp:positionH><wp:positionV relativeFrom="page"><wp:posOffset>%d</wp:posOffset></wp:positionV>`+
+		`<wp:extent cx="%d" cy="%d"/><wp:effectExtent l="0" t="0" r="0" b="0"/><wp:wrapNone/>%s</wp:anchor></w:drawing></w:r>`,
+		docxPageWidthEMU-size-inset, docxPageHeightEMU-size-inset, size, size, graphic)
+}
+
 func formatDocxValue(value string) string {
 	if value == "" {
 		return ""
@@ -1723,134 +1771,3 @@ func derivePublicationYear(date *time.Time, fallback string) string {
 	}
 	return strings.TrimSpace(fallback)
 }
-
-func configureLibreOfficeFonts(tmpDir string) ([]string, error) {
-	fontDirs := collectFontDirectories()
-	if len(fontDirs) == 0 {
-		return nil, nil
-	}
-
-	fontConfigDir := filepath.Join(tmpDir, "fontconfig")
-	if err := os.MkdirAll(fontConfigDir, 0700); err != nil {
-		return nil, fmt.Errorf("failed to prepare fontconfig directory: %w", err)
-	}
-
-	configPath := filepath.Join(fontConfigDir, "fonts.conf")
-	var builder strings.Builder
-	builder.WriteString("<?xml version=\"1.0\"?>\n")
-	builder.WriteString("<fontconfig>\n")
-	for _, dir := range fontDirs {
-		builder.WriteString("  <dir>")
-		builder.WriteString(xmlEscape(dir))
-		builder.WriteString("</dir>\n")
-	}
-
-	aliasMap := map[string]string{
-		"Cordia New":      "TH Sarabun New",
-		"Angsana New":     "TH Sarabun New",
-		"AngsanaUPC":      "TH Sarabun New",
-		"Sarabun":         "TH Sarabun New",
-		"Times New Roman": "DejaVu Serif",
-		"Calibri":         "DejaVu Sans",
-		"Calibri Light":   "DejaVu Sans",
-		"Segoe UI Symbol": "DejaVu Sans",
-	}
-
-	aliasKeys := make([]string, 0, len(aliasMap))
-	for from := range aliasMap {
-		aliasKeys = append(aliasKeys, from)
-	}
-	sort.Strings(aliasKeys)
-
-	for _, from := range aliasKeys {
-		to := aliasMap[from]
-		builder.WriteString("  <alias binding=\"strong\">\n")
-		builder.WriteString("    <family>")
-		builder.WriteString(xmlEscape(from))
-		builder.WriteString("</family>\n")
-		builder.WriteString("    <accept>\n")
-		builder.WriteString("      <family>")
-		builder.WriteString(xmlEscape(to))
-		builder.WriteString("</family>\n")
-		builder.WriteString("    </accept>\n")
-		builder.WriteString("  </alias>\n")
-	}
-
-	builder.WriteString("</fontconfig>\n")
-
-	if err := os.WriteFile(configPath, []byte(builder.String()), 0600); err != nil {
-		return nil, fmt.Errorf("failed to write font configuration: %w", err)
-	}
-
-	xdgDataHome := filepath.Join(tmpDir, "xdg-data")
-	if err := os.MkdirAll(xdgDataHome, 0700); err != nil {
-		return nil, fmt.Errorf("failed to prepare font cache directory: %w", err)
-	}
-
-	xdgCacheHome := filepath.Join(tmpDir, "xdg-cache")
-	if err := os.MkdirAll(xdgCacheHome, 0700); err != nil {
-		return nil, fmt.Errorf("failed to prepare font cache directory: %w", err)
-	}
-
-	env := []string{
-		fmt.Sprintf("FONTCONFIG_FILE=%s", configPath),
-		fmt.Sprintf("FONTCONFIG_PATH=%s", fontConfigDir),
-		fmt.Sprintf("XDG_DATA_HOME=%s", xdgDataHome),
-		fmt.Sprintf("XDG_CACHE_HOME=%s", xdgCacheHome),
-	}
-
-	return env, nil
-}
-
-func collectFontDirectories() []string {
-	candidates := []string{
-		filepath.Join("templates", "fonts"),
-		filepath.Join("frontend_project_fund", "public", "font"),
-	}
-
-	seen := make(map[string]struct{})
-	var result []string
-
-	for _, candidate := range candidates {
-		absRoot, err := filepath.Abs(candidate)
-		if err != nil {
-			continue
-		}
-
-		info, err := os.Stat(absRoot)
-		if err != nil || !info.IsDir() {
-			continue
-		}
-
-		_ = filepath.WalkDir(absRoot, func(path string, d fs.DirEntry, err error) error {
-			if err != nil {
-				return nil
-			}
-			if d.IsDir() {
-				return nil
-			}
-
-			name := d.Name()
-			if strings.HasPrefix(name, "._") {
-				return nil
-			}
-
-			ext := strings.ToLower(filepath.Ext(name))
-			if ext != ".ttf" && ext != ".otf" {
-				return nil
-			}
-
-			dir := filepath.Dir(path)
-			if _, exists := seen[dir]; exists {
-				return nil
-			}
-
-			seen[dir] = struct{}{}
-			result = append(result, dir)
-			return nil
-		})
-	}
-
-	sort.Strings(result)
-	return result
-}
@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"log"
+	"math"
+
+	"fund-management-api/models"
+)
+
+// publicationAmountTolerance absorbs rounding differences between the client's
+// arithmetic and ours.
+const publicationAmountTolerance = 0.01
+
+func roundAmount(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+func sumExternalFundingInputs(funds []publicationExternalFundingInput) float64 {
+	var total float64
+	for _, fund := range funds {
+		total += fund.Amount
+	}
+	return roundAmount(total)
+}
+
+// computePublicationRewardTotal is the requested total of a publication reward:
+// reward plus revision and publication fees, less what external funders already
+// paid, never below zero.
+func computePublicationRewardTotal(detail *models.PublicationRewardDetail) float64 {
+	total := detail.RewardAmount + detail.RevisionFee + detail.PublicationFee - detail.ExternalFundingAmount
+	if total < 0 {
+		total = 0
+	}
+	return roundAmount(total)
+}
+
+// reconcilePublicationRewardTotal overwrites detail.TotalAmount with the server
+// computation. clientTotal is the value the client sent (nil when omitted); a
+// disagreement beyond the tolerance is logged so frontend bugs surface, and
+// reported to the caller.
+func reconcilePublicationRewardTotal(detail *models.PublicationRewardDetail, clientTotal *float64) bool {
+	computed := computePublicationRewardTotal(detail)
+	adjusted := clientTotal != nil && math.Abs(*clientTotal-computed) > publicationAmountTolerance
+	if adjusted {
+		log.Printf("[publicationTotals] submission %d: client total_amount %.2f differs from computed %.2f (reward %.2f + revision %.2f + publication %.2f - external %.2f)",
+			detail.SubmissionID, *clientTotal, computed,
+			detail.RewardAmount, detail.RevisionFee, detail.PublicationFee, detail.ExternalFundingAmount)
+	}
+	detail.TotalAmount = computed
+	return adjusted
+}
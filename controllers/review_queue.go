@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"fund-management-api/config"
+	"fund-management-api/models"
+	"fund-management-api/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const reviewQueuePending = "pending"
+
+// reviewQueueKey orders a review queue oldest first; submission_id breaks ties
+// so every submission has a fixed place.
+const reviewQueueKey = "COALESCE(submissions.submitted_at, submissions.created_at)"
+
+// reviewQueueStatusCode returns the status a reviewer with roleID works
+// through in queue: submissions awaiting the department head for dept heads
+// (role 4) and submissions awaiting admin approval for admins (role 3). ok is
+// false when the role has no such queue.
+func reviewQueueStatusCode(roleID int, queue string) (string, bool) {
+	if queue != reviewQueuePending {
+		return "", false
+	}
+	switch roleID {
+	case 3:
+		return utils.StatusCodePending, true
+	case 4:
+		return utils.StatusCodeDeptHeadPending, true
+	default:
+		return "", false
+	}
+}
+
+// reviewQueueQuery selects the submissions in the queue for statusID.
+func reviewQueueQuery(db *gorm.DB, statusID int) *gorm.DB {
+	return db.Model(&models.Submission{}).
+		Where("submissions.deleted_at IS NULL").
+		Where("submissions.status_id = ?", statusID)
+}
+
+// reviewQueueNeighbour returns the id of the queue entry just before
+// (previous) or just after the position (at, submissionID), or nil at either
+// end of the queue.
+func reviewQueueNeighbour(db *gorm.DB, statusID int, at time.Time, submissionID int, previous bool) (*int, error) {
+	cmp, order := ">", "ASC"
+	if previous {
+		cmp, order = "<", "DESC"
+	}
+
+	var ids []int
+	if err := reviewQueueQuery(db, statusID).
+		Where("("+reviewQueueKey+" "+cmp+" ? OR ("+reviewQueueKey+" = ? AND submissions.submission_id "+cmp+" ?))", at, at, submissionID).
+		Order(reviewQueueKey+" "+order).
+		Order("submissions.submission_id "+order).
+		Limit(1).
+		Pluck("submissions.submission_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return &ids[0], nil
+}
+
+// GetSubmissionSiblings returns the previous and next submission ids around
+// :id in the caller's review queue (oldest first), so the review page can step
+// through the queue without reloading the list. Only submissions in the
+// caller's own queue are returned. When :id has already left the queue (e.g.
+// it was just approved) its neighbours are those at its former position.
+// GET /api/v1/submissions/:id/siblings?queue=pending
+func GetSubmissionSiblings(c *gin.Context) {
+	submissionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || submissionID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid submission ID"})
+		return
+	}
+
+	queue := strings.ToLower(strings.TrimSpace(c.DefaultQuery("queue", reviewQueuePending)))
+	if queue != reviewQueuePending {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "queue must be pending"})
+		return
+	}
+
+	statusCode, ok := reviewQueueStatusCode(c.GetInt("roleID"), queue)
+	if !ok {
+		c.JSON(http.StatusForbidden, gin.H{"success": false, "error": "No review queue for this role"})
+		return
+	}
+	statusID, err := utils.GetStatusIDByCode(statusCode)
+	if err != nil {
+		InternalError(c, "submission siblings: resolve status", err)
+		return
+	}
+
+	var submission models.Submission
+	if err := config.DB.Select("submission_id", "status_id", "submitted_at", "created_at").
+		Where("deleted_at IS NULL").
+		First(&submission, submissionID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "Submission not found"})
+			return
+		}
+		InternalError(c, "submission siblings: load submission", err)
+		return
+	}
+
+	at := submission.CreatedAt
+	if submission.SubmittedAt != nil {
+		at = *submission.SubmittedAt
+	}
+
+	previousID, err := reviewQueueNeighbour(config.DB, statusID, at, submissionID, true)
+	if err != nil {
+		InternalError(c, "submission siblings: previous", err)
+		return
+	}
+	nextID, err := reviewQueueNeighbour(config.DB, statusID, at, submissionID, false)
+	if err != nil {
+		InternalError(c, "submission siblings: next", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":       true,
+		"queue":         queue,
+		"submission_id": submissionID,
+		"in_queue":      submission.StatusID == statusID,
+		"previous_id":   previousID,
+		"next_id":       nextID,
+	})
+}
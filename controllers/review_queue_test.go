@@ -0,0 +1,28 @@
+package controllers
+
+import (
+	"testing"
+
+	"fund-management-api/utils"
+)
+
+func TestReviewQueueStatusCode(t *testing.T) {
+	cases := []struct {
+		roleID int
+		queue  string
+		want   string
+		ok     bool
+	}{
+		{3, reviewQueuePending, utils.StatusCodePending, true},
+		{4, reviewQueuePending, utils.StatusCodeDeptHeadPending, true},
+		{1, reviewQueuePending, "", false},
+		{2, reviewQueuePending, "", false},
+		{3, "approved", "", false},
+	}
+	for _, tc := range cases {
+		got, ok := reviewQueueStatusCode(tc.roleID, tc.queue)
+		if got != tc.want || ok != tc.ok {
+			t.Fatalf("reviewQueueStatusCode(%d, %q) = %q, %v; want %q, %v", tc.roleID, tc.queue, got, ok, tc.want, tc.ok)
+		}
+	}
+}
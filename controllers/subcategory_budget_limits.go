@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+
+	"fund-management-api/models"
+
+	"gorm.io/gorm"
+)
+
+// Reason codes returned when a submission exceeds its subcategory's budget.
+const (
+	budgetLimitAllocatedAmount   = "EXCEEDS_REMAINING_BUDGET"
+	budgetLimitMaxAmountPerGrant = "EXCEEDS_MAX_AMOUNT_PER_GRANT"
+	budgetLimitMaxGrants         = "EXCEEDS_MAX_GRANTS"
+	budgetLimitMaxAmountPerYear  = "EXCEEDS_MAX_AMOUNT_PER_YEAR"
+)
+
+type budgetLimitViolation struct {
+	Code      string  `json:"code"`
+	Message   string  `json:"message"`
+	Limit     float64 `json:"limit"`
+	Used      float64 `json:"used"`
+	Requested float64 `json:"requested"`
+}
+
+// submissionBudgetCheck is the outcome of checking a fund application against
+// its subcategory budget. Enforce mirrors the subcategory's
+// enforce_budget_limits flag; without it the violations are advisory.
+type submissionBudgetCheck struct {
+	SubcategoryID int                    `json:"subcategory_id"`
+	Enforce       bool                   `json:"enforce"`
+	Violations    []budgetLimitViolation `json:"violations"`
+}
+
+// checkSubmissionBudgetLimits compares the requested amount of a fund
+// application with the active overall budget of its subcategory (read through
+// loadSubcategoryBudgetUsage, as the can-apply check does): the remaining
+// allocated budget, max_amount_per_grant (the selected rule budget's value
+// wins) and the user's remaining grants and yearly amount. It returns nil for
+// other submission types or when no budget applies.
+func checkSubmissionBudgetLimits(db *gorm.DB, submission *models.Submission) (*submissionBudgetCheck, error) {
+	if submission.SubmissionType != "fund_application" {
+		return nil, nil
+	}
+
+	var detail models.FundApplicationDetail
+	if err := db.Where("submission_id = ?", submission.SubmissionID).First(&detail).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	subcategoryID := detail.SubcategoryID
+	if submission.SubcategoryID != nil && *submission.SubcategoryID > 0 {
+		subcategoryID = *submission.SubcategoryID
+	}
+	if subcategoryID <= 0 {
+		return nil, nil
+	}
+
+	var subcategory models.FundSubcategory
+	if err := db.Where("subcategory_id = ? AND delete_at IS NULL", subcategoryID).First(&subcategory).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	usage, err := loadSubcategoryBudgetUsage(db, subcategoryID, submission.UserID, submission.YearID)
+	if err != nil || usage == nil {
+		return nil, err
+	}
+	overall := usage.Overall
+
+	check := &submissionBudgetCheck{
+		SubcategoryID: subcategoryID,
+		Enforce:       subcategory.EnforceBudgetLimits,
+		Violations:    []budgetLimitViolation{},
+	}
+	requested := detail.RequestedAmount
+
+	if overall.MaxGrants > 0 && int(usage.UsedGrants)+1 > overall.MaxGrants {
+		check.Violations = append(check.Violations, budgetLimitViolation{
+			Code:      budgetLimitMaxGrants,
+			Message:   fmt.Sprintf("You have already used %d of %d grants for this fund", int(usage.UsedGrants), overall.MaxGrants),
+			Limit:     float64(overall.MaxGrants),
+			Used:      usage.UsedGrants,
+			Requested: 1,
+		})
+	}
+
+	if overall.MaxAmountPerYear != nil && *overall.MaxAmountPerYear > 0 && usage.UsedAmount+requested > *overall.MaxAmountPerYear {
+		check.Violations = append(check.Violations, budgetLimitViolation{
+			Code:      budgetLimitMaxAmountPerYear,
+			Message:   fmt.Sprintf("Requested amount exceeds your yearly limit of %.2f for this fund", *overall.MaxAmountPerYear),
+			Limit:     *overall.MaxAmountPerYear,
+			Used:      usage.UsedAmount,
+			Requested: requested,
+		})
+	}
+
+	maxPerGrant := overall.MaxAmountPerGrant
+	if submission.SubcategoryBudgetID != nil && *submission.SubcategoryBudgetID > 0 {
+		var rule models.SubcategoryBudget
+		if err := db.Where("subcategory_budget_id = ? AND delete_at IS NULL", *submission.SubcategoryBudgetID).
+			First(&rule).Error; err == nil && rule.MaxAmountPerGrant > 0 {
+			maxPerGrant = rule.MaxAmountPerGrant
+		} else if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+	if maxPerGrant > 0 && requested > maxPerGrant {
+		check.Violations = append(check.Violations, budgetLimitViolation{
+			Code:      budgetLimitMaxAmountPerGrant,
+			Message:   fmt.Sprintf("Requested amount exceeds the maximum of %.2f per grant", maxPerGrant),
+			Limit:     maxPerGrant,
+			Requested: requested,
+		})
+	}
+
+	if overall.AllocatedAmount > 0 && requested > usage.Remaining {
+		check.Violations = append(check.Violations, budgetLimitViolation{
+			Code:      budgetLimitAllocatedAmount,
+			Message:   fmt.Sprintf("Requested amount exceeds the remaining budget of %.2f", usage.Remaining),
+			Limit:     overall.AllocatedAmount,
+			Used:      overall.AllocatedAmount - usage.Remaining,
+			Requested: requested,
+		})
+	}
+
+	return check, nil
+}
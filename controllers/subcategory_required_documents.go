@@ -0,0 +1,244 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"fund-management-api/config"
+	"fund-management-api/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// requiredDocumentType is one entry of a subcategory's effective document
+// requirements. Source is "default" for a document type required for the
+// submission type and "subcategory" for a subcategory-specific addition.
+type requiredDocumentType struct {
+	DocumentTypeID   int    `json:"document_type_id"`
+	DocumentTypeName string `json:"document_type_name"`
+	Code             string `json:"code"`
+	Multiple         bool   `json:"multiple"`
+	DocumentOrder    int    `json:"document_order"`
+	Source           string `json:"source"`
+}
+
+// subcategorySubmissionType maps a subcategory's form_type to the submission
+// type its applications are filed as.
+func subcategorySubmissionType(subcategory models.FundSubcategory) string {
+	if strings.TrimSpace(subcategory.FormType) == "publication_reward" {
+		return "publication_reward"
+	}
+	return "fund_application"
+}
+
+// subcategoryRequiredDocumentTypeIDs returns the document types the
+// subcategory requires in addition to the defaults.
+func subcategoryRequiredDocumentTypeIDs(db *gorm.DB, subcategoryID *int) (map[int]bool, error) {
+	ids := map[int]bool{}
+	if subcategoryID == nil || *subcategoryID <= 0 {
+		return ids, nil
+	}
+
+	var requirements []models.SubcategoryDocumentRequirement
+	if err := db.Where("subcategory_id = ?", *subcategoryID).Find(&requirements).Error; err != nil {
+		return nil, err
+	}
+	for _, requirement := range requirements {
+		ids[requirement.DocumentTypeID] = true
+	}
+	return ids, nil
+}
+
+// effectiveRequiredDocumentTypes lists the document types a submission of
+// submissionType to subcategoryID must carry: the required document types that
+// apply to the submission type plus the subcategory's additions, in
+// document_order.
+func effectiveRequiredDocumentTypes(db *gorm.DB, subcategoryID *int, submissionType string) ([]requiredDocumentType, error) {
+	additions, err := subcategoryRequiredDocumentTypeIDs(db, subcategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	var documentTypes []models.DocumentType
+	if err := db.Where("delete_at IS NULL").Order("document_order").Find(&documentTypes).Error; err != nil {
+		return nil, err
+	}
+
+	required := make([]requiredDocumentType, 0)
+	for _, dt := range documentTypes {
+		source := ""
+		switch {
+		case dt.Required && documentTypeAppliesToSubmission(dt, submissionType):
+			source = "default"
+		case additions[dt.DocumentTypeID]:
+			source = "subcategory"
+		default:
+			continue
+		}
+		required = append(required, requiredDocumentType{
+			DocumentTypeID:   dt.DocumentTypeID,
+			DocumentTypeName: dt.DocumentTypeName,
+			Code:             dt.Code,
+			Multiple:         dt.Multiple,
+			DocumentOrder:    dt.DocumentOrder,
+			Source:           source,
+		})
+	}
+	return required, nil
+}
+
+// missingRequiredDocuments returns the effective required document types the
+// submission has no document for.
+func missingRequiredDocuments(db *gorm.DB, submission *models.Submission) ([]requiredDocumentType, error) {
+	required, err := effectiveRequiredDocumentTypes(db, submission.SubcategoryID, submission.SubmissionType)
+	if err != nil || len(required) == 0 {
+		return nil, err
+	}
+
+	var attachedTypeIDs []int
+	if err := db.Model(&models.SubmissionDocument{}).
+		Where("submission_id = ?", submission.SubmissionID).
+		Distinct().
+		Pluck("document_type_id", &attachedTypeIDs).Error; err != nil {
+		return nil, err
+	}
+	attached := make(map[int]bool, len(attachedTypeIDs))
+	for _, id := range attachedTypeIDs {
+		attached[id] = true
+	}
+
+	missing := make([]requiredDocumentType, 0)
+	for _, dt := range required {
+		if !attached[dt.DocumentTypeID] {
+			missing = append(missing, dt)
+		}
+	}
+	return missing, nil
+}
+
+func loadActiveSubcategory(c *gin.Context) (models.FundSubcategory, bool) {
+	var subcategory models.FundSubcategory
+	subcategoryID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || subcategoryID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid subcategory id"})
+		return subcategory, false
+	}
+	if err := config.DB.Where("subcategory_id = ? AND delete_at IS NULL", subcategoryID).First(&subcategory).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "Subcategory not found"})
+			return subcategory, false
+		}
+		InternalError(c, "subcategory required documents", err)
+		return subcategory, false
+	}
+	return subcategory, true
+}
+
+// GetSubcategoryRequiredDocuments returns the effective required document
+// types of a subcategory. submission_type overrides the type derived from the
+// subcategory's form_type.
+// GET /subcategories/:id/required-documents?submission_type=
+func GetSubcategoryRequiredDocuments(c *gin.Context) {
+	subcategory, ok := loadActiveSubcategory(c)
+	if !ok {
+		return
+	}
+
+	submissionType := strings.TrimSpace(c.Query("submission_type"))
+	if submissionType == "" {
+		submissionType = subcategorySubmissionType(subcategory)
+	}
+
+	required, err := effectiveRequiredDocumentTypes(config.DB, &subcategory.SubcategoryID, submissionType)
+	if err != nil {
+		InternalError(c, "subcategory required documents", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":            true,
+		"subcategory_id":     subcategory.SubcategoryID,
+		"submission_type":    submissionType,
+		"required_documents": required,
+		"total":              len(required),
+	})
+}
+
+// UpdateSubcategoryRequiredDocuments replaces the subcategory-specific
+// document requirements. An empty list leaves only the defaults.
+// PUT /admin/subcategories/:id/required-documents
+func UpdateSubcategoryRequiredDocuments(c *gin.Context) {
+	subcategory, ok := loadActiveSubcategory(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		DocumentTypeIDs []int `json:"document_type_ids"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": err.Error()})
+		return
+	}
+
+	ids := uniqueInts(req.DocumentTypeIDs)
+	for _, id := range ids {
+		if id <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid document_type_ids"})
+			return
+		}
+	}
+	if len(ids) > 0 {
+		var count int64
+		if err := config.DB.Model(&models.DocumentType{}).
+			Where("document_type_id IN ? AND delete_at IS NULL", ids).
+			Count(&count).Error; err != nil {
+			InternalError(c, "subcategory required documents: document types", err)
+			return
+		}
+		if int(count) != len(ids) {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Unknown document_type_ids"})
+			return
+		}
+	}
+
+	now := time.Now()
+	if err := config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subcategory_id = ?", subcategory.SubcategoryID).
+			Delete(&models.SubcategoryDocumentRequirement{}).Error; err != nil {
+			return err
+		}
+		for _, id := range ids {
+			requirement := models.SubcategoryDocumentRequirement{
+				SubcategoryID:  subcategory.SubcategoryID,
+				DocumentTypeID: id,
+				CreatedAt:      now,
+				UpdatedAt:      now,
+			}
+			if err := tx.Create(&requirement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		InternalError(c, "subcategory required documents: update", err)
+		return
+	}
+
+	required, err := effectiveRequiredDocumentTypes(config.DB, &subcategory.SubcategoryID, subcategorySubmissionType(subcategory))
+	if err != nil {
+		InternalError(c, "subcategory required documents", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":            true,
+		"message":            "Required documents updated successfully",
+		"subcategory_id":     subcategory.SubcategoryID,
+		"required_documents": required,
+	})
+}
@@ -2,24 +2,25 @@
 package controllers
 
 import (
-	"crypto/rand"
+	"context"
 	"crypto/sha256"
-	"encoding/hex"
 	"errors"
 	"fmt"
 	"fund-management-api/config"
 	"fund-management-api/models"
+	"fund-management-api/services"
+	"fund-management-api/storage"
 	"fund-management-api/utils"
 	"io"
 	"log"
+	"math"
 	"mime/multipart"
 	"net/http"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -65,11 +66,29 @@ func GetSubmissions(c *gin.Context) {
 		query = query.Where("submission_type = ?", submissionType)
 	}
 	if status != "" {
-		query = query.Where("status_id = ?", status)
+		statusIDs, err := parseSubmissionStatusFilter(status)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		query = query.Where("status_id IN ?", statusIDs)
 	}
 	if yearID != "" {
 		query = query.Where("year_id = ?", yearID)
 	}
+	for _, column := range []string{"submitted", "created"} {
+		from, to, err := parseSubmissionDateRange(c, column+"_from", column+"_to")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if from != nil {
+			query = query.Where(column+"_at >= ?", *from)
+		}
+		if to != nil {
+			query = query.Where(column+"_at < ?", *to)
+		}
+	}
 
 	if err := query.Order("created_at DESC").Find(&submissions).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch submissions"})
@@ -107,6 +126,79 @@ func GetSubmissions(c *gin.Context) {
 	})
 }
 
+// parseSubmissionStatusFilter turns a comma-separated status filter into status
+// IDs. Numeric values are application_status IDs; anything else is a status code
+// or alias such as "pending" or "dept_head_pending" resolved via the status cache.
+func parseSubmissionStatusFilter(raw string) ([]int, error) {
+	var ids []int
+	seen := make(map[int]struct{})
+	for _, part := range strings.Split(raw, ",") {
+		value := strings.TrimSpace(part)
+		if value == "" {
+			continue
+		}
+
+		var id int
+		if numeric, err := strconv.Atoi(value); err == nil {
+			status, err := utils.GetApplicationStatusByID(numeric)
+			if err != nil {
+				return nil, fmt.Errorf("unknown status %q", value)
+			}
+			id = status.ApplicationStatusID
+		} else {
+			resolved, err := utils.GetStatusIDByCode(value)
+			if err != nil {
+				return nil, fmt.Errorf("unknown status %q", value)
+			}
+			id = resolved
+		}
+
+		if _, dup := seen[id]; dup {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, errors.New("invalid status filter")
+	}
+	return ids, nil
+}
+
+// parseSubmissionDateRange reads an inclusive YYYY-MM-DD range from two query
+// parameters. Either bound may be omitted; the upper bound is returned as the
+// start of the following day so the whole day is included.
+func parseSubmissionDateRange(c *gin.Context, fromKey, toKey string) (*time.Time, *time.Time, error) {
+	parse := func(key string) (*time.Time, error) {
+		raw := strings.TrimSpace(c.Query(key))
+		if raw == "" {
+			return nil, nil
+		}
+		parsed, err := time.ParseInLocation("2006-01-02", raw, utils.AppLocation())
+		if err != nil {
+			return nil, fmt.Errorf("Invalid %s: expected YYYY-MM-DD", key)
+		}
+		return &parsed, nil
+	}
+
+	from, err := parse(fromKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	to, err := parse(toKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if from != nil && to != nil && to.Before(*from) {
+		return nil, nil, fmt.Errorf("%s must not be before %s", toKey, fromKey)
+	}
+	if to != nil {
+		next := to.AddDate(0, 0, 1)
+		to = &next
+	}
+	return from, to, nil
+}
+
 // GetSubmission returns a specific submission
 func GetSubmission(c *gin.Context) {
 	submissionID := c.Param("id")
@@ -162,27 +254,9 @@ func GetSubmission(c *gin.Context) {
 		Preload("User").
 		Order("display_order ASC").
 		Find(&submissionUsers).Error; err == nil {
-		applicantIncluded := false
 		for i := range submissionUsers {
-			if submissionUsers[i].UserID == submission.UserID {
-				submissionUsers[i].IsApplicant = true
-				applicantIncluded = true
-			}
+			submissionUsers[i].IsApplicant = submissionUsers[i].UserID == submission.UserID
 		}
-
-		if !applicantIncluded && submission.User != nil {
-			applicant := models.SubmissionUser{
-				SubmissionID: submission.SubmissionID,
-				UserID:       submission.UserID,
-				User:         submission.User,
-				Role:         "owner",
-				IsPrimary:    true,
-				DisplayOrder: 1,
-				IsApplicant:  true,
-			}
-			submissionUsers = append([]models.SubmissionUser{applicant}, submissionUsers...)
-		}
-
 		submission.SubmissionUsers = submissionUsers
 	}
 
@@ -268,6 +342,15 @@ func CreateSubmission(c *gin.Context) {
 		return
 	}
 
+	if err := validateSubmissionFundYear(config.DB, req.YearID, req.CategoryID, req.SubcategoryID); err != nil {
+		if errors.Is(err, errSubmissionFundYearMismatch) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		InternalError(c, "submission", err)
+		return
+	}
+
 	statusID, err := determineInitialStatusID(req.SubmissionType, req.StatusID, roleID)
 	if err != nil {
 		InternalError(c, "submission", err)
@@ -288,13 +371,12 @@ func CreateSubmission(c *gin.Context) {
 	// Create submission
 	now := time.Now()
 	submission := models.Submission{
-		SubmissionType:   req.SubmissionType,
-		SubmissionNumber: generateSubmissionNumber(req.SubmissionType),
-		UserID:           userID.(int),
-		YearID:           req.YearID,
-		StatusID:         statusID,
-		CreatedAt:        now,
-		UpdatedAt:        now,
+		SubmissionType: req.SubmissionType,
+		UserID:         userID.(int),
+		YearID:         req.YearID,
+		StatusID:       statusID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
 	}
 
 	submission.ContactPhone = normalizeOptionalString(req.ContactPhone)
@@ -313,7 +395,18 @@ func CreateSubmission(c *gin.Context) {
 		submission.SubcategoryBudgetID = req.SubcategoryBudgetID
 	}
 
-	if err := config.DB.Create(&submission).Error; err != nil {
+	if err := config.DB.Transaction(func(tx *gorm.DB) error {
+		number, err := generateSubmissionNumber(tx, submission)
+		if err != nil {
+			return err
+		}
+		submission.SubmissionNumber = number
+		if err := tx.Create(&submission).Error; err != nil {
+			return err
+		}
+		return ensureApplicantSubmissionUser(tx, submission.SubmissionID, submission.UserID)
+	}); err != nil {
+		log.Printf("[CreateSubmission] failed to create submission: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create submission"})
 		return
 	}
@@ -399,6 +492,25 @@ func UpdateSubmission(c *gin.Context) {
 		return
 	}
 
+	if req.CategoryID != nil || req.SubcategoryID != nil {
+		categoryID := submission.CategoryID
+		if req.CategoryID != nil {
+			categoryID = req.CategoryID
+		}
+		subcategoryID := submission.SubcategoryID
+		if req.SubcategoryID != nil {
+			subcategoryID = req.SubcategoryID
+		}
+		if err := validateSubmissionFundYear(config.DB, submission.YearID, categoryID, subcategoryID); err != nil {
+			if errors.Is(err, errSubmissionFundYearMismatch) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			InternalError(c, "submission", err)
+			return
+		}
+	}
+
 	updates := map[string]interface{}{"updated_at": time.Now()}
 
 	normalizeOptionalString := func(value *string) *string {
@@ -604,9 +716,27 @@ func SubmitSubmission(c *gin.Context) {
 		return
 	}
 
-	if !submission.CanBeSubmitted() {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Submission cannot be submitted"})
-		return
+	checkInput := submitCheckInput{
+		DB:         config.DB,
+		Submission: &submission,
+		UserID:     userID,
+		RoleID:     c.GetInt("roleID"),
+		Now:        time.Now(),
+	}
+	submitWarnings := gin.H{}
+	for _, check := range submitChecks {
+		outcome, err := check.Run(checkInput)
+		if err != nil {
+			InternalError(c, "submission "+check.Name, err)
+			return
+		}
+		if len(outcome.Errors) > 0 {
+			c.JSON(outcome.Status, outcome.Body)
+			return
+		}
+		if outcome.WarningKey != "" && len(outcome.Warnings) > 0 {
+			submitWarnings[outcome.WarningKey] = outcome.Warning
+		}
 	}
 
 	targetStatusCode := utils.StatusCodePending
@@ -662,6 +792,17 @@ func SubmitSubmission(c *gin.Context) {
 		if installmentFundName != nil {
 			updates["installment_fund_name_at_submit"] = *installmentFundName
 		}
+		submissionNumber, err := submitSubmissionNumber(tx, submission, resolvedInstallment, getCurrentBEYearStr(), now)
+		if err != nil {
+			return err
+		}
+		if submissionNumber != "" {
+			updates["submission_number"] = submissionNumber
+		}
+		if _, _, ok := submissionFormDocumentCodes(submission.SubmissionType); ok {
+			updates["form_generation_status"] = formGenerationPending
+			updates["form_generation_error"] = gorm.Expr("NULL")
+		}
 
 		if err := tx.Model(&models.Submission{}).
 			Where("submission_id = ?", submission.SubmissionID).
@@ -675,211 +816,280 @@ func SubmitSubmission(c *gin.Context) {
 		if resolvedInstallment != nil {
 			submission.InstallmentNumberAtSubmit = resolvedInstallment
 		}
+		if submissionNumber != "" {
+			submission.SubmissionNumber = submissionNumber
+		}
 		if installmentFundName != nil {
 			submission.InstallmentFundNameAtSubmit = installmentFundName
 		}
 
-		if submission.SubmissionType != "publication_reward" {
-			return nil
-		}
+		return nil
+	}); err != nil {
+		InternalError(c, "submission", err)
+		return
+	}
 
-		applicant := submission.User
-		if applicant == nil {
-			applicant = &models.User{}
-		}
-		if err := tx.Preload("Position").Where("user_id = ?", submission.UserID).First(applicant).Error; err != nil {
-			return fmt.Errorf("failed to load applicant: %w", err)
+	response := gin.H{
+		"success": true,
+		"message": "Submission submitted successfully",
+	}
+	for key, warning := range submitWarnings {
+		response[key] = warning
+	}
+	if _, _, ok := submissionFormDocumentCodes(submission.SubmissionType); ok {
+		// The submission is already committed; the form is generated by the job
+		// queue and its progress is reported through the form status.
+		formGeneration := gin.H{"status": formGenerationPending}
+		if job, err := EnqueueFormGeneration(submission.SubmissionID); err != nil {
+			log.Printf("[SubmitSubmission] failed to enqueue form generation for submission %d: %v", submission.SubmissionID, err)
+			formGeneration["status"] = formGenerationReady
+			if err := runSubmissionFormGeneration(&submission); err != nil {
+				formGeneration = gin.H{
+					"status":  formGenerationFailed,
+					"message": "The request form could not be generated yet; it can be retried from the form status",
+				}
+			}
+		} else {
+			formGeneration["job_id"] = job.JobID
 		}
-		submission.User = applicant
+		response["form_generation"] = formGeneration
+	}
 
-		var detail models.PublicationRewardDetail
-		if err := tx.Preload("ExternalFunds", func(db *gorm.DB) *gorm.DB {
-			return db.Where("publication_reward_external_funds.deleted_at IS NULL OR publication_reward_external_funds.deleted_at = '0000-00-00 00:00:00'").
-				Order("publication_reward_external_funds.external_fund_id ASC")
-		}).
-			Where("submission_id = ? AND (delete_at IS NULL OR delete_at = '0000-00-00 00:00:00')", submission.SubmissionID).
-			First(&detail).Error; err != nil {
-			return fmt.Errorf("failed to load publication reward detail: %w", err)
-		}
+	c.JSON(http.StatusOK, response)
+}
 
-		sysConfig, err := fetchLatestSystemConfig()
-		if err != nil {
-			return fmt.Errorf("failed to load system configuration: %w", err)
-		}
+// generatePublicationRewardForms renders the publication reward request form as
+// DOCX and PDF and attaches both to the submission, replacing any earlier ones.
+func generatePublicationRewardForms(tx *gorm.DB, submission *models.Submission, now time.Time) error {
+	applicant := submission.User
+	if applicant == nil {
+		applicant = &models.User{}
+	}
+	if err := tx.Preload("Position").Where("user_id = ?", submission.UserID).First(applicant).Error; err != nil {
+		return fmt.Errorf("failed to load applicant: %w", err)
+	}
+	submission.User = applicant
 
-		if err := resequenceSubmissionDocumentsByDocumentType(tx, submission.SubmissionID); err != nil {
-			return fmt.Errorf("failed to resequence submission documents: %w", err)
-		}
+	var detail models.PublicationRewardDetail
+	if err := tx.Preload("ExternalFunds", func(db *gorm.DB) *gorm.DB {
+		return db.Where("publication_reward_external_funds.deleted_at IS NULL OR publication_reward_external_funds.deleted_at = '0000-00-00 00:00:00'").
+			Order("publication_reward_external_funds.external_fund_id ASC")
+	}).
+		Where("submission_id = ? AND (delete_at IS NULL OR delete_at = '0000-00-00 00:00:00')", submission.SubmissionID).
+		First(&detail).Error; err != nil {
+		return fmt.Errorf("failed to load publication reward detail: %w", err)
+	}
 
-		documents, err := fetchSubmissionDocuments(tx, submission.SubmissionID)
-		if err != nil {
-			return fmt.Errorf("failed to load submission documents: %w", err)
-		}
+	sysConfig, err := fetchLatestSystemConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load system configuration: %w", err)
+	}
 
-		replacements, err := buildSubmissionPreviewReplacements(&submission, &detail, sysConfig, documents)
-		if err != nil {
-			return err
-		}
+	if err := resequenceSubmissionDocumentsByDocumentType(tx, submission.SubmissionID); err != nil {
+		return fmt.Errorf("failed to resequence submission documents: %w", err)
+	}
 
-		docType, err := ensurePublicationRewardFormDocumentType(tx)
-		if err != nil {
-			return fmt.Errorf("failed to prepare document type: %w", err)
-		}
+	documents, err := fetchSubmissionDocuments(tx, submission.SubmissionID)
+	if err != nil {
+		return fmt.Errorf("failed to load submission documents: %w", err)
+	}
 
-		pdfDocType, err := ensurePublicationRewardFormPdfDocumentType(tx)
-		if err != nil {
-			return fmt.Errorf("failed to prepare pdf document type: %w", err)
-		}
+	replacements, err := buildSubmissionPreviewReplacements(submission, &detail, sysConfig, documents)
+	if err != nil {
+		return err
+	}
 
-		// Re-submitting a returned application regenerates the request-form documents.
-		// Remove the ones a PREVIOUS submit created first, so they are REPLACED rather
-		// than accumulated — otherwise the merge re-includes stale/duplicate form pages.
-		// (The merged PDF is already upserted; this brings the form docx/pdf in line.)
-		if err := deletePreviousGeneratedFormDocuments(tx, submission.SubmissionID, docType.DocumentTypeID, pdfDocType.DocumentTypeID); err != nil {
-			return fmt.Errorf("failed to remove previous generated form documents: %w", err)
-		}
+	docType, err := ensurePublicationRewardFormDocumentType(tx)
+	if err != nil {
+		return fmt.Errorf("failed to prepare document type: %w", err)
+	}
 
-		uploadPath := os.Getenv("UPLOAD_PATH")
-		if uploadPath == "" {
-			uploadPath = "./uploads"
-		}
+	pdfDocType, err := ensurePublicationRewardFormPdfDocumentType(tx)
+	if err != nil {
+		return fmt.Errorf("failed to prepare pdf document type: %w", err)
+	}
 
-		userFolderPath, err := utils.CreateUserFolderIfNotExists(*submission.User, uploadPath)
-		if err != nil {
-			return fmt.Errorf("failed to prepare user directory: %w", err)
-		}
+	return attachGeneratedFormDocuments(tx, submission, documents, generatedFormSpec{
+		TemplatePath: filepath.Join("templates", "publication_reward_template.docx"),
+		FileSuffix:   "publication_reward_form",
+		DocxType:     docType,
+		PdfType:      pdfDocType,
+	}, replacements, now)
+}
 
-		submissionFolderPath, err := utils.CreateSubmissionFolder(userFolderPath, submission.SubmissionType, submission.SubmissionID, submission.SubmissionNumber, submission.CreatedAt)
-		if err != nil {
-			return fmt.Errorf("failed to prepare submission folder: %w", err)
-		}
+// generatedFormSpec describes a request form rendered from a DOCX template on
+// submit and the document types its DOCX and PDF are registered under.
+type generatedFormSpec struct {
+	TemplatePath string
+	FileSuffix   string
+	DocxType     *models.DocumentType
+	PdfType      *models.DocumentType
+}
 
-		baseFilename := "publication_reward_form.docx"
-		if submission.SubmissionNumber != "" {
-			baseFilename = fmt.Sprintf("%s_publication_reward_form.docx", submission.SubmissionNumber)
-		}
-		uniqueFilename := utils.GenerateUniqueFilename(submissionFolderPath, baseFilename)
-		outputPath := filepath.Join(submissionFolderPath, uniqueFilename)
+// attachGeneratedFormDocuments renders form into the submission folder,
+// converts it to PDF and attaches both files, replacing the ones an earlier
+// submit created. documents are the submission's current documents.
+func attachGeneratedFormDocuments(tx *gorm.DB, submission *models.Submission, documents []models.SubmissionDocument, form generatedFormSpec, replacements map[string]string, now time.Time) error {
+	docType, pdfDocType := form.DocxType, form.PdfType
 
-		if err := renderPublicationRewardDocx(outputPath, replacements); err != nil {
-			return err
-		}
+	// Re-submitting a returned application regenerates the request-form documents.
+	// Remove the ones a PREVIOUS submit created first, so they are REPLACED rather
+	// than accumulated — otherwise the merge re-includes stale/duplicate form pages.
+	// (The merged PDF is already upserted; this brings the form docx/pdf in line.)
+	if err := deletePreviousGeneratedFormDocuments(tx, submission.SubmissionID, docType.DocumentTypeID, pdfDocType.DocumentTypeID); err != nil {
+		return fmt.Errorf("failed to remove previous generated form documents: %w", err)
+	}
 
-		stat, err := os.Stat(outputPath)
-		if err != nil {
-			os.Remove(outputPath)
-			return fmt.Errorf("failed to stat generated docx: %w", err)
-		}
+	uploadPath := os.Getenv("UPLOAD_PATH")
+	if uploadPath == "" {
+		uploadPath = "./uploads"
+	}
 
-		fileUpload := models.FileUpload{
-			OriginalName: uniqueFilename,
-			StoredPath:   outputPath,
-			FolderType:   "submission",
-			FileSize:     stat.Size(),
-			MimeType:     "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
-			FileHash:     "",
-			IsPublic:     false,
-			UploadedBy:   submission.UserID,
-			UploadedAt:   now,
-			CreateAt:     now,
-			UpdateAt:     now,
-		}
+	userFolderPath, err := utils.CreateUserFolderIfNotExists(*submission.User, uploadPath)
+	if err != nil {
+		return fmt.Errorf("failed to prepare user directory: %w", err)
+	}
 
-		if err := createFileUploadRecord(tx, &fileUpload); err != nil {
-			os.Remove(outputPath)
-			return fmt.Errorf("failed to persist generated docx: %w", err)
-		}
+	submissionFolderPath, err := utils.CreateSubmissionFolder(userFolderPath, submission.SubmissionType, submission.SubmissionID, submission.SubmissionNumber, submission.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to prepare submission folder: %w", err)
+	}
 
-		displayOrder := nextDocumentDisplayOrder(documents)
-		submissionDocument := models.SubmissionDocument{
-			SubmissionID:   submission.SubmissionID,
-			FileID:         fileUpload.FileID,
-			OriginalName:   fileUpload.OriginalName,
-			DocumentTypeID: docType.DocumentTypeID,
-			DisplayOrder:   displayOrder,
-			IsRequired:     false,
-			IsVerified:     false,
-			CreatedAt:      now,
-		}
+	baseFilename := form.FileSuffix + ".docx"
+	if submission.SubmissionNumber != "" {
+		baseFilename = fmt.Sprintf("%s_%s.docx", submission.SubmissionNumber, form.FileSuffix)
+	}
+	store := storage.Default()
+	ctx := context.Background()
+	uniqueFilename, err := uniqueStorageFilename(ctx, store, submissionFolderPath, baseFilename)
+	if err != nil {
+		return fmt.Errorf("failed to name generated docx: %w", err)
+	}
 
-		if err := createSubmissionDocumentRecord(tx, &submissionDocument); err != nil {
-			os.Remove(outputPath)
-			return fmt.Errorf("failed to register generated docx: %w", err)
-		}
+	verificationQR, err := submissionVerificationQRImage(submission)
+	if err != nil {
+		return fmt.Errorf("failed to build verification qr code: %w", err)
+	}
 
-		documents = append(documents, submissionDocument)
+	// The template renderer and LibreOffice work on files, so the form is
+	// rendered in a work directory and then stored.
+	workDir, err := os.MkdirTemp("", "generated-form-")
+	if err != nil {
+		return fmt.Errorf("failed to prepare work directory: %w", err)
+	}
+	defer os.RemoveAll(workDir)
 
-		pdfData, err := convertDocxToPDFBytes(outputPath)
-		if err != nil {
-			return fmt.Errorf("failed to generate pdf: %w", err)
-		}
+	outputPath := filepath.Join(workDir, uniqueFilename)
+	if err := renderDocxTemplate(form.TemplatePath, outputPath, replacements, verificationQR); err != nil {
+		return err
+	}
 
-		pdfBaseFilename := strings.TrimSuffix(uniqueFilename, filepath.Ext(uniqueFilename)) + ".pdf"
-		pdfFilename := utils.GenerateUniqueFilename(submissionFolderPath, pdfBaseFilename)
-		pdfOutputPath := filepath.Join(submissionFolderPath, pdfFilename)
+	stat, err := os.Stat(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to stat generated docx: %w", err)
+	}
+	docxKey := storageKey(store, filepath.Join(submissionFolderPath, uniqueFilename))
+	if err := saveFileToStorage(ctx, store, outputPath, docxKey, docxContentType); err != nil {
+		return fmt.Errorf("failed to store generated docx: %w", err)
+	}
 
-		if err := os.WriteFile(pdfOutputPath, pdfData, 0o644); err != nil {
-			return fmt.Errorf("failed to write generated pdf: %w", err)
-		}
+	fileUpload := models.FileUpload{
+		OriginalName: uniqueFilename,
+		StoredPath:   docxKey,
+		FolderType:   "submission",
+		FileSize:     stat.Size(),
+		MimeType:     docxContentType,
+		FileHash:     "",
+		IsPublic:     false,
+		UploadedBy:   submission.UserID,
+		UploadedAt:   now,
+		CreateAt:     now,
+		UpdateAt:     now,
+	}
 
-		pdfStat, err := os.Stat(pdfOutputPath)
-		if err != nil {
-			os.Remove(pdfOutputPath)
-			return fmt.Errorf("failed to stat generated pdf: %w", err)
-		}
+	if err := createFileUploadRecord(tx, &fileUpload); err != nil {
+		_ = store.Delete(ctx, docxKey)
+		return fmt.Errorf("failed to persist generated docx: %w", err)
+	}
 
-		pdfFileUpload := models.FileUpload{
-			OriginalName: pdfFilename,
-			StoredPath:   pdfOutputPath,
-			FolderType:   "submission",
-			FileSize:     pdfStat.Size(),
-			MimeType:     "application/pdf",
-			FileHash:     "",
-			IsPublic:     false,
-			UploadedBy:   submission.UserID,
-			UploadedAt:   now,
-			CreateAt:     now,
-			UpdateAt:     now,
-		}
+	displayOrder := nextDocumentDisplayOrder(documents)
+	submissionDocument := models.SubmissionDocument{
+		SubmissionID:   submission.SubmissionID,
+		FileID:         fileUpload.FileID,
+		OriginalName:   fileUpload.OriginalName,
+		DocumentTypeID: docType.DocumentTypeID,
+		DisplayOrder:   displayOrder,
+		IsRequired:     false,
+		IsVerified:     false,
+		CreatedAt:      now,
+	}
 
-		if err := createFileUploadRecord(tx, &pdfFileUpload); err != nil {
-			os.Remove(pdfOutputPath)
-			return fmt.Errorf("failed to persist generated pdf: %w", err)
-		}
+	if err := createSubmissionDocumentRecord(tx, &submissionDocument); err != nil {
+		_ = store.Delete(ctx, docxKey)
+		return fmt.Errorf("failed to register generated docx: %w", err)
+	}
 
-		pdfDisplayOrder := nextDocumentDisplayOrder(documents)
-		pdfSubmissionDocument := models.SubmissionDocument{
-			SubmissionID:   submission.SubmissionID,
-			FileID:         pdfFileUpload.FileID,
-			OriginalName:   pdfFileUpload.OriginalName,
-			DocumentTypeID: pdfDocType.DocumentTypeID,
-			DisplayOrder:   pdfDisplayOrder,
-			IsRequired:     false,
-			IsVerified:     false,
-			CreatedAt:      now,
-		}
+	documents = append(documents, submissionDocument)
 
-		if err := createSubmissionDocumentRecord(tx, &pdfSubmissionDocument); err != nil {
-			os.Remove(pdfOutputPath)
-			return fmt.Errorf("failed to register generated pdf: %w", err)
-		}
+	pdfData, err := convertDocxToPDFBytes(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to generate pdf: %w", err)
+	}
+	pdfData = watermarkPDFBytes(pdfData, submissionWatermarkText(submission))
 
-		documents = append(documents, pdfSubmissionDocument)
+	pdfBaseFilename := strings.TrimSuffix(uniqueFilename, filepath.Ext(uniqueFilename)) + ".pdf"
+	pdfFilename, err := uniqueStorageFilename(ctx, store, submissionFolderPath, pdfBaseFilename)
+	if err != nil {
+		return fmt.Errorf("failed to name generated pdf: %w", err)
+	}
+	pdfKey, err := saveBytesToStorage(ctx, store, filepath.Join(submissionFolderPath, pdfFilename), pdfData, "application/pdf")
+	if err != nil {
+		return fmt.Errorf("failed to write generated pdf: %w", err)
+	}
 
-		if err := resequenceSubmissionDocumentsByDocumentType(tx, submission.SubmissionID); err != nil {
-			return fmt.Errorf("failed to resequence submission documents: %w", err)
-		}
+	pdfFileUpload := models.FileUpload{
+		OriginalName: pdfFilename,
+		StoredPath:   pdfKey,
+		FolderType:   "submission",
+		FileSize:     int64(len(pdfData)),
+		MimeType:     "application/pdf",
+		FileHash:     "",
+		IsPublic:     false,
+		UploadedBy:   submission.UserID,
+		UploadedAt:   now,
+		CreateAt:     now,
+		UpdateAt:     now,
+	}
 
-		return nil
-	}); err != nil {
-		InternalError(c, "submission", err)
-		return
+	if err := createFileUploadRecord(tx, &pdfFileUpload); err != nil {
+		_ = store.Delete(ctx, pdfKey)
+		return fmt.Errorf("failed to persist generated pdf: %w", err)
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"message": "Submission submitted successfully",
-	})
+	pdfDisplayOrder := nextDocumentDisplayOrder(documents)
+	pdfSubmissionDocument := models.SubmissionDocument{
+		SubmissionID:   submission.SubmissionID,
+		FileID:         pdfFileUpload.FileID,
+		OriginalName:   pdfFileUpload.OriginalName,
+		DocumentTypeID: pdfDocType.DocumentTypeID,
+		DisplayOrder:   pdfDisplayOrder,
+		IsRequired:     false,
+		IsVerified:     false,
+		CreatedAt:      now,
+	}
+
+	if err := createSubmissionDocumentRecord(tx, &pdfSubmissionDocument); err != nil {
+		_ = store.Delete(ctx, pdfKey)
+		return fmt.Errorf("failed to register generated pdf: %w", err)
+	}
+
+	documents = append(documents, pdfSubmissionDocument)
+
+	if err := resequenceSubmissionDocumentsByDocumentType(tx, submission.SubmissionID); err != nil {
+		return fmt.Errorf("failed to resequence submission documents: %w", err)
+	}
+
+	return nil
 }
 
 type installmentFundSelection struct {
@@ -1031,6 +1241,21 @@ func matchesFundKeyword(periodKeyword string, selectionKeyword string) bool {
 }
 
 func resolveInstallmentNumberFromPeriods(db *gorm.DB, yearID int, submissionTime time.Time, selection *installmentFundSelection) (*int, error) {
+	active, err := loadActiveInstallmentPeriods(db, yearID, selection)
+	if err != nil {
+		return nil, err
+	}
+	if len(active) == 0 {
+		return nil, nil
+	}
+
+	return selectInstallmentNumber(active, submissionTime), nil
+}
+
+// loadActiveInstallmentPeriods finds the installment periods configured for the
+// fund selection (falling back to looser keyword matches, then to every period
+// of the year) and keeps the active ones, ordered by cutoff.
+func loadActiveInstallmentPeriods(db *gorm.DB, yearID int, selection *installmentFundSelection) ([]models.FundInstallmentPeriod, error) {
 	if db == nil {
 		db = config.DB
 	}
@@ -1094,9 +1319,6 @@ func resolveInstallmentNumberFromPeriods(db *gorm.DB, yearID int, submissionTime
 			return nil, err
 		}
 	}
-	if len(periods) == 0 {
-		return nil, nil
-	}
 
 	active := make([]models.FundInstallmentPeriod, 0, len(periods))
 	for _, period := range periods {
@@ -1104,28 +1326,38 @@ func resolveInstallmentNumberFromPeriods(db *gorm.DB, yearID int, submissionTime
 			active = append(active, period)
 		}
 	}
+	return active, nil
+}
 
-	if len(active) == 0 {
-		return nil, nil
+// selectInstallmentNumber picks the first period (ordered by cutoff) whose cutoff
+// plus grace_days (end of day in the application timezone) has not passed at
+// submissionTime, falling back to the last period.
+func selectInstallmentNumber(periods []models.FundInstallmentPeriod, submissionTime time.Time) *int {
+	if len(periods) == 0 {
+		return nil
 	}
 
-	candidates := active
-
-	submissionUTC := submissionTime.UTC()
-
-	for _, period := range candidates {
+	for _, period := range periods {
 		if period.CutoffDate.IsZero() {
 			continue
 		}
-		cutoff := endOfDayUTC(period.CutoffDate)
-		if !submissionUTC.After(cutoff) {
+		cutoff := utils.EndOfDayInAppLocation(installmentEffectiveCutoff(period))
+		if !submissionTime.After(cutoff) {
 			value := period.InstallmentNumber
-			return &value, nil
+			return &value
 		}
 	}
 
-	last := candidates[len(candidates)-1].InstallmentNumber
-	return &last, nil
+	last := periods[len(periods)-1].InstallmentNumber
+	return &last
+}
+
+// installmentEffectiveCutoff returns the cutoff date extended by the period's grace days.
+func installmentEffectiveCutoff(period models.FundInstallmentPeriod) time.Time {
+	if period.GraceDays <= 0 || period.CutoffDate.IsZero() {
+		return period.CutoffDate
+	}
+	return period.CutoffDate.AddDate(0, 0, period.GraceDays)
 }
 
 func isInstallmentPeriodActive(status *string) bool {
@@ -1146,15 +1378,6 @@ func isInstallmentPeriodActive(status *string) bool {
 	}
 }
 
-func endOfDayUTC(t time.Time) time.Time {
-	if t.IsZero() {
-		return t
-	}
-
-	year, month, day := t.In(time.UTC).Date()
-	return time.Date(year, month, day, 23, 59, 59, 999999999, time.UTC)
-}
-
 // MergeSubmissionDocuments collects every PDF document attached to a submission, merges them
 // into a single file and stores the result under uploads/merge_submissions/{current_year}.
 func MergeSubmissionDocuments(c *gin.Context) {
@@ -1223,65 +1446,116 @@ func MergeSubmissionDocuments(c *gin.Context) {
 	if uploadRoot == "" {
 		uploadRoot = "./uploads"
 	}
+	store := storage.Default()
+	ctx := c.Request.Context()
 
-	pdfPaths := make([]string, 0, len(documents))
-	for _, doc := range documents {
+	workDir, err := os.MkdirTemp("", "submission-merge-")
+	if err != nil {
+		log.Printf("[MergeSubmissionDocuments] failed to create work directory: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to prepare merge directory"})
+		return
+	}
+	defer os.RemoveAll(workDir)
+
+	// Documents keep their display order; DOCX and images are converted to PDF
+	// first. A document that cannot be used is skipped, not fatal.
+	type skippedMergeDocument struct {
+		DocumentID int    `json:"document_id"`
+		Name       string `json:"name"`
+		Reason     string `json:"reason"`
+	}
+	skipped := make([]skippedMergeDocument, 0)
+	skip := func(doc models.SubmissionDocument, reason string) {
+		log.Printf("[MergeSubmissionDocuments] skipping document %d: %s", doc.DocumentID, reason)
+		name := strings.TrimSpace(doc.OriginalName)
+		if name == "" {
+			name = doc.File.OriginalName
+		}
+		skipped = append(skipped, skippedMergeDocument{DocumentID: doc.DocumentID, Name: name, Reason: reason})
+	}
+
+	inputs := make([]mergeInput, 0, len(documents))
+	convertedCount := 0
+	redundantFormDocx := redundantFormDocxIDs(documents)
+	for index, doc := range documents {
 		if doc.DocumentTypeID == mergedDocumentTypeID {
 			log.Printf("[MergeSubmissionDocuments] skipping document %d: merged pdf placeholder", doc.DocumentID)
 			continue
 		}
+		if redundantFormDocx[doc.DocumentID] {
+			log.Printf("[MergeSubmissionDocuments] skipping document %d: generated form docx with pdf attached", doc.DocumentID)
+			continue
+		}
 
 		file := doc.File
 		log.Printf("[MergeSubmissionDocuments] inspecting document %d (file_id=%d) for submission %d", doc.DocumentID, file.FileID, submission.SubmissionID)
 		if file.FileID == 0 {
-			log.Printf("[MergeSubmissionDocuments] skipping document %d: missing file record", doc.DocumentID)
+			skip(doc, "missing file record")
 			continue
 		}
 
 		storedPath := strings.TrimSpace(file.StoredPath)
 		if storedPath == "" {
-			log.Printf("[MergeSubmissionDocuments] skipping document %d: empty stored path", doc.DocumentID)
+			skip(doc, "empty stored path")
 			continue
 		}
 
-		mimeType := strings.ToLower(strings.TrimSpace(file.MimeType))
-		ext := strings.ToLower(filepath.Ext(storedPath))
-		originalExt := strings.ToLower(filepath.Ext(file.OriginalName))
-		log.Printf("[MergeSubmissionDocuments] document %d mime=%q stored_ext=%q original_ext=%q", doc.DocumentID, mimeType, ext, originalExt)
-
-		if mimeType != "application/pdf" && ext != ".pdf" && originalExt != ".pdf" {
-			log.Printf("[MergeSubmissionDocuments] skipping document %d: not a pdf", doc.DocumentID)
+		kind := mergeInputKind(file)
+		log.Printf("[MergeSubmissionDocuments] document %d mime=%q kind=%q", doc.DocumentID, file.MimeType, kind)
+		if kind == "" {
+			skip(doc, "unsupported file type")
 			continue
 		}
 
-		resolvedPath := resolveStoredFilePath(storedPath, uploadRoot)
+		var resolvedPath string
+		if storage.IsLocal(store) {
+			resolvedPath = resolveStoredFilePath(storedPath, uploadRoot)
+		} else if path, err := localCopyOfStoredFile(ctx, store, storedPath, workDir); err == nil {
+			resolvedPath = path
+		}
 		if resolvedPath == "" {
-			log.Printf("[MergeSubmissionDocuments] skipping document %d: could not resolve stored path %q", doc.DocumentID, storedPath)
+			skip(doc, "file not found")
 			continue
 		}
 
-		log.Printf("[MergeSubmissionDocuments] submission %d resolved pdf path %s", submission.SubmissionID, resolvedPath)
-		pdfPaths = append(pdfPaths, resolvedPath)
+		pdfPath, err := prepareMergeInput(kind, resolvedPath, workDir, index)
+		if err != nil {
+			skip(doc, fmt.Sprintf("conversion failed: %v", err))
+			continue
+		}
+		if kind != mergeInputPDF {
+			convertedCount++
+		}
+
+		log.Printf("[MergeSubmissionDocuments] submission %d resolved pdf path %s", submission.SubmissionID, pdfPath)
+		inputs = append(inputs, mergeInput{Document: doc, Path: pdfPath})
 	}
+	pdfPaths := orderedMergePaths(inputs)
 
 	if len(pdfPaths) == 0 {
-		log.Printf("[MergeSubmissionDocuments] submission %d has no PDF documents", submission.SubmissionID)
+		log.Printf("[MergeSubmissionDocuments] submission %d has no documents to merge", submission.SubmissionID)
 		c.JSON(http.StatusOK, gin.H{
-			"success":       true,
-			"merged_file":   nil,
-			"message":       "No PDF documents available to merge",
-			"pdf_documents": 0,
+			"success":             true,
+			"merged_file":         nil,
+			"message":             "No documents available to merge",
+			"pdf_documents":       0,
+			"included_documents":  0,
+			"converted_documents": 0,
+			"skipped_documents":   len(skipped),
+			"skipped":             skipped,
 		})
 		return
 	}
 
 	currentYear := getCurrentBEYearStr()
 	mergeDir := filepath.Join(uploadRoot, "merge_submissions", currentYear)
-	log.Printf("[MergeSubmissionDocuments] preparing merge output directory %s", mergeDir)
-	if err := os.MkdirAll(mergeDir, 0o755); err != nil {
-		log.Printf("[MergeSubmissionDocuments] failed to create merge directory %s: %v", mergeDir, err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to prepare merge directory"})
-		return
+	if storage.IsLocal(store) {
+		log.Printf("[MergeSubmissionDocuments] preparing merge output directory %s", mergeDir)
+		if err := os.MkdirAll(mergeDir, 0o755); err != nil {
+			log.Printf("[MergeSubmissionDocuments] failed to create merge directory %s: %v", mergeDir, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to prepare merge directory"})
+			return
+		}
 	}
 
 	baseName := strings.TrimSpace(submission.SubmissionNumber)
@@ -1294,8 +1568,19 @@ func MergeSubmissionDocuments(c *gin.Context) {
 	}
 
 	desiredFilename := fmt.Sprintf("%s_merged_document.pdf", baseName)
-	safeFilename := utils.GenerateUniqueFilename(mergeDir, desiredFilename)
+	safeFilename, err := uniqueStorageFilename(ctx, store, mergeDir, desiredFilename)
+	if err != nil {
+		InternalError(c, "submission: merge file name", err)
+		return
+	}
+	mergedKey := storageKey(store, filepath.Join(mergeDir, safeFilename))
+
+	// pdfunite writes to disk, so other backends merge inside workDir and
+	// upload the result.
 	outputPath := filepath.Join(mergeDir, safeFilename)
+	if !storage.IsLocal(store) {
+		outputPath = filepath.Join(workDir, safeFilename)
+	}
 
 	log.Printf("[MergeSubmissionDocuments] merging %d pdf(s) into %s", len(pdfPaths), outputPath)
 	if err := mergePDFs(pdfPaths, outputPath); err != nil {
@@ -1304,6 +1589,10 @@ func MergeSubmissionDocuments(c *gin.Context) {
 		return
 	}
 
+	if err := watermarkPDFFile(outputPath, submissionWatermarkText(&submission)); err != nil {
+		log.Printf("[MergeSubmissionDocuments] watermark skipped for submission %d: %v", submission.SubmissionID, err)
+	}
+
 	info, err := os.Stat(outputPath)
 	if err != nil {
 		os.Remove(outputPath)
@@ -1311,11 +1600,18 @@ func MergeSubmissionDocuments(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to access merged file"})
 		return
 	}
+	if !storage.IsLocal(store) {
+		if err := saveFileToStorage(ctx, store, outputPath, mergedKey, "application/pdf"); err != nil {
+			InternalError(c, "submission: store merged PDF", err)
+			return
+		}
+	}
+	removeMerged := func() { _ = store.Delete(ctx, mergedKey) }
 
 	now := time.Now()
 	fileRecord := models.FileUpload{
 		OriginalName: safeFilename,
-		StoredPath:   outputPath,
+		StoredPath:   mergedKey,
 		FolderType:   "submission",
 		FileSize:     info.Size(),
 		MimeType:     "application/pdf",
@@ -1326,7 +1622,7 @@ func MergeSubmissionDocuments(c *gin.Context) {
 	}
 
 	if err := createFileUploadRecord(config.DB, &fileRecord); err != nil {
-		os.Remove(outputPath)
+		removeMerged()
 		log.Printf("[MergeSubmissionDocuments] failed to persist file record for submission %d: %v", submission.SubmissionID, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to persist merged document"})
 		return
@@ -1337,7 +1633,7 @@ func MergeSubmissionDocuments(c *gin.Context) {
 		Where("submission_id = ? AND document_type_id = ?", submission.SubmissionID, mergedDocumentTypeID).
 		First(&mergedDocument).Error; err != nil {
 		if !errors.Is(err, gorm.ErrRecordNotFound) {
-			os.Remove(outputPath)
+			removeMerged()
 			log.Printf("[MergeSubmissionDocuments] failed to load existing merged document for submission %d: %v", submission.SubmissionID, err)
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to persist merged document"})
 			return
@@ -1359,7 +1655,7 @@ func MergeSubmissionDocuments(c *gin.Context) {
 		}
 
 		if err := createSubmissionDocumentRecord(config.DB, &mergedDocument); err != nil {
-			os.Remove(outputPath)
+			removeMerged()
 			log.Printf("[MergeSubmissionDocuments] failed to register merged document for submission %d: %v", submission.SubmissionID, err)
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to persist merged document"})
 			return
@@ -1382,7 +1678,7 @@ func MergeSubmissionDocuments(c *gin.Context) {
 		}
 
 		if err := saveSubmissionDocumentRecord(config.DB, &mergedDocument); err != nil {
-			os.Remove(outputPath)
+			removeMerged()
 			log.Printf("[MergeSubmissionDocuments] failed to update merged document for submission %d: %v", submission.SubmissionID, err)
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to persist merged document"})
 			return
@@ -1394,7 +1690,7 @@ func MergeSubmissionDocuments(c *gin.Context) {
 			}
 
 			if previousFilePath != "" {
-				if err := os.Remove(previousFilePath); err != nil && !os.IsNotExist(err) {
+				if err := store.Delete(ctx, previousFilePath); err != nil {
 					log.Printf("[MergeSubmissionDocuments] failed to remove previous merged file %s: %v", previousFilePath, err)
 				}
 			}
@@ -1402,7 +1698,7 @@ func MergeSubmissionDocuments(c *gin.Context) {
 	}
 
 	cleanedRoot := filepath.Clean(uploadRoot)
-	relativePath := filepath.ToSlash(outputPath)
+	relativePath := filepath.ToSlash(filepath.Join(mergeDir, safeFilename))
 	if trimmed := strings.TrimPrefix(relativePath, cleanedRoot+"/"); trimmed != relativePath {
 		relativePath = filepath.ToSlash(filepath.Join(filepath.Base(cleanedRoot), trimmed))
 	}
@@ -1418,6 +1714,10 @@ func MergeSubmissionDocuments(c *gin.Context) {
 			"relative_path": relativePath,
 			"size":          fileRecord.FileSize,
 		},
+		"included_documents":  len(pdfPaths),
+		"converted_documents": convertedCount,
+		"skipped_documents":   len(skipped),
+		"skipped":             skipped,
 	})
 }
 
@@ -1515,7 +1815,7 @@ func deletePreviousGeneratedFormDocuments(tx *gorm.DB, submissionID int, typeIDs
 				log.Printf("[SubmitSubmission] failed to delete previous form file record %d: %v", fileID, err)
 			}
 			if filePath != "" {
-				if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+				if err := storage.Default().Delete(context.Background(), filePath); err != nil {
 					log.Printf("[SubmitSubmission] failed to remove previous form file %s: %v", filePath, err)
 				}
 			}
@@ -1526,47 +1826,28 @@ func deletePreviousGeneratedFormDocuments(tx *gorm.DB, submissionID int, typeIDs
 }
 
 func ensurePublicationRewardFormDocumentType(tx *gorm.DB) (*models.DocumentType, error) {
-	var docType models.DocumentType
-	if err := tx.Where("code = ? AND (delete_at IS NULL OR delete_at = '0000-00-00 00:00:00')", publicationRewardFormDocumentCode).
-		First(&docType).Error; err != nil {
-		if !errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, err
-		}
-
-		now := time.Now()
-		fundTypes := "[\"publication_reward\"]"
-		docType = models.DocumentType{
-			DocumentTypeName: "แบบฟอร์มคำขอรับเงินรางวัล (DOCX)",
-			Code:             publicationRewardFormDocumentCode,
-			Required:         false,
-			Multiple:         false,
-			DocumentOrder:    0,
-			CreateAt:         now,
-			UpdateAt:         now,
-			FundTypes:        &fundTypes,
-		}
-
-		if err := tx.Create(&docType).Error; err != nil {
-			return nil, err
-		}
-	}
-
-	return &docType, nil
+	return ensureGeneratedFormDocumentType(tx, publicationRewardFormDocumentCode, "แบบฟอร์มคำขอรับเงินรางวัล (DOCX)", "publication_reward")
 }
 
 func ensurePublicationRewardFormPdfDocumentType(tx *gorm.DB) (*models.DocumentType, error) {
+	return ensureGeneratedFormDocumentType(tx, publicationRewardFormPdfDocumentCode, "แบบฟอร์มคำขอรับเงินรางวัล (PDF)", "publication_reward")
+}
+
+// ensureGeneratedFormDocumentType returns the document type a generated form is
+// registered under, creating it on first use.
+func ensureGeneratedFormDocumentType(tx *gorm.DB, code, name, fundType string) (*models.DocumentType, error) {
 	var docType models.DocumentType
-	if err := tx.Where("code = ? AND (delete_at IS NULL OR delete_at = '0000-00-00 00:00:00')", publicationRewardFormPdfDocumentCode).
+	if err := tx.Where("code = ? AND (delete_at IS NULL OR delete_at = '0000-00-00 00:00:00')", code).
 		First(&docType).Error; err != nil {
 		if !errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err
 		}
 
 		now := time.Now()
-		fundTypes := "[\"publication_reward\"]"
+		fundTypes := fmt.Sprintf("[%q]", fundType)
 		docType = models.DocumentType{
-			DocumentTypeName: "แบบฟอร์มคำขอรับเงินรางวัล (PDF)",
-			Code:             publicationRewardFormPdfDocumentCode,
+			DocumentTypeName: name,
+			Code:             code,
 			Required:         false,
 			Multiple:         false,
 			DocumentOrder:    0,
@@ -1583,71 +1864,10 @@ func ensurePublicationRewardFormPdfDocumentType(tx *gorm.DB) (*models.DocumentTy
 	return &docType, nil
 }
 
+// convertDocxToPDFBytes converts a generated DOCX through the shared
+// LibreOffice conversion pool.
 func convertDocxToPDFBytes(docxPath string) ([]byte, error) {
-	trimmed := strings.TrimSpace(docxPath)
-	if trimmed == "" {
-		return nil, fmt.Errorf("docx path is required")
-	}
-
-	info, err := os.Stat(trimmed)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("docx file not found")
-		}
-		return nil, fmt.Errorf("failed to access docx: %w", err)
-	}
-	if info.IsDir() {
-		return nil, fmt.Errorf("docx path points to a directory")
-	}
-
-	tmpDir, err := os.MkdirTemp("", "publication-form-pdf-")
-	if err != nil {
-		return nil, fmt.Errorf("failed to create temp directory: %w", err)
-	}
-	defer os.RemoveAll(tmpDir)
-
-	fontEnv, err := configureLibreOfficeFonts(tmpDir)
-	if err != nil {
-		return nil, err
-	}
-
-	converter, err := lookupLibreOfficeBinary()
-	if err != nil {
-		return nil, err
-	}
-
-	profileDir := filepath.Join(tmpDir, "lo-profile")
-	if err := os.MkdirAll(profileDir, 0o700); err != nil {
-		return nil, fmt.Errorf("failed to prepare libreoffice profile: %w", err)
-	}
-
-	profileURL, err := fileURLFromPath(profileDir)
-	if err != nil {
-		return nil, fmt.Errorf("failed to prepare libreoffice profile: %w", err)
-	}
-
-	profileArg := fmt.Sprintf("-env:UserInstallation=%s", profileURL)
-	filterArg := "pdf:writer_pdf_Export:EmbedStandardFonts=true;EmbedFonts=true"
-	args := []string{profileArg, "--headless", "--convert-to", filterArg, "--outdir", tmpDir, trimmed}
-	cmd := exec.Command(converter, args...)
-	env := append([]string{}, os.Environ()...)
-	if len(fontEnv) > 0 {
-		env = append(env, fontEnv...)
-	}
-	cmd.Env = env
-
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return nil, fmt.Errorf("failed to convert docx to pdf: %v", strings.TrimSpace(string(output)))
-	}
-
-	pdfName := strings.TrimSuffix(filepath.Base(trimmed), filepath.Ext(trimmed)) + ".pdf"
-	outputPDF := filepath.Join(tmpDir, pdfName)
-	data, err := os.ReadFile(outputPDF)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read generated pdf: %w", err)
-	}
-
-	return data, nil
+	return services.ConvertDocxToPDF(context.Background(), docxPath)
 }
 
 func buildSubmissionPreviewReplacements(submission *models.Submission, detail *models.PublicationRewardDetail, sysConfig *systemConfigSnapshot, documents []models.SubmissionDocument) (map[string]string, error) {
@@ -1714,7 +1934,8 @@ func buildSubmissionPreviewReplacements(submission *models.Submission, detail *m
 	return replacements, nil
 }
 
-func renderPublicationRewardDocx(outputPath string, replacements map[string]string) error {
+// renderDocxTemplate fills the DOCX template at templatePath into outputPath.
+func renderDocxTemplate(templatePath, outputPath string, replacements map[string]string, images ...docxImage) error {
 	if strings.TrimSpace(outputPath) == "" {
 		return fmt.Errorf("output path is required")
 	}
@@ -1722,7 +1943,6 @@ func renderPublicationRewardDocx(outputPath string, replacements map[string]stri
 		return fmt.Errorf("replacement data is required")
 	}
 
-	templatePath := filepath.Join("templates", "publication_reward_template.docx")
 	if _, err := os.Stat(templatePath); err != nil {
 		if os.IsNotExist(err) {
 			return fmt.Errorf("template file not found")
@@ -1734,7 +1954,7 @@ func renderPublicationRewardDocx(outputPath string, replacements map[string]stri
 		return fmt.Errorf("failed to prepare output directory: %w", err)
 	}
 
-	if err := fillDocxTemplate(templatePath, outputPath, replacements); err != nil {
+	if err := fillDocxTemplate(templatePath, outputPath, replacements, images...); err != nil {
 		return err
 	}
 	return nil
@@ -1749,23 +1969,61 @@ func UploadFile(c *gin.Context) {
 	// Get uploaded file
 	file, err := c.FormFile("file")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
+		return
+	}
+
+	// Validate file size against the document type's limit (or MAX_UPLOAD_SIZE_MB)
+	documentTypeID := 0
+	if raw := strings.TrimSpace(c.PostForm("document_type_id")); raw != "" {
+		documentTypeID, err = strconv.Atoi(raw)
+		if err != nil || documentTypeID <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid document_type_id"})
+			return
+		}
+	}
+	limitMB, err := resolveUploadSizeLimitMB(config.DB, documentTypeID)
+	if err != nil {
+		InternalError(c, "upload file: size limit", err)
 		return
 	}
-
-	// Validate file size (10MB limit)
-	maxSize := int64(10 * 1024 * 1024)
-	if file.Size > maxSize {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "File size exceeds 10MB limit"})
+	if file.Size > int64(limitMB)*1024*1024 {
+		respondUploadTooLarge(c, limitMB)
 		return
 	}
 
-	// Validate file type
-	if !isValidFileType(file) {
+	// Validate file type from its content; the Content-Type header alone is client-controlled
+	mimeType, ok := detectUploadFileType(file)
+	if !ok {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "File type not allowed"})
 		return
 	}
 
+	fileHash, err := generateFileHash(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read uploaded file"})
+		return
+	}
+
+	// Dedup reuses only files still waiting in temp; attached files belong to
+	// their submission and would be moved away if attached again.
+	if uploadDedupEnabled(c) {
+		existing, err := findUserFileByHash(config.DB, userID.(int), fileHash, "temp")
+		if err != nil {
+			InternalError(c, "upload file: dedup lookup", err)
+			return
+		}
+		if existing != nil {
+			c.JSON(http.StatusOK, gin.H{
+				"success":      true,
+				"message":      "Identical file already uploaded",
+				"file":         existing,
+				"deduplicated": true,
+			})
+			return
+		}
+	}
+
 	// Get user info for folder creation
 	var user models.User
 	if err := config.DB.First(&user, userID).Error; err != nil {
@@ -1790,11 +2048,25 @@ func UploadFile(c *gin.Context) {
 	tempFolderPath := filepath.Join(userFolderPath, "temp")
 
 	// Generate unique filename in temp directory
-	safeFilename := utils.GenerateUniqueFilename(tempFolderPath, file.Filename)
-	storedPath := filepath.Join(tempFolderPath, safeFilename)
+	store := storage.Default()
+	ctx := c.Request.Context()
+	safeFilename, err := uniqueStorageFilename(ctx, store, tempFolderPath, file.Filename)
+	if err != nil {
+		InternalError(c, "upload file: unique name", err)
+		return
+	}
+	storedPath := storageKey(store, filepath.Join(tempFolderPath, safeFilename))
 
 	// Save file
-	if err := c.SaveUploadedFile(file, storedPath); err != nil {
+	src, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read uploaded file"})
+		return
+	}
+	err = store.Save(ctx, storedPath, src, file.Size, mimeType)
+	src.Close()
+	if err != nil {
+		log.Printf("[UploadFile] failed to store %s: %v", storedPath, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
 		return
 	}
@@ -1806,8 +2078,8 @@ func UploadFile(c *gin.Context) {
 		StoredPath:   storedPath,
 		FolderType:   "temp",
 		FileSize:     file.Size,
-		MimeType:     file.Header.Get("Content-Type"),
-		FileHash:     "", // ไม่ใช้ hash ในระบบ user-based
+		MimeType:     mimeType,
+		FileHash:     fileHash,
 		IsPublic:     false,
 		UploadedBy:   userID.(int),
 		UploadedAt:   now,
@@ -1817,7 +2089,7 @@ func UploadFile(c *gin.Context) {
 
 	if err := createFileUploadRecord(config.DB, &fileUpload); err != nil {
 		// Delete uploaded file if database save fails
-		os.Remove(storedPath)
+		_ = store.Delete(ctx, storedPath)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file info"})
 		return
 	}
@@ -1863,19 +2135,20 @@ func MoveFileToSubmissionFolder(fileID int, submissionID int, submissionType str
 		return err
 	}
 
-	// ===== ตั้งชื่อไฟล์ใหม่: <original-name>_<submission-number><ext>
-	orig := fileUpload.OriginalName
-	ext := filepath.Ext(orig)
-	base := strings.TrimSuffix(orig, ext)
+	// ===== ตั้งชื่อไฟล์ใหม่: <original-name>_<submission-number><ext> (normalized ตาม FILENAME_NORMALIZATION)
+	desiredName := submissionFileName(fileUpload.OriginalName, submission.SubmissionNumber, filenameNormalizationMode())
 
-	desiredName := fmt.Sprintf("%s_%s%s", base, submission.SubmissionNumber, ext)
-
-	// ให้ utils.GenerateUniqueFilename ช่วยกันชื่อซ้ำ (ส่ง desiredName เข้าไปให้เป็น "ต้นฉบับ")
-	newFilename := utils.GenerateUniqueFilename(submissionFolderPath, desiredName)
-	newPath := filepath.Join(submissionFolderPath, newFilename)
+	// กันชื่อซ้ำ (ส่ง desiredName เข้าไปให้เป็น "ต้นฉบับ")
+	store := storage.Default()
+	ctx := context.Background()
+	newFilename, err := uniqueStorageFilename(ctx, store, submissionFolderPath, desiredName)
+	if err != nil {
+		return err
+	}
+	newPath := storageKey(store, filepath.Join(submissionFolderPath, newFilename))
 
-	// Move file on disk
-	if err := utils.MoveFileToSubmissionFolder(fileUpload.StoredPath, newPath); err != nil {
+	// Move the stored object
+	if err := storage.Move(ctx, store, fileUpload.StoredPath, newPath); err != nil {
 		return err
 	}
 
@@ -1946,6 +2219,15 @@ func AttachDocumentToSubmission(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
 		return
 	}
+	limitMB, exceedsLimit, err := attachedFileExceedsLimit(config.DB, &fileUpload, req.DocumentTypeID)
+	if err != nil {
+		InternalError(c, "attach document: size limit", err)
+		return
+	}
+	if exceedsLimit {
+		respondUploadTooLarge(c, limitMB)
+		return
+	}
 
 	// Move file from temp to submission folder
 	if err := MoveFileToSubmissionFolder(req.FileID, submissionID, submission.SubmissionType); err != nil {
@@ -2050,15 +2332,19 @@ func DownloadFile(c *gin.Context) {
 	}
 
 	// Check if file exists
-	if _, err := os.Stat(file.StoredPath); os.IsNotExist(err) {
-		c.JSON(http.StatusNotFound, gin.H{"error": "File not found on disk"})
+	store := storage.Default()
+	info, err := store.Stat(c.Request.Context(), file.StoredPath)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotExist) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "File not found on disk"})
+			return
+		}
+		InternalError(c, "download file: stat", err)
 		return
 	}
 
 	// ===== ประกอบชื่อไฟล์แนบ: <original-name>_<submission-number><ext> (ถ้าหา submission ได้)
-	downloadName := file.OriginalName
-	ext := filepath.Ext(file.OriginalName)
-	base := strings.TrimSuffix(file.OriginalName, ext)
+	downloadName := submissionFileName(file.OriginalName, "", filenameNormalizationSanitize)
 
 	// หา submission_id ผ่านตาราง submission_documents (ไฟล์นี้ถูกแนบกับ submission ไหน)
 	var doc models.SubmissionDocument
@@ -2072,14 +2358,15 @@ func DownloadFile(c *gin.Context) {
 			Select("submission_id", "submission_number").
 			Where("submission_id = ?", doc.SubmissionID).
 			First(&sub).Error; err == nil && sub.SubmissionNumber != "" {
-			downloadName = fmt.Sprintf("%s_%s%s", base, sub.SubmissionNumber, ext)
+			downloadName = submissionFileName(file.OriginalName, sub.SubmissionNumber, filenameNormalizationSanitize)
 		}
 	}
 
 	// Serve file
-	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", downloadName))
-	c.Header("Content-Type", file.MimeType)
-	c.File(file.StoredPath)
+	disposition := utils.ContentDisposition("attachment", downloadName)
+	if err := sendStoredFile(c, store, file.StoredPath, info, file.MimeType, disposition); err != nil {
+		InternalError(c, "download file: send", err)
+	}
 }
 
 // DeleteFile soft deletes a file
@@ -2102,11 +2389,13 @@ func DeleteFile(c *gin.Context) {
 	}
 
 	// Check if file is used in any submissions
-	var docCount int64
-	config.DB.Model(&models.SubmissionDocument{}).Where("file_id = ?", fileID).Count(&docCount)
-
-	if docCount > 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot delete file that is used in submissions"})
+	deletable, docCount, err := fileDeletable(config.DB, &file)
+	if err != nil {
+		InternalError(c, "delete file: reference count", err)
+		return
+	}
+	if !deletable {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot delete file that is used in submissions", "reference_count": docCount})
 		return
 	}
 
@@ -2125,6 +2414,17 @@ func DeleteFile(c *gin.Context) {
 	})
 }
 
+// fileDeletable reports whether DeleteFile may remove file: it must not be
+// deleted already and no submission document may reference it. The reference
+// count is returned as well.
+func fileDeletable(db *gorm.DB, file *models.FileUpload) (bool, int64, error) {
+	var refCount int64
+	if err := db.Model(&models.SubmissionDocument{}).Where("file_id = ?", file.FileID).Count(&refCount).Error; err != nil {
+		return false, 0, err
+	}
+	return file.DeleteAt == nil && refCount == 0, refCount, nil
+}
+
 // ===================== SUBMISSION DOCUMENT MANAGEMENT =====================
 
 // AttachDocument attaches a file to a submission
@@ -2186,6 +2486,15 @@ func AttachDocument(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid document type"})
 		return
 	}
+	limitMB, exceedsLimit, err := attachedFileExceedsLimit(config.DB, &file, docType.DocumentTypeID)
+	if err != nil {
+		InternalError(c, "attach document: size limit", err)
+		return
+	}
+	if exceedsLimit {
+		respondUploadTooLarge(c, limitMB)
+		return
+	}
 
 	// Check if document already attached
 	var existingDoc models.SubmissionDocument
@@ -2268,26 +2577,161 @@ func GetSubmissionDocuments(c *gin.Context) {
 	}
 
 	// Get documents
+	documents, err := loadSubmissionDocumentsWithTypes(submission.SubmissionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch documents"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":   true,
+		"documents": documents,
+		"total":     len(documents),
+	})
+}
+
+func loadSubmissionDocumentsWithTypes(submissionID int) ([]models.SubmissionDocument, error) {
 	var documents []models.SubmissionDocument
-	if err := config.DB.Joins("LEFT JOIN document_types dt ON dt.document_type_id = submission_documents.document_type_id").
+	err := config.DB.Joins("LEFT JOIN document_types dt ON dt.document_type_id = submission_documents.document_type_id").
 		Joins("LEFT JOIN publication_reward_external_funds pref ON pref.document_id = submission_documents.document_id AND (pref.deleted_at IS NULL OR pref.deleted_at = '0000-00-00 00:00:00')").
 		Select("submission_documents.*, dt.document_type_name, pref.external_fund_id AS external_funding_id").
 		Preload("File").
 		Preload("DocumentType").
 		Where("submission_documents.submission_id = ?", submissionID).
 		Order("submission_documents.display_order, submission_documents.created_at").
-		Find(&documents).Error; err != nil {
+		Find(&documents).Error
+	return documents, err
+}
+
+type submissionDocumentGroup struct {
+	DocumentTypeID   int                         `json:"document_type_id"`
+	DocumentTypeName string                      `json:"document_type_name"`
+	Code             string                      `json:"code"`
+	Required         bool                        `json:"required"`
+	Multiple         bool                        `json:"multiple"`
+	DocumentOrder    int                         `json:"document_order"`
+	IsMissing        bool                        `json:"is_missing"`
+	Documents        []models.SubmissionDocument `json:"documents"`
+	Total            int                         `json:"total"`
+}
+
+// GetSubmissionDocumentsGrouped returns a submission's documents grouped per document
+// type, including empty slots for document types applicable to the submission type.
+func GetSubmissionDocumentsGrouped(c *gin.Context) {
+	submissionID := c.Param("id")
+	userID, _ := c.Get("userID")
+	roleID, _ := c.Get("roleID")
+
+	var submission models.Submission
+	query := config.DB.Where("submission_id = ? AND deleted_at IS NULL", submissionID)
+	if roleID.(int) != 3 && roleID.(int) != 4 {
+		query = query.Where("user_id = ?", userID)
+	}
+
+	if err := query.First(&submission).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Submission not found"})
+		return
+	}
+
+	documents, err := loadSubmissionDocumentsWithTypes(submission.SubmissionID)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch documents"})
 		return
 	}
 
+	var documentTypes []models.DocumentType
+	if err := config.DB.Where("delete_at IS NULL").Order("document_order").Find(&documentTypes).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch document types"})
+		return
+	}
+
+	groups := make([]*submissionDocumentGroup, 0)
+	groupByType := make(map[int]*submissionDocumentGroup)
+	addGroup := func(dt models.DocumentType) *submissionDocumentGroup {
+		group := &submissionDocumentGroup{
+			DocumentTypeID:   dt.DocumentTypeID,
+			DocumentTypeName: dt.DocumentTypeName,
+			Code:             dt.Code,
+			Required:         dt.Required,
+			Multiple:         dt.Multiple,
+			DocumentOrder:    dt.DocumentOrder,
+			Documents:        []models.SubmissionDocument{},
+		}
+		groups = append(groups, group)
+		groupByType[dt.DocumentTypeID] = group
+		return group
+	}
+
+	subcategoryRequired, err := subcategoryRequiredDocumentTypeIDs(config.DB, submission.SubcategoryID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch document requirements"})
+		return
+	}
+
+	for _, dt := range documentTypes {
+		if subcategoryRequired[dt.DocumentTypeID] {
+			dt.Required = true
+			addGroup(dt)
+			continue
+		}
+		if documentTypeAppliesToSubmission(dt, submission.SubmissionType) {
+			addGroup(dt)
+		}
+	}
+
+	for _, doc := range documents {
+		group, ok := groupByType[doc.DocumentTypeID]
+		if !ok {
+			// Keep documents whose type no longer applies (or was removed) visible.
+			dt := doc.DocumentType
+			dt.DocumentTypeID = doc.DocumentTypeID
+			if dt.DocumentTypeName == "" {
+				dt.DocumentTypeName = doc.DocumentTypeName
+			}
+			group = addGroup(dt)
+		}
+		group.Documents = append(group.Documents, doc)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].DocumentOrder < groups[j].DocumentOrder
+	})
+
+	missingRequired := make([]int, 0)
+	for _, group := range groups {
+		group.Total = len(group.Documents)
+		group.IsMissing = group.Required && group.Total == 0
+		if group.IsMissing {
+			missingRequired = append(missingRequired, group.DocumentTypeID)
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"success":   true,
-		"documents": documents,
-		"total":     len(documents),
+		"success":          true,
+		"submission_id":    submission.SubmissionID,
+		"groups":           groups,
+		"total":            len(documents),
+		"missing_required": missingRequired,
 	})
 }
 
+// documentTypeAppliesToSubmission mirrors the fund_types filter used by GetDocumentTypes.
+func documentTypeAppliesToSubmission(dt models.DocumentType, submissionType string) bool {
+	meta := computeDocumentTypeMetadata(dt)
+	switch meta.FundTypeMode {
+	case "inactive":
+		return false
+	case "all":
+		return true
+	}
+	for _, ft := range meta.FundTypes {
+		if strings.EqualFold(ft, submissionType) {
+			return true
+		}
+	}
+	return false
+}
+
 func AdminResequenceSubmissionDocuments(c *gin.Context) {
 	submissionIDStr := c.Param("id")
 	submissionID, err := strconv.Atoi(submissionIDStr)
@@ -2364,12 +2808,108 @@ func DetachDocument(c *gin.Context) {
 		return
 	}
 
+	// The document is already gone; a failed resequence only leaves a gap in
+	// display_order, which the next attach or reorder closes.
+	if err := resequenceSubmissionDocumentsByDocumentType(config.DB, submission.SubmissionID); err != nil {
+		log.Printf("[DetachDocument] failed to resequence documents for submission %d: %v", submission.SubmissionID, err)
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"message": "Document detached successfully",
 	})
 }
 
+// ReorderSubmissionDocuments applies a user-supplied document order. The list must
+// contain every document of the submission exactly once; the result is normalized
+// with resequenceSubmissionDocumentsByDocumentType.
+func ReorderSubmissionDocuments(c *gin.Context) {
+	submissionID := c.Param("id")
+	userID, _ := c.Get("userID")
+	roleID, _ := c.Get("roleID")
+
+	var req struct {
+		DocumentIDs []int `json:"document_ids" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "document_ids is required"})
+		return
+	}
+
+	var submission models.Submission
+	query := config.DB.Where("submission_id = ? AND deleted_at IS NULL", submissionID)
+	if roleID.(int) != 3 { // Not admin
+		query = query.Where("user_id = ?", userID)
+	}
+
+	if err := query.First(&submission).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Submission not found"})
+		return
+	}
+
+	if !submission.IsEditable() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot modify submitted submission"})
+		return
+	}
+
+	var existingIDs []int
+	if err := config.DB.Model(&models.SubmissionDocument{}).
+		Where("submission_id = ?", submission.SubmissionID).
+		Pluck("document_id", &existingIDs).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch documents"})
+		return
+	}
+
+	existing := make(map[int]bool, len(existingIDs))
+	for _, id := range existingIDs {
+		existing[id] = true
+	}
+	seen := make(map[int]bool, len(req.DocumentIDs))
+	for _, id := range req.DocumentIDs {
+		if !existing[id] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Document %d does not belong to this submission", id)})
+			return
+		}
+		if seen[id] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Document %d is listed more than once", id)})
+			return
+		}
+		seen[id] = true
+	}
+	if len(seen) != len(existing) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "document_ids must include every document of the submission"})
+		return
+	}
+
+	if err := config.DB.Transaction(func(tx *gorm.DB) error {
+		for index, id := range req.DocumentIDs {
+			if err := tx.Model(&models.SubmissionDocument{}).
+				Where("document_id = ? AND submission_id = ?", id, submission.SubmissionID).
+				Update("display_order", index+1).Error; err != nil {
+				return err
+			}
+		}
+		return resequenceSubmissionDocumentsByDocumentType(tx, submission.SubmissionID)
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reorder documents"})
+		return
+	}
+
+	documents, err := fetchSubmissionDocuments(config.DB, submission.SubmissionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load submission documents"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":        true,
+		"message":        "Documents reordered successfully",
+		"order_strategy": documentOrderStrategy(),
+		"documents":      documents,
+		"total":          len(documents),
+	})
+}
+
 // ===================== HELPER FUNCTIONS =====================
 
 // ดึงปี พ.ศ. (string) จาก system_config.current_year ถ้ามี; ถ้าไม่มี fallback เป็น (ปีค.ศ.+543)
@@ -2411,79 +2951,142 @@ func onlyDigits(s string) string {
 
 // ===================== HELPER FUNCTIONS =====================
 
-// Global mutex for submission number generation
-var submissionNumberMutex sync.Mutex
-
-// REPLACE: generateSubmissionNumber creates a unique submission number (prefix-BEYYYY-RUNNING)
-// - ปีใช้ พ.ศ. จาก system_config.current_year (ถ้าไม่มีค่อย fallback เป็น ปีปัจจุบัน+543)
-// - running number รีเซ็ต "เมื่อปี พ.ศ. เปลี่ยน" (นับรวมทั้งปี ไม่รีเซ็ตรายวัน)
-func generateSubmissionNumber(submissionType string) string {
-	submissionNumberMutex.Lock()
-	defer submissionNumberMutex.Unlock()
-
-	// ปี พ.ศ. จาก system_config (หรือ fallback)
-	beYear := getCurrentBEYearStr()
-
-	var prefix string
+// submissionNumberPrefix returns the submission number prefix of a type.
+func submissionNumberPrefix(submissionType string) string {
 	switch submissionType {
 	case "fund_application":
-		prefix = "FA"
+		return "FA"
 	case "publication_reward":
-		prefix = "PR"
+		return "PR"
 	case "conference_grant":
-		prefix = "CG"
+		return "CG"
 	case "training_request":
-		prefix = "TR"
+		return "TR"
 	default:
-		prefix = "SUB"
+		return "SUB"
 	}
+}
+
+const (
+	submissionNumberPolicyYearly      = "yearly"
+	submissionNumberPolicyInstallment = "installment"
+)
 
-	// นับจำนวน submission ภายใน "ปี พ.ศ. ปัจจุบัน" (ตามเลขใน submission_number)
-	// ตัวอย่าง prefixYear = "PR-2568%" จะ match ทั้งปีนั้น ไม่ขึ้นกับวัน
-	prefixYearLike := fmt.Sprintf("%s-%s%%", prefix, beYear)
+// submissionNumberPolicy selects how running numbers reset
+// (SUBMISSION_NUMBER_POLICY=yearly|installment, default yearly).
+func submissionNumberPolicy() string {
+	if strings.ToLower(strings.TrimSpace(os.Getenv("SUBMISSION_NUMBER_POLICY"))) == submissionNumberPolicyInstallment {
+		return submissionNumberPolicyInstallment
+	}
+	return submissionNumberPolicyYearly
+}
 
-	var count int64
-	config.DB.Model(&models.Submission{}).
-		Where("submission_type = ? AND submission_number LIKE ?", submissionType, prefixYearLike).
-		Count(&count)
+// generateSubmissionNumber creates a unique submission number (prefix-BEYYYY-RUNNING)
+// - ปีใช้ พ.ศ. จาก system_config.current_year (ถ้าไม่มีค่อย fallback เป็น ปีปัจจุบัน+543)
+// - running number รีเซ็ต "เมื่อปี พ.ศ. เปลี่ยน" (นับรวมทั้งปี ไม่รีเซ็ตรายวัน)
+// - policy installment: prefix-BEYYYY-II-RUNNING รีเซ็ตทุกงวด; ถ้าหางวดไม่เจอใช้เลขรายปี
+// Call it in the transaction that inserts the submission so a rolled-back
+// insert does not use up a number.
+func generateSubmissionNumber(tx *gorm.DB, submission models.Submission) (string, error) {
+	now := time.Now()
+	installment := 0
+	if submissionNumberPolicy() == submissionNumberPolicyInstallment {
+		number, err := determineSubmissionInstallmentNumber(tx, submission, now)
+		if err != nil {
+			return "", err
+		}
+		if number != nil && *number > 0 {
+			installment = *number
+		}
+	}
+	return nextSubmissionNumber(tx, submissionNumberPrefix(submission.SubmissionType), getCurrentBEYearStr(), installment, now)
+}
 
-	// พยายามจองเลขลำดับ + ตรวจซ้ำ
-	for i := int64(1); i <= 10; i++ {
-		potentialNumber := fmt.Sprintf("%s-%s-%04d", prefix, beYear, count+i)
+// submitSubmissionNumber returns a new number for a draft submitted for the
+// first time under the installment policy when the number issued at draft
+// creation carries a different installment (or BE year) than the one resolved
+// at submit, so the number agrees with installment_number_at_submit. It
+// returns "" when the number stays. A resubmission after a revision request
+// keeps its number: the revision handlers clear submitted_at but leave the
+// submission in needs_more_info until it is submitted again.
+func submitSubmissionNumber(tx *gorm.DB, submission models.Submission, installment *int, beYear string, now time.Time) (string, error) {
+	if submissionNumberPolicy() != submissionNumberPolicyInstallment {
+		return "", nil
+	}
+	if installment == nil || *installment <= 0 {
+		return "", nil
+	}
+	resubmission, err := utils.StatusMatchesCodes(submission.StatusID, utils.StatusCodeNeedsMoreInfo)
+	if err != nil {
+		return "", err
+	}
+	if resubmission {
+		return "", nil
+	}
+	prefix := submissionNumberPrefix(submission.SubmissionType)
+	if strings.HasPrefix(submission.SubmissionNumber, fmt.Sprintf("%s-%s-%02d-", prefix, beYear, *installment)) {
+		return "", nil
+	}
+	return nextSubmissionNumber(tx, prefix, beYear, *installment, now)
+}
 
-		var existing int64
-		config.DB.Model(&models.Submission{}).
-			Where("submission_number = ?", potentialNumber).
-			Count(&existing)
+// formatSubmissionNumber renders a submission number; installment 0 is the
+// yearly sequence and has no installment segment.
+func formatSubmissionNumber(prefix, beYear string, installment, running int) string {
+	if installment > 0 {
+		return fmt.Sprintf("%s-%s-%02d-%04d", prefix, beYear, installment, running)
+	}
+	return fmt.Sprintf("%s-%s-%04d", prefix, beYear, running)
+}
 
-		if existing == 0 {
-			return potentialNumber
-		}
+// nextSubmissionNumber takes the next running number for prefix, beYear and
+// installment (0 for the yearly sequence) from submission_sequences. The
+// counter row is locked with SELECT ... FOR UPDATE until tx ends, so every API
+// instance gets distinct, consecutive numbers. A missing row is seeded from
+// the highest number already issued in that sequence.
+func nextSubmissionNumber(tx *gorm.DB, prefix, beYear string, installment int, now time.Time) (string, error) {
+	pattern := fmt.Sprintf("%s-%s-%%", prefix, beYear)
+	if installment > 0 {
+		pattern = fmt.Sprintf("%s-%s-%02d-%%", prefix, beYear, installment)
+	}
+	if err := tx.Exec(`
+		INSERT IGNORE INTO submission_sequences (prefix, be_year, installment, last_number, updated_at)
+		SELECT ?, ?, ?, COALESCE(MAX(CAST(SUBSTRING_INDEX(submission_number, '-', -1) AS UNSIGNED)), 0), ?
+		FROM submissions
+		WHERE submission_number LIKE ?
+	`, prefix, beYear, installment, now, pattern).Error; err != nil {
+		return "", err
 	}
 
-	// กรณีชนพร้อมกันหลายเธรด/หลายเครื่อง (โอกาสน้อย) ค่อย fallback เป็นสุ่ม
-	bytes := make([]byte, 3)
-	rand.Read(bytes)
-	randomSuffix := strings.ToUpper(hex.EncodeToString(bytes))
-	return fmt.Sprintf("%s-%s-R-%s", prefix, beYear, randomSuffix)
+	next, err := incrementSubmissionSequence(tx, prefix, beYear, installment, now)
+	if err != nil {
+		return "", err
+	}
+	return formatSubmissionNumber(prefix, beYear, installment, next), nil
 }
 
-// isValidFileType checks if file type is allowed
-func isValidFileType(file *multipart.FileHeader) bool {
-	allowedTypes := map[string]bool{
-		"application/pdf":    true,
-		"image/jpeg":         true,
-		"image/jpg":          true,
-		"image/png":          true,
-		"image/gif":          true,
-		"application/msword": true,
-		"application/vnd.openxmlformats-officedocument.wordprocessingml.document": true,
-		"application/vnd.ms-excel": true,
-		"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": true,
+// incrementSubmissionSequence locks the submission_sequences row for prefix,
+// beYear and installment, which the caller has already seeded, and returns
+// its next number.
+func incrementSubmissionSequence(tx *gorm.DB, prefix, beYear string, installment int, now time.Time) (int, error) {
+	var sequence struct {
+		LastNumber int `gorm:"column:last_number"`
+	}
+	if err := tx.Raw(
+		"SELECT last_number FROM submission_sequences WHERE prefix = ? AND be_year = ? AND installment = ? FOR UPDATE",
+		prefix, beYear, installment,
+	).Scan(&sequence).Error; err != nil {
+		return 0, err
 	}
 
-	contentType := file.Header.Get("Content-Type")
-	return allowedTypes[contentType]
+	next := sequence.LastNumber + 1
+	if err := tx.Exec(
+		"UPDATE submission_sequences SET last_number = ?, updated_at = ? WHERE prefix = ? AND be_year = ? AND installment = ?",
+		next, now, prefix, beYear, installment,
+	).Error; err != nil {
+		return 0, err
+	}
+	return next, nil
 }
 
 // generateFileHash creates SHA256 hash of file content
@@ -2554,12 +3157,7 @@ func AddPublicationDetails(c *gin.Context) {
 		FundingReferences    string `json:"funding_references"`
 		UniversityRankings   string `json:"university_rankings"`
 
-		ExternalFundings []struct {
-			ExternalFundID *int    `json:"external_fund_id"`
-			ClientID       string  `json:"client_id"`
-			FundName       string  `json:"fund_name"`
-			Amount         float64 `json:"amount"`
-		} `json:"external_fundings"`
+		ExternalFundings []publicationExternalFundingInput `json:"external_fundings"`
 	}
 
 	var req PublicationDetailsRequest
@@ -2568,6 +3166,15 @@ func AddPublicationDetails(c *gin.Context) {
 		return
 	}
 
+	if rejectOversizedText(c, "publication_reward_details",
+		utils.TextField{Column: "paper_title", Field: "article_title", Value: req.PaperTitle},
+		utils.TextField{Column: "journal_name", Value: req.JournalName},
+		utils.TextField{Column: "author_name_list", Value: strings.TrimSpace(req.AuthorNameList)},
+		utils.TextField{Column: "signature", Value: strings.TrimSpace(req.Signature)},
+	) {
+		return
+	}
+
 	modeParam := strings.ToLower(strings.TrimSpace(c.Query("mode")))
 	allowIncomplete := modeParam == "draft"
 	if !allowIncomplete {
@@ -2615,11 +3222,12 @@ func AddPublicationDetails(c *gin.Context) {
 
 	now := time.Now()
 
-	var externalTotal float64
-	if len(req.ExternalFundings) > 0 {
-		for _, fund := range req.ExternalFundings {
-			externalTotal += fund.Amount
-		}
+	// The external funding breakdown is authoritative: syncPublicationExternalFunds
+	// stores its sum, so a bare external_funding_amount is only checked.
+	externalTotal := sumExternalFundingInputs(req.ExternalFundings)
+	if math.Abs(req.ExternalFundingAmount-externalTotal) > publicationAmountTolerance {
+		log.Printf("[publicationTotals] submission %s: client external_funding_amount %.2f differs from external_fundings sum %.2f",
+			submissionID, req.ExternalFundingAmount, externalTotal)
 	}
 
 	authorNameList := strings.TrimSpace(req.AuthorNameList)
@@ -2651,6 +3259,13 @@ func AddPublicationDetails(c *gin.Context) {
 	announceRef := strings.TrimSpace(req.AnnounceReferenceNumber)
 	authorType := strings.TrimSpace(req.AuthorType)
 
+	req.DOI = utils.NormalizeDOI(req.DOI)
+
+	duplicates, ok := checkDuplicatePublicationClaim(c, config.DB, submission.UserID, submission.SubmissionID, req.DOI, req.PaperTitle, allowIncomplete)
+	if !ok {
+		return
+	}
+
 	var existing models.PublicationRewardDetail
 	if err := config.DB.Where("submission_id = ?", submission.SubmissionID).First(&existing).Error; err != nil {
 		if !errors.Is(err, gorm.ErrRecordNotFound) {
@@ -2684,6 +3299,8 @@ func AddPublicationDetails(c *gin.Context) {
 		}
 	}
 
+	quartileMatch, quartilePrefilled := prefillJournalQuartile(c.Request.Context(), req.JournalName, pubDate.Year(), &req.Quartile)
+
 	detail.SubmissionID = submission.SubmissionID
 	detail.PaperTitle = req.PaperTitle
 	detail.JournalName = req.JournalName
@@ -2703,12 +3320,8 @@ func AddPublicationDetails(c *gin.Context) {
 	detail.RevisionFeeApproveAmount = req.RevisionFeeApproveAmount
 	detail.PublicationFee = req.PublicationFee
 	detail.PublicationFeeApproveAmount = req.PublicationFeeApproveAmount
-	if externalTotal > 0 {
-		detail.ExternalFundingAmount = externalTotal
-	} else {
-		detail.ExternalFundingAmount = req.ExternalFundingAmount
-	}
-	detail.TotalAmount = req.TotalAmount
+	detail.ExternalFundingAmount = externalTotal
+	totalAdjusted := reconcilePublicationRewardTotal(&detail, &req.TotalAmount)
 	detail.TotalApproveAmount = req.TotalApproveAmount
 
 	detail.AuthorCount = req.AuthorCount
@@ -2741,18 +3354,57 @@ func AddPublicationDetails(c *gin.Context) {
 		return
 	}
 
+	responseExternalFunds, failMsg, err := syncPublicationExternalFunds(config.DB, &detail, req.ExternalFundings, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": failMsg})
+		return
+	}
+
+	clearSubmissionAutosaveAfterSave(submission.SubmissionID)
+
+	response := gin.H{
+		"success":           true,
+		"message":           "Publication details saved successfully",
+		"details":           detail,
+		"external_fundings": responseExternalFunds,
+	}
+	if len(duplicates) > 0 {
+		response["duplicate_warnings"] = duplicates
+	}
+	if quartileMatch != nil {
+		response["quartile_lookup"] = gin.H{"match": quartileMatch, "prefilled": quartilePrefilled}
+	}
+	if totalAdjusted {
+		response["total_amount_adjusted"] = true
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// publicationExternalFundingInput is one row of the external funding breakdown
+// sent with publication details.
+type publicationExternalFundingInput struct {
+	ExternalFundID *int    `json:"external_fund_id"`
+	ClientID       string  `json:"client_id"`
+	FundName       string  `json:"fund_name"`
+	Amount         float64 `json:"amount"`
+}
+
+// syncPublicationExternalFunds replaces the detail's external funding rows with
+// funds and recomputes external_funding_amount from what was saved. On failure
+// it returns the message to report alongside the error.
+func syncPublicationExternalFunds(db *gorm.DB, detail *models.PublicationRewardDetail, funds []publicationExternalFundingInput, now time.Time) ([]gin.H, string, error) {
 	// Handle external funding breakdown records
 	var savedExternalFunds []models.PublicationRewardExternalFund
-	responseExternalFunds := make([]gin.H, 0, len(req.ExternalFundings))
+	responseExternalFunds := make([]gin.H, 0, len(funds))
 
-	if len(req.ExternalFundings) > 0 {
-		keepIDs := make([]int, 0, len(req.ExternalFundings))
+	if len(funds) > 0 {
+		keepIDs := make([]int, 0, len(funds))
 
-		for _, fund := range req.ExternalFundings {
+		for _, fund := range funds {
 			trimmedName := strings.TrimSpace(fund.FundName)
 			record := models.PublicationRewardExternalFund{
 				DetailID:     detail.DetailID,
-				SubmissionID: submission.SubmissionID,
+				SubmissionID: detail.SubmissionID,
 				FundName:     trimmedName,
 				Amount:       fund.Amount,
 				UpdatedAt:    now,
@@ -2761,35 +3413,31 @@ func AddPublicationDetails(c *gin.Context) {
 			if fund.ExternalFundID != nil && *fund.ExternalFundID > 0 {
 				// Try to update existing record
 				var existingFund models.PublicationRewardExternalFund
-				if err := config.DB.Where("external_fund_id = ? AND detail_id = ?", *fund.ExternalFundID, detail.DetailID).
+				if err := db.Where("external_fund_id = ? AND detail_id = ?", *fund.ExternalFundID, detail.DetailID).
 					First(&existingFund).Error; err != nil {
 					if !errors.Is(err, gorm.ErrRecordNotFound) {
-						c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load external funding record"})
-						return
+						return nil, "Failed to load external funding record", err
 					}
 					record.CreatedAt = now
-					if err := config.DB.Create(&record).Error; err != nil {
-						c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save external funding record"})
-						return
+					if err := db.Create(&record).Error; err != nil {
+						return nil, "Failed to save external funding record", err
 					}
 				} else {
 					existingFund.FundName = trimmedName
 					existingFund.Amount = fund.Amount
 					existingFund.UpdatedAt = now
 					if existingFund.SubmissionID == 0 {
-						existingFund.SubmissionID = submission.SubmissionID
+						existingFund.SubmissionID = detail.SubmissionID
 					}
-					if err := config.DB.Save(&existingFund).Error; err != nil {
-						c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update external funding record"})
-						return
+					if err := db.Save(&existingFund).Error; err != nil {
+						return nil, "Failed to update external funding record", err
 					}
 					record = existingFund
 				}
 			} else {
 				record.CreatedAt = now
-				if err := config.DB.Create(&record).Error; err != nil {
-					c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save external funding record"})
-					return
+				if err := db.Create(&record).Error; err != nil {
+					return nil, "Failed to save external funding record", err
 				}
 			}
 
@@ -2805,18 +3453,16 @@ func AddPublicationDetails(c *gin.Context) {
 
 		// Remove stale records
 		if len(keepIDs) > 0 {
-			if err := config.DB.Where("detail_id = ? AND external_fund_id NOT IN ?", detail.DetailID, keepIDs).
+			if err := db.Where("detail_id = ? AND external_fund_id NOT IN ?", detail.DetailID, keepIDs).
 				Delete(&models.PublicationRewardExternalFund{}).Error; err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove outdated external funding records"})
-				return
+				return nil, "Failed to remove outdated external funding records", err
 			}
 		}
 	} else {
 		// No external fundings provided, clear existing ones
-		if err := config.DB.Where("detail_id = ?", detail.DetailID).
+		if err := db.Where("detail_id = ?", detail.DetailID).
 			Delete(&models.PublicationRewardExternalFund{}).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to clear external funding records"})
-			return
+			return nil, "Failed to clear external funding records", err
 		}
 		detail.ExternalFundingAmount = 0
 	}
@@ -2831,19 +3477,13 @@ func AddPublicationDetails(c *gin.Context) {
 
 	detail.ExternalFunds = savedExternalFunds
 
-	if err := config.DB.Model(&models.PublicationRewardDetail{}).
+	if err := db.Model(&models.PublicationRewardDetail{}).
 		Where("detail_id = ?", detail.DetailID).
 		Update("external_funding_amount", detail.ExternalFundingAmount).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update external funding amount"})
-		return
+		return nil, "Failed to update external funding amount", err
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"success":           true,
-		"message":           "Publication details saved successfully",
-		"details":           detail,
-		"external_fundings": responseExternalFunds,
-	})
+	return responseExternalFunds, "", nil
 }
 
 // AddFundDetails
@@ -2870,6 +3510,13 @@ func AddFundDetails(c *gin.Context) {
 		return
 	}
 
+	if rejectOversizedText(c, "fund_application_details",
+		utils.TextField{Column: "project_title", Value: req.ProjectTitle},
+		utils.TextField{Column: "project_description", Value: req.ProjectDescription},
+	) {
+		return
+	}
+
 	// Resolve announcement snapshot at the time of submission.
 	var ann struct {
 		MainAnnoucement             *int
@@ -2927,6 +3574,15 @@ func AddFundDetails(c *gin.Context) {
 		return
 	}
 
+	if err := validateSubmissionFundYear(config.DB, submission.YearID, &subcategory.CategoryID, &req.SubcategoryID); err != nil {
+		if errors.Is(err, errSubmissionFundYearMismatch) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		InternalError(c, "submission", err)
+		return
+	}
+
 	// Find active budget for the selected subcategory
 	var budget models.SubcategoryBudget
 	if err := config.DB.Where("subcategory_id = ? AND status = 'active' AND delete_at IS NULL", req.SubcategoryID).First(&budget).Error; err != nil {
@@ -2973,6 +3629,8 @@ func AddFundDetails(c *gin.Context) {
 		return
 	}
 
+	clearSubmissionAutosaveAfterSave(submission.SubmissionID)
+
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"message": "Fund details saved successfully",
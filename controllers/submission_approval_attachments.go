@@ -18,6 +18,7 @@ import (
 	"fund-management-api/config"
 	"fund-management-api/models"
 	"fund-management-api/services"
+	"fund-management-api/storage"
 	"fund-management-api/utils"
 
 	"github.com/gin-gonic/gin"
@@ -162,29 +163,32 @@ func CreateSubmissionApprovalAttachment(c *gin.Context) {
 		return
 	}
 	evidenceFolder := filepath.Join(submissionFolder, approvalAttachmentFolder)
-	if err := os.MkdirAll(evidenceFolder, 0755); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create evidence directory"})
+
+	hash, err := sha256Upload(file)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to hash PDF file"})
 		return
 	}
 
-	storedFilename := utils.GenerateUniqueFilename(evidenceFolder, file.Filename)
-	storedPath := filepath.Join(evidenceFolder, storedFilename)
-	if err := c.SaveUploadedFile(file, storedPath); err != nil {
+	store := storage.Default()
+	ctx := c.Request.Context()
+	storedFilename, err := uniqueStorageFilename(ctx, store, evidenceFolder, file.Filename)
+	if err != nil {
+		InternalError(c, "approval attachment: unique name", err)
+		return
+	}
+	storedPath, err := saveUploadedFileToStorage(ctx, store, file, filepath.Join(evidenceFolder, storedFilename), "application/pdf")
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save PDF file"})
 		return
 	}
 	removeOnFailure := true
 	defer func() {
 		if removeOnFailure {
-			_ = os.Remove(storedPath)
+			_ = store.Delete(ctx, storedPath)
 		}
 	}()
 
-	hash, err := sha256File(storedPath)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to hash PDF file"})
-		return
-	}
 	now := time.Now()
 	attachment := models.SubmissionApprovalAttachment{
 		SubmissionID: submission.SubmissionID, Label: label,
@@ -311,8 +315,14 @@ func DownloadSubmissionApprovalAttachment(c *gin.Context) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "you do not have access to this attachment"})
 		return
 	}
-	if _, err := os.Stat(attachment.StoredPath); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "file not found on disk"})
+	store := storage.Default()
+	info, err := store.Stat(c.Request.Context(), attachment.StoredPath)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotExist) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "file not found on disk"})
+			return
+		}
+		InternalError(c, "approval attachment download: stat", err)
 		return
 	}
 	if err := createApprovalAttachmentAudit(config.DB, c, c.GetInt("userID"), "download", &attachment, nil); err != nil {
@@ -320,9 +330,10 @@ func DownloadSubmissionApprovalAttachment(c *gin.Context) {
 		// unavailable; the failure is still visible in server logs.
 		fmt.Printf("approval attachment download audit failed: %v\n", err)
 	}
-	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", attachment.OriginalFilename))
-	c.Header("Content-Type", "application/pdf")
-	c.File(attachment.StoredPath)
+	disposition := utils.ContentDisposition("attachment", attachment.OriginalFilename)
+	if err := sendStoredFile(c, store, attachment.StoredPath, info, "application/pdf", disposition); err != nil {
+		InternalError(c, "approval attachment download: send", err)
+	}
 }
 
 func isPDFUpload(file *multipart.FileHeader) bool {
@@ -341,8 +352,8 @@ func isPDFUpload(file *multipart.FileHeader) bool {
 	return string(header) == "%PDF-"
 }
 
-func sha256File(path string) (string, error) {
-	f, err := os.Open(path)
+func sha256Upload(file *multipart.FileHeader) (string, error) {
+	f, err := file.Open()
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,180 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"fund-management-api/config"
+	"fund-management-api/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type submissionAuditEntry struct {
+	Source        string    `json:"source"` // audit_log | status
+	LogID         *int      `json:"log_id,omitempty"`
+	Action        string    `json:"action"`
+	EntityType    string    `json:"entity_type"`
+	ActorID       *int      `json:"actor_id,omitempty"`
+	ActorName     string    `json:"actor_name,omitempty"`
+	ChangedFields *string   `json:"changed_fields,omitempty"`
+	OldValues     *string   `json:"old_values,omitempty"`
+	NewValues     *string   `json:"new_values,omitempty"`
+	Description   string    `json:"description"`
+	IPAddress     string    `json:"ip_address,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// GetSubmissionAuditTrail returns the chronological audit trail of a submission:
+// audit_logs entries tied to it plus the lifecycle timestamps recorded on the
+// submission itself. Visible to the owner and admins; ?format=csv downloads it.
+func GetSubmissionAuditTrail(c *gin.Context) {
+	submissionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || submissionID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid submission ID"})
+		return
+	}
+
+	userID := c.GetInt("userID")
+	isAdmin := c.GetInt("roleID") == 3
+
+	var submission models.Submission
+	query := config.DB.Where("submission_id = ? AND deleted_at IS NULL", submissionID)
+	if !isAdmin {
+		query = query.Where("user_id = ?", userID)
+	}
+	if err := query.First(&submission).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "Submission not found"})
+		return
+	}
+
+	var logs []struct {
+		models.AuditLog
+		ActorName string `gorm:"column:actor_name"`
+	}
+	if err := config.DB.Table("audit_logs al").
+		Select("al.*, TRIM(CONCAT(COALESCE(u.user_fname,''),' ',COALESCE(u.user_lname,''))) AS actor_name").
+		Joins("LEFT JOIN users u ON u.user_id = al.user_id").
+		Where(`(al.entity_type = 'submission' AND al.entity_id = ?)
+			OR (al.entity_number = ? AND al.entity_number <> '')
+			OR (al.entity_type = 'submission_approval_attachment' AND al.entity_number = ?)`,
+			submission.SubmissionID, submission.SubmissionNumber, strconv.Itoa(submission.SubmissionID)).
+		Order("al.created_at ASC, al.log_id ASC").
+		Scan(&logs).Error; err != nil {
+		InternalError(c, "submission audit: load audit logs", err)
+		return
+	}
+
+	entries := make([]submissionAuditEntry, 0, len(logs)+8)
+	for _, row := range logs {
+		logID := row.LogID
+		actorID := row.UserID
+		entry := submissionAuditEntry{
+			Source:        "audit_log",
+			LogID:         &logID,
+			Action:        row.Action,
+			EntityType:    row.EntityType,
+			ActorID:       &actorID,
+			ActorName:     strings.TrimSpace(row.ActorName),
+			ChangedFields: row.ChangedFields,
+			OldValues:     row.OldValues,
+			NewValues:     row.NewValues,
+			CreatedAt:     row.CreatedAt,
+		}
+		if row.Description != nil {
+			entry.Description = *row.Description
+		}
+		if isAdmin {
+			entry.IPAddress = row.IPAddress
+		}
+		entries = append(entries, entry)
+	}
+	entries = append(entries, submissionStatusTimeline(submission)...)
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+
+	if strings.EqualFold(c.Query("format"), "csv") {
+		writeSubmissionAuditCSV(c, submission, entries)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":           true,
+		"submission_id":     submission.SubmissionID,
+		"submission_number": submission.SubmissionNumber,
+		"entries":           entries,
+		"total":             len(entries),
+	})
+}
+
+// submissionStatusTimeline derives lifecycle events from the timestamps stored on
+// the submission row (created, submitted, head and admin decisions).
+func submissionStatusTimeline(submission models.Submission) []submissionAuditEntry {
+	entries := []submissionAuditEntry{{
+		Source:      "status",
+		Action:      "create",
+		EntityType:  "submission",
+		ActorID:     &submission.UserID,
+		Description: "Submission created",
+		CreatedAt:   submission.CreatedAt,
+	}}
+
+	add := func(at *time.Time, actor *int, action, description string) {
+		if at == nil || at.IsZero() {
+			return
+		}
+		entries = append(entries, submissionAuditEntry{
+			Source:      "status",
+			Action:      action,
+			EntityType:  "submission",
+			ActorID:     actor,
+			Description: description,
+			CreatedAt:   *at,
+		})
+	}
+
+	add(submission.SubmittedAt, &submission.UserID, "submit", "Submission submitted")
+	add(submission.HeadApprovedAt, submission.HeadApprovedBy, "review", "Recommended by department head")
+	add(submission.HeadRejectedAt, submission.HeadRejectedBy, "reject", "Not recommended by department head")
+	add(submission.AdminApprovedAt, submission.AdminApprovedBy, "approve", "Approved by admin")
+	add(submission.AdminRejectedAt, submission.AdminRejectedBy, "reject", "Rejected by admin")
+	return entries
+}
+
+func writeSubmissionAuditCSV(c *gin.Context, submission models.Submission, entries []submissionAuditEntry) {
+	var buf bytes.Buffer
+	// Write BOM for Excel compatibility
+	buf.WriteString("\xEF\xBB\xBF")
+	writer := csv.NewWriter(&buf)
+	writer.Write([]string{"created_at", "source", "action", "entity_type", "actor_id", "actor_name", "description", "changed_fields", "old_values", "new_values"})
+	for _, entry := range entries {
+		actorID := ""
+		if entry.ActorID != nil {
+			actorID = strconv.Itoa(*entry.ActorID)
+		}
+		writer.Write([]string{
+			entry.CreatedAt.Format(time.RFC3339),
+			entry.Source,
+			entry.Action,
+			entry.EntityType,
+			actorID,
+			entry.ActorName,
+			entry.Description,
+			ptrValue(entry.ChangedFields),
+			ptrValue(entry.OldValues),
+			ptrValue(entry.NewValues),
+		})
+	}
+	writer.Flush()
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", "attachment; filename=submission_audit_"+strconv.Itoa(submission.SubmissionID)+".csv")
+	c.String(http.StatusOK, buf.String())
+}
@@ -0,0 +1,195 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"fund-management-api/config"
+	"fund-management-api/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// maxAutosavePayloadBytes bounds a single autosaved draft.
+const maxAutosavePayloadBytes = 512 << 10
+
+type submissionAutosaveRequest struct {
+	// Version is the autosave version the client last saw (0 when it has none).
+	// A mismatch means another tab or device saved in between.
+	Version *int                       `json:"version"`
+	Data    map[string]json.RawMessage `json:"data" binding:"required"`
+}
+
+func loadOwnedSubmissionForAutosave(c *gin.Context) (*models.Submission, bool) {
+	submissionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || submissionID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid submission ID"})
+		return nil, false
+	}
+
+	var submission models.Submission
+	if err := config.DB.
+		Where("submission_id = ? AND user_id = ? AND deleted_at IS NULL", submissionID, c.GetInt("userID")).
+		First(&submission).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "Submission not found"})
+			return nil, false
+		}
+		InternalError(c, "submission_autosave", err)
+		return nil, false
+	}
+	return &submission, true
+}
+
+// AutosaveSubmissionDraft stores partial detail form data against a submission
+// without touching the committed detail. Keys in data are merged into the
+// existing draft; the response carries the new version for the next call.
+func AutosaveSubmissionDraft(c *gin.Context) {
+	submission, ok := loadOwnedSubmissionForAutosave(c)
+	if !ok {
+		return
+	}
+
+	var req submissionAutosaveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "data is required"})
+		return
+	}
+
+	var existing models.SubmissionAutosave
+	found := true
+	if err := config.DB.Where("submission_id = ?", submission.SubmissionID).First(&existing).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			InternalError(c, "submission_autosave", err)
+			return
+		}
+		found = false
+	}
+
+	if req.Version != nil && *req.Version != existing.Version {
+		c.JSON(http.StatusConflict, gin.H{
+			"success":  false,
+			"error":    "Draft was autosaved elsewhere; restore the latest version first",
+			"autosave": autosaveOrNil(existing, found),
+		})
+		return
+	}
+
+	merged := map[string]json.RawMessage{}
+	if found && len(existing.Payload) > 0 {
+		if err := json.Unmarshal(existing.Payload, &merged); err != nil {
+			merged = map[string]json.RawMessage{}
+		}
+	}
+	for key, value := range req.Data {
+		merged[key] = value
+	}
+
+	payload, err := json.Marshal(merged)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid draft data"})
+		return
+	}
+	if len(payload) > maxAutosavePayloadBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"success": false, "error": "Draft is too large to autosave"})
+		return
+	}
+
+	now := time.Now()
+	if !found {
+		existing = models.SubmissionAutosave{
+			SubmissionID: submission.SubmissionID,
+			UserID:       submission.UserID,
+			Payload:      payload,
+			Version:      1,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+		if err := config.DB.Create(&existing).Error; err != nil {
+			// Lost a race with a concurrent first autosave (unique submission_id).
+			c.JSON(http.StatusConflict, gin.H{"success": false, "error": "Draft was autosaved elsewhere; restore the latest version first"})
+			return
+		}
+	} else {
+		// Conditional on the version we read so concurrent autosaves cannot interleave.
+		result := config.DB.Model(&models.SubmissionAutosave{}).
+			Where("autosave_id = ? AND version = ?", existing.AutosaveID, existing.Version).
+			Updates(map[string]interface{}{
+				"payload":    payload,
+				"version":    gorm.Expr("version + 1"),
+				"updated_at": now,
+			})
+		if result.Error != nil {
+			InternalError(c, "submission_autosave", result.Error)
+			return
+		}
+		if result.RowsAffected == 0 {
+			c.JSON(http.StatusConflict, gin.H{"success": false, "error": "Draft was autosaved elsewhere; restore the latest version first"})
+			return
+		}
+		existing.Payload = payload
+		existing.Version++
+		existing.UpdatedAt = now
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "autosave": existing})
+}
+
+// GetSubmissionAutosave returns the latest autosaved draft, or null when the
+// submission has none (e.g. after an explicit save).
+func GetSubmissionAutosave(c *gin.Context) {
+	submission, ok := loadOwnedSubmissionForAutosave(c)
+	if !ok {
+		return
+	}
+
+	var autosave models.SubmissionAutosave
+	found := true
+	if err := config.DB.Where("submission_id = ?", submission.SubmissionID).First(&autosave).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			InternalError(c, "submission_autosave", err)
+			return
+		}
+		found = false
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "autosave": autosaveOrNil(autosave, found)})
+}
+
+// DiscardSubmissionAutosave drops the autosaved draft without saving it.
+func DiscardSubmissionAutosave(c *gin.Context) {
+	submission, ok := loadOwnedSubmissionForAutosave(c)
+	if !ok {
+		return
+	}
+
+	if err := clearSubmissionAutosave(config.DB, submission.SubmissionID); err != nil {
+		InternalError(c, "submission_autosave", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Autosaved draft discarded"})
+}
+
+func autosaveOrNil(autosave models.SubmissionAutosave, found bool) interface{} {
+	if !found {
+		return nil
+	}
+	return autosave
+}
+
+// clearSubmissionAutosave removes the autosaved draft once the committed detail
+// has been saved explicitly.
+func clearSubmissionAutosave(db *gorm.DB, submissionID int) error {
+	return db.Where("submission_id = ?", submissionID).Delete(&models.SubmissionAutosave{}).Error
+}
+
+func clearSubmissionAutosaveAfterSave(submissionID int) {
+	if err := clearSubmissionAutosave(config.DB, submissionID); err != nil {
+		log.Printf("[submissionAutosave] failed to clear autosave for submission %d: %v", submissionID, err)
+	}
+}
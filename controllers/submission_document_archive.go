@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"fund-management-api/config"
+	"fund-management-api/models"
+	"fund-management-api/storage"
+	"fund-management-api/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type submissionArchiveEntry struct {
+	Name string
+	Key  string
+	Info storage.ObjectInfo
+}
+
+// submissionArchiveEntries resolves the files to put in a submission's archive,
+// skipping deleted files and files missing from storage. Entries are named
+// "<display order>_<original name>" and made unique within the archive.
+func submissionArchiveEntries(ctx context.Context, store storage.Storage, documents []models.SubmissionDocument) []submissionArchiveEntry {
+	entries := make([]submissionArchiveEntry, 0, len(documents))
+	used := make(map[string]int, len(documents))
+	for i, doc := range documents {
+		file := doc.File
+		if file.FileID == 0 || file.DeleteAt != nil {
+			continue
+		}
+		key := strings.TrimSpace(file.StoredPath)
+		info, err := store.Stat(ctx, key)
+		if err != nil {
+			log.Printf("[SubmissionDocumentsArchive] document %d: file unavailable at %q: %v", doc.DocumentID, key, err)
+			continue
+		}
+
+		original := strings.TrimSpace(doc.OriginalName)
+		if original == "" {
+			original = strings.TrimSpace(file.OriginalName)
+		}
+		if original == "" {
+			original = filepath.Base(filepath.FromSlash(key))
+		}
+		original = utils.SanitizeForFilename(filepath.Base(strings.ReplaceAll(original, "\\", "/")))
+
+		order := doc.DisplayOrder
+		if order <= 0 {
+			order = i + 1
+		}
+		name := fmt.Sprintf("%02d_%s", order, original)
+		if count := used[name]; count > 0 {
+			ext := filepath.Ext(name)
+			name = fmt.Sprintf("%s (%d)%s", strings.TrimSuffix(name, ext), count+1, ext)
+		}
+		used[name]++
+
+		entries = append(entries, submissionArchiveEntry{Name: name, Key: key, Info: info})
+	}
+	return entries
+}
+
+// GetSubmissionDocumentsArchive streams every attached document of a
+// submission as one ZIP. Access follows GetSubmissionDocuments: the owner,
+// admins and department heads.
+// GET /submissions/:id/documents/archive
+func GetSubmissionDocumentsArchive(c *gin.Context) {
+	submissionID := c.Param("id")
+	userID, _ := c.Get("userID")
+	roleID, _ := c.Get("roleID")
+
+	var submission models.Submission
+	query := config.DB.Where("submission_id = ? AND deleted_at IS NULL", submissionID)
+	if roleID.(int) != 3 && roleID.(int) != 4 {
+		query = query.Where("user_id = ?", userID)
+	}
+	if err := query.First(&submission).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Submission not found"})
+		return
+	}
+
+	documents, err := loadSubmissionDocumentsWithTypes(submission.SubmissionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch documents"})
+		return
+	}
+
+	store := storage.Default()
+	ctx := c.Request.Context()
+	entries := submissionArchiveEntries(ctx, store, documents)
+	if len(entries) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No documents available to download"})
+		return
+	}
+
+	baseName := utils.SanitizeForFilename(strings.TrimSpace(submission.SubmissionNumber))
+	if baseName == "" {
+		baseName = fmt.Sprintf("submission-%d", submission.SubmissionID)
+	}
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", utils.ContentDisposition("attachment", baseName+"_documents.zip"))
+	c.Status(http.StatusOK)
+
+	// The archive is written straight to the response; once streaming starts
+	// an error can only be logged and the connection cut short.
+	zw := zip.NewWriter(c.Writer)
+	for _, entry := range entries {
+		if err := writeSubmissionArchiveEntry(ctx, store, zw, entry); err != nil {
+			log.Printf("[SubmissionDocumentsArchive] submission %d: %s: %v", submission.SubmissionID, entry.Name, err)
+			c.Abort()
+			return
+		}
+	}
+	if err := zw.Close(); err != nil {
+		log.Printf("[SubmissionDocumentsArchive] submission %d: close archive: %v", submission.SubmissionID, err)
+	}
+}
+
+func writeSubmissionArchiveEntry(ctx context.Context, store storage.Storage, zw *zip.Writer, entry submissionArchiveEntry) error {
+	src, err := store.Open(ctx, entry.Key)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	header := &zip.FileHeader{Name: entry.Name, Method: zip.Deflate, Modified: entry.Info.ModTime}
+
+	w, err := zw.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, src)
+	return err
+}
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"errors"
+	"os"
 	"strings"
 	"sync"
 
@@ -127,18 +128,43 @@ type submissionDocumentWithTypeOrder struct {
 	DocumentOrder *int `gorm:"column:document_order"`
 }
 
+const (
+	// documentOrderStrategyType groups documents by document_types.document_order and
+	// keeps the user's display_order within each type (default).
+	documentOrderStrategyType = "document_type"
+	// documentOrderStrategyManual keeps the user's display_order across types.
+	documentOrderStrategyManual = "manual"
+)
+
+// documentOrderStrategy reads DOCUMENT_ORDER_STRATEGY, falling back to document_type.
+func documentOrderStrategy() string {
+	if strings.EqualFold(strings.TrimSpace(os.Getenv("DOCUMENT_ORDER_STRATEGY")), documentOrderStrategyManual) {
+		return documentOrderStrategyManual
+	}
+	return documentOrderStrategyType
+}
+
+// resequenceSubmissionDocumentsByDocumentType rewrites display_order to a contiguous
+// 1..N sequence according to documentOrderStrategy. Colliding display_order values
+// are broken by document_id.
 func resequenceSubmissionDocumentsByDocumentType(db *gorm.DB, submissionID int) error {
 	if db == nil {
 		db = config.DB
 	}
 
-	var documents []submissionDocumentWithTypeOrder
-	if err := db.Model(&models.SubmissionDocument{}).
+	query := db.Model(&models.SubmissionDocument{}).
 		Joins("LEFT JOIN document_types dt ON dt.document_type_id = submission_documents.document_type_id").
 		Select("submission_documents.document_id, submission_documents.display_order, dt.document_order").
-		Where("submission_documents.submission_id = ?", submissionID).
-		Order("CASE WHEN dt.document_order IS NULL THEN 1 ELSE 0 END").
-		Order("dt.document_order ASC").
+		Where("submission_documents.submission_id = ?", submissionID)
+	if documentOrderStrategy() == documentOrderStrategyType {
+		query = query.
+			Order("CASE WHEN dt.document_order IS NULL THEN 1 ELSE 0 END").
+			Order("dt.document_order ASC")
+	}
+
+	var documents []submissionDocumentWithTypeOrder
+	if err := query.
+		Order("CASE WHEN submission_documents.display_order > 0 THEN 0 ELSE 1 END").
 		Order("submission_documents.display_order ASC").
 		Order("submission_documents.document_id ASC").
 		Find(&documents).Error; err != nil {
@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"fund-management-api/utils"
+)
+
+// Values of FILENAME_NORMALIZATION, which controls how the names of files
+// moved into a submission folder are normalized on disk.
+const (
+	// filenameNormalizationSanitize removes characters that are unsafe in paths
+	// and headers but keeps Thai and other Unicode letters (default).
+	filenameNormalizationSanitize = "sanitize"
+	// filenameNormalizationASCII additionally reduces names to ASCII letters,
+	// digits, '.', '_' and '-', for storage that mishandles UTF-8 names.
+	filenameNormalizationASCII = "ascii"
+)
+
+var (
+	filenameControlChars = regexp.MustCompile(`[\x00-\x1f\x7f]`)
+	filenameNonASCII     = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+	filenameUnderscores  = regexp.MustCompile(`_{2,}`)
+)
+
+// filenameNormalizationMode reads FILENAME_NORMALIZATION, falling back to sanitize.
+func filenameNormalizationMode() string {
+	if strings.EqualFold(strings.TrimSpace(os.Getenv("FILENAME_NORMALIZATION")), filenameNormalizationASCII) {
+		return filenameNormalizationASCII
+	}
+	return filenameNormalizationSanitize
+}
+
+// normalizeFilenamePart cleans a file base name or extension with
+// utils.SanitizeForFilename, the same rules the merged PDF name goes through,
+// and drops control characters and leading dots.
+func normalizeFilenamePart(value, mode string) string {
+	cleaned := filenameControlChars.ReplaceAllString(value, "")
+	cleaned = utils.SanitizeForFilename(cleaned)
+	if mode == filenameNormalizationASCII {
+		cleaned = filenameNonASCII.ReplaceAllString(cleaned, "_")
+	}
+	cleaned = filenameUnderscores.ReplaceAllString(cleaned, "_")
+	return strings.TrimLeft(cleaned, ".")
+}
+
+// submissionFileName composes <original-name>_<submission-number><ext> from
+// the uploaded file's original name, normalized according to mode.
+func submissionFileName(originalName, submissionNumber, mode string) string {
+	ext := filepath.Ext(originalName)
+	base := normalizeFilenamePart(strings.TrimSuffix(originalName, ext), mode)
+	ext = normalizeFilenamePart(ext, mode)
+	if ext != "" {
+		ext = "." + ext
+	}
+	base = strings.Trim(base, "_. ")
+	if base == "" {
+		base = "file"
+	}
+
+	number := normalizeFilenamePart(submissionNumber, mode)
+	if number == "" {
+		return base + ext
+	}
+	return fmt.Sprintf("%s_%s%s", base, number, ext)
+}
@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	"fund-management-api/utils"
+)
+
+func TestSubmissionFileName(t *testing.T) {
+	cases := []struct {
+		original, number, mode, want string
+	}{
+		{"report.pdf", "PR-2568-0001", filenameNormalizationSanitize, "report_PR-2568-0001.pdf"},
+		{"บทความ ฉบับ:สุดท้าย?.pdf", "PR-2568-0001", filenameNormalizationSanitize, "บทความ_ฉบับ_สุดท้าย_PR-2568-0001.pdf"},
+		{"a\x00b\"c|d.docx", "FA-2568-0002", filenameNormalizationSanitize, "ab_c_d_FA-2568-0002.docx"},
+		{"../../etc/passwd", "PR-2568-0001", filenameNormalizationSanitize, "etc_passwd_PR-2568-0001"},
+		{"บทความ final.pdf", "PR-2568-0001", filenameNormalizationASCII, "final_PR-2568-0001.pdf"},
+		{"???.pdf", "", filenameNormalizationSanitize, "file.pdf"},
+	}
+	for _, tc := range cases {
+		if got := submissionFileName(tc.original, tc.number, tc.mode); got != tc.want {
+			t.Fatalf("submissionFileName(%q, %q, %s): expected %q, got %q", tc.original, tc.number, tc.mode, tc.want, got)
+		}
+	}
+}
+
+func TestContentDisposition_EncodesThaiNames(t *testing.T) {
+	got := utils.ContentDisposition("attachment", "บทความ_PR-2568-0001.pdf")
+	if !strings.HasPrefix(got, `attachment; filename="`) {
+		t.Fatalf("unexpected header %q", got)
+	}
+	if !strings.Contains(got, "filename*=UTF-8''%E0%B8%9A%E0%B8%97%E0%B8%84%E0%B8%A7%E0%B8%B2%E0%B8%A1_PR-2568-0001.pdf") {
+		t.Fatalf("expected an RFC 5987 filename*, got %q", got)
+	}
+	if strings.ContainsFunc(got, func(r rune) bool { return r > 0x7e }) {
+		t.Fatalf("header must be ASCII, got %q", got)
+	}
+}
+
+func TestContentDisposition_ASCIIFallback(t *testing.T) {
+	for name, want := range map[string]string{
+		`report "final".pdf`: `inline; filename="report _final_.pdf"; filename*=UTF-8''report%20%22final%22.pdf`,
+		"บทความ.pdf":         `inline; filename="download.pdf"; filename*=UTF-8''%E0%B8%9A%E0%B8%97%E0%B8%84%E0%B8%A7%E0%B8%B2%E0%B8%A1.pdf`,
+	} {
+		if got := utils.ContentDisposition("inline", name); got != want {
+			t.Fatalf("%q: expected %s, got %s", name, want, got)
+		}
+	}
+}
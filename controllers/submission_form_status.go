@@ -0,0 +1,488 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"fund-management-api/config"
+	"fund-management-api/models"
+	"fund-management-api/storage"
+	"fund-management-api/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Values of submissions.form_generation_status.
+const (
+	formGenerationPending = "pending"
+	formGenerationReady   = "ready"
+	formGenerationFailed  = "failed"
+)
+
+// submissionFormDocumentCodes returns the document-type codes of the request
+// form DOCX and PDF generated on submit for a submission type; ok is false for
+// types without a generated form.
+func submissionFormDocumentCodes(submissionType string) (docx, pdf string, ok bool) {
+	switch strings.TrimSpace(submissionType) {
+	case "publication_reward":
+		return publicationRewardFormDocumentCode, publicationRewardFormPdfDocumentCode, true
+	case "fund_application":
+		return fundApplicationFormDocumentCode, fundApplicationFormPdfDocumentCode, true
+	}
+	return "", "", false
+}
+
+// generateSubmissionForms renders the submission type's request form.
+func generateSubmissionForms(tx *gorm.DB, submission *models.Submission, now time.Time) error {
+	switch strings.TrimSpace(submission.SubmissionType) {
+	case "publication_reward":
+		return generatePublicationRewardForms(tx, submission, now)
+	case "fund_application":
+		return generateFundApplicationForms(tx, submission, now)
+	}
+	return fmt.Errorf("submission type %q has no generated form", submission.SubmissionType)
+}
+
+// runSubmissionFormGeneration generates the request forms in their own
+// transaction after the submit has committed and records the outcome on the
+// submission, so a LibreOffice failure leaves a retryable "failed" flag instead
+// of blocking the submit. The form job queue calls it for each attempt.
+func runSubmissionFormGeneration(submission *models.Submission) error {
+	now := time.Now()
+	genErr := config.DB.Transaction(func(tx *gorm.DB) error {
+		return generateSubmissionForms(tx, submission, now)
+	})
+
+	updates := map[string]interface{}{
+		"form_generation_status": formGenerationReady,
+		"form_generation_error":  gorm.Expr("NULL"),
+		"form_generated_at":      now,
+	}
+	if genErr != nil {
+		log.Printf("[formGeneration] submission %d: %v", submission.SubmissionID, genErr)
+		updates = map[string]interface{}{
+			"form_generation_status": formGenerationFailed,
+			"form_generation_error":  genErr.Error(),
+		}
+	}
+	if err := config.DB.Model(&models.Submission{}).
+		Where("submission_id = ?", submission.SubmissionID).
+		Updates(updates).Error; err != nil {
+		log.Printf("[formGeneration] failed to record outcome for submission %d: %v", submission.SubmissionID, err)
+	}
+	return genErr
+}
+
+type generatedFormFileStatus struct {
+	Exists       bool       `json:"exists"`
+	FilePresent  bool       `json:"file_present"`
+	DocumentID   *int       `json:"document_id"`
+	FileID       *int       `json:"file_id"`
+	OriginalName *string    `json:"original_name"`
+	FileSize     *int64     `json:"file_size"`
+	GeneratedAt  *time.Time `json:"generated_at"`
+}
+
+// generatedFormStatus describes the latest submission document of a generated
+// form type. FilePresent is false when the record survived but the file did not.
+func generatedFormStatus(ctx context.Context, store storage.Storage, documents []models.SubmissionDocument, code string) generatedFormFileStatus {
+	var latest *models.SubmissionDocument
+	for i := range documents {
+		if documents[i].DocumentType.Code != code {
+			continue
+		}
+		if latest == nil || documents[i].CreatedAt.After(latest.CreatedAt) {
+			latest = &documents[i]
+		}
+	}
+
+	status := generatedFormFileStatus{}
+	if latest == nil {
+		return status
+	}
+
+	status.Exists = true
+	documentID := latest.DocumentID
+	status.DocumentID = &documentID
+	generatedAt := latest.CreatedAt
+	status.GeneratedAt = &generatedAt
+	if latest.File.FileID != 0 {
+		fileID := latest.File.FileID
+		name := latest.File.OriginalName
+		size := latest.File.FileSize
+		status.FileID = &fileID
+		status.OriginalName = &name
+		status.FileSize = &size
+		if path := strings.TrimSpace(latest.File.StoredPath); path != "" {
+			if exists, err := storage.Exists(ctx, store, path); err == nil {
+				status.FilePresent = exists
+			}
+		}
+	}
+	return status
+}
+
+// GetSubmissionFormStatus reports whether the request-form DOCX and PDF that
+// SubmitSubmission generates exist for a submission.
+// GET /submissions/:id/form-status
+//
+// status is "ready" when both files exist, "partial" when only one does,
+// "missing" when neither does after submit, and "not_submitted" for drafts.
+// Only publication rewards and fund applications generate forms; other types
+// report "not_applicable".
+func GetSubmissionFormStatus(c *gin.Context) {
+	submissionID := c.Param("id")
+	userID := c.GetInt("userID")
+	roleID := c.GetInt("roleID")
+
+	var submission models.Submission
+	query := config.DB.Where("submission_id = ? AND deleted_at IS NULL", submissionID)
+	if roleID != 3 && roleID != 4 {
+		query = query.Where("user_id = ?", userID)
+	}
+	if err := query.First(&submission).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Submission not found"})
+		return
+	}
+
+	docxCode, pdfCode, hasForm := submissionFormDocumentCodes(submission.SubmissionType)
+	if !hasForm {
+		c.JSON(http.StatusOK, gin.H{
+			"success":       true,
+			"submission_id": submission.SubmissionID,
+			"status":        "not_applicable",
+			"docx":          generatedFormFileStatus{},
+			"pdf":           generatedFormFileStatus{},
+		})
+		return
+	}
+
+	var documents []models.SubmissionDocument
+	if err := config.DB.Preload("File").
+		Preload("DocumentType").
+		Joins("JOIN document_types dt ON dt.document_type_id = submission_documents.document_type_id").
+		Where("submission_documents.submission_id = ?", submission.SubmissionID).
+		Where("dt.code IN ?", []string{docxCode, pdfCode}).
+		Find(&documents).Error; err != nil {
+		InternalError(c, "submission form status", err)
+		return
+	}
+
+	store := storage.Default()
+	docx := generatedFormStatus(c.Request.Context(), store, documents, docxCode)
+	pdf := generatedFormStatus(c.Request.Context(), store, documents, pdfCode)
+
+	generationStatus := ""
+	if submission.FormGenerationStatus != nil {
+		generationStatus = *submission.FormGenerationStatus
+	}
+
+	status := "missing"
+	switch {
+	case generationStatus == formGenerationPending:
+		status = formGenerationPending
+	case docx.FilePresent && pdf.FilePresent:
+		status = "ready"
+	case generationStatus == formGenerationFailed:
+		status = formGenerationFailed
+	case docx.FilePresent || pdf.FilePresent:
+		status = "partial"
+	case submission.SubmittedAt == nil:
+		status = "not_submitted"
+	}
+
+	generation := gin.H{
+		"status":       submission.FormGenerationStatus,
+		"generated_at": submission.FormGeneratedAt,
+	}
+	if roleID == 3 && submission.FormGenerationError != nil {
+		generation["error"] = *submission.FormGenerationError
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":       true,
+		"submission_id": submission.SubmissionID,
+		"status":        status,
+		"submitted_at":  submission.SubmittedAt,
+		"can_retry":     submission.SubmittedAt != nil && status != "ready" && status != formGenerationPending,
+		"generation":    generation,
+		"docx":          docx,
+		"pdf":           pdf,
+	})
+}
+
+// formRegenerationBlocker returns why the submission's request form cannot be
+// generated again, or "" when it can.
+func formRegenerationBlocker(submission *models.Submission) (string, error) {
+	if _, _, ok := submissionFormDocumentCodes(submission.SubmissionType); !ok {
+		return "This submission type has no generated form", nil
+	}
+	isDraft, err := utils.StatusMatchesCodes(submission.StatusID, utils.StatusCodeDraft)
+	if err != nil {
+		return "", err
+	}
+	if submission.SubmittedAt == nil || isDraft {
+		return "Submission has not been submitted", nil
+	}
+	return "", nil
+}
+
+// RegenerateSubmissionForm queues request-form generation again for a submitted
+// publication reward or fund application, e.g. after the post-submit job failed.
+// POST /submissions/:id/form/regenerate
+func RegenerateSubmissionForm(c *gin.Context) {
+	submissionID := c.Param("id")
+	userID := c.GetInt("userID")
+	roleID := c.GetInt("roleID")
+
+	var submission models.Submission
+	query := config.DB.Preload("User").Where("submission_id = ? AND deleted_at IS NULL", submissionID)
+	if roleID != 3 {
+		query = query.Where("user_id = ?", userID)
+	}
+	if err := query.First(&submission).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Submission not found"})
+		return
+	}
+
+	reason, err := formRegenerationBlocker(&submission)
+	if err != nil {
+		InternalError(c, "regenerate submission form", err)
+		return
+	}
+	if reason != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": reason})
+		return
+	}
+
+	job, err := EnqueueFormGeneration(submission.SubmissionID)
+	if err != nil {
+		InternalError(c, "regenerate submission form", err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"status":  formGenerationPending,
+		"job_id":  job.JobID,
+	})
+}
+
+type missingFormSubmission struct {
+	SubmissionID         int        `json:"submission_id"`
+	SubmissionNumber     string     `json:"submission_number"`
+	UserID               int        `json:"user_id"`
+	OwnerName            string     `json:"owner_name"`
+	YearID               int        `json:"year_id"`
+	StatusID             int        `json:"status_id"`
+	SubmittedAt          *time.Time `json:"submitted_at"`
+	FormGenerationStatus *string    `json:"form_generation_status"`
+	FormGenerationError  *string    `json:"form_generation_error"`
+	HasDocx              bool       `json:"has_docx"`
+}
+
+// missingFormSubmissionsQuery selects submitted publication rewards and fund
+// applications that have no generated request-form PDF document. Fund
+// applications only count once they were submitted with form generation (a
+// form_generation_status is set); older ones never had a generated form.
+func missingFormSubmissionsQuery(db *gorm.DB, draftIDs []int) *gorm.DB {
+	return db.Table("submissions s").
+		Where("s.deleted_at IS NULL AND s.submitted_at IS NOT NULL").
+		Where("(s.submission_type = 'publication_reward' OR (s.submission_type = 'fund_application' AND s.form_generation_status IS NOT NULL))").
+		Where("s.status_id NOT IN ?", ensureIDs(draftIDs)).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM submission_documents sd
+			JOIN document_types dt ON dt.document_type_id = sd.document_type_id
+			WHERE sd.submission_id = s.submission_id AND dt.code IN ?)`,
+			[]string{publicationRewardFormPdfDocumentCode, fundApplicationFormPdfDocumentCode})
+}
+
+// GetAdminMissingFormSubmissions lists submitted publication rewards and fund
+// applications without a generated request-form PDF, e.g. after a failed
+// conversion.
+// GET /admin/submissions/missing-forms?year_id=&page=&limit=
+func GetAdminMissingFormSubmissions(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if page < 1 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 500 {
+		limit = 500
+	}
+	offset := (page - 1) * limit
+
+	draftIDs, err := utils.GetStatusIDsByCodes(utils.StatusCodeDraft)
+	if err != nil {
+		InternalError(c, "missing forms: resolve draft status", err)
+		return
+	}
+
+	query := missingFormSubmissionsQuery(config.DB, draftIDs)
+	if yearID, err := strconv.Atoi(c.Query("year_id")); err == nil && yearID > 0 {
+		query = query.Where("s.year_id = ?", yearID)
+	}
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		InternalError(c, "missing forms: count", err)
+		return
+	}
+
+	items := make([]missingFormSubmission, 0)
+	if err := query.
+		Select(`s.submission_id, s.submission_number, s.user_id,
+			TRIM(CONCAT(COALESCE(u.user_fname,''),' ',COALESCE(u.user_lname,''))) AS owner_name,
+			s.year_id, s.status_id, s.submitted_at, s.form_generation_status, s.form_generation_error,
+			EXISTS (SELECT 1 FROM submission_documents sd
+				JOIN document_types dt ON dt.document_type_id = sd.document_type_id
+				WHERE sd.submission_id = s.submission_id AND dt.code IN ?) AS has_docx`,
+			[]string{publicationRewardFormDocumentCode, fundApplicationFormDocumentCode}).
+		Joins("LEFT JOIN users u ON u.user_id = s.user_id").
+		Order("s.submitted_at DESC").
+		Order("s.submission_id DESC").
+		Offset(offset).Limit(limit).
+		Scan(&items).Error; err != nil {
+		InternalError(c, "missing forms: list", err)
+		return
+	}
+
+	totalPages := (totalCount + int64(limit) - 1) / int64(limit)
+	c.JSON(http.StatusOK, gin.H{
+		"success":     true,
+		"submissions": items,
+		"pagination": gin.H{
+			"current_page": page,
+			"per_page":     limit,
+			"total_count":  totalCount,
+			"total_pages":  totalPages,
+			"has_next":     page < int(totalPages),
+			"has_prev":     page > 1,
+		},
+	})
+}
+
+// maxMissingFormQueueBatch bounds one queue-missing-forms request.
+const maxMissingFormQueueBatch = 500
+
+type bulkFormRegenerationResult struct {
+	SubmissionID int    `json:"submission_id"`
+	Status       string `json:"status"` // pending | failed | skipped
+	JobID        int    `json:"job_id,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// QueueMissingFormRegeneration queues form generation on the background job
+// queue and reports per submission whether it was queued, e.g. to recover after
+// a LibreOffice outage. It takes the given submission_ids, or without them the
+// submissions currently missing their form, optionally limited to year_id. At
+// most maxMissingFormQueueBatch are queued per request. Submissions that cannot
+// have a form regenerated are skipped; those that already have a queued or
+// running job keep that job.
+// POST /admin/submissions/missing-forms/regenerate {"submission_ids": [...]} | {"year_id": 3}
+func QueueMissingFormRegeneration(c *gin.Context) {
+	var req struct {
+		SubmissionIDs []int `json:"submission_ids"`
+		YearID        int   `json:"year_id"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid request data"})
+		return
+	}
+	if len(req.SubmissionIDs) > maxMissingFormQueueBatch {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   fmt.Sprintf("At most %d submissions can be queued per request", maxMissingFormQueueBatch),
+		})
+		return
+	}
+
+	submissionIDs := req.SubmissionIDs
+	if len(submissionIDs) == 0 {
+		draftIDs, err := utils.GetStatusIDsByCodes(utils.StatusCodeDraft)
+		if err != nil {
+			InternalError(c, "queue missing forms: resolve draft status", err)
+			return
+		}
+		query := missingFormSubmissionsQuery(config.DB, draftIDs)
+		if req.YearID > 0 {
+			query = query.Where("s.year_id = ?", req.YearID)
+		}
+		if err := query.Order("s.submission_id ASC").
+			Limit(maxMissingFormQueueBatch).
+			Pluck("s.submission_id", &submissionIDs).Error; err != nil {
+			InternalError(c, "queue missing forms: load submissions", err)
+			return
+		}
+	}
+
+	results := make([]bulkFormRegenerationResult, 0, len(submissionIDs))
+	counts := map[string]int{formGenerationPending: 0, formGenerationFailed: 0, "skipped": 0}
+	for _, submissionID := range submissionIDs {
+		result := bulkFormRegenerationResult{SubmissionID: submissionID, Status: "skipped"}
+
+		var submission models.Submission
+		err := config.DB.Where("submission_id = ? AND deleted_at IS NULL", submissionID).
+			First(&submission).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			result.Error = "Submission not found"
+		case err != nil:
+			log.Printf("[formGeneration] bulk: failed to load submission %d: %v", submissionID, err)
+			result.Status = formGenerationFailed
+			result.Error = "Failed to load submission"
+		default:
+			result = queueFormForBulk(&submission)
+		}
+
+		counts[result.Status]++
+		results = append(results, result)
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success":       true,
+		"total":         len(results),
+		"queued_count":  counts[formGenerationPending],
+		"failed_count":  counts[formGenerationFailed],
+		"skipped_count": counts["skipped"],
+		"results":       results,
+	})
+}
+
+func queueFormForBulk(submission *models.Submission) bulkFormRegenerationResult {
+	result := bulkFormRegenerationResult{SubmissionID: submission.SubmissionID, Status: "skipped"}
+
+	reason, err := formRegenerationBlocker(submission)
+	if err != nil {
+		log.Printf("[formGeneration] bulk: submission %d: %v", submission.SubmissionID, err)
+		result.Status = formGenerationFailed
+		result.Error = "Failed to check submission status"
+		return result
+	}
+	if reason != "" {
+		result.Error = reason
+		return result
+	}
+
+	job, err := EnqueueFormGeneration(submission.SubmissionID)
+	if err != nil {
+		log.Printf("[formJobs] bulk: failed to queue submission %d: %v", submission.SubmissionID, err)
+		result.Status = formGenerationFailed
+		result.Error = "Failed to queue form generation"
+		return result
+	}
+	result.Status = formGenerationPending
+	result.JobID = job.JobID
+	return result
+}
@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+
+	"fund-management-api/config"
+
+	"gorm.io/gorm"
+)
+
+// errSubmissionFundYearMismatch marks validation failures that should be reported as 400.
+var errSubmissionFundYearMismatch = errors.New("fund year mismatch")
+
+// validateSubmissionFundYear ensures the category/subcategory chosen for a submission
+// belong to the submission's year (fund_categories.year_id), and that the subcategory
+// belongs to the chosen category. Dashboard aggregates group by submissions.year_id and
+// assume the fund matches it. Nil or zero IDs are skipped.
+func validateSubmissionFundYear(db *gorm.DB, yearID int, categoryID, subcategoryID *int) error {
+	if db == nil {
+		db = config.DB
+	}
+
+	if categoryID != nil && *categoryID > 0 {
+		var row struct {
+			YearID int
+		}
+		result := db.Table("fund_categories").
+			Select("year_id").
+			Where("category_id = ? AND delete_at IS NULL", *categoryID).
+			Limit(1).
+			Scan(&row)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: category %d not found", errSubmissionFundYearMismatch, *categoryID)
+		}
+		if row.YearID != yearID {
+			return fmt.Errorf("%w: category %d belongs to a different year", errSubmissionFundYearMismatch, *categoryID)
+		}
+	}
+
+	if subcategoryID != nil && *subcategoryID > 0 {
+		var row struct {
+			CategoryID int
+			YearID     int
+		}
+		result := db.Table("fund_subcategories fs").
+			Select("fs.category_id, fc.year_id").
+			Joins("JOIN fund_categories fc ON fc.category_id = fs.category_id").
+			Where("fs.subcategory_id = ? AND fs.delete_at IS NULL", *subcategoryID).
+			Limit(1).
+			Scan(&row)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: subcategory %d not found", errSubmissionFundYearMismatch, *subcategoryID)
+		}
+		if row.YearID != yearID {
+			return fmt.Errorf("%w: subcategory %d belongs to a different year", errSubmissionFundYearMismatch, *subcategoryID)
+		}
+		if categoryID != nil && *categoryID > 0 && row.CategoryID != *categoryID {
+			return fmt.Errorf("%w: subcategory %d does not belong to category %d", errSubmissionFundYearMismatch, *subcategoryID, *categoryID)
+		}
+	}
+
+	return nil
+}
@@ -925,3 +925,82 @@ func SearchSubmissions(c *gin.Context) {
 		},
 	})
 }
+
+// submissionsByInstallmentQuery selects the non-draft submissions assigned to
+// one installment of a year.
+func submissionsByInstallmentQuery(yearID, installment int) *gorm.DB {
+	query := config.DB.Model(&models.Submission{}).
+		Where("submissions.deleted_at IS NULL").
+		Where("submissions.year_id = ?", yearID).
+		Where("submissions.installment_number_at_submit = ?", installment)
+	if draftStatusID, err := utils.GetStatusIDByCode(utils.StatusCodeDraft); err == nil && draftStatusID > 0 {
+		query = query.Where("submissions.status_id <> ?", draftStatusID)
+	}
+	return query
+}
+
+// GetAdminSubmissionsByInstallment lists the submissions assigned to one
+// installment of a year (installment_number_at_submit), for reconciling a
+// closed round. Drafts are never assigned an installment and are excluded.
+func GetAdminSubmissionsByInstallment(c *gin.Context) {
+	yearID, err := strconv.Atoi(strings.TrimSpace(c.Query("year_id")))
+	if err != nil || yearID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "year_id is required"})
+		return
+	}
+	installment, err := strconv.Atoi(strings.TrimSpace(c.Query("installment")))
+	if err != nil || installment <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "installment is required"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if page < 1 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 1000 {
+		limit = 1000
+	}
+	offset := (page - 1) * limit
+
+	query := submissionsByInstallmentQuery(yearID, installment)
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		InternalError(c, "submissions by installment", err)
+		return
+	}
+
+	var submissions []models.Submission
+	if err := query.Preload("User").Preload("Year").Preload("Status").Preload("Category").Preload("Subcategory").
+		Order("submissions.submitted_at ASC, submissions.submission_id ASC").
+		Offset(offset).Limit(limit).
+		Find(&submissions).Error; err != nil {
+		InternalError(c, "submissions by installment", err)
+		return
+	}
+
+	if err := enrichAdminSubmissionListDetails(submissions); err != nil {
+		log.Printf("GetAdminSubmissionsByInstallment detail enrichment error: %v", err)
+	}
+
+	totalPages := (totalCount + int64(limit) - 1) / int64(limit)
+	c.JSON(http.StatusOK, gin.H{
+		"success":     true,
+		"year_id":     yearID,
+		"installment": installment,
+		"submissions": toAdminSubmissionListItems(submissions),
+		"pagination": gin.H{
+			"current_page": page,
+			"per_page":     limit,
+			"total_count":  totalCount,
+			"total_pages":  totalPages,
+			"has_next":     page < int(totalPages),
+			"has_prev":     page > 1,
+		},
+	})
+}
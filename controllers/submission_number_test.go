@@ -0,0 +1,291 @@
+package controllers
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"fund-management-api/config"
+	"fund-management-api/models"
+	"fund-management-api/utils"
+
+	"gorm.io/gorm"
+)
+
+// sequenceStore models submission_sequences. SELECT ... FOR UPDATE holds the
+// row lock until the transaction ends, as InnoDB would for the row.
+type sequenceStore struct {
+	mu      sync.Mutex
+	numbers map[string]int64
+	seed    int64
+	// statuses answers application_status lookups by id with a status code.
+	statuses map[int64]string
+}
+
+func (s *sequenceStore) key(args []driver.NamedValue) string {
+	return fmt.Sprint(args[0].Value, "/", args[1].Value, "/", args[2].Value)
+}
+
+func newSequenceDB(t *testing.T, store *sequenceStore) *gorm.DB {
+	t.Helper()
+
+	return newLockingGormDB(t, 8, lockingSQLHandler{
+		query: func(query string, args []driver.NamedValue, _ bool) ([]string, []driver.Value, error) {
+			if strings.Contains(query, "FROM `application_status`") {
+				id := args[0].Value.(int64)
+				code, ok := store.statuses[id]
+				if !ok {
+					return nil, nil, fmt.Errorf("unexpected status id %d", id)
+				}
+				return []string{"application_status_id", "status_code", "status_name"}, []driver.Value{id, code, code}, nil
+			}
+			if strings.Contains(query, "FROM system_config") {
+				return []string{"current_year"}, []driver.Value{"2568"}, nil
+			}
+			if !strings.HasPrefix(query, "SELECT last_number FROM submission_sequences") || !strings.HasSuffix(query, "FOR UPDATE") {
+				return nil, nil, fmt.Errorf("unexpected query: %s", query)
+			}
+			store.mu.Lock()
+			last := store.numbers[store.key(args)]
+			store.mu.Unlock()
+			return []string{"last_number"}, []driver.Value{last}, nil
+		},
+		exec: func(query string, args []driver.NamedValue, locked bool) (int64, error) {
+			store.mu.Lock()
+			defer store.mu.Unlock()
+
+			switch {
+			case strings.HasPrefix(query, "INSERT IGNORE INTO submission_sequences"):
+				key := store.key(args)
+				if _, ok := store.numbers[key]; ok {
+					return 0, nil
+				}
+				store.numbers[key] = store.seed
+				return 1, nil
+			case strings.HasPrefix(query, "UPDATE submission_sequences SET last_number"):
+				if !locked {
+					return 0, errors.New("sequence updated without row lock")
+				}
+				store.numbers[store.key(args[2:])] = args[0].Value.(int64)
+				return 1, nil
+			}
+			return 0, fmt.Errorf("unexpected exec: %s", query)
+		},
+	})
+}
+
+func TestNextSubmissionNumber_ConcurrentCallersGetUniqueConsecutiveNumbers(t *testing.T) {
+	store := &sequenceStore{numbers: map[string]int64{}, seed: 6}
+	db := newSequenceDB(t, store)
+
+	const callers = 50
+	numbers := make([]string, callers)
+	errs := make([]error, callers)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			errs[i] = db.Transaction(func(tx *gorm.DB) error {
+				number, err := nextSubmissionNumber(tx, "FA", "2568", 0, time.Now())
+				numbers[i] = number
+				return err
+			})
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	seen := make(map[string]bool, callers)
+	for i, number := range numbers {
+		if errs[i] != nil {
+			t.Fatalf("caller %d failed: %v", i, errs[i])
+		}
+		if seen[number] {
+			t.Fatalf("duplicate submission number %s", number)
+		}
+		seen[number] = true
+	}
+	// Seeded at 6 (FA-2568-0006 already issued), so the callers take 7..56.
+	for n := 7; n < 7+callers; n++ {
+		if want := fmt.Sprintf("FA-2568-%04d", n); !seen[want] {
+			t.Fatalf("expected %s to be issued; numbers must have no gaps", want)
+		}
+	}
+}
+
+func TestNextSubmissionNumber_SeparateSequencePerPrefixYearAndInstallment(t *testing.T) {
+	store := &sequenceStore{numbers: map[string]int64{}}
+	db := newSequenceDB(t, store)
+
+	for _, tc := range []struct {
+		prefix, year string
+		installment  int
+		want         string
+	}{
+		{"PR", "2568", 0, "PR-2568-0001"},
+		{"PR", "2568", 0, "PR-2568-0002"},
+		{"FA", "2568", 0, "FA-2568-0001"},
+		{"PR", "2569", 0, "PR-2569-0001"},
+		{"PR", "2568", 1, "PR-2568-01-0001"},
+		{"PR", "2568", 1, "PR-2568-01-0002"},
+		{"PR", "2568", 2, "PR-2568-02-0001"},
+		{"PR", "2568", 0, "PR-2568-0003"},
+	} {
+		var got string
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var err error
+			got, err = nextSubmissionNumber(tx, tc.prefix, tc.year, tc.installment, time.Now())
+			return err
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != tc.want {
+			t.Fatalf("expected %s, got %s", tc.want, got)
+		}
+	}
+}
+
+func TestGenerateAnnounceReferenceNumber_ConcurrentBatchesGetDistinctReferences(t *testing.T) {
+	store := &sequenceStore{numbers: map[string]int64{}, seed: 3}
+	db := newSequenceDB(t, store)
+	previous := config.DB
+	config.DB = db
+	t.Cleanup(func() { config.DB = previous })
+
+	// Each caller also reads system_config outside its transaction, so stay
+	// below the connection limit.
+	const callers = 6
+	refs := make([]string, callers)
+	errs := make([]error, callers)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			errs[i] = db.Transaction(func(tx *gorm.DB) error {
+				ref, err := generateAnnounceReferenceNumber(tx)
+				refs[i] = ref
+				return err
+			})
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	seen := make(map[string]bool, callers)
+	for i, ref := range refs {
+		if errs[i] != nil {
+			t.Fatalf("caller %d failed: %v", i, errs[i])
+		}
+		if seen[ref] {
+			t.Fatalf("duplicate announce reference %s", ref)
+		}
+		seen[ref] = true
+	}
+	// Seeded at 3 (ANN-2568-0003 already issued), so the callers take 4..9.
+	for n := 4; n < 4+callers; n++ {
+		if want := fmt.Sprintf("ANN-2568-%04d", n); !seen[want] {
+			t.Fatalf("expected %s to be issued", want)
+		}
+	}
+}
+
+func TestSubmissionNumberPolicy_DefaultsToYearly(t *testing.T) {
+	for value, want := range map[string]string{
+		"":            submissionNumberPolicyYearly,
+		"bogus":       submissionNumberPolicyYearly,
+		"Installment": submissionNumberPolicyInstallment,
+	} {
+		t.Setenv("SUBMISSION_NUMBER_POLICY", value)
+		if got := submissionNumberPolicy(); got != want {
+			t.Fatalf("SUBMISSION_NUMBER_POLICY=%q: expected %s, got %s", value, want, got)
+		}
+	}
+}
+
+func TestSubmitSubmissionNumber_ReissuesDraftNumberForSubmitInstallment(t *testing.T) {
+	t.Setenv("SUBMISSION_NUMBER_POLICY", submissionNumberPolicyInstallment)
+	const draftID, needsMoreInfoID, deptHeadPendingID = 4, 3, 5
+	store := &sequenceStore{numbers: map[string]int64{}, statuses: map[int64]string{
+		draftID:           utils.StatusCodeDraft,
+		needsMoreInfoID:   utils.StatusCodeNeedsMoreInfo,
+		deptHeadPendingID: utils.StatusCodeDeptHeadPending,
+	}}
+	db := newSequenceDB(t, store)
+	previous := config.DB
+	config.DB = db
+	utils.ResetStatusCache()
+	t.Cleanup(func() {
+		config.DB = previous
+		utils.ResetStatusCache()
+	})
+	first, second := 1, 2
+
+	number := func(submission models.Submission, installment *int) string {
+		t.Helper()
+		var got string
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var err error
+			got, err = submitSubmissionNumber(tx, submission, installment, "2568", time.Now())
+			return err
+		})
+		if err != nil {
+			t.Fatalf("submitSubmissionNumber: %v", err)
+		}
+		return got
+	}
+
+	for _, tc := range []struct {
+		name        string
+		submission  models.Submission
+		installment *int
+		want        string
+	}{
+		{"draft already numbered for the installment", models.Submission{StatusID: draftID, SubmissionType: "publication_reward", SubmissionNumber: "PR-2568-02-0009"}, &second, ""},
+		{"installment not resolved", models.Submission{StatusID: draftID, SubmissionType: "publication_reward", SubmissionNumber: "PR-2568-01-0004"}, nil, ""},
+		{"draft created last year", models.Submission{StatusID: draftID, SubmissionType: "fund_application", SubmissionNumber: "FA-2567-01-0009"}, &first, "FA-2568-01-0001"},
+	} {
+		if got := number(tc.submission, tc.installment); got != tc.want {
+			t.Fatalf("%s: expected %q, got %q", tc.name, tc.want, got)
+		}
+	}
+
+	// A draft created in installment 1 and first submitted in installment 2 is
+	// renumbered, as SubmitSubmission then records installment 2.
+	submission := models.Submission{StatusID: draftID, SubmissionType: "publication_reward", SubmissionNumber: "PR-2568-01-0004"}
+	renumbered := number(submission, &second)
+	if renumbered != "PR-2568-02-0001" {
+		t.Fatalf("first submit: expected PR-2568-02-0001, got %q", renumbered)
+	}
+	submittedAt := time.Now()
+	submission.SubmissionNumber = renumbered
+	submission.StatusID = deptHeadPendingID
+	submission.SubmittedAt = &submittedAt
+	submission.InstallmentNumberAtSubmit = &second
+
+	// RequestSubmissionRevision / DeptHeadRequestRevision: back to
+	// needs_more_info with submitted_at cleared.
+	submission.StatusID = needsMoreInfoID
+	submission.SubmittedAt = nil
+
+	// Resubmitted after installment 2 closed: the number stays.
+	third := 3
+	if got := number(submission, &third); got != "" {
+		t.Fatalf("resubmission after revision: expected the number to stay, got %q", got)
+	}
+
+	t.Setenv("SUBMISSION_NUMBER_POLICY", submissionNumberPolicyYearly)
+	if got := number(models.Submission{StatusID: draftID, SubmissionType: "publication_reward", SubmissionNumber: "PR-2568-0004"}, &second); got != "" {
+		t.Fatalf("expected the yearly policy to keep the draft number, got %q", got)
+	}
+}
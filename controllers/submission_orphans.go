@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"fund-management-api/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+type orphanSubmissionItem struct {
+	SubmissionID     int        `json:"submission_id"`
+	SubmissionNumber string     `json:"submission_number"`
+	SubmissionType   string     `json:"submission_type"`
+	ApplicantID      int        `json:"applicant_id"`
+	ApplicantName    string     `json:"applicant_name"`
+	YearID           int        `json:"year_id"`
+	StatusID         int        `json:"status_id"`
+	MissingDetail    string     `json:"missing_detail"`
+	SubmittedAt      *time.Time `json:"submitted_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// GetAdminOrphanSubmissions lists submitted submissions whose type-specific
+// detail row (fund_application_details or publication_reward_details) is
+// missing, typically left by an interrupted create flow. Such rows drop out of
+// the dashboard's detail joins without any error.
+// GET /admin/submissions/orphans?submission_type=&year_id=&page=&limit=
+func GetAdminOrphanSubmissions(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if page < 1 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 200 {
+		limit = 200
+	}
+	offset := (page - 1) * limit
+
+	query := config.DB.Table("submissions s").
+		Joins("LEFT JOIN fund_application_details fad ON fad.submission_id = s.submission_id").
+		Joins("LEFT JOIN publication_reward_details prd ON prd.submission_id = s.submission_id").
+		Joins("LEFT JOIN users u ON u.user_id = s.user_id").
+		Where("s.deleted_at IS NULL AND s.submitted_at IS NOT NULL").
+		Where(`(s.submission_type = 'fund_application' AND fad.submission_id IS NULL)
+			OR (s.submission_type = 'publication_reward' AND prd.submission_id IS NULL)`)
+
+	if submissionType := strings.TrimSpace(c.Query("submission_type")); submissionType != "" {
+		if submissionType != "fund_application" && submissionType != "publication_reward" {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "submission_type must be fund_application or publication_reward"})
+			return
+		}
+		query = query.Where("s.submission_type = ?", submissionType)
+	}
+	if raw := strings.TrimSpace(c.Query("year_id")); raw != "" {
+		yearID, err := strconv.Atoi(raw)
+		if err != nil || yearID <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid year_id"})
+			return
+		}
+		query = query.Where("s.year_id = ?", yearID)
+	}
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		InternalError(c, "orphan submissions: count", err)
+		return
+	}
+
+	items := make([]orphanSubmissionItem, 0)
+	if err := query.
+		Select(`s.submission_id, s.submission_number, s.submission_type,
+			s.user_id AS applicant_id,
+			TRIM(CONCAT(COALESCE(u.user_fname,''),' ',COALESCE(u.user_lname,''))) AS applicant_name,
+			s.year_id, s.status_id,
+			CASE WHEN s.submission_type = 'fund_application' THEN 'fund_application_details'
+			     ELSE 'publication_reward_details' END AS missing_detail,
+			s.submitted_at, s.created_at`).
+		Order("s.submitted_at ASC").
+		Order("s.submission_id ASC").
+		Offset(offset).Limit(limit).
+		Scan(&items).Error; err != nil {
+		InternalError(c, "orphan submissions: list", err)
+		return
+	}
+
+	totalPages := (totalCount + int64(limit) - 1) / int64(limit)
+	c.JSON(http.StatusOK, gin.H{
+		"success":     true,
+		"submissions": items,
+		"pagination": gin.H{
+			"current_page": page,
+			"per_page":     limit,
+			"total_count":  totalCount,
+			"total_pages":  totalPages,
+			"has_next":     page < int(totalPages),
+			"has_prev":     page > 1,
+		},
+	})
+}
@@ -0,0 +1,262 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+
+	"fund-management-api/config"
+	"fund-management-api/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// rewardSplitTolerance absorbs DECIMAL(5,2) rounding when checking the 100% total.
+const rewardSplitTolerance = 0.01
+
+type rewardSplitEntry struct {
+	UserID          int     `json:"user_id"`
+	Name            string  `json:"name"`
+	Email           string  `json:"email,omitempty"`
+	Role            string  `json:"role"`
+	IsApplicant     bool    `json:"is_applicant"`
+	SharePercentage float64 `json:"share_percentage"`
+	ShareAmount     float64 `json:"share_amount"`
+}
+
+// loadRewardSplitSubmission loads the submission for the caller (owner or admin).
+func loadRewardSplitSubmission(c *gin.Context) (*models.Submission, bool) {
+	userID, _ := c.Get("userID")
+	roleID, _ := c.Get("roleID")
+
+	query := config.DB.Preload("User").Preload("PublicationRewardDetail").
+		Where("submission_id = ? AND deleted_at IS NULL", c.Param("id"))
+	if roleID.(int) != 3 { // Not admin
+		query = query.Where("user_id = ?", userID)
+	}
+
+	var submission models.Submission
+	if err := query.First(&submission).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Submission not found"})
+		} else {
+			InternalError(c, "reward split", err)
+		}
+		return nil, false
+	}
+	if submission.SubmissionType != "publication_reward" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Reward split is only available for publication rewards"})
+		return nil, false
+	}
+	return &submission, true
+}
+
+// rewardSplitBaseAmount is the approved reward when set, otherwise the requested one.
+func rewardSplitBaseAmount(detail *models.PublicationRewardDetail) float64 {
+	if detail == nil {
+		return 0
+	}
+	if detail.RewardApproveAmount > 0 {
+		return detail.RewardApproveAmount
+	}
+	return detail.RewardAmount
+}
+
+// buildRewardSplit lists every author with their share. When no shares are stored
+// the applicant receives the whole reward, matching the behaviour before splits.
+func buildRewardSplit(db *gorm.DB, submission *models.Submission) ([]rewardSplitEntry, bool, error) {
+	var members []models.SubmissionUser
+	if err := db.Preload("User").
+		Where("submission_id = ? AND role IN ?", submission.SubmissionID, []string{"owner", "coauthor"}).
+		Order("display_order ASC").
+		Find(&members).Error; err != nil {
+		return nil, false, err
+	}
+
+	configured := false
+	for _, member := range members {
+		if member.SharePercentage != nil {
+			configured = true
+			break
+		}
+	}
+
+	baseAmount := rewardSplitBaseAmount(submission.PublicationRewardDetail)
+	entries := make([]rewardSplitEntry, 0, len(members)+1)
+	applicantListed := false
+	for _, member := range members {
+		entry := rewardSplitEntry{
+			UserID:      member.UserID,
+			Role:        member.Role,
+			IsApplicant: member.UserID == submission.UserID,
+		}
+		if member.User != nil {
+			entry.Name = strings.TrimSpace(member.User.UserFname + " " + member.User.UserLname)
+			entry.Email = member.User.Email
+		}
+		if member.SharePercentage != nil {
+			entry.SharePercentage = *member.SharePercentage
+		} else if !configured && entry.IsApplicant {
+			entry.SharePercentage = 100
+		}
+		if entry.IsApplicant {
+			applicantListed = true
+		}
+		entries = append(entries, entry)
+	}
+
+	if !applicantListed {
+		entry := rewardSplitEntry{UserID: submission.UserID, Role: "owner", IsApplicant: true}
+		if submission.User != nil {
+			entry.Name = strings.TrimSpace(submission.User.UserFname + " " + submission.User.UserLname)
+			entry.Email = submission.User.Email
+		}
+		if !configured {
+			entry.SharePercentage = 100
+		}
+		entries = append([]rewardSplitEntry{entry}, entries...)
+	}
+
+	for i := range entries {
+		entries[i].ShareAmount = math.Round(baseAmount*entries[i].SharePercentage) / 100
+	}
+	return entries, configured, nil
+}
+
+// GetSubmissionRewardSplit returns each author's share of a publication reward.
+// GET /api/v1/submissions/:id/reward-split
+func GetSubmissionRewardSplit(c *gin.Context) {
+	submission, ok := loadRewardSplitSubmission(c)
+	if !ok {
+		return
+	}
+
+	entries, configured, err := buildRewardSplit(config.DB, submission)
+	if err != nil {
+		InternalError(c, "reward split", err)
+		return
+	}
+
+	total := 0.0
+	for _, entry := range entries {
+		total += entry.SharePercentage
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":          true,
+		"submission_id":    submission.SubmissionID,
+		"base_amount":      rewardSplitBaseAmount(submission.PublicationRewardDetail),
+		"configured":       configured,
+		"total_percentage": total,
+		"valid":            math.Abs(total-100) <= rewardSplitTolerance,
+		"authors":          entries,
+	})
+}
+
+// UpdateSubmissionRewardSplit replaces the reward shares. Shares must cover only
+// the applicant and co-authors of the submission and add up to exactly 100%.
+// PUT /api/v1/submissions/:id/reward-split
+func UpdateSubmissionRewardSplit(c *gin.Context) {
+	submission, ok := loadRewardSplitSubmission(c)
+	if !ok {
+		return
+	}
+
+	roleID, _ := c.Get("roleID")
+	if roleID.(int) != 3 && !submission.IsEditable() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot modify submitted submission"})
+		return
+	}
+
+	var req struct {
+		Shares []struct {
+			UserID          int     `json:"user_id" binding:"required"`
+			SharePercentage float64 `json:"share_percentage"`
+		} `json:"shares" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || len(req.Shares) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "shares is required"})
+		return
+	}
+
+	var members []models.SubmissionUser
+	if err := config.DB.Where("submission_id = ? AND role IN ?", submission.SubmissionID, []string{"owner", "coauthor"}).
+		Find(&members).Error; err != nil {
+		InternalError(c, "reward split", err)
+		return
+	}
+	memberByUser := make(map[int]models.SubmissionUser, len(members))
+	for _, member := range members {
+		memberByUser[member.UserID] = member
+	}
+
+	shares := make(map[int]float64, len(req.Shares))
+	total := 0.0
+	for _, share := range req.Shares {
+		if _, dup := shares[share.UserID]; dup {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("User %d is listed more than once", share.UserID)})
+			return
+		}
+		if share.SharePercentage < 0 || share.SharePercentage > 100 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "share_percentage must be between 0 and 100"})
+			return
+		}
+		if _, isMember := memberByUser[share.UserID]; !isMember && share.UserID != submission.UserID {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("User %d is not an author of this submission", share.UserID)})
+			return
+		}
+		shares[share.UserID] = math.Round(share.SharePercentage*100) / 100
+		total += shares[share.UserID]
+	}
+	if math.Abs(total-100) > rewardSplitTolerance {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":            "Reward shares must add up to 100%",
+			"total_percentage": total,
+		})
+		return
+	}
+
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		// Authors left out of the request no longer receive a share.
+		if err := tx.Model(&models.SubmissionUser{}).
+			Where("submission_id = ? AND role IN ?", submission.SubmissionID, []string{"owner", "coauthor"}).
+			Update("share_percentage", gorm.Expr("NULL")).Error; err != nil {
+			return err
+		}
+
+		// Submissions created before the applicant row was guaranteed may still
+		// lack it until cmd/dedupe-submission-users has run.
+		if err := ensureApplicantSubmissionUser(tx, submission.SubmissionID, submission.UserID); err != nil {
+			return err
+		}
+
+		for userID, percentage := range shares {
+			if err := tx.Model(&models.SubmissionUser{}).
+				Where("submission_id = ? AND user_id = ?", submission.SubmissionID, userID).
+				Update("share_percentage", percentage).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		InternalError(c, "reward split", err)
+		return
+	}
+
+	entries, _, err := buildRewardSplit(config.DB, submission)
+	if err != nil {
+		InternalError(c, "reward split", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":     true,
+		"message":     "Reward split updated successfully",
+		"base_amount": rewardSplitBaseAmount(submission.PublicationRewardDetail),
+		"authors":     entries,
+	})
+}
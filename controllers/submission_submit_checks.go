@@ -0,0 +1,204 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"fund-management-api/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// submitCheckInput is the submission being checked and who is submitting it.
+type submitCheckInput struct {
+	DB         *gorm.DB
+	Submission *models.Submission
+	UserID     int
+	RoleID     int
+	Now        time.Time
+}
+
+// submitCheckOutcome is what one pre-submit check found. Errors stop
+// SubmitSubmission, which answers with Status and Body; warnings do not. When
+// the submission goes ahead, Warning is added to the submit response under
+// WarningKey (if set).
+type submitCheckOutcome struct {
+	Errors     []submitCheckIssue
+	Warnings   []submitCheckIssue
+	Status     int
+	Body       gin.H
+	WarningKey string
+	Warning    interface{}
+}
+
+type submitCheck struct {
+	Name string
+	Run  func(in submitCheckInput) (submitCheckOutcome, error)
+}
+
+// submitChecks is the one list of checks behind SubmitSubmission and
+// ValidateSubmissionForSubmit, in the order SubmitSubmission applies them.
+var submitChecks = []submitCheck{
+	{Name: "status", Run: checkSubmittable},
+	{Name: "publication reward cap", Run: checkPublicationRewardCap},
+	{Name: "duplicate publication", Run: checkSubmissionDuplicatePublication},
+	{Name: "category", Run: checkSubmissionCategory},
+	{Name: "eligibility", Run: checkSubmissionEligibility},
+	{Name: "budget limits", Run: checkSubmissionBudget},
+	{Name: "required documents", Run: checkSubmissionRequiredDocuments},
+	{Name: "profile", Run: checkSubmissionProfile},
+}
+
+func checkSubmittable(in submitCheckInput) (submitCheckOutcome, error) {
+	if in.Submission.CanBeSubmitted() {
+		return submitCheckOutcome{}, nil
+	}
+	return submitCheckOutcome{
+		Errors: []submitCheckIssue{{Code: "NOT_SUBMITTABLE", Message: "Submission cannot be submitted in its current status"}},
+		Status: http.StatusBadRequest,
+		Body:   gin.H{"error": "Submission cannot be submitted"},
+	}, nil
+}
+
+func checkSubmissionDuplicatePublication(in submitCheckInput) (submitCheckOutcome, error) {
+	duplicates, err := submissionDuplicatePublicationClaims(in.DB, in.Submission)
+	if err != nil || len(duplicates) == 0 {
+		return submitCheckOutcome{}, err
+	}
+	issue := duplicatePublicationIssue(duplicates)
+	if publicationDuplicatePolicy() == policyBlock {
+		return submitCheckOutcome{
+			Errors: []submitCheckIssue{issue},
+			Status: http.StatusConflict,
+			Body: gin.H{
+				"success":    false,
+				"error":      issue.Message,
+				"code":       "DUPLICATE_PUBLICATION",
+				"duplicates": duplicates,
+			},
+		}, nil
+	}
+	return submitCheckOutcome{
+		Warnings:   []submitCheckIssue{issue},
+		WarningKey: "duplicate_warnings",
+		Warning:    duplicates,
+	}, nil
+}
+
+func checkSubmissionCategory(in submitCheckInput) (submitCheckOutcome, error) {
+	issues, err := submissionCategoryIssues(in.DB, in.Submission)
+	if err != nil || len(issues) == 0 {
+		return submitCheckOutcome{}, err
+	}
+	if categoryCheckPolicy() == policyBlock {
+		return submitCheckOutcome{
+			Errors: issues,
+			Status: http.StatusUnprocessableEntity,
+			Body: gin.H{
+				"error":   "Select a valid fund category and subcategory before submitting",
+				"code":    "CATEGORY_REQUIRED",
+				"reasons": issues,
+			},
+		}, nil
+	}
+	return submitCheckOutcome{
+		Warnings:   issues,
+		WarningKey: "category_warnings",
+		Warning:    issues,
+	}, nil
+}
+
+func checkSubmissionEligibility(in submitCheckInput) (submitCheckOutcome, error) {
+	submission := in.Submission
+	if submission.SubcategoryID == nil || *submission.SubcategoryID <= 0 {
+		return submitCheckOutcome{}, nil
+	}
+	decision, err := evaluateFundApplyEligibility(in.DB, in.UserID, in.RoleID, *submission.SubcategoryID, submission.YearID, in.Now)
+	if err != nil {
+		return submitCheckOutcome{}, err
+	}
+	reasons := submitBlockingReasons(decision, submission.SubmissionType)
+	if len(reasons) == 0 {
+		return submitCheckOutcome{}, nil
+	}
+	issues := make([]submitCheckIssue, 0, len(reasons))
+	for _, reason := range reasons {
+		issues = append(issues, submitCheckIssue{Code: reason.Code, Message: reason.Message})
+	}
+	return submitCheckOutcome{
+		Errors: issues,
+		Status: http.StatusBadRequest,
+		Body: gin.H{
+			"error":   "Submission is not eligible for this fund",
+			"reasons": reasons,
+		},
+	}, nil
+}
+
+func checkSubmissionBudget(in submitCheckInput) (submitCheckOutcome, error) {
+	budgetCheck, err := checkSubmissionBudgetLimits(in.DB, in.Submission)
+	if err != nil || budgetCheck == nil || len(budgetCheck.Violations) == 0 {
+		return submitCheckOutcome{}, err
+	}
+	issues := make([]submitCheckIssue, 0, len(budgetCheck.Violations))
+	for _, violation := range budgetCheck.Violations {
+		issues = append(issues, submitCheckIssue{Code: violation.Code, Message: violation.Message})
+	}
+	if budgetCheck.Enforce {
+		return submitCheckOutcome{
+			Errors: issues,
+			Status: http.StatusUnprocessableEntity,
+			Body: gin.H{
+				"error":   "Submission exceeds the fund's budget limits",
+				"code":    "BUDGET_LIMIT_EXCEEDED",
+				"reasons": budgetCheck.Violations,
+			},
+		}, nil
+	}
+	// Advisory mode: the subcategory does not enforce its limits yet.
+	return submitCheckOutcome{
+		Warnings:   issues,
+		WarningKey: "budget_warnings",
+		Warning:    budgetCheck.Violations,
+	}, nil
+}
+
+func checkSubmissionRequiredDocuments(in submitCheckInput) (submitCheckOutcome, error) {
+	missing, err := missingRequiredDocuments(in.DB, in.Submission)
+	if err != nil {
+		return submitCheckOutcome{}, err
+	}
+	outcome := submitCheckOutcome{}
+	for _, dt := range missing {
+		outcome.Warnings = append(outcome.Warnings, submitCheckIssue{
+			Code:    "REQUIRED_DOCUMENT_MISSING",
+			Field:   dt.Code,
+			Message: "Required document is missing: " + dt.DocumentTypeName,
+		})
+	}
+	return outcome, nil
+}
+
+func checkSubmissionProfile(in submitCheckInput) (submitCheckOutcome, error) {
+	issues := submissionProfileIssues(in.Submission)
+	if len(issues) == 0 {
+		return submitCheckOutcome{}, nil
+	}
+	if profileCheckPolicy() == policyBlock {
+		return submitCheckOutcome{
+			Errors: issues,
+			Status: http.StatusUnprocessableEntity,
+			Body: gin.H{
+				"error":   "Complete your profile before submitting",
+				"code":    "PROFILE_INCOMPLETE",
+				"reasons": issues,
+			},
+		}, nil
+	}
+	return submitCheckOutcome{
+		Warnings:   issues,
+		WarningKey: "profile_warnings",
+		Warning:    issues,
+	}, nil
+}
@@ -0,0 +1,168 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"fund-management-api/config"
+	"fund-management-api/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// submitCheckIssue is one finding of the pre-submit validation.
+type submitCheckIssue struct {
+	Code    string `json:"code"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// profileCheckPolicy controls what happens when the applicant's form-critical
+// profile fields are blank (SUBMIT_PROFILE_CHECK_POLICY=warn|block|off,
+// default warn).
+func profileCheckPolicy() string {
+	return envPolicy("SUBMIT_PROFILE_CHECK_POLICY", policyWarn)
+}
+
+// submissionProfileIssues lists the applicant fields that would render blank
+// on the submission's generated form. Only publication rewards have a form
+// that prints the position and employment date.
+func submissionProfileIssues(submission *models.Submission) []submitCheckIssue {
+	if submission == nil || submission.SubmissionType != "publication_reward" || profileCheckPolicy() == policyOff {
+		return nil
+	}
+
+	issues := []submitCheckIssue{}
+	if resolveApplicantPosition(submission.User) == "" {
+		issues = append(issues, submitCheckIssue{
+			Code:    "PROFILE_POSITION_MISSING",
+			Field:   "position",
+			Message: "Your position is missing from your profile and will be blank on the reward form",
+		})
+	}
+	if resolveApplicantEmploymentDate(submission.User) == "" {
+		issues = append(issues, submitCheckIssue{
+			Code:    "PROFILE_EMPLOYMENT_DATE_MISSING",
+			Field:   "date_of_employment",
+			Message: "Your employment date is missing from your profile and will be blank on the reward form",
+		})
+	}
+	return issues
+}
+
+// categoryCheckPolicy controls what happens when a fund application is
+// submitted without a valid category and subcategory
+// (SUBMIT_CATEGORY_CHECK_POLICY=block|warn|off, default block). Uncategorized
+// submissions cannot be attributed on the dashboard.
+func categoryCheckPolicy() string {
+	return envPolicy("SUBMIT_CATEGORY_CHECK_POLICY", policyBlock)
+}
+
+// submissionCategoryIssues reports a fund application whose category or
+// subcategory is unset, deleted, or whose subcategory belongs to another
+// category.
+func submissionCategoryIssues(db *gorm.DB, submission *models.Submission) ([]submitCheckIssue, error) {
+	if submission == nil || submission.SubmissionType != "fund_application" || categoryCheckPolicy() == policyOff {
+		return nil, nil
+	}
+
+	issues := []submitCheckIssue{}
+	hasCategory := submission.CategoryID != nil && *submission.CategoryID > 0
+	hasSubcategory := submission.SubcategoryID != nil && *submission.SubcategoryID > 0
+	if !hasCategory {
+		issues = append(issues, submitCheckIssue{
+			Code:    "CATEGORY_MISSING",
+			Field:   "category_id",
+			Message: "Select a fund category before submitting",
+		})
+	}
+	if !hasSubcategory {
+		issues = append(issues, submitCheckIssue{
+			Code:    "SUBCATEGORY_MISSING",
+			Field:   "subcategory_id",
+			Message: "Select a fund subcategory before submitting",
+		})
+	}
+
+	if hasCategory {
+		var category models.FundCategory
+		err := db.Where("category_id = ? AND delete_at IS NULL", *submission.CategoryID).First(&category).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			issues = append(issues, submitCheckIssue{
+				Code:    "CATEGORY_INVALID",
+				Field:   "category_id",
+				Message: "The selected fund category no longer exists",
+			})
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	if hasSubcategory {
+		var subcategory models.FundSubcategory
+		err := db.Where("subcategory_id = ? AND delete_at IS NULL", *submission.SubcategoryID).First(&subcategory).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			issues = append(issues, submitCheckIssue{
+				Code:    "SUBCATEGORY_INVALID",
+				Field:   "subcategory_id",
+				Message: "The selected fund subcategory no longer exists",
+			})
+		case err != nil:
+			return nil, err
+		case hasCategory && subcategory.CategoryID != *submission.CategoryID:
+			issues = append(issues, submitCheckIssue{
+				Code:    "SUBCATEGORY_CATEGORY_MISMATCH",
+				Field:   "subcategory_id",
+				Message: "The selected fund subcategory does not belong to the selected category",
+			})
+		}
+	}
+	return issues, nil
+}
+
+// ValidateSubmissionForSubmit runs every check in submitChecks without
+// submitting, so the applicant can fix problems first. Errors block submit;
+// warnings do not.
+// GET /submissions/:id/validate
+func ValidateSubmissionForSubmit(c *gin.Context) {
+	userID := c.GetInt("userID")
+
+	var submission models.Submission
+	if err := config.DB.
+		Preload("User").
+		Preload("User.Position").
+		Where("submission_id = ? AND user_id = ? AND deleted_at IS NULL", c.Param("id"), userID).
+		First(&submission).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Submission not found"})
+		return
+	}
+
+	checkInput := submitCheckInput{
+		DB:         config.DB,
+		Submission: &submission,
+		UserID:     userID,
+		RoleID:     c.GetInt("roleID"),
+		Now:        time.Now(),
+	}
+	errs := []submitCheckIssue{}
+	warnings := []submitCheckIssue{}
+	for _, check := range submitChecks {
+		outcome, err := check.Run(checkInput)
+		if err != nil {
+			InternalError(c, "validate submission: "+check.Name, err)
+			return
+		}
+		errs = append(errs, outcome.Errors...)
+		warnings = append(warnings, outcome.Warnings...)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"ready":    len(errs) == 0,
+		"errors":   errs,
+		"warnings": warnings,
+	})
+}
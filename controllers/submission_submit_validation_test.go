@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"fund-management-api/models"
+)
+
+func TestSubmissionCategoryIssues_UncategorizedFundApplication(t *testing.T) {
+	cases := []struct {
+		name       string
+		policy     string
+		submission models.Submission
+		want       int
+	}{
+		{"uncategorized application", "", models.Submission{SubmissionType: "fund_application"}, 2},
+		{"warn still reports", "warn", models.Submission{SubmissionType: "fund_application"}, 2},
+		{"policy off", "off", models.Submission{SubmissionType: "fund_application"}, 0},
+		{"publication reward", "", models.Submission{SubmissionType: "publication_reward"}, 0},
+	}
+	for _, tc := range cases {
+		t.Setenv("SUBMIT_CATEGORY_CHECK_POLICY", tc.policy)
+		got, err := submissionCategoryIssues(nil, &tc.submission)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if len(got) != tc.want {
+			t.Fatalf("%s: expected %d issues, got %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestCategoryCheckPolicy_DefaultsToBlock(t *testing.T) {
+	for value, want := range map[string]string{
+		"":      policyBlock,
+		"bogus": policyBlock,
+		"Warn":  policyWarn,
+		"off":   policyOff,
+	} {
+		t.Setenv("SUBMIT_CATEGORY_CHECK_POLICY", value)
+		if got := categoryCheckPolicy(); got != want {
+			t.Fatalf("SUBMIT_CATEGORY_CHECK_POLICY=%q: expected %s, got %s", value, want, got)
+		}
+	}
+}
+
+func TestSubmitChecks_PublicationRewardCapIsShared(t *testing.T) {
+	db := newLockingGormDB(t, 1, lockingSQLHandler{
+		query: func(query string, _ []driver.NamedValue, _ bool) ([]string, []driver.Value, error) {
+			switch {
+			case strings.Contains(query, "max_publication_rewards_per_year"):
+				return []string{"max_publication_rewards_per_year"}, []driver.Value{int64(2)}, nil
+			case strings.Contains(query, "count(*)"):
+				return []string{"count(*)"}, []driver.Value{int64(2)}, nil
+			}
+			return nil, nil, fmt.Errorf("unexpected query: %s", query)
+		},
+	})
+
+	var capCheck *submitCheck
+	for i := range submitChecks {
+		if submitChecks[i].Name == "publication reward cap" {
+			capCheck = &submitChecks[i]
+		}
+	}
+	if capCheck == nil {
+		t.Fatal("expected the publication reward cap in the shared submit checks")
+	}
+
+	submission := &models.Submission{SubmissionID: 9, SubmissionType: "publication_reward", UserID: 5, YearID: 3}
+	outcome, err := capCheck.Run(submitCheckInput{DB: db, Submission: submission, UserID: 5, RoleID: 1})
+	if err != nil {
+		t.Fatalf("cap check: %v", err)
+	}
+	if len(outcome.Errors) != 1 || outcome.Errors[0].Code != "PUBLICATION_REWARD_CAP_REACHED" || outcome.Status != http.StatusBadRequest {
+		t.Fatalf("expected the cap to block, got %+v", outcome)
+	}
+
+	outcome, err = capCheck.Run(submitCheckInput{DB: db, Submission: submission, UserID: 5, RoleID: 3})
+	if err != nil || len(outcome.Errors) != 0 {
+		t.Fatalf("expected admins not to be capped, got %+v (%v)", outcome, err)
+	}
+}
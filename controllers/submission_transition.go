@@ -0,0 +1,284 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"fund-management-api/config"
+	"fund-management-api/models"
+	"fund-management-api/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// submissionTransition is one allowed move out of a status. OwnerAllowed lets
+// the submission's owner make it; every transition is open to admins.
+type submissionTransition struct {
+	To           string
+	OwnerAllowed bool
+}
+
+// submissionTransitions lists the allowed moves keyed by the current canonical
+// status code. Moves that carry more than a status change are not here and go
+// through their own handlers (see dedicatedTransitionEndpoint): submitting and
+// resubmitting (SubmitSubmission validates, generates forms and routes through
+// the department head), requesting a revision (which clears submitted_at and
+// the reviewer fields so the owner can resubmit), the department head's
+// recommendation and approving. The only move into approved is reopening a
+// closed submission.
+var submissionTransitions = map[string][]submissionTransition{
+	utils.StatusCodePending: {
+		{To: utils.StatusCodeRejected},
+	},
+	utils.StatusCodeDeptHeadPending: {
+		{To: utils.StatusCodeRejected},
+	},
+	utils.StatusCodeApproved: {
+		{To: utils.StatusCodeAdminClosed},
+	},
+	utils.StatusCodeAdminClosed: {
+		{To: utils.StatusCodeApproved},
+	},
+}
+
+// dedicatedTransitionEndpoint names the endpoint that makes a move left out of
+// submissionTransitions, or "" when the move is not allowed at all.
+func dedicatedTransitionEndpoint(from, to string) string {
+	switch to {
+	case utils.StatusCodeApproved:
+		return "Approve submissions through POST /submissions-admin/:id/approve"
+	case utils.StatusCodeNeedsMoreInfo:
+		if from == utils.StatusCodeDeptHeadPending {
+			return "Request a revision through POST /dept-head/submissions/:id/request-revision"
+		}
+		return "Request a revision through POST /submissions-admin/:id/request-revision"
+	case utils.StatusCodePending:
+		if from == utils.StatusCodeDeptHeadPending {
+			return "Forward to admin review through POST /dept-head/submissions/:id/recommend"
+		}
+		if from == utils.StatusCodeNeedsMoreInfo || from == utils.StatusCodeDraft {
+			return "Submit the submission through POST /submissions/:id/submit"
+		}
+	}
+	return ""
+}
+
+// findSubmissionTransition returns the rule for moving between two canonical
+// status codes, or nil when the move is not allowed.
+func findSubmissionTransition(from, to string) *submissionTransition {
+	for _, rule := range submissionTransitions[from] {
+		if rule.To == to {
+			return &rule
+		}
+	}
+	return nil
+}
+
+func submissionTransitionAuditAction(to string) string {
+	switch to {
+	case utils.StatusCodeApproved:
+		return "approve"
+	case utils.StatusCodeRejected:
+		return "reject"
+	}
+	return "update"
+}
+
+// submissionWasApproved reports whether the submission has been approved
+// before, i.e. its budget has already been reserved.
+func submissionWasApproved(submission *models.Submission) bool {
+	return submission.ApprovedAt != nil || submission.AdminApprovedAt != nil
+}
+
+// reserveBudgetForTransition reserves the subcategory budget when a transition
+// approves a submission for the first time. Reopening a closed submission
+// (admin_closed -> approved) keeps the reservation of its original approval,
+// so a close/reopen cycle does not charge the budget again.
+func reserveBudgetForTransition(tx *gorm.DB, submission *models.Submission, to string) error {
+	if to != utils.StatusCodeApproved || submissionWasApproved(submission) {
+		return nil
+	}
+	amount, err := submissionApprovedAmount(tx, submission)
+	if err != nil {
+		return err
+	}
+	return reserveSubcategoryBudget(tx, submission.SubcategoryID, amount)
+}
+
+func statusSnapshot(status models.ApplicationStatus) gin.H {
+	return gin.H{
+		"status_id":   status.ApplicationStatusID,
+		"status_code": status.StatusCode,
+		"status_name": status.StatusName,
+	}
+}
+
+// TransitionSubmissionStatus moves a submission to another status through the
+// submissionTransitions table. The status update is conditional on the status
+// read at the start, so a concurrent change yields 409 instead of being
+// overwritten.
+// POST /submissions/:id/transition {"to_status_code": "approved", "comment": "..."}
+func TransitionSubmissionStatus(c *gin.Context) {
+	submissionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || submissionID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid submission ID"})
+		return
+	}
+	userID := c.GetInt("userID")
+	roleID := c.GetInt("roleID")
+
+	var req struct {
+		ToStatusCode string `json:"to_status_code" binding:"required"`
+		Comment      string `json:"comment"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to_status_code is required"})
+		return
+	}
+
+	target, err := utils.GetApplicationStatusByCode(req.ToStatusCode)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown status code: " + strings.TrimSpace(req.ToStatusCode)})
+		return
+	}
+
+	var submission models.Submission
+	if err := config.DB.Where("submission_id = ? AND deleted_at IS NULL", submissionID).First(&submission).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Submission not found"})
+		return
+	}
+	isAdmin := roleID == 3
+	if !isAdmin && submission.UserID != userID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Submission not found"})
+		return
+	}
+
+	current, err := utils.GetApplicationStatusByID(submission.StatusID)
+	if err != nil {
+		InternalError(c, "submission transition: load current status", err)
+		return
+	}
+
+	from := utils.CanonicalStatusCode(current.StatusCode)
+	to := utils.CanonicalStatusCode(target.StatusCode)
+	rule := findSubmissionTransition(from, to)
+	if rule == nil {
+		message := "Transition not allowed"
+		if endpoint := dedicatedTransitionEndpoint(from, to); endpoint != "" {
+			message = endpoint
+		}
+		c.JSON(http.StatusConflict, gin.H{
+			"error":     message,
+			"current":   statusSnapshot(current),
+			"attempted": statusSnapshot(target),
+		})
+		return
+	}
+	if !isAdmin && !rule.OwnerAllowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only admins can make this transition"})
+		return
+	}
+
+	comment := strings.TrimSpace(req.Comment)
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status_id":  target.ApplicationStatusID,
+		"updated_at": now,
+	}
+	switch to {
+	case utils.StatusCodeApproved:
+		// Reopening keeps the original approver and approval time.
+		if !submissionWasApproved(&submission) {
+			updates["approved_by"] = userID
+			updates["approved_at"] = now
+		}
+		updates["rejected_by"] = gorm.Expr("NULL")
+		updates["rejected_at"] = gorm.Expr("NULL")
+	case utils.StatusCodeRejected:
+		updates["rejected_by"] = userID
+		updates["rejected_at"] = now
+		updates["approved_by"] = gorm.Expr("NULL")
+		updates["approved_at"] = gorm.Expr("NULL")
+	}
+	if comment != "" && isAdmin {
+		updates["admin_comment"] = comment
+	}
+
+	conflict := false
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Submission{}).
+			Where("submission_id = ? AND status_id = ?", submissionID, current.ApplicationStatusID).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			conflict = true
+			return nil
+		}
+		if err := reserveBudgetForTransition(tx, &submission, to); err != nil {
+			return err
+		}
+
+		oldValues, _ := json.Marshal(statusSnapshot(current))
+		newValues, _ := json.Marshal(statusSnapshot(target))
+		oldJSON := string(oldValues)
+		newJSON := string(newValues)
+		changed := "status_id"
+		desc := "Status changed from " + current.StatusName + " to " + target.StatusName
+		if comment != "" {
+			desc += ": " + comment
+		}
+		userAgent := c.GetHeader("User-Agent")
+		return tx.Create(&models.AuditLog{
+			UserID:        userID,
+			Action:        submissionTransitionAuditAction(to),
+			EntityType:    "submission",
+			EntityID:      &submission.SubmissionID,
+			EntityNumber:  &submission.SubmissionNumber,
+			ChangedFields: &changed,
+			OldValues:     &oldJSON,
+			NewValues:     &newJSON,
+			Description:   &desc,
+			IPAddress:     c.ClientIP(),
+			UserAgent:     &userAgent,
+			CreatedAt:     now,
+		}).Error
+	})
+	if err != nil {
+		var shortfall *budgetShortfallError
+		if errors.As(err, &shortfall) {
+			c.JSON(http.StatusConflict, shortfall.response())
+			return
+		}
+		InternalError(c, "submission transition", err)
+		return
+	}
+	if conflict {
+		latest := current
+		var reloaded models.Submission
+		if err := config.DB.Select("status_id").First(&reloaded, submissionID).Error; err == nil {
+			if status, err := utils.GetApplicationStatusByID(reloaded.StatusID); err == nil {
+				latest = status
+			}
+		}
+		c.JSON(http.StatusConflict, gin.H{
+			"error":     "Submission status changed concurrently",
+			"current":   statusSnapshot(latest),
+			"attempted": statusSnapshot(target),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":       true,
+		"submission_id": submission.SubmissionID,
+		"from":          statusSnapshot(current),
+		"to":            statusSnapshot(target),
+	})
+}
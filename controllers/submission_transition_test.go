@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"fund-management-api/models"
+	"fund-management-api/utils"
+)
+
+func TestReserveBudgetForTransition_CloseAndReopenChargeOnce(t *testing.T) {
+	store := &lockingBudgetStore{remaining: 1000}
+	reservations := 0
+	db := newLockingGormDB(t, 1, lockingSQLHandler{
+		query: func(query string, _ []driver.NamedValue, locked bool) ([]string, []driver.Value, error) {
+			switch {
+			case strings.Contains(query, "publication_reward_details"):
+				return []string{"amount"}, []driver.Value{700.0}, nil
+			case strings.Contains(query, "subcategory_budgets") && locked:
+				remaining, used := store.snapshot()
+				return []string{"subcategory_budget_id", "subcategory_id", "record_scope", "allocated_amount", "used_amount", "remaining_budget", "status"},
+					[]driver.Value{int64(11), int64(5), "overall", 1000.0, used, remaining, "active"}, nil
+			}
+			return nil, nil, fmt.Errorf("unexpected query: %s", query)
+		},
+		exec: func(query string, args []driver.NamedValue, locked bool) (int64, error) {
+			if !strings.HasPrefix(query, "UPDATE subcategory_budgets") || !locked {
+				return 0, errors.New("unexpected budget update: " + query)
+			}
+			reservations++
+			amount := args[0].Value.(float64)
+			store.mu.Lock()
+			store.remaining -= amount
+			store.used += amount
+			store.mu.Unlock()
+			return 1, nil
+		},
+	})
+
+	subcategoryID := 5
+	submission := &models.Submission{SubmissionID: 42, SubmissionType: "publication_reward", SubcategoryID: &subcategoryID}
+
+	// First approval charges the budget.
+	if err := reserveBudgetForTransition(db, submission, utils.StatusCodeApproved); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	approvedAt := time.Now()
+	submission.ApprovedAt = &approvedAt
+
+	// approved -> admin_closed -> approved, twice.
+	for i := 0; i < 2; i++ {
+		if err := reserveBudgetForTransition(db, submission, utils.StatusCodeAdminClosed); err != nil {
+			t.Fatalf("close: %v", err)
+		}
+		if err := reserveBudgetForTransition(db, submission, utils.StatusCodeApproved); err != nil {
+			t.Fatalf("reopen: %v", err)
+		}
+	}
+
+	if reservations != 1 {
+		t.Fatalf("expected the budget to be reserved once, got %d", reservations)
+	}
+	if remaining, used := store.snapshot(); remaining != 300 || used != 700 {
+		t.Fatalf("expected remaining 300 and used 700, got %v and %v", remaining, used)
+	}
+}
+
+func TestFindSubmissionTransition_ApprovalOnlyByReopening(t *testing.T) {
+	if rule := findSubmissionTransition(utils.StatusCodePending, utils.StatusCodeApproved); rule != nil {
+		t.Fatalf("pending -> approved must go through ApproveSubmission")
+	}
+	if rule := findSubmissionTransition(utils.StatusCodeAdminClosed, utils.StatusCodeApproved); rule == nil {
+		t.Fatalf("expected admin_closed -> approved (reopen) to be allowed")
+	}
+}
+
+func TestFindSubmissionTransition_WorkflowMovesUseTheirHandlers(t *testing.T) {
+	for _, move := range []struct{ from, to string }{
+		{utils.StatusCodeNeedsMoreInfo, utils.StatusCodePending},
+		{utils.StatusCodePending, utils.StatusCodeNeedsMoreInfo},
+		{utils.StatusCodeDeptHeadPending, utils.StatusCodePending},
+		{utils.StatusCodeDeptHeadPending, utils.StatusCodeNeedsMoreInfo},
+	} {
+		if rule := findSubmissionTransition(move.from, move.to); rule != nil {
+			t.Fatalf("%s -> %s must go through its own handler, not a status flip", move.from, move.to)
+		}
+		if dedicatedTransitionEndpoint(move.from, move.to) == "" {
+			t.Fatalf("%s -> %s: expected the rejection to name the endpoint to use", move.from, move.to)
+		}
+	}
+}
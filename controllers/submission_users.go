@@ -11,17 +11,45 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"time"
 
 	"fund-management-api/config"
 	"fund-management-api/models"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // ===================== SUBMISSION USERS MANAGEMENT (UNIFIED) =====================
 // ใช้จัดการ co-authors, advisors, team members และ users อื่นๆ ใน submission
 
+// ensureApplicantSubmissionUser adds the applicant's owner row to
+// submission_users when it is missing, so every submission lists its
+// applicant. submission_users holds one row per user per submission.
+func ensureApplicantSubmissionUser(tx *gorm.DB, submissionID, applicantID int) error {
+	if applicantID <= 0 {
+		return nil
+	}
+	var count int64
+	if err := tx.Model(&models.SubmissionUser{}).
+		Where("submission_id = ? AND user_id = ?", submissionID, applicantID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	return tx.Create(&models.SubmissionUser{
+		SubmissionID: submissionID,
+		UserID:       applicantID,
+		Role:         "owner",
+		IsPrimary:    true,
+		DisplayOrder: 1,
+		CreatedAt:    time.Now(),
+	}).Error
+}
+
 // Helper function to map frontend role to database role
 func mapFrontendRoleToDatabase(frontendRole string) string {
 	roleMap := map[string]string{
@@ -177,10 +205,9 @@ func GetSubmissionUsers(c *gin.Context) {
 		return
 	}
 
-	// Mark applicant and ensure they're included in the list
+	// Mark the applicant; every submission stores its applicant's owner row
 	applicantID := submission.UserID
-	filtered := make([]models.SubmissionUser, 0, len(users)+1)
-	applicantIncluded := false
+	filtered := make([]models.SubmissionUser, 0, len(users))
 	for i := range users {
 		users[i].IsApplicant = users[i].UserID == applicantID
 		if users[i].User == nil {
@@ -189,23 +216,8 @@ func GetSubmissionUsers(c *gin.Context) {
 				users[i].User = &u
 			}
 		}
-		if users[i].IsApplicant {
-			applicantIncluded = true
-		}
 		filtered = append(filtered, users[i])
 	}
-	if !applicantIncluded && submission.User != nil {
-		applicant := models.SubmissionUser{
-			SubmissionID: submission.SubmissionID,
-			UserID:       applicantID,
-			User:         submission.User,
-			Role:         "owner",
-			IsPrimary:    true,
-			DisplayOrder: 1,
-			IsApplicant:  true,
-		}
-		filtered = append([]models.SubmissionUser{applicant}, filtered...)
-	}
 	// Separate by role for easier frontend handling
 	var coauthors []models.SubmissionUser
 	var others []models.SubmissionUser
@@ -297,6 +309,20 @@ func SetCoauthors(c *gin.Context) {
 		return
 	}
 
+	// Members kept in another role cannot also be co-authors
+	var otherMemberIDs []int
+	if err := tx.Model(&models.SubmissionUser{}).
+		Where("submission_id = ?", submissionID).
+		Pluck("user_id", &otherMemberIDs).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load submission users"})
+		return
+	}
+	seen := make(map[int]bool, len(otherMemberIDs)+len(req.Coauthors))
+	for _, id := range otherMemberIDs {
+		seen[id] = true
+	}
+
 	// Add new co-authors
 	var results []models.SubmissionUser
 	var errors []string
@@ -315,6 +341,11 @@ func SetCoauthors(c *gin.Context) {
 			errors = append(errors, fmt.Sprintf("Cannot add submission owner (User %d) as co-author", coauthorReq.UserID))
 			continue
 		}
+		if seen[coauthorReq.UserID] {
+			errors = append(errors, fmt.Sprintf("User %d already in submission", coauthorReq.UserID))
+			continue
+		}
+		seen[coauthorReq.UserID] = true
 
 		// Set order sequence
 		orderSequence := coauthorReq.OrderSequence
@@ -460,6 +491,11 @@ func RemoveSubmissionUser(c *gin.Context) {
 		return
 	}
 
+	if id, err := strconv.Atoi(targetUserID); err == nil && id == submission.UserID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot remove the applicant from the submission"})
+		return
+	}
+
 	// Remove user from submission
 	result := config.DB.Where("submission_id = ? AND user_id = ?", submissionID, targetUserID).
 		Delete(&models.SubmissionUser{})
@@ -480,7 +516,10 @@ func RemoveSubmissionUser(c *gin.Context) {
 	})
 }
 
-// AddMultipleUsers เพิ่ม users หลายคนพร้อมกัน
+// maxBatchSubmissionUsers bounds one AddMultipleUsers request.
+const maxBatchSubmissionUsers = 100
+
+// AddMultipleUsers เพิ่ม users หลายคนพร้อมกัน (at most maxBatchSubmissionUsers per request)
 func AddMultipleUsers(c *gin.Context) {
 	submissionID := c.Param("id")
 	userID, _ := c.Get("userID")
@@ -500,6 +539,10 @@ func AddMultipleUsers(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if len(req.Users) > maxBatchSubmissionUsers {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("At most %d users can be added per request", maxBatchSubmissionUsers)})
+		return
+	}
 
 	// Find submission and check permission
 	var submission models.Submission
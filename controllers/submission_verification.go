@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"fund-management-api/config"
+	"fund-management-api/models"
+	"fund-management-api/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	verificationQRPlaceholder = "{{verification_qr}}"
+	verificationQRSizeEMU     = 20 * docxEMUPerMM
+	verificationQRScale       = 8
+)
+
+var errInvalidVerificationToken = errors.New("invalid verification token")
+
+// submissionVerificationSecret signs the QR tokens printed on generated forms.
+// VERIFY_TOKEN_SECRET lets them be rotated independently of JWT_SECRET.
+func submissionVerificationSecret() []byte {
+	if secret := strings.TrimSpace(os.Getenv("VERIFY_TOKEN_SECRET")); secret != "" {
+		return []byte(secret)
+	}
+	return []byte(os.Getenv("JWT_SECRET"))
+}
+
+func submissionVerificationSignature(payload string) []byte {
+	mac := hmac.New(sha256.New, submissionVerificationSecret())
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)[:16]
+}
+
+// signSubmissionVerificationToken encodes the submission id and number with an
+// HMAC so the public verify endpoint can trust them without a lookup table.
+func signSubmissionVerificationToken(submissionID int, submissionNumber string) string {
+	payload := fmt.Sprintf("%d:%s", submissionID, submissionNumber)
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." +
+		base64.RawURLEncoding.EncodeToString(submissionVerificationSignature(payload))
+}
+
+func parseSubmissionVerificationToken(token string) (int, string, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(strings.TrimSpace(token), ".")
+	if !ok {
+		return 0, "", errInvalidVerificationToken
+	}
+	payloadBytes, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return 0, "", errInvalidVerificationToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return 0, "", errInvalidVerificationToken
+	}
+
+	payload := string(payloadBytes)
+	if !hmac.Equal(signature, submissionVerificationSignature(payload)) {
+		return 0, "", errInvalidVerificationToken
+	}
+
+	rawID, number, ok := strings.Cut(payload, ":")
+	if !ok {
+		return 0, "", errInvalidVerificationToken
+	}
+	submissionID, err := strconv.Atoi(rawID)
+	if err != nil || submissionID <= 0 {
+		return 0, "", errInvalidVerificationToken
+	}
+	return submissionID, number, nil
+}
+
+// submissionVerificationURL is what the QR code on a form points to.
+// VERIFY_BASE_URL may point at a frontend page; by default the API endpoint is used.
+func submissionVerificationURL(token string) string {
+	base := strings.TrimRight(strings.TrimSpace(os.Getenv("VERIFY_BASE_URL")), "/")
+	if base == "" {
+		base = strings.TrimRight(strings.TrimSpace(os.Getenv("APP_BACKEND_BASE_URL")), "/") + "/api/v1/verify"
+	}
+	return base + "/" + token
+}
+
+func submissionVerificationQRImage(submission *models.Submission) (docxImage, error) {
+	token := signSubmissionVerificationToken(submission.SubmissionID, submission.SubmissionNumber)
+	png, err := utils.QRCodePNG([]byte(submissionVerificationURL(token)), verificationQRScale)
+	if err != nil {
+		return docxImage{}, err
+	}
+	return docxImage{
+		Placeholder: verificationQRPlaceholder,
+		PNG:         png,
+		SizeEMU:     verificationQRSizeEMU,
+	}, nil
+}
+
+// VerifySubmission validates the token from a form's QR code and returns the
+// submission's public details so finance can confirm the document is genuine.
+func VerifySubmission(c *gin.Context) {
+	submissionID, submissionNumber, err := parseSubmissionVerificationToken(c.Param("token"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid verification token"})
+		return
+	}
+
+	var submission models.Submission
+	if err := config.DB.
+		Where("submission_id = ? AND deleted_at IS NULL", submissionID).
+		First(&submission).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "Submission not found"})
+			return
+		}
+		InternalError(c, "verify_submission", err)
+		return
+	}
+
+	if submission.SubmissionNumber != submissionNumber {
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "Submission not found"})
+		return
+	}
+
+	var status models.ApplicationStatus
+	if err := config.DB.
+		Where("application_status_id = ?", submission.StatusID).
+		First(&status).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		InternalError(c, "verify_submission", err)
+		return
+	}
+
+	title, amount, err := loadSubmissionVerificationSummary(&submission)
+	if err != nil {
+		InternalError(c, "verify_submission", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"verification": gin.H{
+			"submission_number": submission.SubmissionNumber,
+			"submission_type":   submission.SubmissionType,
+			"title":             title,
+			"status_code":       status.StatusCode,
+			"status_name":       status.StatusName,
+			"amount":            amount,
+			"submitted_at":      submission.SubmittedAt,
+		},
+	})
+}
+
+// loadSubmissionVerificationSummary picks the title and the amount to show:
+// the approved amount once there is one, the requested amount otherwise.
+func loadSubmissionVerificationSummary(submission *models.Submission) (string, float64, error) {
+	switch submission.SubmissionType {
+	case "publication_reward":
+		var detail models.PublicationRewardDetail
+		err := config.DB.
+			Where("submission_id = ? AND (delete_at IS NULL OR delete_at = '0000-00-00 00:00:00')", submission.SubmissionID).
+			First(&detail).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", 0, nil
+		}
+		if err != nil {
+			return "", 0, err
+		}
+		amount := detail.TotalAmount
+		if detail.TotalApproveAmount > 0 {
+			amount = detail.TotalApproveAmount
+		}
+		return strings.TrimSpace(detail.PaperTitle), amount, nil
+	case "fund_application":
+		var detail models.FundApplicationDetail
+		err := config.DB.
+			Where("submission_id = ?", submission.SubmissionID).
+			First(&detail).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", 0, nil
+		}
+		if err != nil {
+			return "", 0, err
+		}
+		amount := detail.RequestedAmount
+		if detail.ApprovedAmount > 0 {
+			amount = detail.ApprovedAmount
+		}
+		return strings.TrimSpace(detail.ProjectTitle), amount, nil
+	}
+	return "", 0, nil
+}
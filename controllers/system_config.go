@@ -583,13 +583,15 @@ func GetSystemConfigAdmin(c *gin.Context) {
 		KkuReportYear               sql.NullString `json:"kku_report_year"`
 		Installment                 sql.NullInt64  `json:"installment"`
 		MaxSubmissionsPerYear       sql.NullInt64  `json:"max_submissions_per_year"`
+		MaxPublicationRewards       sql.NullInt64  `json:"max_publication_rewards_per_year"`
 	}
 
 	if err := config.DB.Raw(`
 	SELECT
                   config_id, system_version, current_year, start_date, end_date, last_updated, updated_by, contact_info,
                   main_annoucement, reward_announcement, activity_support_announcement, conference_announcement, service_announcement,
-                  kku_report_year, installment, max_submissions_per_year
+                  kku_report_year, installment, max_submissions_per_year,
+                  max_publication_rewards_per_year AS max_publication_rewards
 	FROM system_config
 	ORDER BY config_id DESC
 	LIMIT 1
@@ -700,6 +702,7 @@ func GetSystemConfigAdmin(c *gin.Context) {
 			}
 			return nil
 		}(),
+		"max_publication_rewards_per_year": toIntPtr(row.MaxPublicationRewards),
 
 		"is_open_raw":       isOpenRaw,
 		"is_open_effective": isOpenEff,
@@ -719,6 +722,9 @@ type updateWindowPayload struct {
 	EndDate               *string `json:"end_date"`
 	ContactInfo           *string `json:"contact_info"`
 	MaxSubmissionsPerYear *int    `json:"max_submissions_per_year"`
+	// Yearly cap on publication reward claims per user; 0 means no cap and
+	// omitting it keeps the current value.
+	MaxPublicationRewardsPerYear *int `json:"max_publication_rewards_per_year"`
 }
 
 func UpdateSystemConfigWindow(c *gin.Context) {
@@ -757,9 +763,9 @@ func UpdateSystemConfigWindow(c *gin.Context) {
 		}
 		// insert
 		if err := config.DB.Exec(`
-                        INSERT INTO system_config (current_year, start_date, end_date, contact_info, max_submissions_per_year, last_updated, updated_by)
-                        VALUES (?, ?, ?, ?, ?, NOW(), ?)
-                `, p.CurrentYear, stPtr, enPtr, p.ContactInfo, maxSubmissions, updatedBy).Error; err != nil {
+                        INSERT INTO system_config (current_year, start_date, end_date, contact_info, max_submissions_per_year, max_publication_rewards_per_year, last_updated, updated_by)
+                        VALUES (?, ?, ?, ?, ?, ?, NOW(), ?)
+                `, p.CurrentYear, stPtr, enPtr, p.ContactInfo, maxSubmissions, p.MaxPublicationRewardsPerYear, updatedBy).Error; err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"success": false, "error": "failed to insert system_config"})
 			return
 		}
@@ -786,9 +792,10 @@ func UpdateSystemConfigWindow(c *gin.Context) {
 	// update
 	if err := config.DB.Exec(`
                 UPDATE system_config
-                SET current_year = ?, start_date = ?, end_date = ?, contact_info = ?, max_submissions_per_year = ?, last_updated = NOW(), updated_by = ?
+                SET current_year = ?, start_date = ?, end_date = ?, contact_info = ?, max_submissions_per_year = ?,
+                    max_publication_rewards_per_year = COALESCE(?, max_publication_rewards_per_year), last_updated = NOW(), updated_by = ?
                 WHERE config_id = ?
-        `, p.CurrentYear, stPtr, enPtr, p.ContactInfo, maxSubmissions, updatedBy, int(cfgID.Int64)).Error; err != nil {
+        `, p.CurrentYear, stPtr, enPtr, p.ContactInfo, maxSubmissions, p.MaxPublicationRewardsPerYear, updatedBy, int(cfgID.Int64)).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "error": "failed to update system_config"})
 		return
 	}
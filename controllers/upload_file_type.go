@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+const (
+	docxContentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	msWordType      = "application/msword"
+	msExcelType     = "application/vnd.ms-excel"
+)
+
+// oleSignature starts every legacy Office (.doc/.xls) compound file.
+var oleSignature = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+
+// uploadTypeAliases maps each allowed Content-Type to the type its content must
+// sniff as. .doc and .xls share the OLE container, so either claim accepts it.
+var uploadTypeAliases = map[string]string{
+	"application/pdf": "application/pdf",
+	"image/jpeg":      "image/jpeg",
+	"image/jpg":       "image/jpeg",
+	"image/png":       "image/png",
+	"image/gif":       "image/gif",
+	msWordType:        "application/x-ole-storage",
+	msExcelType:       "application/x-ole-storage",
+	docxContentType:   docxContentType,
+	xlsxContentType:   xlsxContentType,
+}
+
+// sniffUploadContent detects the real type of an upload from its bytes. PDF and
+// images come from http.DetectContentType on the first 512 bytes; DOCX/XLSX
+// sniff as zip, so the archive is opened to find the part that identifies it.
+func sniffUploadContent(src io.ReaderAt, size int64) (string, error) {
+	head := make([]byte, 512)
+	n, err := src.ReadAt(head, 0)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	head = head[:n]
+
+	if bytes.HasPrefix(head, oleSignature) {
+		return "application/x-ole-storage", nil
+	}
+
+	detected := http.DetectContentType(head)
+	if detected != "application/zip" {
+		return strings.TrimSpace(strings.Split(detected, ";")[0]), nil
+	}
+
+	archive, err := zip.NewReader(src, size)
+	if err != nil {
+		return detected, nil
+	}
+	for _, entry := range archive.File {
+		switch entry.Name {
+		case "word/document.xml":
+			return docxContentType, nil
+		case "xl/workbook.xml":
+			return xlsxContentType, nil
+		}
+	}
+	return detected, nil
+}
+
+// detectUploadFileType returns the MIME type to store for an upload, or false
+// when the file is not an allowed type. The content decides: a claimed
+// Content-Type must agree with the sniffed bytes, and a generic or missing
+// claim takes the sniffed type.
+func detectUploadFileType(file *multipart.FileHeader) (string, bool) {
+	src, err := file.Open()
+	if err != nil {
+		return "", false
+	}
+	defer src.Close()
+
+	sniffed, err := sniffUploadContent(src, file.Size)
+	if err != nil {
+		return "", false
+	}
+
+	claimed := strings.ToLower(strings.TrimSpace(strings.Split(file.Header.Get("Content-Type"), ";")[0]))
+	if claimed == "" || claimed == "application/octet-stream" {
+		// Legacy Office files sniff only as OLE, which cannot say .doc from .xls.
+		if uploadTypeAliases[sniffed] == sniffed {
+			return sniffed, true
+		}
+		return "", false
+	}
+
+	expected, ok := uploadTypeAliases[claimed]
+	if !ok || expected != sniffed {
+		return "", false
+	}
+	return claimed, true
+}
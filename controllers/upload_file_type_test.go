@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"archive/zip"
+	"bytes"
+	"mime/multipart"
+	"net/textproto"
+	"testing"
+)
+
+// newUploadFileHeader builds the *multipart.FileHeader that c.FormFile would
+// return for content sent with the given Content-Type.
+func newUploadFileHeader(t *testing.T, filename, contentType string, content []byte) *multipart.FileHeader {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="file"; filename="`+filename+`"`)
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		t.Fatalf("create part: %v", err)
+	}
+	if _, err := part.Write(content); err != nil {
+		t.Fatalf("write part: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("close writer: %v", err)
+	}
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatalf("read form: %v", err)
+	}
+	t.Cleanup(func() { _ = form.RemoveAll() })
+	return form.File["file"][0]
+}
+
+func zipWithParts(t *testing.T, names ...string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range names {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("create %s: %v", name, err)
+		}
+		_, _ = w.Write([]byte("<xml/>"))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("close zip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDetectUploadFileType_RejectsRenamedBinary(t *testing.T) {
+	elf := append([]byte{0x7F, 'E', 'L', 'F', 2, 1, 1, 0}, bytes.Repeat([]byte{0}, 64)...)
+	file := newUploadFileHeader(t, "paper.pdf", "application/pdf", elf)
+
+	if mimeType, ok := detectUploadFileType(file); ok {
+		t.Fatalf("expected a binary labelled application/pdf to be rejected, got %s", mimeType)
+	}
+}
+
+func TestDetectUploadFileType_AcceptsPDF(t *testing.T) {
+	pdf := []byte("%PDF-1.7\n1 0 obj\n<< /Type /Catalog >>\nendobj\ntrailer\n%%EOF\n")
+
+	for _, claimed := range []string{"application/pdf", "application/octet-stream", ""} {
+		file := newUploadFileHeader(t, "paper.pdf", claimed, pdf)
+		mimeType, ok := detectUploadFileType(file)
+		if !ok || mimeType != "application/pdf" {
+			t.Fatalf("claim %q: expected application/pdf, got %q (ok=%v)", claimed, mimeType, ok)
+		}
+	}
+
+	file := newUploadFileHeader(t, "paper.png", "image/png", pdf)
+	if _, ok := detectUploadFileType(file); ok {
+		t.Fatal("expected a PDF labelled image/png to be rejected")
+	}
+}
+
+func TestDetectUploadFileType_DOCXSniffsAsZip(t *testing.T) {
+	docx := zipWithParts(t, "[Content_Types].xml", "word/document.xml")
+
+	file := newUploadFileHeader(t, "form.docx", docxContentType, docx)
+	if mimeType, ok := detectUploadFileType(file); !ok || mimeType != docxContentType {
+		t.Fatalf("expected DOCX to be accepted, got %q (ok=%v)", mimeType, ok)
+	}
+
+	file = newUploadFileHeader(t, "form.xlsx", xlsxContentType, docx)
+	if _, ok := detectUploadFileType(file); ok {
+		t.Fatal("expected a DOCX labelled as XLSX to be rejected")
+	}
+
+	plainZip := zipWithParts(t, "payload.exe")
+	file = newUploadFileHeader(t, "form.docx", docxContentType, plainZip)
+	if _, ok := detectUploadFileType(file); ok {
+		t.Fatal("expected a plain zip labelled as DOCX to be rejected")
+	}
+}
+
+func TestDetectUploadFileType_LegacyOfficeNeedsClaim(t *testing.T) {
+	doc := append(append([]byte{}, oleSignature...), bytes.Repeat([]byte{0}, 504)...)
+
+	file := newUploadFileHeader(t, "form.doc", msWordType, doc)
+	if mimeType, ok := detectUploadFileType(file); !ok || mimeType != msWordType {
+		t.Fatalf("expected .doc to be accepted, got %q (ok=%v)", mimeType, ok)
+	}
+
+	file = newUploadFileHeader(t, "form.doc", "application/octet-stream", doc)
+	if _, ok := detectUploadFileType(file); ok {
+		t.Fatal("expected an OLE file without a specific claim to be rejected")
+	}
+}
@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"fund-management-api/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultMaxUploadSizeMB = 10
+	// maxDocumentTypeUploadSizeMB caps the per-document-type override.
+	maxDocumentTypeUploadSizeMB = 100
+)
+
+// defaultUploadSizeLimitMB is the global upload limit
+// (MAX_UPLOAD_SIZE_MB, default 10).
+func defaultUploadSizeLimitMB() int {
+	if value, err := strconv.Atoi(strings.TrimSpace(os.Getenv("MAX_UPLOAD_SIZE_MB"))); err == nil && value > 0 {
+		return value
+	}
+	return defaultMaxUploadSizeMB
+}
+
+// resolveUploadSizeLimitMB returns the limit for an upload of documentTypeID:
+// the document type's max_upload_size_mb when set, otherwise the global
+// default. Unknown or zero document types use the default.
+func resolveUploadSizeLimitMB(db *gorm.DB, documentTypeID int) (int, error) {
+	if documentTypeID <= 0 {
+		return defaultUploadSizeLimitMB(), nil
+	}
+
+	var documentType models.DocumentType
+	if err := db.Select("document_type_id", "max_upload_size_mb").
+		Where("document_type_id = ? AND delete_at IS NULL", documentTypeID).
+		First(&documentType).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return defaultUploadSizeLimitMB(), nil
+		}
+		return 0, err
+	}
+	if documentType.MaxUploadSizeMB != nil && *documentType.MaxUploadSizeMB > 0 {
+		return *documentType.MaxUploadSizeMB, nil
+	}
+	return defaultUploadSizeLimitMB(), nil
+}
+
+// attachedFileExceedsLimit checks a stored upload against the limit of the
+// document type it is being attached as. UploadFile can only apply the limit
+// of the document_type_id the client sent with the upload, so attaching
+// enforces it again against the recorded file size.
+func attachedFileExceedsLimit(db *gorm.DB, file *models.FileUpload, documentTypeID int) (limitMB int, exceeds bool, err error) {
+	limitMB, err = resolveUploadSizeLimitMB(db, documentTypeID)
+	if err != nil {
+		return 0, false, err
+	}
+	return limitMB, file.FileSize > int64(limitMB)*1024*1024, nil
+}
+
+// validateDocumentTypeUploadSize checks an admin-supplied max_upload_size_mb;
+// nil and 0 mean "use the global default".
+func validateDocumentTypeUploadSize(value *int) error {
+	if value == nil || *value == 0 {
+		return nil
+	}
+	if *value < 0 || *value > maxDocumentTypeUploadSizeMB {
+		return fmt.Errorf("max_upload_size_mb must be between 1 and %d, or 0 to use the default", maxDocumentTypeUploadSizeMB)
+	}
+	return nil
+}
+
+// respondUploadTooLarge reports the effective limit so the frontend can show it.
+func respondUploadTooLarge(c *gin.Context, limitMB int) {
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error":              fmt.Sprintf("File size exceeds %dMB limit", limitMB),
+		"code":               "FILE_TOO_LARGE",
+		"max_upload_size_mb": limitMB,
+	})
+}
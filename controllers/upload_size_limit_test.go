@@ -0,0 +1,29 @@
+package controllers
+
+import (
+	"testing"
+
+	"fund-management-api/models"
+)
+
+func TestAttachedFileExceedsLimit_UsesStoredFileSize(t *testing.T) {
+	t.Setenv("MAX_UPLOAD_SIZE_MB", "2")
+
+	for _, tc := range []struct {
+		name string
+		size int64
+		want bool
+	}{
+		{"under the limit", 1 << 20, false},
+		{"exactly the limit", 2 << 20, false},
+		{"over the limit", 2<<20 + 1, true},
+	} {
+		limitMB, exceeds, err := attachedFileExceedsLimit(nil, &models.FileUpload{FileSize: tc.size}, 0)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if limitMB != 2 || exceeds != tc.want {
+			t.Fatalf("%s: got limit %dMB exceeds=%v, want 2MB exceeds=%v", tc.name, limitMB, exceeds, tc.want)
+		}
+	}
+}
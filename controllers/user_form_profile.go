@@ -0,0 +1,209 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"fund-management-api/config"
+	"fund-management-api/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const employmentDateLayout = "2006-01-02"
+
+// userFormProfileRequest carries the profile fields that fill the reward and
+// application forms. Omitted fields are left unchanged; an empty prefix,
+// position or date_of_employment clears the value.
+type userFormProfileRequest struct {
+	Prefix           *string `json:"prefix"`
+	UserFname        *string `json:"user_fname"`
+	UserLname        *string `json:"user_lname"`
+	Position         *string `json:"position"`
+	PositionID       *int    `json:"position_id"`
+	DateOfEmployment *string `json:"date_of_employment"`
+}
+
+// parseEmploymentDate accepts a Gregorian YYYY-MM-DD date that is not in the
+// future. The value is kept as a calendar date in the server's zone, which is
+// how the driver reads the DATE column back.
+func parseEmploymentDate(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse(employmentDateLayout, value)
+	if err != nil {
+		return nil, errors.New("date_of_employment must be in YYYY-MM-DD format")
+	}
+	if parsed.Year() < 1950 {
+		return nil, errors.New("date_of_employment is too early; use a Gregorian (ค.ศ.) year")
+	}
+	today := time.Now()
+	if parsed.After(time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)) {
+		return nil, errors.New("date_of_employment cannot be in the future; use a Gregorian (ค.ศ.) year")
+	}
+	date := time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, time.Local)
+	return &date, nil
+}
+
+// optionalProfileValue stores a blank optional field as NULL.
+func optionalProfileValue(value string) *string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func userFormProfileResponse(user models.User) gin.H {
+	dateOfEmployment := ""
+	if user.DateOfEmployment != nil && !user.DateOfEmployment.IsZero() {
+		dateOfEmployment = user.DateOfEmployment.Format(employmentDateLayout)
+	}
+	position := resolveApplicantPosition(&user)
+	employmentDisplay := resolveApplicantEmploymentDate(&user)
+
+	missing := []string{}
+	if strings.TrimSpace(user.UserFname) == "" {
+		missing = append(missing, "user_fname")
+	}
+	if strings.TrimSpace(user.UserLname) == "" {
+		missing = append(missing, "user_lname")
+	}
+	if position == "" {
+		missing = append(missing, "position")
+	}
+	if employmentDisplay == "" {
+		missing = append(missing, "date_of_employment")
+	}
+
+	return gin.H{
+		"user_id":                    user.UserID,
+		"prefix":                     stringValue(user.Prefix),
+		"user_fname":                 user.UserFname,
+		"user_lname":                 user.UserLname,
+		"position":                   stringValue(user.PositionTitle),
+		"position_id":                user.PositionID,
+		"position_name":              user.Position.PositionName,
+		"date_of_employment":         dateOfEmployment,
+		"position_display":           position,
+		"date_of_employment_display": employmentDisplay,
+		"missing_fields":             missing,
+	}
+}
+
+func loadUserFormProfile(userID int) (models.User, error) {
+	var user models.User
+	err := config.DB.Preload("Position").
+		Where("user_id = ? AND delete_at IS NULL", userID).
+		First(&user).Error
+	return user, err
+}
+
+// GetMyFormProfile returns the caller's profile fields used on submission
+// forms, with the values as the forms will render them and the fields still
+// missing.
+// GET /users/me/profile
+func GetMyFormProfile(c *gin.Context) {
+	user, err := loadUserFormProfile(c.GetInt("userID"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "User not found"})
+			return
+		}
+		InternalError(c, "form profile", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "profile": userFormProfileResponse(user)})
+}
+
+// UpdateMyFormProfile lets the caller complete the profile fields used on
+// submission forms.
+// PUT /users/me/profile
+func UpdateMyFormProfile(c *gin.Context) {
+	userID := c.GetInt("userID")
+
+	var req userFormProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": err.Error()})
+		return
+	}
+
+	updates := map[string]interface{}{}
+	if req.UserFname != nil {
+		name := strings.TrimSpace(*req.UserFname)
+		if name == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "user_fname cannot be empty"})
+			return
+		}
+		updates["user_fname"] = name
+	}
+	if req.UserLname != nil {
+		name := strings.TrimSpace(*req.UserLname)
+		if name == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "user_lname cannot be empty"})
+			return
+		}
+		updates["user_lname"] = name
+	}
+	if req.Prefix != nil {
+		updates["prefix"] = optionalProfileValue(*req.Prefix)
+	}
+	if req.Position != nil {
+		updates["position"] = optionalProfileValue(*req.Position)
+	}
+	if req.PositionID != nil {
+		var count int64
+		if err := config.DB.Model(&models.Position{}).
+			Where("position_id = ? AND delete_at IS NULL", *req.PositionID).
+			Count(&count).Error; err != nil {
+			InternalError(c, "form profile: position lookup", err)
+			return
+		}
+		if count == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid position_id"})
+			return
+		}
+		updates["position_id"] = *req.PositionID
+	}
+	if req.DateOfEmployment != nil {
+		date, err := parseEmploymentDate(*req.DateOfEmployment)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": err.Error()})
+			return
+		}
+		updates["date_of_employment"] = date
+	}
+
+	if len(updates) > 0 {
+		updates["update_at"] = time.Now()
+		result := config.DB.Model(&models.User{}).
+			Where("user_id = ? AND delete_at IS NULL", userID).
+			Updates(updates)
+		if result.Error != nil {
+			InternalError(c, "form profile: update", result.Error)
+			return
+		}
+		if result.RowsAffected == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "User not found"})
+			return
+		}
+	}
+
+	user, err := loadUserFormProfile(userID)
+	if err != nil {
+		InternalError(c, "form profile: reload", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Profile updated successfully",
+		"profile": userFormProfileResponse(user),
+	})
+}
@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"fund-management-api/models"
+)
+
+func TestParseEmploymentDate(t *testing.T) {
+	date, err := parseEmploymentDate("2015-06-01")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if date.Year() != 2015 || date.Month() != time.June || date.Day() != 1 || date.Location() != time.Local {
+		t.Fatalf("expected 2015-06-01 as a local calendar date, got %v", date)
+	}
+
+	if date, err := parseEmploymentDate("  "); err != nil || date != nil {
+		t.Fatalf("expected a blank value to clear the date, got %v, %v", date, err)
+	}
+
+	tomorrow := time.Now().AddDate(0, 0, 1).Format(employmentDateLayout)
+	for _, value := range []string{"01/06/2015", "2558-06-01", tomorrow, "1900-01-01"} {
+		if _, err := parseEmploymentDate(value); err == nil {
+			t.Fatalf("expected %q to be rejected", value)
+		}
+	}
+}
+
+func TestResolveApplicantEmploymentDate_RendersStoredCalendarDate(t *testing.T) {
+	useAppTimezone(t, "Asia/Bangkok")
+
+	// A DATE column read with a server zone ahead of the app zone must not
+	// roll back to the previous day.
+	tokyo := time.FixedZone("UTC+9", 9*60*60)
+	stored := time.Date(2015, time.June, 1, 0, 0, 0, 0, tokyo)
+	user := &models.User{UserID: 1, DateOfEmployment: &stored}
+
+	if got, want := resolveApplicantEmploymentDate(user), "1 มิถุนายน 2558"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestSubmissionProfileIssues(t *testing.T) {
+	position := "Assistant Professor"
+	employed := time.Date(2015, time.June, 1, 0, 0, 0, 0, time.Local)
+	complete := &models.User{PositionTitle: &position, DateOfEmployment: &employed}
+	blank := &models.User{}
+
+	cases := []struct {
+		name       string
+		policy     string
+		submission models.Submission
+		want       int
+	}{
+		{"complete profile", "", models.Submission{SubmissionType: "publication_reward", User: complete}, 0},
+		{"blank profile", "", models.Submission{SubmissionType: "publication_reward", User: blank}, 2},
+		{"no reward form", "", models.Submission{SubmissionType: "fund_application", User: blank}, 0},
+		{"policy off", "off", models.Submission{SubmissionType: "publication_reward", User: blank}, 0},
+	}
+	for _, tc := range cases {
+		t.Setenv("SUBMIT_PROFILE_CHECK_POLICY", tc.policy)
+		if got := submissionProfileIssues(&tc.submission); len(got) != tc.want {
+			t.Fatalf("%s: expected %d issues, got %v", tc.name, tc.want, got)
+		}
+	}
+}
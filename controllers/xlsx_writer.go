@@ -0,0 +1,204 @@
+package controllers
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// xlsxStyle indexes the cellXfs declared in xlsxStylesXML.
+type xlsxStyle int
+
+const (
+	xlsxStyleDefault xlsxStyle = iota
+	xlsxStyleBold
+	xlsxStyleMoney
+	xlsxStyleBoldMoney
+)
+
+// xlsxCell is one worksheet cell. Value may be a string or a number; nil
+// leaves the cell empty.
+type xlsxCell struct {
+	Value interface{}
+	Style xlsxStyle
+}
+
+// xlsxSheet is a single worksheet written by writeXLSX. Widths are column
+// widths in characters; missing entries use Excel's default.
+type xlsxSheet struct {
+	Name   string
+	Widths []float64
+	Rows   [][]xlsxCell
+}
+
+const xlsxStylesXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<numFmts count="1"><numFmt numFmtId="164" formatCode="#,##0.00"/></numFmts>
+<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>
+<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="4">
+<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>
+<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>
+<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>
+<xf numFmtId="164" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1" applyNumberFormat="1"/>
+</cellXfs>
+</styleSheet>`
+
+// writeXLSX renders a one-sheet workbook without third-party dependencies,
+// the writing counterpart of readXLSXRows. Strings are stored inline.
+func writeXLSX(sheet xlsxSheet) ([]byte, error) {
+	var buf bytes.Buffer
+	sw, err := newXLSXStreamWriter(&buf, sheet.Name, sheet.Widths)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range sheet.Rows {
+		if err := sw.WriteRow(row); err != nil {
+			return nil, err
+		}
+	}
+	if err := sw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// xlsxStreamWriter writes a one-sheet workbook to w row by row, so large
+// exports can go straight to the response without building the sheet in
+// memory. Close must be called to finish the file.
+type xlsxStreamWriter struct {
+	zw    *zip.Writer
+	sheet *bufio.Writer
+	row   int
+}
+
+func newXLSXStreamWriter(w io.Writer, sheetName string, widths []float64) (*xlsxStreamWriter, error) {
+	name := strings.TrimSpace(sheetName)
+	if name == "" {
+		name = "Sheet1"
+	}
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+</Types>`},
+		{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`},
+		{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="` + xlsxEscape(name) + `" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`},
+		{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`},
+		{"xl/styles.xml", xlsxStylesXML},
+	}
+
+	zw := zip.NewWriter(w)
+	for _, part := range parts {
+		pw, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := pw.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+	}
+
+	// The worksheet is the last part so its rows can be written as they come.
+	sheetPart, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	sheet := bufio.NewWriter(sheetPart)
+	sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if len(widths) > 0 {
+		sheet.WriteString("<cols>")
+		for i, width := range widths {
+			if width <= 0 {
+				continue
+			}
+			fmt.Fprintf(sheet, `<col min="%d" max="%d" width="%s" customWidth="1"/>`, i+1, i+1, strconv.FormatFloat(width, 'f', -1, 64))
+		}
+		sheet.WriteString("</cols>")
+	}
+	sheet.WriteString("<sheetData>")
+
+	return &xlsxStreamWriter{zw: zw, sheet: sheet}, nil
+}
+
+// WriteRow appends the next row to the worksheet.
+func (sw *xlsxStreamWriter) WriteRow(row []xlsxCell) error {
+	sw.row++
+	b := sw.sheet
+	fmt.Fprintf(b, `<row r="%d">`, sw.row)
+	for col, cell := range row {
+		ref := xlsxColumnName(col+1) + strconv.Itoa(sw.row)
+		switch v := cell.Value.(type) {
+		case nil:
+			if cell.Style != xlsxStyleDefault {
+				fmt.Fprintf(b, `<c r="%s" s="%d"/>`, ref, cell.Style)
+			}
+		case string:
+			fmt.Fprintf(b, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, cell.Style, xlsxEscape(v))
+		case int:
+			fmt.Fprintf(b, `<c r="%s" s="%d"><v>%d</v></c>`, ref, cell.Style, v)
+		case int64:
+			fmt.Fprintf(b, `<c r="%s" s="%d"><v>%d</v></c>`, ref, cell.Style, v)
+		case float64:
+			fmt.Fprintf(b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, cell.Style, strconv.FormatFloat(v, 'f', -1, 64))
+		default:
+			fmt.Fprintf(b, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, cell.Style, xlsxEscape(fmt.Sprint(v)))
+		}
+	}
+	_, err := b.WriteString("</row>")
+	return err
+}
+
+// Close ends the worksheet and writes the zip directory.
+func (sw *xlsxStreamWriter) Close() error {
+	if _, err := sw.sheet.WriteString("</sheetData></worksheet>"); err != nil {
+		return err
+	}
+	if err := sw.sheet.Flush(); err != nil {
+		return err
+	}
+	return sw.zw.Close()
+}
+
+// xlsxColumnName converts a 1-based column index to its letters (1 -> A, 27 -> AA).
+func xlsxColumnName(index int) string {
+	name := ""
+	for index > 0 {
+		index--
+		name = string(rune('A'+index%26)) + name
+		index /= 26
+	}
+	return name
+}
+
+func xlsxEscape(value string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteXLSX_ReadsBackWithReadXLSXRows(t *testing.T) {
+	content, err := writeXLSX(xlsxSheet{
+		Name:   "Report",
+		Widths: []float64{10, 20},
+		Rows: [][]xlsxCell{
+			{{Value: "ชื่อ & <name>", Style: xlsxStyleBold}, {Value: "Amount", Style: xlsxStyleBold}},
+			{{Value: "สมชาย"}, {Value: 1234.5, Style: xlsxStyleMoney}},
+			{},
+			{{Value: "Total"}, {Value: 2}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("writeXLSX: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "report.xlsx")
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := readXLSXRows(path)
+	if err != nil {
+		t.Fatalf("readXLSXRows: %v", err)
+	}
+
+	want := [][]string{{"ชื่อ & <name>", "Amount"}, {"สมชาย", "1234.5"}, {}, {"Total", "2"}}
+	if len(rows) != len(want) {
+		t.Fatalf("expected %d rows, got %d: %v", len(want), len(rows), rows)
+	}
+	for i := range want {
+		if len(rows[i]) != len(want[i]) {
+			t.Fatalf("row %d: expected %v, got %v", i, want[i], rows[i])
+		}
+		for j := range want[i] {
+			if rows[i][j] != want[i][j] {
+				t.Fatalf("row %d col %d: expected %q, got %q", i, j, want[i][j], rows[i][j])
+			}
+		}
+	}
+}
+
+func TestXLSXColumnName(t *testing.T) {
+	for index, want := range map[int]string{1: "A", 11: "K", 26: "Z", 27: "AA", 52: "AZ", 703: "AAA"} {
+		if got := xlsxColumnName(index); got != want {
+			t.Fatalf("xlsxColumnName(%d) = %q, want %q", index, got, want)
+		}
+	}
+}
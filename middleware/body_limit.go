@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultMaxRequestBodyMB = 2
+	// maxSingleUploadMB is the largest per-document-type upload limit; the
+	// default multipart limit leaves room for it even when MAX_UPLOAD_SIZE_MB
+	// is lower.
+	maxSingleUploadMB = 100
+	// multipartFieldsHeadroomMB is added to the file budget for the other form
+	// fields and multipart framing.
+	multipartFieldsHeadroomMB = 10
+)
+
+// routeMultipartLimits holds the multipart body limits (bytes) of routes that
+// accept several files per request, keyed by method and full route path.
+var routeMultipartLimits sync.Map
+
+// SetMultipartUploadLimit gives a multipart route its own body limit of
+// maxFiles files of maxFileMB each plus the form field headroom. path is the
+// full route path as registered, e.g. group.BasePath()+"/activities".
+func SetMultipartUploadLimit(method, path string, maxFileMB, maxFiles int) {
+	limitMB := int64(maxFileMB)*int64(maxFiles) + multipartFieldsHeadroomMB
+	routeMultipartLimits.Store(method+" "+path, limitMB<<20)
+}
+
+// bodyLimitMB reads a limit in MB from name, falling back to fallback.
+func bodyLimitMB(name string, fallback int) int64 {
+	if value, err := strconv.Atoi(strings.TrimSpace(os.Getenv(name))); err == nil && value > 0 {
+		return int64(value)
+	}
+	return int64(fallback)
+}
+
+// multipartBodyLimitMB is MAX_MULTIPART_BODY_MB or, by default, the largest
+// single upload (MAX_UPLOAD_SIZE_MB or the 100MB per-document-type cap,
+// whichever is higher) plus the form field headroom.
+func multipartBodyLimitMB() int64 {
+	fileMB := bodyLimitMB("MAX_UPLOAD_SIZE_MB", maxSingleUploadMB)
+	if fileMB < maxSingleUploadMB {
+		fileMB = maxSingleUploadMB
+	}
+	return bodyLimitMB("MAX_MULTIPART_BODY_MB", int(fileMB+multipartFieldsHeadroomMB))
+}
+
+// requestBodyLimit returns the byte limit for the request: the route's own
+// limit from SetMultipartUploadLimit or multipartBodyLimitMB for multipart
+// uploads, MAX_REQUEST_BODY_MB (default 2) for everything else. The per-file
+// upload limits are still enforced by the upload handlers; this only stops
+// bodies no handler would accept.
+func requestBodyLimit(c *gin.Context) int64 {
+	if isMultipartRequest(c.Request) {
+		if limit, ok := routeMultipartLimits.Load(c.Request.Method + " " + c.FullPath()); ok {
+			return limit.(int64)
+		}
+		return multipartBodyLimitMB() << 20
+	}
+	return bodyLimitMB("MAX_REQUEST_BODY_MB", defaultMaxRequestBodyMB) << 20
+}
+
+func isMultipartRequest(r *http.Request) bool {
+	return strings.HasPrefix(strings.ToLower(r.Header.Get("Content-Type")), "multipart/")
+}
+
+func abortBodyTooLarge(c *gin.Context, limit int64) {
+	c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+		"success":          false,
+		"error":            fmt.Sprintf("Request body exceeds %dMB limit", limit>>20),
+		"code":             "REQUEST_TOO_LARGE",
+		"max_body_size_mb": limit >> 20,
+	})
+	c.Abort()
+}
+
+// BodySizeLimitMiddleware rejects oversized request bodies with 413 before a
+// handler reads them into memory. A declared Content-Length over the limit is
+// refused up front. A non-multipart body without one (chunked) is read up to
+// the limit here so it can still be refused with 413; a multipart body is
+// streamed through http.MaxBytesReader so the upload handler fails instead.
+func BodySizeLimitMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Body == nil || c.Request.Body == http.NoBody {
+			c.Next()
+			return
+		}
+
+		limit := requestBodyLimit(c)
+		if c.Request.ContentLength > limit {
+			abortBodyTooLarge(c, limit)
+			return
+		}
+
+		body := http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		if c.Request.ContentLength < 0 && !isMultipartRequest(c.Request) {
+			data, err := io.ReadAll(body)
+			if err != nil {
+				var maxErr *http.MaxBytesError
+				if errors.As(err, &maxErr) {
+					abortBodyTooLarge(c, limit)
+					return
+				}
+				c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Failed to read request body"})
+				c.Abort()
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(data))
+			c.Next()
+			return
+		}
+
+		c.Request.Body = body
+		c.Next()
+	}
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newBodyLimitRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(BodySizeLimitMiddleware())
+	router.POST("/echo", func(c *gin.Context) {
+		data, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.String(http.StatusOK, "%d", len(data))
+	})
+	return router
+}
+
+func TestBodySizeLimitMiddleware(t *testing.T) {
+	t.Setenv("MAX_REQUEST_BODY_MB", "1")
+	t.Setenv("MAX_MULTIPART_BODY_MB", "3")
+	router := newBodyLimitRouter()
+	oversized := strings.Repeat("x", 2<<20)
+
+	cases := []struct {
+		name        string
+		contentType string
+		chunked     bool
+		body        string
+		want        int
+	}{
+		{"small json", "application/json", false, `{"ids":[1,2,3]}`, http.StatusOK},
+		{"oversized json", "application/json", false, oversized, http.StatusRequestEntityTooLarge},
+		{"oversized chunked json", "application/json", true, oversized, http.StatusRequestEntityTooLarge},
+		{"multipart within its limit", "multipart/form-data; boundary=x", false, oversized, http.StatusOK},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(tc.body))
+		req.Header.Set("Content-Type", tc.contentType)
+		if tc.chunked {
+			req.ContentLength = -1
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != tc.want {
+			t.Fatalf("%s: expected %d, got %d (%s)", tc.name, tc.want, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestMultipartBodyLimitMB_FollowsUploadLimit(t *testing.T) {
+	for _, tc := range []struct {
+		upload, multipart string
+		want              int64
+	}{
+		{"", "", 110},
+		{"10", "", 110},
+		{"200", "", 210},
+		{"200", "150", 150},
+	} {
+		t.Setenv("MAX_UPLOAD_SIZE_MB", tc.upload)
+		t.Setenv("MAX_MULTIPART_BODY_MB", tc.multipart)
+		if got := multipartBodyLimitMB(); got != tc.want {
+			t.Errorf("MAX_UPLOAD_SIZE_MB=%q MAX_MULTIPART_BODY_MB=%q: got %dMB, want %dMB", tc.upload, tc.multipart, got, tc.want)
+		}
+	}
+}
+
+func TestBodySizeLimitMiddleware_RouteMultipartLimit(t *testing.T) {
+	t.Setenv("MAX_MULTIPART_BODY_MB", "1")
+	router := newBodyLimitRouter()
+	router.POST("/batch", func(c *gin.Context) {
+		data, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.String(http.StatusOK, "%d", len(data))
+	})
+	// Two 1MB files plus the headroom.
+	SetMultipartUploadLimit(http.MethodPost, "/batch", 1, 2)
+	body := strings.Repeat("x", 2<<20)
+
+	for path, want := range map[string]int{"/echo": http.StatusRequestEntityTooLarge, "/batch": http.StatusOK} {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "multipart/form-data; boundary=x")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != want {
+			t.Fatalf("%s: expected %d, got %d (%s)", path, want, w.Code, w.Body.String())
+		}
+	}
+}
@@ -1,12 +1,43 @@
 package middleware
 
 import (
+	"net/http"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
+const defaultCORSMaxAge = 86400 // 24 hours
+
+// corsAllowList splits a comma-separated env value into trimmed, non-empty entries.
+func corsAllowList(value string, upper bool) []string {
+	parts := strings.Split(value, ",")
+	items := make([]string, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if upper {
+			part = strings.ToUpper(part)
+		}
+		items = append(items, part)
+	}
+	return items
+}
+
+// corsMaxAge reads CORS_MAX_AGE (seconds a preflight may be cached), default 24h.
+func corsMaxAge() string {
+	if v := strings.TrimSpace(os.Getenv("CORS_MAX_AGE")); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			return strconv.Itoa(n)
+		}
+	}
+	return strconv.Itoa(defaultCORSMaxAge)
+}
+
 // CORSMiddleware handles Cross-Origin Resource Sharing with security considerations
 func CORSMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -37,12 +68,15 @@ func CORSMiddleware() gin.HandlerFunc {
 			// Don't set the header if origin is not allowed
 			// This prevents unauthorized origins from making requests
 		}
+		// Responses differ per Origin, so caches must key on it
+		c.Writer.Header().Add("Vary", "Origin")
 
 		// Get allowed methods from environment
 		allowedMethods := os.Getenv("ALLOWED_METHODS")
 		if allowedMethods == "" {
 			allowedMethods = "GET,POST,PUT,DELETE,PATCH,OPTIONS"
 		}
+		methods := corsAllowList(allowedMethods, true)
 
 		// Get allowed headers from environment
 		allowedHeaders := os.Getenv("ALLOWED_HEADERS")
@@ -50,17 +84,35 @@ func CORSMiddleware() gin.HandlerFunc {
 			allowedHeaders = "Content-Type,Authorization,X-Requested-With"
 		}
 
-		c.Header("Access-Control-Allow-Methods", allowedMethods)
-		c.Header("Access-Control-Allow-Headers", allowedHeaders)
+		c.Header("Access-Control-Allow-Methods", strings.Join(methods, ","))
+		c.Header("Access-Control-Allow-Headers", strings.Join(corsAllowList(allowedHeaders, false), ","))
 		c.Header("Access-Control-Allow-Credentials", "true")
-		c.Header("Access-Control-Max-Age", "86400") // 24 hours
+		c.Header("Access-Control-Max-Age", corsMaxAge())
 
-		// Handle preflight requests
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
+		// Handle preflight requests: answer here so they never reach route handlers
+		if c.Request.Method == http.MethodOptions {
+			requestedMethod := strings.ToUpper(strings.TrimSpace(c.GetHeader("Access-Control-Request-Method")))
+			if requestedMethod != "" && !containsString(methods, requestedMethod) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+					"success": false,
+					"error":   "Method not allowed by CORS policy",
+					"code":    "CORS_METHOD_NOT_ALLOWED",
+				})
+				return
+			}
+			c.AbortWithStatus(http.StatusNoContent)
 			return
 		}
 
 		c.Next()
 	}
 }
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,199 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"fund-management-api/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaintenanceTogglePath is the admin endpoint that switches maintenance mode.
+// It is always exempt so an admin can turn the mode off again.
+const MaintenanceTogglePath = "/api/v1/admin/maintenance"
+
+const defaultMaintenanceMessage = "ระบบอยู่ระหว่างการปรับปรุง ไม่สามารถบันทึกข้อมูลได้ชั่วคราว"
+
+// maintenanceExemptPaths lists write endpoints that stay open in maintenance mode.
+// Login/refresh are kept open so admins can still obtain a token to reach the toggle.
+var maintenanceExemptPaths = map[string]struct{}{
+	MaintenanceTogglePath: {},
+	"/api/v1/login":       {},
+	"/api/v1/refresh":     {},
+}
+
+// MaintenanceState describes the current read-only maintenance configuration.
+type MaintenanceState struct {
+	Enabled   bool       `json:"enabled"`
+	Message   string     `json:"message"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	UpdatedBy *int       `json:"updated_by,omitempty"`
+}
+
+// maintenanceCacheTTL is how long an instance trusts its copy of the persisted
+// state, so a toggle made on one API instance reaches the others within it.
+const maintenanceCacheTTL = 5 * time.Second
+
+// maintenanceStore persists the maintenance state; tests replace it.
+type maintenanceStore interface {
+	Load() (MaintenanceState, error)
+	Save(state MaintenanceState) error
+}
+
+var maintenanceSettings maintenanceStore = systemConfigMaintenanceStore{}
+
+var (
+	maintenanceMu       sync.Mutex
+	maintenanceState    MaintenanceState
+	maintenanceLoadedAt time.Time
+)
+
+// systemConfigMaintenanceStore keeps the state on the latest system_config
+// row, so it survives restarts and is shared by every instance.
+type systemConfigMaintenanceStore struct{}
+
+func (systemConfigMaintenanceStore) Load() (MaintenanceState, error) {
+	var row struct {
+		MaintenanceMode      bool
+		MaintenanceMessage   *string
+		MaintenanceUpdatedAt *time.Time
+		MaintenanceUpdatedBy *int
+	}
+	if err := config.DB.Raw(`
+		SELECT maintenance_mode, maintenance_message, maintenance_updated_at, maintenance_updated_by
+		FROM system_config
+		ORDER BY config_id DESC
+		LIMIT 1
+	`).Scan(&row).Error; err != nil {
+		return MaintenanceState{}, err
+	}
+
+	state := MaintenanceState{
+		Enabled:   row.MaintenanceMode,
+		UpdatedAt: row.MaintenanceUpdatedAt,
+		UpdatedBy: row.MaintenanceUpdatedBy,
+	}
+	if row.MaintenanceMessage != nil {
+		state.Message = *row.MaintenanceMessage
+	}
+	return state, nil
+}
+
+func (systemConfigMaintenanceStore) Save(state MaintenanceState) error {
+	result := config.DB.Exec(`
+		UPDATE system_config
+		SET maintenance_mode = ?, maintenance_message = ?, maintenance_updated_at = ?, maintenance_updated_by = ?
+		ORDER BY config_id DESC
+		LIMIT 1
+	`, state.Enabled, state.Message, state.UpdatedAt, state.UpdatedBy)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("system_config has no row to store maintenance mode on")
+	}
+	return nil
+}
+
+// applyMaintenanceEnv fills in what the stored state leaves open.
+// MAINTENANCE_MODE=true forces the mode on (e.g. during a deploy) whatever
+// the stored toggle says; MAINTENANCE_MESSAGE replaces the default message.
+func applyMaintenanceEnv(state MaintenanceState) MaintenanceState {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("MAINTENANCE_MODE"))) {
+	case "1", "true", "yes", "on":
+		state.Enabled = true
+	}
+	if strings.TrimSpace(state.Message) == "" {
+		state.Message = strings.TrimSpace(os.Getenv("MAINTENANCE_MESSAGE"))
+	}
+	if state.Message == "" {
+		state.Message = defaultMaintenanceMessage
+	}
+	return state
+}
+
+// GetMaintenanceState returns the current maintenance state.
+func GetMaintenanceState() MaintenanceState {
+	maintenanceMu.Lock()
+	defer maintenanceMu.Unlock()
+	refreshMaintenanceStateLocked()
+	return applyMaintenanceEnv(maintenanceState)
+}
+
+// refreshMaintenanceStateLocked reloads the stored toggle once
+// maintenanceCacheTTL has passed. If the reload fails the last known state is
+// kept. maintenanceMu must be held.
+func refreshMaintenanceStateLocked() {
+	if !maintenanceLoadedAt.IsZero() && time.Since(maintenanceLoadedAt) < maintenanceCacheTTL {
+		return
+	}
+	state, err := maintenanceSettings.Load()
+	if err != nil {
+		log.Printf("[maintenance] failed to load maintenance mode: %v", err)
+	} else {
+		maintenanceState = state
+	}
+	maintenanceLoadedAt = time.Now()
+}
+
+// SetMaintenanceState stores a new maintenance toggle. An empty message keeps
+// the previous one.
+func SetMaintenanceState(enabled bool, message string, updatedBy *int) (MaintenanceState, error) {
+	maintenanceMu.Lock()
+	defer maintenanceMu.Unlock()
+	refreshMaintenanceStateLocked()
+
+	now := time.Now()
+	state := MaintenanceState{
+		Enabled:   enabled,
+		Message:   maintenanceState.Message,
+		UpdatedAt: &now,
+		UpdatedBy: updatedBy,
+	}
+	if trimmed := strings.TrimSpace(message); trimmed != "" {
+		state.Message = trimmed
+	}
+	if err := maintenanceSettings.Save(state); err != nil {
+		return MaintenanceState{}, err
+	}
+	maintenanceState = state
+	maintenanceLoadedAt = now
+	return applyMaintenanceEnv(state), nil
+}
+
+// MaintenanceMiddleware rejects mutating requests with 503 while maintenance
+// mode is on. Safe methods (GET/HEAD/OPTIONS) are always allowed.
+func MaintenanceMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		state := GetMaintenanceState()
+		if !state.Enabled {
+			c.Next()
+			return
+		}
+
+		if _, exempt := maintenanceExemptPaths[strings.TrimRight(c.Request.URL.Path, "/")]; exempt {
+			c.Next()
+			return
+		}
+
+		c.Header("Retry-After", "300")
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"success": false,
+			"error":   state.Message,
+			"code":    "MAINTENANCE_MODE",
+		})
+		c.Abort()
+	}
+}
@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// memoryMaintenanceStore stands in for system_config.
+type memoryMaintenanceStore struct {
+	state MaintenanceState
+	loads int
+}
+
+func (s *memoryMaintenanceStore) Load() (MaintenanceState, error) {
+	s.loads++
+	return s.state, nil
+}
+
+func (s *memoryMaintenanceStore) Save(state MaintenanceState) error {
+	s.state = state
+	return nil
+}
+
+func useMaintenanceStore(t *testing.T, store maintenanceStore) {
+	t.Helper()
+	previous := maintenanceSettings
+	maintenanceSettings = store
+	resetMaintenanceCache()
+	t.Cleanup(func() {
+		maintenanceSettings = previous
+		resetMaintenanceCache()
+	})
+}
+
+func resetMaintenanceCache() {
+	maintenanceMu.Lock()
+	defer maintenanceMu.Unlock()
+	maintenanceState = MaintenanceState{}
+	maintenanceLoadedAt = time.Time{}
+}
+
+func newMaintenanceRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(MaintenanceMiddleware())
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/api/v1/submissions", ok)
+	router.POST("/api/v1/submissions", ok)
+	router.POST("/api/v1/login", ok)
+	router.PUT(MaintenanceTogglePath, ok)
+	return router
+}
+
+func TestMaintenanceMiddleware(t *testing.T) {
+	t.Setenv("MAINTENANCE_MODE", "")
+	store := &memoryMaintenanceStore{state: MaintenanceState{Enabled: true, Message: "back soon"}}
+	useMaintenanceStore(t, store)
+	router := newMaintenanceRouter()
+
+	for _, tc := range []struct {
+		method, path string
+		want         int
+	}{
+		{http.MethodGet, "/api/v1/submissions", http.StatusOK},
+		{http.MethodPost, "/api/v1/submissions", http.StatusServiceUnavailable},
+		{http.MethodPost, "/api/v1/login", http.StatusOK},
+		{http.MethodPut, MaintenanceTogglePath, http.StatusOK},
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, nil))
+		if w.Code != tc.want {
+			t.Errorf("%s %s: got %d, want %d", tc.method, tc.path, w.Code, tc.want)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/submissions", nil))
+	if got := w.Header().Get("Retry-After"); got != "300" {
+		t.Errorf("Retry-After = %q, want 300", got)
+	}
+	if store.loads != 1 {
+		t.Errorf("stored state loaded %d times, want 1 within the cache TTL", store.loads)
+	}
+}
+
+func TestMaintenanceStatePersistsAcrossInstances(t *testing.T) {
+	t.Setenv("MAINTENANCE_MODE", "")
+	t.Setenv("MAINTENANCE_MESSAGE", "")
+	store := &memoryMaintenanceStore{}
+	useMaintenanceStore(t, store)
+	router := newMaintenanceRouter()
+
+	post := func() int {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/submissions", nil))
+		return w.Code
+	}
+	if code := post(); code != http.StatusOK {
+		t.Fatalf("before toggle: got %d, want 200", code)
+	}
+
+	adminID := 7
+	state, err := SetMaintenanceState(true, "  upgrading  ", &adminID)
+	if err != nil {
+		t.Fatalf("SetMaintenanceState: %v", err)
+	}
+	if !store.state.Enabled || store.state.Message != "upgrading" || store.state.UpdatedBy == nil || *store.state.UpdatedBy != adminID {
+		t.Fatalf("stored state = %+v, want enabled with message and updater", store.state)
+	}
+	if state.Message != "upgrading" {
+		t.Fatalf("returned message = %q, want upgrading", state.Message)
+	}
+
+	// A restarted (or another) instance starts with an empty cache and reads
+	// the stored toggle.
+	resetMaintenanceCache()
+	if code := post(); code != http.StatusServiceUnavailable {
+		t.Fatalf("after restart: got %d, want 503", code)
+	}
+
+	// An empty message keeps the stored one.
+	if _, err := SetMaintenanceState(false, "", &adminID); err != nil {
+		t.Fatalf("SetMaintenanceState: %v", err)
+	}
+	if store.state.Enabled || store.state.Message != "upgrading" {
+		t.Fatalf("stored state = %+v, want disabled keeping the message", store.state)
+	}
+	resetMaintenanceCache()
+	if code := post(); code != http.StatusOK {
+		t.Fatalf("after turning off: got %d, want 200", code)
+	}
+}
+
+func TestMaintenanceEnvForcesModeOn(t *testing.T) {
+	t.Setenv("MAINTENANCE_MODE", "true")
+	t.Setenv("MAINTENANCE_MESSAGE", "deploying")
+	useMaintenanceStore(t, &memoryMaintenanceStore{})
+
+	state := GetMaintenanceState()
+	if !state.Enabled || state.Message != "deploying" {
+		t.Fatalf("state = %+v, want enabled with the env message", state)
+	}
+}
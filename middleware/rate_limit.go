@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type rateLimitWindow struct {
+	start time.Time
+	count int
+}
+
+// RateLimit allows at most limit requests per window for each caller. Callers are
+// keyed by the authenticated userID when present, otherwise by client IP, so it
+// should be registered after AuthMiddleware on protected routes.
+func RateLimit(limit int, window time.Duration) gin.HandlerFunc {
+	var (
+		mu      sync.Mutex
+		windows = make(map[string]*rateLimitWindow)
+	)
+
+	return func(c *gin.Context) {
+		key := "ip:" + c.ClientIP()
+		if userID, ok := c.Get("userID"); ok {
+			key = fmt.Sprintf("user:%v", userID)
+		}
+
+		now := time.Now()
+		mu.Lock()
+		entry, ok := windows[key]
+		if !ok || now.Sub(entry.start) >= window {
+			entry = &rateLimitWindow{start: now}
+			windows[key] = entry
+			// Opportunistically drop expired windows so the map stays small.
+			for k, w := range windows {
+				if now.Sub(w.start) >= window {
+					delete(windows, k)
+				}
+			}
+		}
+		entry.count++
+		count := entry.count
+		resetIn := window - now.Sub(entry.start)
+		mu.Unlock()
+
+		if count > limit {
+			c.Header("Retry-After", strconv.Itoa(int(resetIn.Seconds())+1))
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"success": false,
+				"error":   "Too many requests, please try again later",
+				"code":    "RATE_LIMITED",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
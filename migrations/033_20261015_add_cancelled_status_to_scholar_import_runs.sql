@@ -0,0 +1,2 @@
+ALTER TABLE scholar_import_runs
+  MODIFY COLUMN status ENUM('running','success','failed','cancelled') NOT NULL DEFAULT 'running';
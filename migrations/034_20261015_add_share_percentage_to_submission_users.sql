@@ -0,0 +1,2 @@
+ALTER TABLE submission_users
+  ADD COLUMN share_percentage DECIMAL(5,2) DEFAULT NULL AFTER display_order;
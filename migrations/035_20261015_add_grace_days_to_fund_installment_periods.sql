@@ -0,0 +1,2 @@
+ALTER TABLE fund_installment_periods
+  ADD COLUMN grace_days INT NOT NULL DEFAULT 0 AFTER cutoff_date;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS public_holidays (
+  holiday_id INT AUTO_INCREMENT PRIMARY KEY,
+  holiday_date DATE NOT NULL,
+  name VARCHAR(255) NOT NULL DEFAULT '',
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  deleted_at DATETIME NULL,
+  UNIQUE KEY uq_public_holidays_date (holiday_date)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS submission_autosaves (
+  autosave_id INT AUTO_INCREMENT PRIMARY KEY,
+  submission_id INT NOT NULL,
+  user_id INT NOT NULL,
+  payload LONGTEXT NOT NULL,
+  version INT NOT NULL DEFAULT 1,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  UNIQUE KEY uq_submission_autosaves_submission (submission_id),
+  CONSTRAINT fk_submission_autosaves_submission FOREIGN KEY (submission_id) REFERENCES submissions (submission_id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS budget_alerts (
+  alert_id INT AUTO_INCREMENT PRIMARY KEY,
+  subcategory_id INT NOT NULL,
+  threshold_percent DECIMAL(5,2) NOT NULL,
+  allocated_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+  remaining_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+  remaining_percent DECIMAL(7,2) NOT NULL DEFAULT 0,
+  recipients TEXT NULL,
+  sent_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  resolved_at DATETIME NULL,
+  KEY idx_budget_alerts_open (subcategory_id, resolved_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
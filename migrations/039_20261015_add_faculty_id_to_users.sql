@@ -0,0 +1,5 @@
+-- Link users to the existing faculties lookup (see 024) for per-department stats.
+ALTER TABLE users
+  ADD COLUMN faculty_id INT DEFAULT NULL AFTER position_id,
+  ADD KEY idx_users_faculty (faculty_id),
+  ADD CONSTRAINT fk_users_faculty FOREIGN KEY (faculty_id) REFERENCES faculties (id) ON DELETE SET NULL;
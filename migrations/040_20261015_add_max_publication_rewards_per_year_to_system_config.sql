@@ -0,0 +1,2 @@
+ALTER TABLE system_config
+  ADD COLUMN max_publication_rewards_per_year INT DEFAULT NULL AFTER max_submissions_per_year;
@@ -0,0 +1,4 @@
+ALTER TABLE submissions
+  ADD COLUMN form_generation_status VARCHAR(20) DEFAULT NULL AFTER installment_fund_name_at_submit,
+  ADD COLUMN form_generation_error TEXT DEFAULT NULL AFTER form_generation_status,
+  ADD COLUMN form_generated_at DATETIME DEFAULT NULL AFTER form_generation_error;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS background_jobs (
+  job_id INT AUTO_INCREMENT PRIMARY KEY,
+  job_type VARCHAR(50) NOT NULL,
+  submission_id INT NULL,
+  status ENUM('queued','running','succeeded','failed') NOT NULL DEFAULT 'queued',
+  attempts INT NOT NULL DEFAULT 0,
+  max_attempts INT NOT NULL DEFAULT 3,
+  last_error TEXT NULL,
+  run_after DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  started_at DATETIME NULL,
+  finished_at DATETIME NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  KEY idx_background_jobs_due (status, run_after),
+  KEY idx_background_jobs_submission (submission_id, status)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
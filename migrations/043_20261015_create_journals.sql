@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS journals (
+  journal_id INT AUTO_INCREMENT PRIMARY KEY,
+  journal_name VARCHAR(500) NOT NULL,
+  issn VARCHAR(9) DEFAULT NULL,
+  eissn VARCHAR(9) DEFAULT NULL,
+  quartile ENUM('Q1','Q2','Q3','Q4') DEFAULT NULL,
+  metric_year INT DEFAULT NULL,
+  source VARCHAR(50) DEFAULT NULL COMMENT 'jcr | scimago | manual',
+  create_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  delete_at DATETIME DEFAULT NULL,
+  KEY idx_journals_issn (issn),
+  KEY idx_journals_eissn (eissn),
+  KEY idx_journals_name (journal_name(191))
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,2 @@
+ALTER TABLE journals
+  ADD COLUMN indexing VARCHAR(100) DEFAULT NULL COMMENT 'e.g. ISI, Scopus, TCI1' AFTER quartile;
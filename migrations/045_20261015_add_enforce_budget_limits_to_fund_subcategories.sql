@@ -0,0 +1,3 @@
+ALTER TABLE fund_subcategories
+  ADD COLUMN enforce_budget_limits TINYINT(1) NOT NULL DEFAULT 0
+    COMMENT '1 = reject submissions over the budget limits, 0 = warn only' AFTER status;
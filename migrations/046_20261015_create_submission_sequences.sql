@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS submission_sequences (
+  prefix VARCHAR(10) NOT NULL,
+  be_year CHAR(4) NOT NULL,
+  last_number INT UNSIGNED NOT NULL DEFAULT 0,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (prefix, be_year)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci
+  COMMENT='Running number per submission prefix and Buddhist year';
@@ -0,0 +1,6 @@
+-- Installment numbering policy: one sequence per prefix, Buddhist year and
+-- installment. Installment 0 is the yearly sequence.
+ALTER TABLE submission_sequences
+  ADD COLUMN installment SMALLINT UNSIGNED NOT NULL DEFAULT 0 AFTER be_year,
+  DROP PRIMARY KEY,
+  ADD PRIMARY KEY (prefix, be_year, installment);
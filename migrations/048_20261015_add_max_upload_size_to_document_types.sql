@@ -0,0 +1,3 @@
+-- Per-document-type upload limit in MB; NULL uses MAX_UPLOAD_SIZE_MB.
+ALTER TABLE document_types
+  ADD COLUMN max_upload_size_mb INT UNSIGNED NULL DEFAULT NULL AFTER multiple;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS subcategory_document_requirements (
+  requirement_id INT NOT NULL AUTO_INCREMENT,
+  subcategory_id INT NOT NULL,
+  document_type_id INT NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (requirement_id),
+  UNIQUE KEY uq_subcategory_document_type (subcategory_id, document_type_id),
+  KEY idx_subcategory_document_requirements_type (document_type_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci
+  COMMENT='Document types a subcategory requires in addition to the document-type defaults';
@@ -0,0 +1,23 @@
+-- Incremental scholar import: skip counters on each run, a per-user decision log
+-- used to find users imported recently, and a content hash so unchanged
+-- publications are not rewritten.
+ALTER TABLE scholar_import_runs
+  ADD COLUMN users_skipped INT UNSIGNED NOT NULL DEFAULT 0 AFTER users_with_errors,
+  ADD COLUMN publications_unchanged INT UNSIGNED NOT NULL DEFAULT 0 AFTER publications_updated;
+
+ALTER TABLE publications
+  ADD COLUMN content_hash CHAR(64) NULL DEFAULT NULL AFTER fingerprint,
+  ADD KEY idx_publications_user_content_hash (user_id, content_hash);
+
+CREATE TABLE IF NOT EXISTS scholar_import_run_users (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  run_id BIGINT UNSIGNED NOT NULL,
+  user_id INT NOT NULL,
+  decision ENUM('processed','skipped','failed') NOT NULL,
+  reason VARCHAR(255) NULL DEFAULT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  KEY idx_scholar_import_run_users_run (run_id),
+  KEY idx_scholar_import_run_users_user (user_id, decision, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci
+  COMMENT='Whether each scholar import run processed or skipped a user';
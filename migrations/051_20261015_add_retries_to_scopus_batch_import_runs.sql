@@ -0,0 +1,3 @@
+-- Number of Scopus API retries (429/5xx/network errors) made during a batch run.
+ALTER TABLE scopus_batch_import_runs
+  ADD COLUMN retries INT NOT NULL DEFAULT 0 AFTER users_with_errors;
@@ -0,0 +1,25 @@
+-- DOI-based deduplication across the Scholar and Scopus importers: both stores
+-- keep the DOI lowercased, without its doi.org / "doi:" prefix or trailing dots
+-- (as utils.NormalizeDOI does), and the import runs count records merged into
+-- an existing publication.
+ALTER TABLE publications
+  ADD COLUMN doi_normalized VARCHAR(255) NULL DEFAULT NULL AFTER doi,
+  ADD KEY idx_publications_doi_normalized (doi_normalized);
+
+UPDATE publications
+SET doi_normalized = NULLIF(REGEXP_REPLACE(TRIM(REGEXP_REPLACE(LOWER(TRIM(doi)), '^(https?://(dx\\.)?doi\\.org/|doi\\.org/|doi:)', '')), '[. ]+$', ''), '')
+WHERE doi IS NOT NULL;
+
+ALTER TABLE scopus_documents
+  ADD COLUMN doi_normalized VARCHAR(255) NULL DEFAULT NULL AFTER doi,
+  ADD KEY idx_scopus_documents_doi_normalized (doi_normalized);
+
+UPDATE scopus_documents
+SET doi_normalized = NULLIF(REGEXP_REPLACE(TRIM(REGEXP_REPLACE(LOWER(TRIM(doi)), '^(https?://(dx\\.)?doi\\.org/|doi\\.org/|doi:)', '')), '[. ]+$', ''), '')
+WHERE doi IS NOT NULL;
+
+ALTER TABLE scholar_import_runs
+  ADD COLUMN publications_merged INT UNSIGNED NOT NULL DEFAULT 0 AFTER publications_unchanged;
+
+ALTER TABLE scopus_batch_import_runs
+  ADD COLUMN publications_merged INT NOT NULL DEFAULT 0 AFTER documents_failed;
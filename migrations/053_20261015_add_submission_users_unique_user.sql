@@ -0,0 +1,18 @@
+-- One row per user per submission. Run `go run ./cmd/dedupe-submission-users`
+-- first: it removes existing duplicates (keeping the lowest display_order); the
+-- key cannot be added while duplicates remain.
+
+-- Add the owner row for submissions whose applicant is not listed yet, as
+-- ensureApplicantSubmissionUser does for new ones.
+INSERT INTO submission_users (submission_id, user_id, role, is_primary, display_order, created_at)
+SELECT s.submission_id, s.user_id, 'owner', 1, 1, NOW()
+FROM submissions s
+WHERE s.deleted_at IS NULL
+  AND s.user_id > 0
+  AND NOT EXISTS (
+    SELECT 1 FROM submission_users su
+    WHERE su.submission_id = s.submission_id AND su.user_id = s.user_id
+  );
+
+ALTER TABLE submission_users
+  ADD UNIQUE KEY uniq_submission_users_submission_user (submission_id, user_id);
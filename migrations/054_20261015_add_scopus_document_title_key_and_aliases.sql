@@ -0,0 +1,29 @@
+-- Scopus deduplication without a DOI matches on the normalized title, so keep
+-- it in an indexed column instead of normalizing every document of the year on
+-- each import. The backfill mirrors publicationTitleKey: lowercase, runs of
+-- anything but letters, digits and marks collapsed to one space, and no key for
+-- titles shorter than 20 characters.
+ALTER TABLE scopus_documents
+  ADD COLUMN title_key TEXT NULL DEFAULT NULL AFTER title,
+  ADD KEY idx_scopus_documents_title_key (title_key(191));
+
+UPDATE scopus_documents
+SET title_key = NULLIF(TRIM(REGEXP_REPLACE(LOWER(title), '[^\\p{L}\\p{N}\\p{M}]+', ' ')), '')
+WHERE title IS NOT NULL;
+
+UPDATE scopus_documents
+SET title_key = NULL
+WHERE CHAR_LENGTH(title_key) < 20;
+
+-- EIDs Scopus returned for a document that was merged into another one, so the
+-- next import of that EID updates the merged document directly.
+CREATE TABLE IF NOT EXISTS scopus_document_aliases (
+  eid VARCHAR(64) NOT NULL,
+  document_id BIGINT UNSIGNED NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (eid),
+  KEY idx_scopus_document_aliases_document (document_id),
+  CONSTRAINT fk_scopus_document_aliases_document FOREIGN KEY (document_id)
+    REFERENCES scopus_documents (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci
+  COMMENT='Alternate Scopus EIDs of documents merged by DOI or title';
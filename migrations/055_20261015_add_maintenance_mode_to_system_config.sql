@@ -0,0 +1,7 @@
+-- Read-only maintenance mode is toggled by admins at runtime; keep it on the
+-- system_config row so it survives restarts and applies to every API instance.
+ALTER TABLE system_config
+  ADD COLUMN maintenance_mode TINYINT(1) NOT NULL DEFAULT 0 AFTER max_publication_rewards_per_year,
+  ADD COLUMN maintenance_message VARCHAR(500) DEFAULT NULL AFTER maintenance_mode,
+  ADD COLUMN maintenance_updated_at DATETIME DEFAULT NULL AFTER maintenance_message,
+  ADD COLUMN maintenance_updated_by INT DEFAULT NULL AFTER maintenance_updated_at;
@@ -77,6 +77,10 @@ type FundSubcategory struct {
 	UpdateAt        *time.Time `gorm:"column:update_at" json:"update_at"`
 	DeleteAt        *time.Time `gorm:"column:delete_at" json:"delete_at,omitempty"`
 
+	// EnforceBudgetLimits rejects submissions over the budget limits; when
+	// false the limits are only reported as warnings.
+	EnforceBudgetLimits bool `gorm:"column:enforce_budget_limits" json:"enforce_budget_limits"`
+
 	// Relations
 	Category          FundCategory      `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
 	SubcategoryBudget SubcategoryBudget `gorm:"foreignKey:SubcategoryID" json:"subcategory_budget,omitempty"`
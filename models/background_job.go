@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// Background job statuses.
+const (
+	BackgroundJobQueued    = "queued"
+	BackgroundJobRunning   = "running"
+	BackgroundJobSucceeded = "succeeded"
+	BackgroundJobFailed    = "failed"
+)
+
+// BackgroundJob is a persisted unit of work for the in-process worker queue.
+// Queued rows are picked up again after a restart; a job is retried until
+// Attempts reaches MaxAttempts and is then left as failed.
+type BackgroundJob struct {
+	JobID        int        `gorm:"column:job_id;primaryKey" json:"job_id"`
+	JobType      string     `gorm:"column:job_type" json:"job_type"`
+	SubmissionID *int       `gorm:"column:submission_id" json:"submission_id,omitempty"`
+	Status       string     `gorm:"column:status" json:"status"`
+	Attempts     int        `gorm:"column:attempts" json:"attempts"`
+	MaxAttempts  int        `gorm:"column:max_attempts" json:"max_attempts"`
+	LastError    *string    `gorm:"column:last_error" json:"last_error,omitempty"`
+	RunAfter     time.Time  `gorm:"column:run_after" json:"run_after"`
+	StartedAt    *time.Time `gorm:"column:started_at" json:"started_at,omitempty"`
+	FinishedAt   *time.Time `gorm:"column:finished_at" json:"finished_at,omitempty"`
+	CreatedAt    time.Time  `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt    time.Time  `gorm:"column:updated_at" json:"updated_at"`
+}
+
+// TableName implements gorm's tablename interface.
+func (BackgroundJob) TableName() string {
+	return "background_jobs"
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// BudgetAlert records a near-exhaustion email sent for a subcategory budget.
+// An alert stays open (ResolvedAt nil) until the remaining budget climbs back
+// above the threshold, so each dip is reported once.
+type BudgetAlert struct {
+	AlertID          int        `gorm:"column:alert_id;primaryKey" json:"alert_id"`
+	SubcategoryID    int        `gorm:"column:subcategory_id" json:"subcategory_id"`
+	ThresholdPercent float64    `gorm:"column:threshold_percent" json:"threshold_percent"`
+	AllocatedAmount  float64    `gorm:"column:allocated_amount" json:"allocated_amount"`
+	RemainingAmount  float64    `gorm:"column:remaining_amount" json:"remaining_amount"`
+	RemainingPercent float64    `gorm:"column:remaining_percent" json:"remaining_percent"`
+	Recipients       *string    `gorm:"column:recipients" json:"recipients,omitempty"`
+	SentAt           time.Time  `gorm:"column:sent_at" json:"sent_at"`
+	ResolvedAt       *time.Time `gorm:"column:resolved_at" json:"resolved_at,omitempty"`
+}
+
+// TableName implements gorm's tablename interface.
+func (BudgetAlert) TableName() string {
+	return "budget_alerts"
+}
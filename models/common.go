@@ -20,7 +20,7 @@ type FileUpload struct {
 	Metadata     string     `gorm:"column:metadata" json:"metadata"`
 	FileSize     int64      `gorm:"column:file_size" json:"file_size"`
 	MimeType     string     `gorm:"column:mime_type" json:"mime_type"`
-	FileHash     string     `gorm:"column:file_hash" json:"file_hash"` // เก็บไว้แต่ไม่ใช้
+	FileHash     string     `gorm:"column:file_hash" json:"file_hash"` // SHA-256 ของเนื้อไฟล์ ใช้ตรวจไฟล์ซ้ำของผู้อัปโหลด
 	IsPublic     bool       `gorm:"column:is_public" json:"is_public"`
 	UploadedBy   int        `gorm:"column:uploaded_by" json:"uploaded_by"`
 	UploadedAt   time.Time  `gorm:"column:uploaded_at" json:"uploaded_at"`
@@ -46,6 +46,19 @@ type DocumentType struct {
 
 	// เพิ่มฟิลด์ใหม่
 	FundTypes *string `gorm:"column:fund_types" json:"fund_types"` // JSON field
+
+	// ขนาดไฟล์สูงสุด (MB) ของเอกสารประเภทนี้; NULL ใช้ MAX_UPLOAD_SIZE_MB
+	MaxUploadSizeMB *int `gorm:"column:max_upload_size_mb" json:"max_upload_size_mb"`
+}
+
+// SubcategoryDocumentRequirement marks a document type as required for one
+// subcategory on top of the document type's own required flag.
+type SubcategoryDocumentRequirement struct {
+	RequirementID  int       `gorm:"primaryKey;column:requirement_id" json:"requirement_id"`
+	SubcategoryID  int       `gorm:"column:subcategory_id" json:"subcategory_id"`
+	DocumentTypeID int       `gorm:"column:document_type_id" json:"document_type_id"`
+	CreatedAt      time.Time `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt      time.Time `gorm:"column:updated_at" json:"updated_at"`
 }
 
 // TableName overrides
@@ -57,6 +70,10 @@ func (DocumentType) TableName() string {
 	return "document_types"
 }
 
+func (SubcategoryDocumentRequirement) TableName() string {
+	return "subcategory_document_requirements"
+}
+
 // ===== Helper methods สำหรับ FileUpload =====
 
 // GetReadablePath แปลง path ให้อ่านง่าย
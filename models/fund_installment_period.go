@@ -11,6 +11,7 @@ type FundInstallmentPeriod struct {
 	YearID              int        `gorm:"column:year_id" json:"year_id"`
 	InstallmentNumber   int        `gorm:"column:installment_number" json:"installment_number"`
 	CutoffDate          time.Time  `gorm:"column:cutoff_date" json:"cutoff_date"`
+	GraceDays           int        `gorm:"column:grace_days;default:0" json:"grace_days"`
 	Name                *string    `gorm:"column:name" json:"name,omitempty"`
 	Status              *string    `gorm:"column:status" json:"status,omitempty"`
 	Remark              *string    `gorm:"column:remark" json:"remark,omitempty"`
@@ -80,6 +80,10 @@ func (t *ImportTemplate) normalizedFilePath() string {
 	for strings.Contains(path, "//") {
 		path = strings.ReplaceAll(path, "//", "/")
 	}
+	// object-store keys are relative to the upload root
+	if !strings.HasPrefix(path, "/uploads/") {
+		path = "/uploads" + path
+	}
 	return path
 }
 
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// Journal is an entry in the institution's journal reference list, imported
+// from JCR/Scimago data or maintained by hand. A journal may have one row per
+// metric year.
+type Journal struct {
+	JournalID   int        `gorm:"column:journal_id;primaryKey" json:"journal_id"`
+	JournalName string     `gorm:"column:journal_name" json:"journal_name"`
+	ISSN        *string    `gorm:"column:issn" json:"issn,omitempty"`
+	EISSN       *string    `gorm:"column:eissn" json:"eissn,omitempty"`
+	Quartile    *string    `gorm:"column:quartile" json:"quartile,omitempty"`
+	Indexing    *string    `gorm:"column:indexing" json:"indexing,omitempty"`
+	MetricYear  *int       `gorm:"column:metric_year" json:"metric_year,omitempty"`
+	Source      *string    `gorm:"column:source" json:"source,omitempty"`
+	CreateAt    time.Time  `gorm:"column:create_at" json:"create_at"`
+	UpdateAt    time.Time  `gorm:"column:update_at" json:"update_at"`
+	DeleteAt    *time.Time `gorm:"column:delete_at" json:"delete_at,omitempty"`
+}
+
+// TableName implements gorm's tablename interface.
+func (Journal) TableName() string {
+	return "journals"
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// PublicHoliday represents public_holidays records used for business-day calculations.
+type PublicHoliday struct {
+	HolidayID   int        `gorm:"column:holiday_id;primaryKey" json:"holiday_id"`
+	HolidayDate time.Time  `gorm:"column:holiday_date;type:date" json:"holiday_date"`
+	Name        string     `gorm:"column:name" json:"name"`
+	CreatedAt   time.Time  `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at" json:"updated_at"`
+	DeletedAt   *time.Time `gorm:"column:deleted_at" json:"deleted_at,omitempty"`
+}
+
+// TableName implements gorm's tablename interface.
+func (PublicHoliday) TableName() string {
+	return "public_holidays"
+}
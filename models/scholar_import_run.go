@@ -7,26 +7,36 @@ import (
 )
 
 const (
-	ScholarImportRunStatusRunning = "running"
-	ScholarImportRunStatusSuccess = "success"
-	ScholarImportRunStatusFailed  = "failed"
+	ScholarImportUserProcessed = "processed"
+	ScholarImportUserSkipped   = "skipped"
+	ScholarImportUserFailed    = "failed"
+)
+
+const (
+	ScholarImportRunStatusRunning   = "running"
+	ScholarImportRunStatusSuccess   = "success"
+	ScholarImportRunStatusFailed    = "failed"
+	ScholarImportRunStatusCancelled = "cancelled"
 )
 
 type ScholarImportRun struct {
 	ID uint `json:"id" gorm:"primaryKey;autoIncrement"`
 
 	TriggerSource string     `json:"trigger_source" gorm:"type:varchar(64);not null"`
-	Status        string     `json:"status" gorm:"type:enum('running','success','failed');not null;default:'running'"`
+	Status        string     `json:"status" gorm:"type:enum('running','success','failed','cancelled');not null;default:'running'"`
 	ErrorMessage  *string    `json:"error_message" gorm:"type:text"`
 	StartedAt     time.Time  `json:"started_at" gorm:"column:started_at;autoCreateTime"`
 	FinishedAt    *time.Time `json:"finished_at" gorm:"column:finished_at"`
 
-	UsersProcessed      uint `json:"users_processed" gorm:"column:users_processed;not null;default:0"`
-	UsersWithErrors     uint `json:"users_with_errors" gorm:"column:users_with_errors;not null;default:0"`
-	PublicationsFetched uint `json:"publications_fetched" gorm:"column:publications_fetched;not null;default:0"`
-	PublicationsCreated uint `json:"publications_created" gorm:"column:publications_created;not null;default:0"`
-	PublicationsUpdated uint `json:"publications_updated" gorm:"column:publications_updated;not null;default:0"`
-	PublicationsFailed  uint `json:"publications_failed" gorm:"column:publications_failed;not null;default:0"`
+	UsersProcessed        uint `json:"users_processed" gorm:"column:users_processed;not null;default:0"`
+	UsersWithErrors       uint `json:"users_with_errors" gorm:"column:users_with_errors;not null;default:0"`
+	UsersSkipped          uint `json:"users_skipped" gorm:"column:users_skipped;not null;default:0"`
+	PublicationsFetched   uint `json:"publications_fetched" gorm:"column:publications_fetched;not null;default:0"`
+	PublicationsCreated   uint `json:"publications_created" gorm:"column:publications_created;not null;default:0"`
+	PublicationsUpdated   uint `json:"publications_updated" gorm:"column:publications_updated;not null;default:0"`
+	PublicationsUnchanged uint `json:"publications_unchanged" gorm:"column:publications_unchanged;not null;default:0"`
+	PublicationsMerged    uint `json:"publications_merged" gorm:"column:publications_merged;not null;default:0"`
+	PublicationsFailed    uint `json:"publications_failed" gorm:"column:publications_failed;not null;default:0"`
 
 	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt time.Time      `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
@@ -34,3 +44,16 @@ type ScholarImportRun struct {
 }
 
 func (ScholarImportRun) TableName() string { return "scholar_import_runs" }
+
+// ScholarImportRunUser records whether a run processed or skipped a user.
+// Incremental runs use the latest processed entry to decide who is due.
+type ScholarImportRunUser struct {
+	ID        uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	RunID     uint      `json:"run_id" gorm:"column:run_id;not null;index"`
+	UserID    uint      `json:"user_id" gorm:"column:user_id;not null"`
+	Decision  string    `json:"decision" gorm:"type:enum('processed','skipped','failed');not null"`
+	Reason    *string   `json:"reason,omitempty" gorm:"type:varchar(255)"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+}
+
+func (ScholarImportRunUser) TableName() string { return "scholar_import_run_users" }
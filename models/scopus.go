@@ -9,6 +9,7 @@ type ScopusDocument struct {
 	ScopusID           *string    `gorm:"column:scopus_id" json:"scopus_id,omitempty"`
 	ScopusLink         *string    `gorm:"column:scopus_link" json:"scopus_link,omitempty"`
 	Title              *string    `gorm:"column:title" json:"title,omitempty"`
+	TitleKey           *string    `gorm:"column:title_key" json:"-"`
 	Abstract           *string    `gorm:"column:abstract" json:"abstract,omitempty"`
 	AggregationType    *string    `gorm:"column:aggregation_type" json:"aggregation_type,omitempty"`
 	Subtype            *string    `gorm:"column:subtype" json:"subtype,omitempty"`
@@ -24,6 +25,7 @@ type ScopusDocument struct {
 	ArticleNumber      *string    `gorm:"column:article_number" json:"article_number,omitempty"`
 	CoverDate          *time.Time `gorm:"column:cover_date" json:"cover_date,omitempty"`
 	DOI                *string    `gorm:"column:doi" json:"doi,omitempty"`
+	DOINormalized      *string    `gorm:"column:doi_normalized" json:"-"`
 	PII                *string    `gorm:"column:pii" json:"pii,omitempty"`
 	CitedByCount       *int       `gorm:"column:citedby_count" json:"citedby_count,omitempty"`
 	OpenAccess         *uint8     `gorm:"column:openaccess" json:"openaccess,omitempty"`
@@ -49,6 +51,19 @@ func (ScopusDocument) TableName() string {
 	return "scopus_documents"
 }
 
+// ScopusDocumentAlias maps another EID Scopus returned for a document that was
+// merged into an existing one by DOI or title.
+type ScopusDocumentAlias struct {
+	EID        string    `gorm:"primaryKey;column:eid" json:"eid"`
+	DocumentID uint      `gorm:"column:document_id" json:"document_id"`
+	CreatedAt  time.Time `gorm:"column:created_at" json:"created_at"`
+}
+
+// TableName overrides the table name used by ScopusDocumentAlias to `scopus_document_aliases`.
+func (ScopusDocumentAlias) TableName() string {
+	return "scopus_document_aliases"
+}
+
 // ScopusAuthor represents an author entry from Scopus.
 type ScopusAuthor struct {
 	ID             uint    `gorm:"primaryKey;column:id" json:"id"`
@@ -10,10 +10,12 @@ type ScopusBatchImportRun struct {
 	Limit               *int       `json:"limit,omitempty" gorm:"column:limit"`
 	UsersProcessed      int        `json:"users_processed" gorm:"column:users_processed;not null;default:0"`
 	UsersWithErrors     int        `json:"users_with_errors" gorm:"column:users_with_errors;not null;default:0"`
+	Retries             int        `json:"retries" gorm:"column:retries;not null;default:0"`
 	DocumentsFetched    int        `json:"documents_fetched" gorm:"column:documents_fetched;not null;default:0"`
 	DocumentsCreated    int        `json:"documents_created" gorm:"column:documents_created;not null;default:0"`
 	DocumentsUpdated    int        `json:"documents_updated" gorm:"column:documents_updated;not null;default:0"`
 	DocumentsFailed     int        `json:"documents_failed" gorm:"column:documents_failed;not null;default:0"`
+	PublicationsMerged  int        `json:"publications_merged" gorm:"column:publications_merged;not null;default:0"`
 	AuthorsCreated      int        `json:"authors_created" gorm:"column:authors_created;not null;default:0"`
 	AuthorsUpdated      int        `json:"authors_updated" gorm:"column:authors_updated;not null;default:0"`
 	AffiliationsCreated int        `json:"affiliations_created" gorm:"column:affiliations_created;not null;default:0"`
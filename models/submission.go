@@ -28,6 +28,9 @@ type Submission struct {
 	SubmittedAt                  *time.Time `gorm:"column:submitted_at" json:"submitted_at"`
 	InstallmentNumberAtSubmit    *int       `gorm:"column:installment_number_at_submit" json:"installment_number_at_submit,omitempty"`
 	InstallmentFundNameAtSubmit  *string    `gorm:"column:installment_fund_name_at_submit" json:"installment_fund_name_at_submit,omitempty"`
+	FormGenerationStatus         *string    `gorm:"column:form_generation_status" json:"form_generation_status,omitempty"` // pending | ready | failed
+	FormGenerationError          *string    `gorm:"column:form_generation_error" json:"-"`
+	FormGeneratedAt              *time.Time `gorm:"column:form_generated_at" json:"form_generated_at,omitempty"`
 	CreatedAt                    time.Time  `gorm:"column:created_at" json:"created_at"`
 	UpdatedAt                    time.Time  `gorm:"column:updated_at" json:"updated_at"`
 	DeletedAt                    *time.Time `gorm:"column:deleted_at" json:"deleted_at"`
@@ -215,13 +218,14 @@ type SubmissionDocument struct {
 
 // SubmissionUser represents co-authors and collaborators in submissions
 type SubmissionUser struct {
-	ID           int       `gorm:"primaryKey;column:id" json:"id"`
-	SubmissionID int       `gorm:"column:submission_id" json:"submission_id"`
-	UserID       int       `gorm:"column:user_id" json:"user_id"`
-	Role         string    `gorm:"column:role;type:enum('owner','coauthor','team_member','advisor','coordinator');default:'coauthor'" json:"role"`
-	IsPrimary    bool      `gorm:"column:is_primary;default:false" json:"is_primary"`
-	DisplayOrder int       `gorm:"column:display_order;default:0" json:"display_order"`
-	CreatedAt    time.Time `gorm:"column:created_at;default:CURRENT_TIMESTAMP" json:"created_at"`
+	ID              int       `gorm:"primaryKey;column:id" json:"id"`
+	SubmissionID    int       `gorm:"column:submission_id" json:"submission_id"`
+	UserID          int       `gorm:"column:user_id" json:"user_id"`
+	Role            string    `gorm:"column:role;type:enum('owner','coauthor','team_member','advisor','coordinator');default:'coauthor'" json:"role"`
+	IsPrimary       bool      `gorm:"column:is_primary;default:false" json:"is_primary"`
+	DisplayOrder    int       `gorm:"column:display_order;default:0" json:"display_order"`
+	SharePercentage *float64  `gorm:"column:share_percentage" json:"share_percentage,omitempty"`
+	CreatedAt       time.Time `gorm:"column:created_at;default:CURRENT_TIMESTAMP" json:"created_at"`
 
 	// Relations
 	Submission Submission `gorm:"foreignKey:SubmissionID" json:"submission,omitempty"`
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// SubmissionAutosave holds the latest autosaved (uncommitted) detail form data
+// for a submission. Version increases on every autosave for optimistic locking.
+type SubmissionAutosave struct {
+	AutosaveID   int             `gorm:"column:autosave_id;primaryKey" json:"autosave_id"`
+	SubmissionID int             `gorm:"column:submission_id" json:"submission_id"`
+	UserID       int             `gorm:"column:user_id" json:"user_id"`
+	Payload      json.RawMessage `gorm:"column:payload" json:"data"`
+	Version      int             `gorm:"column:version" json:"version"`
+	CreatedAt    time.Time       `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt    time.Time       `gorm:"column:updated_at" json:"updated_at"`
+}
+
+// TableName implements gorm's tablename interface.
+func (SubmissionAutosave) TableName() string {
+	return "submission_autosaves"
+}
@@ -15,6 +15,7 @@ type User struct {
 	Password          *string    `gorm:"column:password" json:"-"`
 	RoleID            int        `gorm:"column:role_id" json:"role_id"`
 	PositionID        int        `gorm:"column:position_id" json:"position_id"`
+	FacultyID         *int       `gorm:"column:faculty_id" json:"faculty_id,omitempty"`
 	DateOfEmployment  *time.Time `gorm:"column:date_of_employment" json:"date_of_employment,omitempty"`
 	LastLoginAt       *time.Time `gorm:"column:last_login_at" json:"last_login_at,omitempty"`
 	CreateAt          *time.Time `gorm:"column:create_at" json:"create_at"`
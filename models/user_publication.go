@@ -19,6 +19,7 @@ type UserPublication struct {
 	PublicationDate *time.Time `json:"publication_date,omitempty" gorm:"type:date"`
 	PublicationYear *uint16    `json:"publication_year,omitempty" gorm:"index:idx_user_year"`
 	DOI             *string    `json:"doi,omitempty"     gorm:"type:varchar(255);uniqueIndex:uniq_doi"`
+	DOINormalized   *string    `json:"-"                 gorm:"column:doi_normalized;type:varchar(255)"`
 	URL             *string    `json:"url,omitempty"     gorm:"type:varchar(512)"`
 	CitedBy         *uint      `json:"cited_by" gorm:"column:cited_by"`
 	CitedByURL      *string    `json:"cited_by_url" gorm:"column:cited_by_url"`
@@ -26,6 +27,7 @@ type UserPublication struct {
 	Source          *string    `json:"source,omitempty"  gorm:"type:enum('scholar','openalex','orcid','crossref')"`
 	ExternalIDs     *string    `json:"external_ids,omitempty" gorm:"type:longtext"`
 	Fingerprint     *string    `json:"fingerprint,omitempty"  gorm:"type:varchar(64);uniqueIndex:uniq_fingerprint"`
+	ContentHash     *string    `json:"-"                 gorm:"column:content_hash;type:char(64)"`
 	IsVerified      bool       `json:"is_verified"       gorm:"type:tinyint(1);not null;default:0"`
 
 	CreatedAt time.Time      `json:"created_at"  gorm:"column:created_at;autoCreateTime"`
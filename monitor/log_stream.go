@@ -0,0 +1,199 @@
+package monitor
+
+import (
+	"bufio"
+	"fund-management-api/config"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultLogStreamMaxClients = 5
+	logStreamPollInterval      = time.Second
+	logStreamHeartbeatInterval = 15 * time.Second
+)
+
+var (
+	logStreamMu       sync.Mutex
+	logStreamActive   int
+	logStreamStopOnce sync.Once
+	logStreamStop     = make(chan struct{})
+)
+
+// logStreamMaxClients reads LOG_STREAM_MAX_CLIENTS (default 5).
+func logStreamMaxClients() int {
+	if v := strings.TrimSpace(os.Getenv("LOG_STREAM_MAX_CLIENTS")); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			return n
+		}
+	}
+	return defaultLogStreamMaxClients
+}
+
+func acquireLogStream() bool {
+	logStreamMu.Lock()
+	defer logStreamMu.Unlock()
+	if logStreamActive >= logStreamMaxClients() {
+		return false
+	}
+	logStreamActive++
+	return true
+}
+
+func releaseLogStream() {
+	logStreamMu.Lock()
+	logStreamActive--
+	logStreamMu.Unlock()
+}
+
+// StopLogStreams ends every open /logs/stream connection. Call it on server shutdown
+// so long-lived SSE responses do not hold the process open.
+func StopLogStreams() {
+	logStreamStopOnce.Do(func() {
+		close(logStreamStop)
+	})
+}
+
+// logTail follows the active log file, reopening it after rotation or truncation.
+type logTail struct {
+	path    string
+	file    *os.File
+	info    os.FileInfo
+	reader  *bufio.Reader
+	offset  int64
+	partial string
+}
+
+func openLogTail(path string) (*logTail, error) {
+	t := &logTail{path: path}
+	if err := t.open(true); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *logTail) open(fromEnd bool) error {
+	file, err := os.Open(t.path)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	offset := int64(0)
+	if fromEnd {
+		if offset, err = file.Seek(0, io.SeekEnd); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	if t.file != nil {
+		t.file.Close()
+	}
+	t.file, t.info, t.offset, t.partial = file, info, offset, ""
+	t.reader = bufio.NewReader(file)
+	return nil
+}
+
+func (t *logTail) Close() {
+	if t.file != nil {
+		t.file.Close()
+	}
+}
+
+// readLines returns complete lines appended since the last call.
+func (t *logTail) readLines() ([]string, error) {
+	if current, err := os.Stat(t.path); err == nil {
+		if !os.SameFile(current, t.info) || current.Size() < t.offset {
+			// Rotated or truncated: start over on the new file.
+			if err := t.open(false); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var lines []string
+	for {
+		chunk, err := t.reader.ReadString('\n')
+		t.offset += int64(len(chunk))
+		if err == io.EOF {
+			t.partial += chunk
+			return lines, nil
+		}
+		if err != nil {
+			return lines, err
+		}
+		lines = append(lines, strings.TrimRight(t.partial+chunk, "\r\n"))
+		t.partial = ""
+	}
+}
+
+// streamLogs tails the active log file and pushes new lines as SSE "log" events.
+func streamLogs(c *gin.Context) {
+	if !acquireLogStream() {
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many active log streams"})
+		return
+	}
+	defer releaseLogStream()
+
+	tail, err := openLogTail(config.LogFilePath())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to read log"})
+		return
+	}
+	defer tail.Close()
+
+	log.Printf("[logs] stream opened by user %d", c.GetInt("userID"))
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	poll := time.NewTicker(logStreamPollInterval)
+	defer poll.Stop()
+	heartbeat := time.NewTicker(logStreamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	ctx := c.Request.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-logStreamStop:
+			c.SSEvent("shutdown", "server shutting down")
+			c.Writer.Flush()
+			return
+		case <-heartbeat.C:
+			if _, err := io.WriteString(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case <-poll.C:
+			lines, err := tail.readLines()
+			for _, line := range lines {
+				c.SSEvent("log", line)
+			}
+			if len(lines) > 0 {
+				c.Writer.Flush()
+			}
+			if err != nil {
+				c.SSEvent("error", "log stream interrupted")
+				c.Writer.Flush()
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package monitor
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	logTailChunkSize       = 64 * 1024
+	defaultLogTailMaxBytes = 2 * 1024 * 1024
+)
+
+// logTailMaxBytes reads LOG_TAIL_MAX_BYTES (default 2 MiB), the most log text a
+// single /logs response returns.
+func logTailMaxBytes() int64 {
+	if v := strings.TrimSpace(os.Getenv("LOG_TAIL_MAX_BYTES")); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil && n > 0 {
+			return n
+		}
+	}
+	return defaultLogTailMaxBytes
+}
+
+// logWindow is a range of whole lines counted back from the end of a log file.
+type logWindow struct {
+	Lines []string
+	Start int64 // first byte returned
+	End   int64 // one past the last byte returned
+	Size  int64
+	// TotalLines is only known when the walk reached the start of the file.
+	TotalLines int
+	Truncated  bool
+}
+
+// readLogWindow returns up to count lines ending offset lines before the end of
+// the file. It walks backwards in fixed-size chunks looking only for newlines,
+// so memory stays bounded by maxBytes however large the file is. When the lines
+// exceed maxBytes, the oldest are dropped and Truncated is set.
+func readLogWindow(f *os.File, count, offset int, maxBytes int64) (*logWindow, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := info.Size()
+	tail := &logWindow{Size: size, Start: size, End: size}
+	if size == 0 || count <= 0 {
+		return tail, nil
+	}
+
+	// A final newline terminates the last line rather than starting an empty one.
+	last := make([]byte, 1)
+	if _, err := f.ReadAt(last, size-1); err != nil {
+		return nil, err
+	}
+	limit := size
+	if last[0] == '\n' {
+		limit = size - 1
+	}
+
+	end := limit
+	start := int64(0)
+	newlines := 0
+	reachedStart := true
+	buf := make([]byte, logTailChunkSize)
+
+scan:
+	for pos := limit; pos > 0; {
+		n := int64(len(buf))
+		if pos < n {
+			n = pos
+		}
+		pos -= n
+		if _, err := f.ReadAt(buf[:n], pos); err != nil && err != io.EOF {
+			return nil, err
+		}
+		chunk := buf[:n]
+		for i := len(chunk) - 1; i >= 0; i-- {
+			if chunk[i] != '\n' {
+				continue
+			}
+			newlines++
+			at := pos + int64(i)
+			if newlines == offset {
+				end = at
+			}
+			if newlines == offset+count {
+				start = at + 1
+				reachedStart = false
+				break scan
+			}
+		}
+	}
+
+	if reachedStart {
+		tail.TotalLines = newlines + 1
+	}
+	if newlines < offset {
+		// The offset skips past the first line: nothing to return.
+		return tail, nil
+	}
+
+	if end-start > maxBytes {
+		start = end - maxBytes
+		tail.Truncated = true
+	}
+
+	data := make([]byte, end-start)
+	if _, err := f.ReadAt(data, start); err != nil && err != io.EOF {
+		return nil, err
+	}
+	if tail.Truncated {
+		// Drop the partial line left at the cut.
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			data = data[i+1:]
+			start += int64(i + 1)
+		} else {
+			data = nil
+			start = end
+		}
+	}
+
+	tail.Start = start
+	tail.End = end
+	if len(data) > 0 {
+		tail.Lines = strings.Split(string(data), "\n")
+	}
+	return tail, nil
+}
+
+// countLogLines counts the lines of the file the way bufio.Scanner would: a
+// final newline does not start another line. It reads in fixed-size chunks, so
+// memory stays bounded while the whole file is read.
+func countLogLines(f *os.File, size int64) (int, error) {
+	buf := make([]byte, logTailChunkSize)
+	lines := 0
+	var last byte
+	for pos := int64(0); pos < size; {
+		n, err := f.ReadAt(buf, pos)
+		if n > 0 {
+			lines += bytes.Count(buf[:n], []byte{'\n'})
+			last = buf[n-1]
+			pos += int64(n)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	if size > 0 && last != '\n' {
+		lines++
+	}
+	return lines, nil
+}
@@ -0,0 +1,153 @@
+package monitor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// numberedLines returns "line-<from>" ... "line-<to>".
+func numberedLines(from, to int) []string {
+	lines := make([]string, 0, to-from+1)
+	for i := from; i <= to; i++ {
+		lines = append(lines, fmt.Sprintf("line-%d", i))
+	}
+	return lines
+}
+
+func writeLogFile(t *testing.T, content string) *os.File {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "fund-api.log")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func TestReadLogWindow(t *testing.T) {
+	three := strings.Join(numberedLines(1, 3), "\n") + "\n"
+	many := strings.Join(numberedLines(1, 150), "\n") + "\n"
+	// Spans several logTailChunkSize chunks.
+	large := strings.Join(numberedLines(1, 20000), "\n") + "\n"
+
+	for _, tc := range []struct {
+		name          string
+		content       string
+		count, offset int
+		maxBytes      int64
+		want          []string
+		wantTotal     int
+		wantTruncated bool
+	}{
+		{
+			name:    "empty file",
+			content: "",
+			count:   100,
+		},
+		{
+			name:      "default window covers a short file",
+			content:   three,
+			count:     100,
+			want:      numberedLines(1, 3),
+			wantTotal: 3,
+		},
+		{
+			name:    "default window ends at the last line",
+			content: many,
+			count:   100,
+			want:    numberedLines(51, 150),
+		},
+		{
+			name:    "no final newline",
+			content: strings.TrimSuffix(three, "\n"),
+			count:   2,
+			want:    numberedLines(2, 3),
+		},
+		{
+			name:    "offset pages backwards",
+			content: many,
+			count:   10,
+			offset:  100,
+			want:    numberedLines(41, 50),
+		},
+		{
+			name:      "window reaching past the first line",
+			content:   three,
+			count:     5,
+			offset:    2,
+			want:      numberedLines(1, 1),
+			wantTotal: 3,
+		},
+		{
+			name:      "window past EOF",
+			content:   three,
+			count:     5,
+			offset:    10,
+			wantTotal: 3,
+		},
+		{
+			name:    "window across chunk boundaries",
+			content: large,
+			count:   50,
+			offset:  10000,
+			want:    numberedLines(9951, 10000),
+		},
+		{
+			name:          "oldest lines dropped past maxBytes",
+			content:       many,
+			count:         10,
+			maxBytes:      20,
+			want:          numberedLines(149, 150),
+			wantTruncated: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			maxBytes := tc.maxBytes
+			if maxBytes == 0 {
+				maxBytes = defaultLogTailMaxBytes
+			}
+			window, err := readLogWindow(writeLogFile(t, tc.content), tc.count, tc.offset, maxBytes)
+			if err != nil {
+				t.Fatalf("readLogWindow: %v", err)
+			}
+
+			if !reflect.DeepEqual(window.Lines, tc.want) {
+				t.Errorf("lines = %q, want %q", window.Lines, tc.want)
+			}
+			if window.Size != int64(len(tc.content)) {
+				t.Errorf("size = %d, want %d", window.Size, len(tc.content))
+			}
+			if got := tc.content[window.Start:window.End]; got != strings.Join(tc.want, "\n") {
+				t.Errorf("range %d-%d holds %q, want the returned lines", window.Start, window.End, got)
+			}
+			if tc.wantTotal > 0 && window.TotalLines != tc.wantTotal {
+				t.Errorf("total lines = %d, want %d", window.TotalLines, tc.wantTotal)
+			}
+			if window.Truncated != tc.wantTruncated {
+				t.Errorf("truncated = %v, want %v", window.Truncated, tc.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestLogTailMaxBytes(t *testing.T) {
+	for value, want := range map[string]int64{
+		"":      defaultLogTailMaxBytes,
+		"bogus": defaultLogTailMaxBytes,
+		"0":     defaultLogTailMaxBytes,
+		"4096":  4096,
+	} {
+		t.Setenv("LOG_TAIL_MAX_BYTES", value)
+		if got := logTailMaxBytes(); got != want {
+			t.Errorf("LOG_TAIL_MAX_BYTES=%q: got %d, want %d", value, got, want)
+		}
+	}
+}
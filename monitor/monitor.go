@@ -2,10 +2,16 @@ package monitor
 
 import (
 	"bufio"
+	"fmt"
 	"fund-management-api/config"
+	"fund-management-api/middleware"
+	"fund-management-api/models"
+	"log"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -390,7 +396,7 @@ func RegisterMonitorPage(router *gin.Engine) {
       if (!liveLogs && currentViewMode === 'tail') return;
       
       const lines = parseInt(linesInput.value) || 100;
-      let url = '/logs?token=secret-token&limit=' + lines;
+      let url = '/logs?limit=' + lines;
       
       if (currentViewMode === 'head') {
         url += '&from=start';
@@ -398,8 +404,13 @@ func RegisterMonitorPage(router *gin.Engine) {
         url += '&search=' + encodeURIComponent(searchTerm);
       }
       
-      fetch(url)
-        .then(res => res.json())
+      fetch(url, { credentials: 'same-origin' })
+        .then(res => {
+          if (res.status === 401 || res.status === 403) {
+            throw new Error('sign in with an admin account to view logs');
+          }
+          return res.json();
+        })
         .then(data => {
           logsElement.textContent = data.logs;
           logsElement.classList.remove('loading');
@@ -484,11 +495,11 @@ func RegisterMonitorPage(router *gin.Engine) {
     function confirmClearLogs() {
       fetch('/logs/clear', {
         method: 'POST',
+        credentials: 'same-origin',
         headers: {
           'Content-Type': 'application/json',
         },
         body: JSON.stringify({
-          token: 'secret-token',
           confirm: true
         })
       })
@@ -538,14 +549,16 @@ func RegisterMonitorPage(router *gin.Engine) {
 	})
 }
 
+// RegisterLogsRoute exposes the server log to authenticated admins only. Access goes
+// through the regular JWT auth (header or auth cookie) and is rate limited per user.
 func RegisterLogsRoute(router *gin.Engine) {
-	router.GET("/logs", func(c *gin.Context) {
-		const token = "secret-token"
-		if c.Query("token") != token {
-			c.JSON(401, gin.H{"error": "Unauthorized"})
-			return
-		}
+	logs := router.Group("/logs",
+		middleware.AuthMiddleware(),
+		middleware.RequireRole(3),
+		middleware.RateLimit(60, time.Minute),
+	)
 
+	logs.GET("", func(c *gin.Context) {
 		// Get parameters
 		limitStr := c.DefaultQuery("limit", "100")
 		limit, err := strconv.Atoi(limitStr)
@@ -556,17 +569,89 @@ func RegisterLogsRoute(router *gin.Engine) {
 			limit = 1000 // Max limit for safety
 		}
 
+		// lines/offset page backwards from the end: lines=500&offset=500 returns
+		// the 500 lines before the last 500.
+		if raw := c.Query("lines"); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil || n < 1 {
+				c.JSON(400, gin.H{"error": "Invalid lines"})
+				return
+			}
+			if n > 10000 {
+				n = 10000
+			}
+			limit = n
+		}
+		offset := 0
+		if raw := c.Query("offset"); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil || n < 0 {
+				c.JSON(400, gin.H{"error": "Invalid offset"})
+				return
+			}
+			offset = n
+		}
+
 		fromStart := c.Query("from") == "start"
 		searchTerm := c.Query("search")
 
+		// Optional rotated file (fund-api.log.1, ...); validated against the log name pattern
+		logPath, err := config.ResolveLogFilePath(c.Query("file"))
+		if err != nil {
+			c.JSON(400, gin.H{"error": "Invalid log file"})
+			return
+		}
+
 		// Read log file
-		file, err := os.Open(config.LogFilePath())
+		file, err := os.Open(logPath)
 		if err != nil {
+			if os.IsNotExist(err) {
+				c.JSON(404, gin.H{"error": "Log file not found"})
+				return
+			}
 			c.JSON(500, gin.H{"error": "Unable to read log"})
 			return
 		}
 		defer file.Close()
 
+		if searchTerm == "" && !fromStart {
+			tail, err := readLogWindow(file, limit, offset, logTailMaxBytes())
+			if err != nil {
+				c.JSON(500, gin.H{"error": "Error reading log file"})
+				return
+			}
+			if tail.End > tail.Start {
+				c.Header("Content-Range", fmt.Sprintf("bytes %d-%d/%d", tail.Start, tail.End-1, tail.Size))
+			}
+
+			response := gin.H{
+				"logs":      strings.Join(tail.Lines, "\n"),
+				"count":     len(tail.Lines),
+				"offset":    offset,
+				"truncated": tail.Truncated,
+				"range": gin.H{
+					"start": tail.Start,
+					"end":   tail.End,
+					"size":  tail.Size,
+				},
+				"file": filepath.Base(logPath),
+			}
+			if tail.TotalLines > 0 {
+				response["total_lines"] = tail.TotalLines
+			} else if c.Query("lines") == "" && c.Query("offset") == "" {
+				// The default response has always carried total_lines; only
+				// windowed requests skip the full count.
+				total, err := countLogLines(file, tail.Size)
+				if err != nil {
+					c.JSON(500, gin.H{"error": "Error reading log file"})
+					return
+				}
+				response["total_lines"] = total
+			}
+			c.JSON(200, response)
+			return
+		}
+
 		var lines []string
 		var totalLines int
 		scanner := bufio.NewScanner(file)
@@ -596,20 +681,6 @@ func RegisterLogsRoute(router *gin.Engine) {
 					totalLines++
 				}
 			}
-		} else {
-			// Read all lines to get the tail
-			allLines := []string{}
-			for scanner.Scan() {
-				allLines = append(allLines, scanner.Text())
-			}
-			totalLines = len(allLines)
-
-			// Get last N lines
-			start := len(allLines) - limit
-			if start < 0 {
-				start = 0
-			}
-			lines = allLines[start:]
 		}
 
 		if err := scanner.Err(); err != nil {
@@ -624,16 +695,31 @@ func RegisterLogsRoute(router *gin.Engine) {
 			"logs":        logContent,
 			"count":       len(lines),
 			"total_lines": totalLines,
+			"file":        filepath.Base(logPath),
 		})
 	})
 
-	// Clear logs endpoint
-	router.POST("/logs/clear", func(c *gin.Context) {
-		const token = "secret-token"
+	// Live tail as Server-Sent Events (bounded by LOG_STREAM_MAX_CLIENTS)
+	logs.GET("/stream", streamLogs)
+
+	// Available log files (active + rotated) with sizes and modification times
+	logs.GET("/list", func(c *gin.Context) {
+		files, err := config.ListLogFiles()
+		if err != nil {
+			c.JSON(500, gin.H{"error": "Unable to list log files"})
+			return
+		}
+
+		c.JSON(200, gin.H{
+			"files": files,
+			"count": len(files),
+		})
+	})
 
+	// Clear logs endpoint
+	logs.POST("/clear", func(c *gin.Context) {
 		var request struct {
-			Token   string `json:"token"`
-			Confirm bool   `json:"confirm"`
+			Confirm bool `json:"confirm"`
 		}
 
 		if err := c.ShouldBindJSON(&request); err != nil {
@@ -641,11 +727,6 @@ func RegisterLogsRoute(router *gin.Engine) {
 			return
 		}
 
-		if request.Token != token {
-			c.JSON(401, gin.H{"error": "Unauthorized"})
-			return
-		}
-
 		if !request.Confirm {
 			c.JSON(400, gin.H{"error": "Confirmation required"})
 			return
@@ -658,6 +739,22 @@ func RegisterLogsRoute(router *gin.Engine) {
 			return
 		}
 
+		userID := c.GetInt("userID")
+		log.Printf("[logs] log file cleared by user %d", userID)
+		description := "Server log file cleared"
+		userAgent := c.GetHeader("User-Agent")
+		if err := config.DB.Create(&models.AuditLog{
+			UserID:      userID,
+			Action:      "delete",
+			EntityType:  "server_log",
+			Description: &description,
+			IPAddress:   c.ClientIP(),
+			UserAgent:   &userAgent,
+			CreatedAt:   time.Now(),
+		}).Error; err != nil {
+			log.Printf("[logs] failed to record audit log for log clear: %v", err)
+		}
+
 		c.JSON(200, gin.H{
 			"success": true,
 			"message": "Log file cleared successfully",
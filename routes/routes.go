@@ -7,6 +7,7 @@ import (
 	"fund-management-api/middleware"
 	"fund-management-api/monitor"
 	"fund-management-api/services"
+	"fund-management-api/storage"
 	"fund-management-api/utils"
 	"log"
 	"net/http"
@@ -77,6 +78,9 @@ func SetupRoutes(router *gin.Engine) {
 			// NEW: Refresh token endpoint (public)
 			public.POST("/refresh", controllers.RefreshTokenWithRefreshToken)
 
+			// Public verification of the QR code printed on generated reward forms
+			public.GET("/verify/:token", controllers.VerifySubmission)
+
 			// Health check
 			public.GET("/health", func(c *gin.Context) {
 				c.JSON(http.StatusOK, gin.H{
@@ -159,6 +163,7 @@ func SetupRoutes(router *gin.Engine) {
 			// Common endpoints (all authenticated users)
 			protected.GET("/categories", controllers.GetCategories)
 			protected.GET("/subcategories", controllers.GetSubcategories)
+			protected.GET("/subcategories/:id/required-documents", controllers.GetSubcategoryRequiredDocuments)
 			protected.GET("/application-status", controllers.GetApplicationStatuses)
 			protected.GET("/system-config/current-year", controllers.GetSystemConfigCurrentYear)
 			protected.GET("/system-config/submission-usage", controllers.GetSubmissionUsageLimit)
@@ -188,6 +193,9 @@ func SetupRoutes(router *gin.Engine) {
 			// ===== MOU (Memorandum of Understanding) =====
 			mou := protected.Group("/mou")
 			mou.Use(middleware.RequireRole(3))
+			// MOU and activity creates take several attachments in one request.
+			middleware.SetMultipartUploadLimit(http.MethodPost, mou.BasePath(), controllers.MouUploadMaxFileMB, controllers.MouUploadMaxFiles)
+			middleware.SetMultipartUploadLimit(http.MethodPost, mou.BasePath()+"/activities", controllers.MouUploadMaxFileMB, controllers.MouUploadMaxFiles)
 			{
 				mou.GET("", controllers.GetMous)                                                             // List all MOUs
 				mou.GET("/statuses", controllers.GetMouStatuses)                                             // List available statuses
@@ -340,16 +348,29 @@ func SetupRoutes(router *gin.Engine) {
 				submissions.DELETE("/:id/hard", controllers.HardDeleteSubmission)
 
 				// Submit submission
+				submissions.GET("/:id/validate", controllers.ValidateSubmissionForSubmit)
 				submissions.POST("/:id/submit", controllers.SubmitSubmission)
+				submissions.POST("/:id/transition", controllers.TransitionSubmissionStatus) // {"to_status_code","comment"}
 				submissions.POST("/:id/merge-documents", controllers.MergeSubmissionDocuments)
 
 				// Add specific details
 				submissions.POST("/:id/publication-details", controllers.AddPublicationDetails)
+				submissions.PATCH("/:id/publication-details", controllers.PatchPublicationDetails)
+				submissions.GET("/:id/autosave", controllers.GetSubmissionAutosave)
+				submissions.PUT("/:id/autosave", controllers.AutosaveSubmissionDraft)
+				submissions.DELETE("/:id/autosave", controllers.DiscardSubmissionAutosave)
 				submissions.POST("/:id/fund-details", controllers.AddFundDetails)
 
 				// Documents management
 				submissions.POST("/:id/documents", controllers.AttachDocument)
 				submissions.GET("/:id/documents", controllers.GetSubmissionDocuments)
+				submissions.GET("/:id/documents/grouped", controllers.GetSubmissionDocumentsGrouped)
+				submissions.GET("/:id/documents/archive", controllers.GetSubmissionDocumentsArchive)
+				submissions.PUT("/:id/documents/reorder", controllers.ReorderSubmissionDocuments)
+				submissions.GET("/:id/audit", controllers.GetSubmissionAuditTrail)  // ?format=csv
+				submissions.GET("/:id/siblings", controllers.GetSubmissionSiblings) // ?queue=pending; prev/next in the caller's review queue
+				submissions.GET("/:id/form-status", controllers.GetSubmissionFormStatus)
+				submissions.POST("/:id/form/regenerate", controllers.RegenerateSubmissionForm)
 				submissions.DELETE("/:id/documents/:doc_id", controllers.DetachDocument)
 
 				// Approval evidence is read-only for the submission owner.
@@ -368,9 +389,13 @@ func SetupRoutes(router *gin.Engine) {
 				submissions.DELETE("/:id/users/:user_id", controllers.RemoveSubmissionUser) // ลบ user จาก submission
 
 				// === NEW: Batch Operations for Frontend ===
-				submissions.POST("/:id/users/batch", controllers.AddMultipleUsers)     // เพิ่ม users หลายคนพร้อมกัน
+				submissions.POST("/:id/users/batch", controllers.AddMultipleUsers)     // เพิ่ม users หลายคนพร้อมกัน (max 100)
 				submissions.POST("/:id/users/set-coauthors", controllers.SetCoauthors) // ตั้งค่า co-authors ทั้งหมด (replace existing)
 
+				// Reward split among authors (publication rewards)
+				submissions.GET("/:id/reward-split", controllers.GetSubmissionRewardSplit)
+				submissions.PUT("/:id/reward-split", controllers.UpdateSubmissionRewardSplit)
+
 				// Enhanced submission details with co-authors
 				//submissions.GET("/:id/full", controllers.GetSubmissionWithCoauthors) // ดู submission พร้อม co-authors
 
@@ -383,6 +408,7 @@ func SetupRoutes(router *gin.Engine) {
 			files := protected.Group("/files")
 			{
 				files.POST("/upload", controllers.UploadFile)
+				files.GET("/by-hash/:hash", controllers.GetFileByHash)
 				files.GET("/managed/:id", controllers.GetFile)               // เปลี่ยนเป็น /managed/:id
 				files.GET("/managed/:id/download", controllers.DownloadFile) // เปลี่ยนเป็น /managed/:id/download
 				files.DELETE("/managed/:id", controllers.DeleteFile)         // เปลี่ยนเป็น /managed/:id
@@ -411,6 +437,9 @@ func SetupRoutes(router *gin.Engine) {
 				dashboard.GET("/stats", middleware.RequirePermission("dashboard.view.self", "dashboard.view.admin", "ui.page.admin.dashboard.view"), controllers.GetDashboardStats)
 				dashboard.GET("/budget-summary", controllers.GetBudgetSummary)
 				dashboard.GET("/applications-summary", controllers.GetApplicationsSummary)
+				dashboard.GET("/activity", middleware.RequireRole(3, 4), controllers.GetDashboardActivity)             // ?scope=&year=&installment=&limit=
+				dashboard.GET("/top-users", middleware.RequireRole(3), controllers.GetDashboardTopUsers)               // ?scope=&year=&installment=&limit=
+				dashboard.GET("/quota-summary/export", middleware.RequireRole(3), controllers.ExportAdminQuotaSummary) // ?scope=&year=&installment=&format=csv|xlsx
 			}
 
 			// Permission-based admin submission endpoints for mixed-role users
@@ -472,7 +501,7 @@ func SetupRoutes(router *gin.Engine) {
 					// Admin only endpoints
 					rates.GET("/admin", middleware.RequirePermission("publication.reward.rate.manage"), controllers.GetPublicationRewardRatesAdmin)
 					rates.POST("", middleware.RequirePermission("publication.reward.rate.manage"), controllers.CreatePublicationRewardRate)
-					rates.PUT("/bulk", middleware.RequirePermission("publication.reward.rate.manage"), controllers.UpdatePublicationRewardRates)
+					rates.PUT("/bulk", middleware.RequirePermission("publication.reward.rate.manage"), controllers.UpdatePublicationRewardRates) // max 500 rates
 					rates.PUT("/:id", middleware.RequirePermission("publication.reward.rate.manage"), controllers.UpdatePublicationRewardRate)
 					rates.DELETE("/:id", middleware.RequirePermission("publication.reward.rate.manage"), controllers.DeletePublicationRewardRate)
 					rates.PATCH("/:id/toggle", middleware.RequirePermission("publication.reward.rate.manage"), controllers.TogglePublicationRewardRateStatus)
@@ -497,6 +526,9 @@ func SetupRoutes(router *gin.Engine) {
 
 			// Users endpoint for form dropdown
 			protected.GET("/users", controllers.GetUsers)
+			protected.GET("/users/me/profile", controllers.GetMyFormProfile)
+			protected.PUT("/users/me/profile", controllers.UpdateMyFormProfile)
+			protected.GET("/users/me/statement.pdf", controllers.GetMyFundingStatement)
 
 			// Document types with category filter
 			protected.GET("/document-types", controllers.GetDocumentTypes)
@@ -585,14 +617,32 @@ func SetupRoutes(router *gin.Engine) {
 					accessControl.GET("/users/:id/effective", middleware.RequirePermission("access.view", "ui.page.admin.access_control.view"), controllers.AdminGetUserEffectivePermissions)
 				}
 
+				// Read-only maintenance mode (exempt from MaintenanceMiddleware)
+				admin.GET("/maintenance", controllers.GetMaintenanceMode)
+				admin.PUT("/maintenance", middleware.RequireRole(3), controllers.UpdateMaintenanceMode)
+
 				// Dashboard
 				admin.GET("/dashboard/stats", controllers.GetDashboardStats)
-				admin.GET("/submissions", controllers.GetAdminSubmissions) // Admin ดู submissions ทั้งหมด
+				admin.GET("/stats/turnaround", controllers.GetAdminTurnaroundStats)                           // ?scope=&year=&installment=
+				admin.GET("/stats/by-department", controllers.GetAdminDepartmentStats)                        // ?scope=&year=&installment=
+				admin.GET("/stats/by-reviewer", controllers.GetAdminReviewerStats)                            // ?scope=&year=&installment=
+				admin.GET("/financial-overview", controllers.GetAdminFinancialOverview)                       // ?scope=&year=&installment=
+				admin.GET("/trends", controllers.GetAdminTrends)                                              // ?granularity=monthly|yearly|quarterly|installment
+				admin.GET("/submissions", controllers.GetAdminSubmissions)                                    // Admin ดู submissions ทั้งหมด
+				admin.GET("/submissions/missing-forms", controllers.GetAdminMissingFormSubmissions)           // ?year_id=&page=&limit=
+				admin.POST("/submissions/missing-forms/regenerate", controllers.QueueMissingFormRegeneration) // queues form jobs, {"submission_ids": [...]} (max 500) or {"year_id": N}
+
+				// Background form-generation queue
+				admin.GET("/jobs", controllers.GetAdminJobQueue)
+				admin.POST("/jobs/:id/retry", controllers.RetryAdminJob)
 
 				// User Publications Import from Scholar
 				admin.POST("/user-publications/import/scholar", controllers.AdminImportScholarPublications)
 				admin.POST("/user-publications/import/scholar/all", controllers.AdminImportScholarForAll)
 				admin.GET("/user-publications/import/scholar/runs", controllers.AdminListScholarImportRuns)
+				admin.GET("/import-runs", controllers.AdminListImportRuns)   // ?source=scholar|scopus&date_from=&date_to=&page=&per_page=
+				admin.GET("/import-runs/:id", controllers.AdminGetImportRun) // ?source=scholar|scopus
+				admin.POST("/import-runs/:id/cancel", controllers.AdminCancelImportRun)
 				admin.POST("/user-publications/import/scopus", controllers.AdminImportScopusPublications)
 				admin.POST("/user-publications/import/scopus/all", controllers.AdminImportScopusForAll)
 				admin.POST("/user-publications/import/thaijo", controllers.AdminImportThaiJOPublications)
@@ -621,7 +671,9 @@ func SetupRoutes(router *gin.Engine) {
 				admin.GET("/users/search", controllers.AdminSearchUsers)
 				admin.GET("/users/scopus", controllers.AdminListUsersWithScopusID)
 				admin.GET("/users/thaijo", controllers.AdminListUsersWithThaiJO)
+				admin.PUT("/users/:id/faculty", controllers.AdminSetUserFaculty) // {"faculty_id": n|null}; grouped by /admin/stats/by-department
 				admin.POST("/users/:id/scholar-author", controllers.AdminSetUserScholarAuthorID)
+				admin.POST("/users/:id/scholar-import", controllers.AdminImportScholarForUser) // ?dry_run=true
 				admin.POST("/users/:id/scopus-author", controllers.AdminSetUserScopusAuthorID)
 				admin.POST("/users/:id/thaijo-author", controllers.AdminSetUserThaiJOAuthorID)
 				admin.POST("/users/:id/thaijo-sync", controllers.AdminSetUserThaiJOSyncEnabled)
@@ -686,6 +738,7 @@ func SetupRoutes(router *gin.Engine) {
 				{
 					installments.POST("/copy", controllers.AdminCopyFundInstallmentPeriods)
 					installments.GET("", controllers.AdminListFundInstallmentPeriods)
+					installments.GET("/:year_id/:installment/report.xlsx", controllers.GetAdminInstallmentReport)
 					installments.POST("", controllers.AdminCreateFundInstallmentPeriod)
 					installments.PUT("/:id", controllers.AdminUpdateFundInstallmentPeriod)
 					installments.PATCH("/:id", controllers.AdminUpdateFundInstallmentPeriod)
@@ -693,6 +746,11 @@ func SetupRoutes(router *gin.Engine) {
 					installments.PATCH("/:id/restore", controllers.AdminRestoreFundInstallmentPeriod)
 				}
 
+				installmentPeriods := admin.Group("/installment-periods")
+				{
+					installmentPeriods.POST("/copy", controllers.AdminCloneYearInstallmentPeriods) // POST /api/v1/admin/installment-periods/copy
+				}
+
 				sdgs := admin.Group("/sdgs")
 				{
 					sdgs.GET("", controllers.GetAdminSDGs)
@@ -700,14 +758,26 @@ func SetupRoutes(router *gin.Engine) {
 					sdgs.PUT("/:id", controllers.UpdateAdminSDG)
 				}
 
+				journals := admin.Group("/journals")
+				{
+					journals.GET("", controllers.GetAdminJournals)               // ?q=&quartile=&metric_year=&page=&limit=
+					journals.POST("", controllers.CreateAdminJournal)            // POST /api/v1/admin/journals
+					journals.POST("/import", controllers.ImportAdminJournalsCSV) // multipart "file" (CSV)
+					journals.PUT("/:id", controllers.UpdateAdminJournal)         // PUT /api/v1/admin/journals/:id
+					journals.DELETE("/:id", controllers.DeleteAdminJournal)      // DELETE /api/v1/admin/journals/:id
+				}
+
 				// ========== FUND CATEGORIES MANAGEMENT ==========
 				categories := admin.Group("/categories")
 				{
-					categories.GET("", controllers.GetAllCategories)                  // GET /api/v1/admin/categories
-					categories.POST("", controllers.CreateCategory)                   // POST /api/v1/admin/categories
-					categories.PUT("/:id", controllers.UpdateCategory)                // PUT /api/v1/admin/categories/:id
-					categories.DELETE("/:id", controllers.DeleteCategory)             // DELETE /api/v1/admin/categories/:id
-					categories.PATCH("/:id/toggle", controllers.ToggleCategoryStatus) // PATCH /api/v1/admin/categories/:id/toggle
+					categories.GET("", controllers.GetAllCategories)                      // GET /api/v1/admin/categories
+					categories.GET("/counts", controllers.GetAdminCategoryCounts)         // GET /api/v1/admin/categories/counts?year_id=
+					categories.POST("", controllers.CreateCategory)                       // POST /api/v1/admin/categories
+					categories.PUT("/:id", controllers.UpdateCategory)                    // PUT /api/v1/admin/categories/:id
+					categories.DELETE("/:id", controllers.DeleteCategory)                 // DELETE /api/v1/admin/categories/:id
+					categories.PATCH("/:id/toggle", controllers.ToggleCategoryStatus)     // PATCH /api/v1/admin/categories/:id/toggle
+					categories.POST("/bulk-status", controllers.BulkUpdateCategoryStatus) // POST /api/v1/admin/categories/bulk-status (max 500)
+					categories.GET("/:id/trend", controllers.GetAdminCategoryTrend)       // ?granularity=monthly|yearly|quarterly|installment
 				}
 
 				// ========== PROJECT MANAGEMENT ==========
@@ -745,21 +815,23 @@ func SetupRoutes(router *gin.Engine) {
 				// ========== FUND SUBCATEGORIES MANAGEMENT ==========
 				subcategories := admin.Group("/subcategories")
 				{
-					subcategories.GET("", controllers.GetAllSubcategories)                  // GET /api/v1/admin/subcategories
-					subcategories.POST("", controllers.CreateSubcategory)                   // POST /api/v1/admin/subcategories
-					subcategories.PUT("/:id", controllers.UpdateSubcategory)                // PUT /api/v1/admin/subcategories/:id
-					subcategories.DELETE("/:id", controllers.DeleteSubcategory)             // DELETE /api/v1/admin/subcategories/:id
-					subcategories.PATCH("/:id/toggle", controllers.ToggleSubcategoryStatus) // PATCH /api/v1/admin/subcategories/:id/toggle
+					subcategories.GET("", controllers.GetAllSubcategories)                                       // GET /api/v1/admin/subcategories
+					subcategories.POST("", controllers.CreateSubcategory)                                        // POST /api/v1/admin/subcategories
+					subcategories.PUT("/:id", controllers.UpdateSubcategory)                                     // PUT /api/v1/admin/subcategories/:id
+					subcategories.DELETE("/:id", controllers.DeleteSubcategory)                                  // DELETE /api/v1/admin/subcategories/:id
+					subcategories.PATCH("/:id/toggle", controllers.ToggleSubcategoryStatus)                      // PATCH /api/v1/admin/subcategories/:id/toggle
+					subcategories.PUT("/:id/required-documents", controllers.UpdateSubcategoryRequiredDocuments) // PUT /api/v1/admin/subcategories/:id/required-documents
 
 					// Target roles management (existing functionality)
 					subcategories.PUT("/:id/roles", controllers.UpdateSubcategoryTargetRoles) // PUT /api/v1/admin/subcategories/:id/roles
-					subcategories.POST("/bulk-roles", controllers.BulkUpdateSubcategoryRoles) // POST /api/v1/admin/subcategories/bulk-roles
+					subcategories.POST("/bulk-roles", controllers.BulkUpdateSubcategoryRoles) // POST /api/v1/admin/subcategories/bulk-roles (max 500)
 				}
 
 				// ========== SUBCATEGORY BUDGETS MANAGEMENT ==========
 				budgets := admin.Group("/budgets")
 				{
 					budgets.GET("", controllers.GetAllSubcategoryBudgets)                   // GET /api/v1/admin/budgets
+					budgets.GET("/overruns", controllers.GetAdminBudgetOverruns)            // GET /api/v1/admin/budgets/overruns?year_id=
 					budgets.GET("/:id", controllers.GetSubcategoryBudget)                   // GET /api/v1/admin/budgets/:id
 					budgets.POST("", controllers.CreateSubcategoryBudget)                   // POST /api/v1/admin/budgets
 					budgets.PUT("/:id", controllers.UpdateSubcategoryBudget)                // PUT /api/v1/admin/budgets/:id
@@ -811,10 +883,20 @@ func SetupRoutes(router *gin.Engine) {
 				//     users.PUT("/:id/role", controllers.UpdateUserRole)
 				// }
 
+				// Document verification queue
+				admin.GET("/documents/verification-queue", controllers.GetAdminDocumentVerificationQueue) // ?submission_type=&document_type=&date_from=&date_to=&page=&limit=
+				admin.GET("/documents/stats", controllers.GetAdminDocumentStats)                          // ?submission_type=
+				admin.PUT("/documents/:id/verify", controllers.VerifySubmissionDocument)
+
 				// User folders management
-				admin.GET("/files/users", controllers.ListUserFolders)   // ดู user folders ทั้งหมด
-				admin.GET("/files/users/:id", controllers.ListUserFiles) // ดูไฟล์ของ user
-				admin.GET("/files/stats", controllers.GetFileStats)      // สถิติการใช้งานไฟล์
+				admin.GET("/files", controllers.AdminListFiles)                          // ?folder_type=&sort=size|uploaded_at&order=&page=&limit=
+				admin.GET("/files/users", controllers.ListUserFolders)                   // ดู user folders ทั้งหมด
+				admin.GET("/files/users/:id", controllers.ListUserFiles)                 // ดูไฟล์ของ user
+				admin.GET("/files/stats", controllers.GetFileStats)                      // สถิติการใช้งานไฟล์
+				admin.GET("/files/:id/submissions", controllers.AdminGetFileSubmissions) // submissions ที่อ้างอิงไฟล์นี้
+
+				// Storage usage report
+				admin.GET("/storage/by-user", controllers.AdminStorageByUser) // พื้นที่จัดเก็บต่อผู้ใช้
 
 				// ===== ANNOUNCEMENT ADMIN ROUTES =====
 				admin.GET("/announcements/stats", controllers.GetAnnouncementStats) // สถิติประกาศ
@@ -840,6 +922,10 @@ func SetupRoutes(router *gin.Engine) {
 
 				submissionManagement := admin.Group("/submissions")
 				{
+					submissionManagement.GET("/by-installment", controllers.GetAdminSubmissionsByInstallment)              // GET /api/v1/admin/submissions/by-installment?year_id=&installment=
+					submissionManagement.GET("/orphans", controllers.GetAdminOrphanSubmissions)                            // GET /api/v1/admin/submissions/orphans?submission_type=&year_id=
+					submissionManagement.POST("/bulk-announce", controllers.BulkAnnounceSubmissions)                       // POST /api/v1/admin/submissions/bulk-announce (max 500)
+					submissionManagement.POST("/recompute-installments", controllers.AdminRecomputeSubmissionInstallments) // POST /api/v1/admin/submissions/recompute-installments?year_id=&dry_run=false
 					submissionManagement.POST("/:id/documents/resequence", controllers.AdminResequenceSubmissionDocuments)
 					// Detail view
 					submissionManagement.GET("/:id/details", controllers.GetSubmissionDetails)
@@ -877,6 +963,9 @@ func SetupRoutes(router *gin.Engine) {
 
 			// Fund API - Structured data endpoint
 			protected.GET("/funds/structure", controllers.GetFundStructure)
+			protected.GET("/funds/can-apply", controllers.GetFundCanApply)      // ?subcategory_id=&year_id=
+			protected.GET("/doi/validate", controllers.ValidateDOI)             // ?doi=&resolve=true
+			protected.GET("/journals/quartile", controllers.GetJournalQuartile) // ?issn=&name=&year=
 
 			// Teacher specific fund structure
 			teacher.GET("/funds/structure", controllers.GetFundStructure)
@@ -898,29 +987,6 @@ func SetupRoutes(router *gin.Engine) {
 	})
 }
 
-func RegisterLogRoute(r *gin.Engine) {
-	r.GET("/logs", func(c *gin.Context) {
-		// Set your access token here
-		const accessToken = "secret-token"
-
-		// Validate query token
-		if c.Query("token") != accessToken {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
-			return
-		}
-
-		// Path to your log file
-		logData, err := os.ReadFile(config.LogFilePath())
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to read log"})
-			return
-		}
-
-		// Return log content
-		c.Data(http.StatusOK, "text/plain; charset=utf-8", logData)
-	})
-}
-
 func RegisterUploadRoutes(rg *gin.RouterGroup) {
 	rg.POST("/upload", func(c *gin.Context) {
 		file, err := c.FormFile("file")
@@ -1189,6 +1255,11 @@ func RegisterFileViewRoutes(rg *gin.RouterGroup) {
 			return
 		}
 
+		if store := storage.Default(); !storage.IsLocal(store) {
+			serveStoredObject(c, store, normalized)
+			return
+		}
+
 		uploadRoot := strings.TrimSpace(os.Getenv("UPLOAD_PATH"))
 		if uploadRoot == "" {
 			uploadRoot = "./uploads"
@@ -0,0 +1,66 @@
+package routes
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"fund-management-api/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// serveStoredObject streams key from a backend that does not keep files on
+// this host. Local backends are served with c.File / router.Static instead.
+func serveStoredObject(c *gin.Context, store storage.Storage, key string) {
+	info, err := store.Stat(c.Request.Context(), key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotExist) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to access file"})
+		return
+	}
+
+	r, err := store.Open(c.Request.Context(), key)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to access file"})
+		return
+	}
+	defer r.Close()
+
+	contentType := info.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(path.Ext(key))
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.DataFromReader(http.StatusOK, info.Size, contentType, r, nil)
+}
+
+// MountPublicUploadFolder serves folder (under UPLOAD_PATH) read-only and
+// without login at urlPrefix. Local storage uses router.Static; other backends
+// stream the object stored under folder/<path>.
+func MountPublicUploadFolder(router *gin.Engine, urlPrefix, uploadPath, folder string) {
+	store := storage.Default()
+	if storage.IsLocal(store) {
+		router.Static(urlPrefix, filepath.Join(uploadPath, folder))
+		return
+	}
+
+	handler := func(c *gin.Context) {
+		rel, err := normalizeUploadRelPath(c.Param("path"))
+		if err != nil || strings.HasSuffix(rel, "/") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+			return
+		}
+		serveStoredObject(c, store, path.Join(folder, rel))
+	}
+	router.GET(urlPrefix+"/*path", handler)
+	router.HEAD(urlPrefix+"/*path", handler)
+}
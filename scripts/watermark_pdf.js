@@ -0,0 +1,76 @@
+#!/usr/bin/env node
+
+const fs = require('fs');
+const path = require('path');
+
+async function loadPdfLib() {
+  try {
+    return require('pdf-lib');
+  } catch (error) {
+    console.error('Unable to load pdf-lib. Ensure NODE_PATH includes a directory containing pdf-lib.');
+    throw error;
+  }
+}
+
+// Helvetica only covers Latin text; anything else (e.g. Thai) is drawn with the
+// TrueType font passed by the API, which pdf-lib embeds through fontkit.
+async function embedWatermarkFont(pdf, StandardFonts, text, fontPath) {
+  if (/^[\x20-\x7e]*$/.test(text) || !fontPath) {
+    return pdf.embedFont(StandardFonts.HelveticaBold);
+  }
+
+  let fontkit;
+  try {
+    fontkit = require('@pdf-lib/fontkit');
+  } catch (error) {
+    console.error('Unable to load @pdf-lib/fontkit, which is required for non-Latin watermark text.');
+    throw error;
+  }
+  pdf.registerFontkit(fontkit);
+  const fontBytes = await fs.promises.readFile(path.resolve(fontPath));
+  return pdf.embedFont(fontBytes, { subset: true });
+}
+
+async function watermarkPDF(outputPath, inputPath, text, fontPath) {
+  const { PDFDocument, StandardFonts, degrees, grayscale } = await loadPdfLib();
+  const data = await fs.promises.readFile(path.resolve(inputPath));
+  const pdf = await PDFDocument.load(data);
+  const font = await embedWatermarkFont(pdf, StandardFonts, text, fontPath);
+
+  for (const page of pdf.getPages()) {
+    const { width, height } = page.getSize();
+    const size = Math.min(width, height) / Math.max(text.length, 4) * 1.2;
+    const textWidth = font.widthOfTextAtSize(text, size);
+    const angle = Math.PI / 4;
+    page.drawText(text, {
+      x: width / 2 - (textWidth / 2) * Math.cos(angle) + (size / 2) * Math.sin(angle),
+      y: height / 2 - (textWidth / 2) * Math.sin(angle) - (size / 2) * Math.cos(angle),
+      size,
+      font,
+      color: grayscale(0.5),
+      opacity: 0.2,
+      rotate: degrees(45),
+    });
+  }
+
+  const bytes = await pdf.save();
+  await fs.promises.writeFile(path.resolve(outputPath), bytes);
+}
+
+async function main() {
+  const args = process.argv.slice(2);
+  if (args.length < 3) {
+    console.error('Usage: watermark_pdf.js <output> <input> <text> [font.ttf]');
+    process.exit(1);
+  }
+
+  const [output, input, text, fontPath] = args;
+  try {
+    await watermarkPDF(output, input, text, fontPath);
+  } catch (error) {
+    console.error(error && error.stack ? error.stack : error.message || String(error));
+    process.exit(1);
+  }
+}
+
+main();
\ No newline at end of file
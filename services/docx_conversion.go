@@ -0,0 +1,378 @@
+package services
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxConcurrentConversions = 2
+	defaultConversionTimeout        = 2 * time.Minute
+)
+
+// ErrConversionTimeout is returned when a conversion did not finish, or could
+// not start, before its deadline.
+var ErrConversionTimeout = errors.New("document conversion timed out")
+
+// ConversionPool bounds how many LibreOffice conversions run at once. Each
+// conversion starts a full office process, so requests beyond the pool's
+// concurrency queue instead of spawning competing soffice processes.
+type ConversionPool struct {
+	slots   chan struct{}
+	timeout time.Duration
+}
+
+// NewConversionPool returns a pool running at most concurrency conversions,
+// each bounded by timeout (queueing included). Non-positive values use the
+// defaults.
+func NewConversionPool(concurrency int, timeout time.Duration) *ConversionPool {
+	if concurrency <= 0 {
+		concurrency = defaultMaxConcurrentConversions
+	}
+	if timeout <= 0 {
+		timeout = defaultConversionTimeout
+	}
+	return &ConversionPool{slots: make(chan struct{}, concurrency), timeout: timeout}
+}
+
+// Do waits for a free slot and runs fn with a context that expires at the
+// pool's timeout. It returns an error wrapping ErrConversionTimeout when the
+// deadline passes while queued or while fn is running.
+func (p *ConversionPool) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	select {
+	case p.slots <- struct{}{}:
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("%w: no conversion slot became free within %s", ErrConversionTimeout, p.timeout)
+		}
+		return ctx.Err()
+	}
+	defer func() { <-p.slots }()
+
+	err := fn(ctx)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w: conversion did not finish within %s", ErrConversionTimeout, p.timeout)
+	}
+	return err
+}
+
+var (
+	defaultConversionPool     *ConversionPool
+	defaultConversionPoolOnce sync.Once
+)
+
+// DefaultConversionPool is the process-wide pool, sized by
+// MAX_CONCURRENT_CONVERSIONS (default 2) with DOCX_CONVERSION_TIMEOUT_SECONDS
+// (default 120) per conversion.
+func DefaultConversionPool() *ConversionPool {
+	defaultConversionPoolOnce.Do(func() {
+		concurrency := defaultMaxConcurrentConversions
+		if v, err := strconv.Atoi(strings.TrimSpace(os.Getenv("MAX_CONCURRENT_CONVERSIONS"))); err == nil && v > 0 {
+			concurrency = v
+		}
+		timeout := defaultConversionTimeout
+		if v, err := strconv.Atoi(strings.TrimSpace(os.Getenv("DOCX_CONVERSION_TIMEOUT_SECONDS"))); err == nil && v > 0 {
+			timeout = time.Duration(v) * time.Second
+		}
+		defaultConversionPool = NewConversionPool(concurrency, timeout)
+	})
+	return defaultConversionPool
+}
+
+// ConvertDocxToPDF converts the DOCX at docxPath with LibreOffice through the
+// default pool and returns the PDF bytes.
+func ConvertDocxToPDF(ctx context.Context, docxPath string) ([]byte, error) {
+	trimmed := strings.TrimSpace(docxPath)
+	if trimmed == "" {
+		return nil, fmt.Errorf("docx path is required")
+	}
+
+	info, err := os.Stat(trimmed)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("docx file not found")
+		}
+		return nil, fmt.Errorf("failed to access docx: %w", err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("docx path points to a directory")
+	}
+
+	// A fresh profile per conversion keeps concurrent soffice processes from
+	// sharing (and corrupting) one user installation.
+	tmpDir, err := os.MkdirTemp("", "docx-pdf-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	fontEnv, err := configureLibreOfficeFonts(tmpDir)
+	if err != nil {
+		return nil, err
+	}
+
+	converter, err := lookupLibreOfficeBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	profileDir := filepath.Join(tmpDir, "lo-profile")
+	if err := os.MkdirAll(profileDir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to prepare libreoffice profile: %w", err)
+	}
+
+	profileURL, err := fileURLFromPath(profileDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare libreoffice profile: %w", err)
+	}
+
+	profileArg := fmt.Sprintf("-env:UserInstallation=%s", profileURL)
+	filterArg := "pdf:writer_pdf_Export:EmbedStandardFonts=true;EmbedFonts=true"
+	args := []string{profileArg, "--headless", "--convert-to", filterArg, "--outdir", tmpDir, trimmed}
+	env := append([]string{}, os.Environ()...)
+	env = append(env, fontEnv...)
+
+	err = DefaultConversionPool().Do(ctx, func(ctx context.Context) error {
+		cmd := exec.CommandContext(ctx, converter, args...)
+		cmd.Env = env
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to convert docx to pdf: %v", strings.TrimSpace(string(output)))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pdfName := strings.TrimSuffix(filepath.Base(trimmed), filepath.Ext(trimmed)) + ".pdf"
+	data, err := os.ReadFile(filepath.Join(tmpDir, pdfName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read generated pdf: %w", err)
+	}
+	return data, nil
+}
+
+func lookupLibreOfficeBinary() (string, error) {
+	if explicit := strings.TrimSpace(os.Getenv("LIBREOFFICE_PATH")); explicit != "" {
+		if runtime.GOOS == "windows" {
+			explicit = strings.Trim(explicit, "\"")
+		}
+
+		candidate := explicit
+		if !filepath.IsAbs(candidate) {
+			absPath, err := filepath.Abs(candidate)
+			if err != nil {
+				return "", fmt.Errorf("invalid LIBREOFFICE_PATH: %w", err)
+			}
+			candidate = absPath
+		}
+
+		info, err := os.Stat(candidate)
+		if err != nil {
+			return "", fmt.Errorf("invalid LIBREOFFICE_PATH: %w", err)
+		}
+		if info.IsDir() {
+			return "", fmt.Errorf("LIBREOFFICE_PATH must point to the soffice executable, not a directory")
+		}
+
+		return candidate, nil
+	}
+
+	if path, err := exec.LookPath("soffice"); err == nil {
+		return path, nil
+	}
+	if path, err := exec.LookPath("libreoffice"); err == nil {
+		return path, nil
+	}
+	return "", fmt.Errorf("libreoffice (soffice) binary not found in PATH; set LIBREOFFICE_PATH to override")
+}
+
+func fileURLFromPath(path string) (string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	absPath = filepath.ToSlash(absPath)
+
+	if runtime.GOOS == "windows" {
+		if strings.HasPrefix(absPath, "//") {
+			trimmed := strings.TrimPrefix(absPath, "//")
+			parts := strings.SplitN(trimmed, "/", 2)
+			host := parts[0]
+			var uncPath string
+			if len(parts) == 2 {
+				uncPath = "/" + parts[1]
+			}
+
+			u := &url.URL{
+				Scheme: "file",
+				Host:   host,
+				Path:   uncPath,
+			}
+			return u.String(), nil
+		}
+
+		if !strings.HasPrefix(absPath, "/") {
+			absPath = "/" + absPath
+		}
+	} else if !strings.HasPrefix(absPath, "/") {
+		absPath = "/" + absPath
+	}
+
+	u := &url.URL{Scheme: "file", Path: absPath}
+	return u.String(), nil
+}
+
+func configureLibreOfficeFonts(tmpDir string) ([]string, error) {
+	fontDirs := collectFontDirectories()
+	if len(fontDirs) == 0 {
+		return nil, nil
+	}
+
+	fontConfigDir := filepath.Join(tmpDir, "fontconfig")
+	if err := os.MkdirAll(fontConfigDir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to prepare fontconfig directory: %w", err)
+	}
+
+	configPath := filepath.Join(fontConfigDir, "fonts.conf")
+	var builder strings.Builder
+	builder.WriteString("<?xml version=\"1.0\"?>\n")
+	builder.WriteString("<fontconfig>\n")
+	for _, dir := range fontDirs {
+		builder.WriteString("  <dir>")
+		builder.WriteString(fontConfigEscape(dir))
+		builder.WriteString("</dir>\n")
+	}
+
+	aliasMap := map[string]string{
+		"Cordia New":      "TH Sarabun New",
+		"Angsana New":     "TH Sarabun New",
+		"AngsanaUPC":      "TH Sarabun New",
+		"Sarabun":         "TH Sarabun New",
+		"Times New Roman": "DejaVu Serif",
+		"Calibri":         "DejaVu Sans",
+		"Calibri Light":   "DejaVu Sans",
+		"Segoe UI Symbol": "DejaVu Sans",
+	}
+
+	aliasKeys := make([]string, 0, len(aliasMap))
+	for from := range aliasMap {
+		aliasKeys = append(aliasKeys, from)
+	}
+	sort.Strings(aliasKeys)
+
+	for _, from := range aliasKeys {
+		to := aliasMap[from]
+		builder.WriteString("  <alias binding=\"strong\">\n")
+		builder.WriteString("    <family>")
+		builder.WriteString(fontConfigEscape(from))
+		builder.WriteString("</family>\n")
+		builder.WriteString("    <accept>\n")
+		builder.WriteString("      <family>")
+		builder.WriteString(fontConfigEscape(to))
+		builder.WriteString("</family>\n")
+		builder.WriteString("    </accept>\n")
+		builder.WriteString("  </alias>\n")
+	}
+
+	builder.WriteString("</fontconfig>\n")
+
+	if err := os.WriteFile(configPath, []byte(builder.String()), 0600); err != nil {
+		return nil, fmt.Errorf("failed to write font configuration: %w", err)
+	}
+
+	xdgDataHome := filepath.Join(tmpDir, "xdg-data")
+	if err := os.MkdirAll(xdgDataHome, 0700); err != nil {
+		return nil, fmt.Errorf("failed to prepare font cache directory: %w", err)
+	}
+
+	xdgCacheHome := filepath.Join(tmpDir, "xdg-cache")
+	if err := os.MkdirAll(xdgCacheHome, 0700); err != nil {
+		return nil, fmt.Errorf("failed to prepare font cache directory: %w", err)
+	}
+
+	env := []string{
+		fmt.Sprintf("FONTCONFIG_FILE=%s", configPath),
+		fmt.Sprintf("FONTCONFIG_PATH=%s", fontConfigDir),
+		fmt.Sprintf("XDG_DATA_HOME=%s", xdgDataHome),
+		fmt.Sprintf("XDG_CACHE_HOME=%s", xdgCacheHome),
+	}
+
+	return env, nil
+}
+
+func collectFontDirectories() []string {
+	candidates := []string{
+		filepath.Join("templates", "fonts"),
+		filepath.Join("frontend_project_fund", "public", "font"),
+	}
+
+	seen := make(map[string]struct{})
+	var result []string
+
+	for _, candidate := range candidates {
+		absRoot, err := filepath.Abs(candidate)
+		if err != nil {
+			continue
+		}
+
+		info, err := os.Stat(absRoot)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+
+		_ = filepath.WalkDir(absRoot, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if d.IsDir() {
+				return nil
+			}
+
+			name := d.Name()
+			if strings.HasPrefix(name, "._") {
+				return nil
+			}
+
+			ext := strings.ToLower(filepath.Ext(name))
+			if ext != ".ttf" && ext != ".otf" {
+				return nil
+			}
+
+			dir := filepath.Dir(path)
+			if _, exists := seen[dir]; exists {
+				return nil
+			}
+
+			seen[dir] = struct{}{}
+			result = append(result, dir)
+			return nil
+		})
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+func fontConfigEscape(value string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestConversionPool_CapsConcurrency(t *testing.T) {
+	pool := NewConversionPool(2, 5*time.Second)
+
+	var running, peak, done int32
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = pool.Do(context.Background(), func(context.Context) error {
+				n := atomic.AddInt32(&running, 1)
+				for {
+					p := atomic.LoadInt32(&peak)
+					if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+				atomic.AddInt32(&running, -1)
+				atomic.AddInt32(&done, 1)
+				return nil
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("conversion %d failed: %v", i, err)
+		}
+	}
+	if done != 10 {
+		t.Fatalf("expected 10 conversions to run, got %d", done)
+	}
+	if peak != 2 {
+		t.Fatalf("expected at most 2 concurrent conversions (and the pool to be used fully), got %d", peak)
+	}
+}
+
+func TestConversionPool_TimesOutWhileQueued(t *testing.T) {
+	pool := NewConversionPool(1, 50*time.Millisecond)
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	go func() {
+		_ = pool.Do(context.Background(), func(ctx context.Context) error {
+			close(started)
+			select {
+			case <-release:
+			case <-ctx.Done():
+			}
+			return nil
+		})
+	}()
+	<-started
+	defer close(release)
+
+	ran := false
+	err := pool.Do(context.Background(), func(context.Context) error {
+		ran = true
+		return nil
+	})
+	if !errors.Is(err, ErrConversionTimeout) {
+		t.Fatalf("expected ErrConversionTimeout, got %v", err)
+	}
+	if ran {
+		t.Fatalf("queued conversion must not run after its deadline")
+	}
+}
+
+func TestConversionPool_TimesOutWhileRunning(t *testing.T) {
+	pool := NewConversionPool(1, 30*time.Millisecond)
+
+	err := pool.Do(context.Background(), func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if !errors.Is(err, ErrConversionTimeout) {
+		t.Fatalf("expected ErrConversionTimeout, got %v", err)
+	}
+}
+
+func BenchmarkConversionPool(b *testing.B) {
+	pool := NewConversionPool(2, time.Minute)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = pool.Do(context.Background(), func(context.Context) error { return nil })
+		}
+	})
+}
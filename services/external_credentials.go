@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+)
+
+// ErrScopusAPIKeyRejected means Scopus answered the probe with 401/403.
+var ErrScopusAPIKeyRejected = errors.New("scopus api key rejected")
+
+// ValidateScholarEnvironment checks that the Python interpreter (VENV_PY) and the
+// scholar scripts (SCHOLAR_SCRIPT, SCHOLAR_AUTHOR_SCRIPT) used by the import exist.
+func ValidateScholarEnvironment() error {
+	py := os.Getenv("VENV_PY")
+	if py == "" {
+		py = "python3"
+	}
+	if _, err := exec.LookPath(py); err != nil {
+		return fmt.Errorf("python interpreter %q not found (set VENV_PY): %w", py, err)
+	}
+
+	scripts := map[string]string{
+		"SCHOLAR_SCRIPT":        "scripts/scholarly_fetch.py",
+		"SCHOLAR_AUTHOR_SCRIPT": "scripts/scholar_author_indices.py",
+	}
+	for env, fallback := range scripts {
+		script := os.Getenv(env)
+		if script == "" {
+			script = fallback
+		}
+		if _, err := os.Stat(script); err != nil {
+			return fmt.Errorf("scholar script %q not found (set %s): %w", script, env, err)
+		}
+	}
+	return nil
+}
+
+// ValidateCredentials checks that a Scopus API key is configured and accepted
+// by Elsevier with a single one-result search request.
+func (s *ScopusIngestService) ValidateCredentials(ctx context.Context) error {
+	apiKey, err := s.getAPIKey(ctx)
+	if err != nil {
+		return fmt.Errorf("%w (set %s in scopus_config or via the admin scopus config page)", err, scopusAPIKeyField)
+	}
+
+	reqURL, err := url.Parse(scopusBaseURL)
+	if err != nil {
+		return err
+	}
+	query := reqURL.Query()
+	query.Set("query", "PUBYEAR > 3000")
+	query.Set("count", "1")
+	reqURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-ELS-APIKey", apiKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("scopus probe request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("%w: status %d", ErrScopusAPIKeyRejected, resp.StatusCode)
+	case resp.StatusCode != http.StatusOK:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("scopus probe returned status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+// ValidateCredentials checks the Scopus configuration before a batch run.
+func (s *ScopusIngestJobService) ValidateCredentials(ctx context.Context) error {
+	return s.ingest.ValidateCredentials(ctx)
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrImportRunCancelled is returned by batch imports stopped through CancelImportRun.
+var ErrImportRunCancelled = errors.New("import run cancelled")
+
+const (
+	ImportRunSourceScholar = "scholar"
+	ImportRunSourceScopus  = "scopus"
+)
+
+var (
+	importRunCancelMu sync.Mutex
+	importRunCancels  = map[string]context.CancelFunc{}
+)
+
+func importRunKey(source string, runID uint64) string {
+	return fmt.Sprintf("%s:%d", source, runID)
+}
+
+// registerImportRun returns a context that is cancelled when CancelImportRun is
+// called for the run. Jobs check it between users so the current user finishes
+// cleanly. The returned func must be called once the run ends.
+func registerImportRun(source string, runID uint64) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	key := importRunKey(source, runID)
+
+	importRunCancelMu.Lock()
+	importRunCancels[key] = cancel
+	importRunCancelMu.Unlock()
+
+	return ctx, func() {
+		importRunCancelMu.Lock()
+		delete(importRunCancels, key)
+		importRunCancelMu.Unlock()
+		cancel()
+	}
+}
+
+// CancelImportRun signals a run started by this process to stop at the next
+// user boundary. It reports false when the run is not active here.
+func CancelImportRun(source string, runID uint64) bool {
+	importRunCancelMu.Lock()
+	cancel, ok := importRunCancels[importRunKey(source, runID)]
+	importRunCancelMu.Unlock()
+	if ok {
+		cancel()
+	}
+	return ok
+}
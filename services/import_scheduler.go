@@ -0,0 +1,259 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"fund-management-api/config"
+	"fund-management-api/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultImportScheduleInterval = 24 * time.Hour
+	// importScheduleBusyRetry is how long a scheduled import waits after finding
+	// another run (CLI, admin API, another instance) holding the advisory lock.
+	importScheduleBusyRetry = 15 * time.Minute
+	importSchedulerTrigger  = "scheduler"
+)
+
+// ParseInterval accepts Go durations plus a day suffix ("7d").
+func ParseInterval(raw string) (time.Duration, error) {
+	raw = strings.TrimSpace(raw)
+	if days, ok := strings.CutSuffix(raw, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(raw)
+}
+
+// scheduledImport is one import job run by ImportScheduler.
+type scheduledImport struct {
+	name     string
+	interval time.Duration
+	// lastStartedAt returns when the job last started from any trigger, so a
+	// restart or another instance does not cause an early run.
+	lastStartedAt func(ctx context.Context) (time.Time, error)
+	// run performs one import and describes its summary for the log.
+	run func(ctx context.Context) (string, error)
+}
+
+// ImportScheduler runs the scholar and Scopus batch imports in-process on an
+// interval, replacing external cron. Each job runs in its own goroutine, so a
+// job never overlaps itself here, and the imports' advisory locks keep other
+// triggers and instances out.
+type ImportScheduler struct {
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// importScheduleFromEnv reads an import's enable flag and interval
+// (e.g. SCHOLAR_IMPORT_CRON=true, SCHOLAR_IMPORT_INTERVAL=24h or 1d).
+func importScheduleFromEnv(flagVar, intervalVar string) (bool, time.Duration) {
+	enabled, _ := strconv.ParseBool(strings.TrimSpace(os.Getenv(flagVar)))
+	if !enabled {
+		return false, 0
+	}
+	interval := defaultImportScheduleInterval
+	if raw := strings.TrimSpace(os.Getenv(intervalVar)); raw != "" {
+		if d, err := ParseInterval(raw); err == nil && d > 0 {
+			interval = d
+		} else {
+			log.Printf("[Scheduler] invalid %s %q, using %s", intervalVar, raw, interval)
+		}
+	}
+	return true, interval
+}
+
+// StartImportScheduler starts the imports enabled by SCHOLAR_IMPORT_CRON and
+// SCOPUS_IMPORT_CRON. It returns nil when neither is enabled.
+func StartImportScheduler(db *gorm.DB) *ImportScheduler {
+	if db == nil {
+		db = config.DB
+	}
+
+	var jobs []scheduledImport
+	if enabled, interval := importScheduleFromEnv("SCHOLAR_IMPORT_CRON", "SCHOLAR_IMPORT_INTERVAL"); enabled {
+		jobs = append(jobs, scholarScheduledImport(db, interval))
+	}
+	if enabled, interval := importScheduleFromEnv("SCOPUS_IMPORT_CRON", "SCOPUS_IMPORT_INTERVAL"); enabled {
+		jobs = append(jobs, scopusScheduledImport(db, interval))
+	}
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scheduler := &ImportScheduler{cancel: cancel}
+	for _, job := range jobs {
+		scheduler.wg.Add(1)
+		go scheduler.loop(ctx, job)
+	}
+	return scheduler
+}
+
+// Stop cancels the schedule and waits up to timeout for running imports to
+// stop at their next user boundary. It reports whether they finished in time.
+func (s *ImportScheduler) Stop(timeout time.Duration) bool {
+	if s == nil {
+		return true
+	}
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+func (s *ImportScheduler) loop(ctx context.Context, job scheduledImport) {
+	defer s.wg.Done()
+	log.Printf("[Scheduler] Starting %s import (interval: %s)", job.name, job.interval)
+
+	next := time.Now()
+	for {
+		due := next
+		if last, err := job.lastStartedAt(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("[Scheduler] %s import: failed to read last run: %v", job.name, err)
+		} else if !last.IsZero() && last.Add(job.interval).After(due) {
+			due = last.Add(job.interval)
+		}
+
+		timer := time.NewTimer(time.Until(due))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		started := time.Now()
+		if s.runOnce(ctx, job) {
+			next = time.Now().Add(importScheduleBusyRetry)
+		} else {
+			next = started.Add(job.interval)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// runOnce runs the job, logging its outcome. A failure or panic is logged and
+// never reaches the API server. It reports whether the run was skipped
+// because another run holds the lock.
+func (s *ImportScheduler) runOnce(ctx context.Context, job scheduledImport) (busy bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("[Scheduler] %s import panicked: %v\n%s", job.name, r, debug.Stack())
+		}
+	}()
+
+	log.Printf("[Scheduler] %s import started", job.name)
+	started := time.Now()
+	summary, err := job.run(ctx)
+	elapsed := time.Since(started).Round(time.Second)
+
+	switch {
+	case errors.Is(err, ErrScholarImportAlreadyRunning), errors.Is(err, ErrScopusBatchImportAlreadyRunning):
+		log.Printf("[Scheduler] %s import skipped: another run is in progress", job.name)
+		return true
+	case errors.Is(err, ErrImportRunCancelled):
+		log.Printf("[Scheduler] %s import stopped after %s: %s", job.name, elapsed, summary)
+	case err != nil:
+		log.Printf("[Scheduler] %s import failed after %s: %v", job.name, elapsed, err)
+	default:
+		log.Printf("[Scheduler] %s import finished in %s: %s", job.name, elapsed, summary)
+	}
+	return false
+}
+
+func scholarScheduledImport(db *gorm.DB, interval time.Duration) scheduledImport {
+	job := NewScholarImportJobService(db)
+	return scheduledImport{
+		name:     "scholar",
+		interval: interval,
+		lastStartedAt: func(ctx context.Context) (time.Time, error) {
+			var last *time.Time
+			err := db.WithContext(ctx).Model(&models.ScholarImportRun{}).
+				Select("MAX(started_at)").Scan(&last).Error
+			if err != nil || last == nil {
+				return time.Time{}, err
+			}
+			return *last, nil
+		},
+		run: func(ctx context.Context) (string, error) {
+			input := &ScholarImportAllInput{
+				TriggerSource: importSchedulerTrigger,
+				LockName:      ScholarImportLockName,
+				RecordRun:     true,
+			}
+			// Scheduled runs are incremental when SCHOLAR_IMPORT_MIN_INTERVAL is set,
+			// matching the CLI's default.
+			if raw := strings.TrimSpace(os.Getenv("SCHOLAR_IMPORT_MIN_INTERVAL")); raw != "" {
+				if d, err := ParseInterval(raw); err == nil && d > 0 {
+					input.Incremental = true
+					input.MinInterval = d
+				}
+			}
+
+			summary, err := job.RunForAll(ctx, input)
+			if summary == nil {
+				return "", err
+			}
+			return fmt.Sprintf("users %d (errors %d, skipped %d); publications fetched %d, created %d, updated %d, unchanged %d, merged %d, failed %d",
+				summary.UsersProcessed, summary.UsersWithErrors, summary.UsersSkipped,
+				summary.PublicationsFetched, summary.PublicationsCreated, summary.PublicationsUpdated,
+				summary.PublicationsUnchanged, summary.PublicationsMerged, summary.PublicationsFailed), err
+		},
+	}
+}
+
+func scopusScheduledImport(db *gorm.DB, interval time.Duration) scheduledImport {
+	job := NewScopusIngestJobService(db)
+	return scheduledImport{
+		name:     "scopus",
+		interval: interval,
+		lastStartedAt: func(ctx context.Context) (time.Time, error) {
+			var last *time.Time
+			err := db.WithContext(ctx).Model(&models.ScopusBatchImportRun{}).
+				Select("MAX(started_at)").Scan(&last).Error
+			if err != nil || last == nil {
+				return time.Time{}, err
+			}
+			return *last, nil
+		},
+		run: func(ctx context.Context) (string, error) {
+			summary, err := job.RunForAll(ctx, &ScopusIngestAllInput{})
+			if summary == nil {
+				return "", err
+			}
+			return fmt.Sprintf("users %d (errors %d, API retries %d); documents fetched %d, created %d, updated %d, merged %d, failed %d",
+				summary.UsersProcessed, summary.UsersWithErrors, summary.Retries,
+				summary.DocumentsFetched, summary.DocumentsCreated, summary.DocumentsUpdated,
+				summary.PublicationsMerged, summary.DocumentsFailed), err
+		},
+	}
+}
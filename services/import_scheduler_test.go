@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestImportScheduleFromEnv(t *testing.T) {
+	t.Setenv("SCHOLAR_IMPORT_CRON", "")
+	t.Setenv("SCHOLAR_IMPORT_INTERVAL", "1h")
+	if enabled, _ := importScheduleFromEnv("SCHOLAR_IMPORT_CRON", "SCHOLAR_IMPORT_INTERVAL"); enabled {
+		t.Fatal("expected the schedule to be off unless the flag is set")
+	}
+
+	t.Setenv("SCHOLAR_IMPORT_CRON", "true")
+	for raw, want := range map[string]time.Duration{
+		"":      defaultImportScheduleInterval,
+		"6h":    6 * time.Hour,
+		"7d":    7 * 24 * time.Hour,
+		"bogus": defaultImportScheduleInterval,
+		"-1h":   defaultImportScheduleInterval,
+	} {
+		t.Setenv("SCHOLAR_IMPORT_INTERVAL", raw)
+		enabled, got := importScheduleFromEnv("SCHOLAR_IMPORT_CRON", "SCHOLAR_IMPORT_INTERVAL")
+		if !enabled || got != want {
+			t.Fatalf("SCHOLAR_IMPORT_INTERVAL=%q: expected %s, got %v %s", raw, want, enabled, got)
+		}
+	}
+}
+
+func TestImportSchedulerRunOnceSurvivesFailures(t *testing.T) {
+	scheduler := &ImportScheduler{}
+
+	busy := scheduler.runOnce(context.Background(), scheduledImport{
+		name: "test",
+		run:  func(context.Context) (string, error) { return "", ErrScopusBatchImportAlreadyRunning },
+	})
+	if !busy {
+		t.Fatal("expected a held lock to report the run as busy")
+	}
+
+	busy = scheduler.runOnce(context.Background(), scheduledImport{
+		name: "test",
+		run:  func(context.Context) (string, error) { panic("boom") },
+	})
+	if busy {
+		t.Fatal("expected a panicking run to be recovered and not reported busy")
+	}
+}
+
+func TestImportSchedulerStopsWhileWaiting(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	scheduler := &ImportScheduler{cancel: cancel}
+	runs := 0
+	scheduler.wg.Add(1)
+	go scheduler.loop(ctx, scheduledImport{
+		name:     "test",
+		interval: time.Hour,
+		lastStartedAt: func(context.Context) (time.Time, error) {
+			return time.Now(), nil
+		},
+		run: func(context.Context) (string, error) { runs++; return "", nil },
+	})
+
+	if !scheduler.Stop(time.Second) {
+		t.Fatal("expected the scheduler to stop promptly while waiting for the next run")
+	}
+	if runs != 0 {
+		t.Fatalf("expected no run before the interval elapsed, got %d", runs)
+	}
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"fund-management-api/models"
+	"fund-management-api/utils"
+
+	"gorm.io/gorm"
+)
+
+// JournalQuartileMatch is the quartile found for a journal and where it came from.
+type JournalQuartileMatch struct {
+	Quartile    string  `json:"quartile"`
+	Source      string  `json:"source"` // journals | scopus
+	MatchedOn   string  `json:"matched_on"`
+	JournalID   *int    `json:"journal_id,omitempty"`
+	JournalName *string `json:"journal_name,omitempty"`
+	ISSN        *string `json:"issn,omitempty"`
+	Indexing    *string `json:"indexing,omitempty"`
+	MetricYear  *int    `json:"metric_year,omitempty"`
+}
+
+// JournalQuartileService looks up journal quartiles in the local journals table,
+// falling back to the CiteScore quartile of imported Scopus source metrics.
+type JournalQuartileService struct {
+	db *gorm.DB
+}
+
+func NewJournalQuartileService(db *gorm.DB) *JournalQuartileService {
+	return &JournalQuartileService{db: db}
+}
+
+// Lookup finds the quartile by ISSN (print or electronic) or, failing that, by
+// exact journal name. When year is set, a row for that metric year wins over the
+// latest one. It returns nil when nothing is known.
+func (s *JournalQuartileService) Lookup(ctx context.Context, issn, name string, year int) (*JournalQuartileMatch, error) {
+	issn = utils.NormalizeISSN(issn)
+	name = strings.TrimSpace(name)
+	if issn == "" && name == "" {
+		return nil, nil
+	}
+
+	if issn != "" {
+		match, err := s.lookupLocal(ctx, "(issn = ? OR eissn = ?)", []interface{}{issn, issn}, year)
+		if err != nil || match != nil {
+			if match != nil {
+				match.MatchedOn = "issn"
+			}
+			return match, err
+		}
+	}
+	if name != "" {
+		match, err := s.lookupLocal(ctx, "journal_name = ?", []interface{}{name}, year)
+		if err != nil || match != nil {
+			if match != nil {
+				match.MatchedOn = "name"
+			}
+			return match, err
+		}
+	}
+	if issn != "" {
+		return s.lookupScopus(ctx, issn, year)
+	}
+	return nil, nil
+}
+
+func (s *JournalQuartileService) lookupLocal(ctx context.Context, where string, args []interface{}, year int) (*JournalQuartileMatch, error) {
+	query := s.db.WithContext(ctx).
+		Where("delete_at IS NULL AND quartile IS NOT NULL").
+		Where(where, args...)
+	if year > 0 {
+		query = query.Order(fmt.Sprintf("metric_year = %d DESC", year))
+	}
+
+	var journal models.Journal
+	if err := query.Order("metric_year DESC").Order("journal_id DESC").First(&journal).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	id := journal.JournalID
+	name := journal.JournalName
+	return &JournalQuartileMatch{
+		Quartile:    *journal.Quartile,
+		Source:      "journals",
+		JournalID:   &id,
+		JournalName: &name,
+		ISSN:        journal.ISSN,
+		Indexing:    journal.Indexing,
+		MetricYear:  journal.MetricYear,
+	}, nil
+}
+
+func (s *JournalQuartileService) lookupScopus(ctx context.Context, issn string, year int) (*JournalQuartileMatch, error) {
+	compact := strings.ReplaceAll(issn, "-", "")
+	query := s.db.WithContext(ctx).
+		Where("cite_score_quartile IS NOT NULL AND cite_score_quartile <> ''").
+		Where("(REPLACE(issn, '-', '') = ? OR REPLACE(eissn, '-', '') = ?)", compact, compact)
+	if year > 0 {
+		query = query.Order(fmt.Sprintf("metric_year = %d DESC", year))
+	}
+
+	var metric models.ScopusSourceMetric
+	if err := query.Order("metric_year DESC").First(&metric).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	metricYear := metric.MetricYear
+	return &JournalQuartileMatch{
+		Quartile:   *metric.CiteScoreQuartile,
+		Source:     "scopus",
+		MatchedOn:  "issn",
+		ISSN:       &issn,
+		MetricYear: &metricYear,
+	}, nil
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode"
+
+	"fund-management-api/utils"
+)
+
+// minDedupTitleKeyLength keeps short generic titles ("Preface", "Editorial")
+// from being merged on title and year alone.
+const minDedupTitleKeyLength = 20
+
+// normalizedDOIPtr is utils.NormalizeDOI for optional columns; both importers
+// match on this form.
+func normalizedDOIPtr(doi *string) *string {
+	if doi == nil {
+		return nil
+	}
+	if normalized := utils.NormalizeDOI(*doi); normalized != "" {
+		return &normalized
+	}
+	return nil
+}
+
+// publicationTitleKey reduces a title to lowercase letters and digits separated
+// by single spaces, so punctuation and spacing differences between sources do
+// not prevent a title and year match. Titles too short to be distinctive
+// return "".
+func publicationTitleKey(title string) string {
+	fields := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r)
+	})
+	key := strings.Join(fields, " ")
+	if len([]rune(key)) < minDedupTitleKeyLength {
+		return ""
+	}
+	return key
+}
+
+// titleKeyPtr returns publicationTitleKey(title) for storing in title_key, or
+// nil when the title is too short to match on.
+func titleKeyPtr(title string) *string {
+	if key := publicationTitleKey(title); key != "" {
+		return &key
+	}
+	return nil
+}
+
+// mergeExternalIDs combines two external_ids JSON objects. Identifiers from
+// incoming win on conflicts; those only the existing record has are kept, so
+// a publication seen by several sources carries every source's IDs.
+func mergeExternalIDs(existing, incoming *string) *string {
+	merged := map[string]interface{}{}
+	for _, raw := range []*string{existing, incoming} {
+		if raw == nil || strings.TrimSpace(*raw) == "" {
+			continue
+		}
+		var ids map[string]interface{}
+		if err := json.Unmarshal([]byte(*raw), &ids); err != nil {
+			continue
+		}
+		for key, value := range ids {
+			merged[key] = value
+		}
+	}
+	if len(merged) == 0 {
+		if incoming != nil {
+			return incoming
+		}
+		return existing
+	}
+	b, err := json.Marshal(merged)
+	if err != nil {
+		return incoming
+	}
+	out := string(b)
+	return &out
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"fund-management-api/models"
+	"fund-management-api/utils"
+)
+
+func TestNormalizeDOI(t *testing.T) {
+	for input, want := range map[string]string{
+		"10.1000/ABC.123":                    "10.1000/abc.123",
+		"  https://doi.org/10.1000/ABC.123 ": "10.1000/abc.123",
+		"http://dx.doi.org/10.1000/abc.123":  "10.1000/abc.123",
+		"HTTPS://DOI.ORG/10.1000/abc.123":    "10.1000/abc.123",
+		"doi: 10.1000/abc.123":               "10.1000/abc.123",
+		"doi.org/10.1000/abc.123":            "10.1000/abc.123",
+		"":                                   "",
+		"   ":                                "",
+	} {
+		if got := utils.NormalizeDOI(input); got != want {
+			t.Fatalf("NormalizeDOI(%q) = %q, want %q", input, got, want)
+		}
+	}
+	if got := normalizedDOIPtr(stringPtr(" https://doi.org/ ")); got != nil {
+		t.Fatalf("expected a bare prefix to normalize to no DOI, got %q", *got)
+	}
+}
+
+func TestPublicationTitleKey(t *testing.T) {
+	a := publicationTitleKey("Deep Learning for Rice-Disease Detection: A Survey")
+	b := publicationTitleKey("  deep learning for rice disease detection -- a survey.")
+	if a == "" || a != b {
+		t.Fatalf("expected titles differing only in punctuation to match, got %q and %q", a, b)
+	}
+	if thai := publicationTitleKey("การตรวจจับโรคข้าวด้วยการเรียนรู้เชิงลึก"); thai == "" {
+		t.Fatal("expected Thai titles to keep their letters and vowel marks")
+	}
+	if got := publicationTitleKey("Editorial"); got != "" {
+		t.Fatalf("expected a short generic title to have no key, got %q", got)
+	}
+}
+
+func TestMergeExternalIDs(t *testing.T) {
+	merged := mergeExternalIDs(
+		stringPtr(`{"scholar_cluster_id":"old","openalex_id":"W1"}`),
+		stringPtr(`{"scholar_cluster_id":"new","scopus_eid":"2-s2.0-1"}`),
+	)
+	var got map[string]string
+	if merged == nil || json.Unmarshal([]byte(*merged), &got) != nil {
+		t.Fatalf("expected merged JSON, got %v", merged)
+	}
+	want := map[string]string{"scholar_cluster_id": "new", "openalex_id": "W1", "scopus_eid": "2-s2.0-1"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Fatalf("expected %s=%s, got %v", key, value, got)
+		}
+	}
+
+	if got := mergeExternalIDs(nil, nil); got != nil {
+		t.Fatalf("expected nil, got %q", *got)
+	}
+}
+
+func TestUnchangedSourceRecord(t *testing.T) {
+	stored := &models.UserPublication{Source: stringPtr("scholar"), Fingerprint: stringPtr("fp"), ContentHash: stringPtr("h1")}
+
+	if !unchangedSourceRecord(stored, &models.UserPublication{Source: stringPtr("scholar"), Fingerprint: stringPtr("fp"), ContentHash: stringPtr("h1")}) {
+		t.Fatal("expected the same record with the same hash to be unchanged")
+	}
+	if unchangedSourceRecord(stored, &models.UserPublication{Source: stringPtr("scholar"), Fingerprint: stringPtr("fp"), ContentHash: stringPtr("h2")}) {
+		t.Fatal("expected a new content hash to be an update")
+	}
+	if unchangedSourceRecord(stored, &models.UserPublication{Source: stringPtr("scopus"), Fingerprint: stringPtr("fp"), ContentHash: stringPtr("h1")}) {
+		t.Fatal("expected a record from another source to be merged, not unchanged")
+	}
+	if unchangedSourceRecord(&models.UserPublication{Source: stringPtr("scholar"), Fingerprint: stringPtr("fp")}, &models.UserPublication{Source: stringPtr("scholar"), Fingerprint: stringPtr("fp"), ContentHash: stringPtr("h1")}) {
+		t.Fatal("expected a stored record without a hash to be updated")
+	}
+}
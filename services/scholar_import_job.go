@@ -2,11 +2,15 @@ package services
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
+	"time"
 
 	"fund-management-api/config"
 	"fund-management-api/models"
@@ -18,20 +22,39 @@ var (
 	ErrScholarImportAlreadyRunning = errors.New("scholar import already running")
 )
 
+// ScholarImportLockName is the MySQL advisory lock shared by every scholar
+// import trigger (CLI, admin API and the in-process scheduler).
+const ScholarImportLockName = "scholar_import_job"
+
 type ScholarImportSummary struct {
-	UsersProcessed      int `json:"users"`
-	UsersWithErrors     int `json:"users_with_errors"`
-	PublicationsFetched int `json:"fetched"`
-	PublicationsCreated int `json:"created"`
-	PublicationsUpdated int `json:"updated"`
-	PublicationsFailed  int `json:"failed"`
+	UsersProcessed        int  `json:"users"`
+	UsersWithErrors       int  `json:"users_with_errors"`
+	UsersSkipped          int  `json:"users_skipped"`
+	PublicationsFetched   int  `json:"fetched"`
+	PublicationsCreated   int  `json:"created"`
+	PublicationsUpdated   int  `json:"updated"`
+	PublicationsUnchanged int  `json:"unchanged"`
+	PublicationsMerged    int  `json:"merged"`
+	PublicationsFailed    int  `json:"failed"`
+	Incremental           bool `json:"incremental"`
+
+	Decisions []ScholarImportUserDecision `json:"decisions,omitempty"`
+}
+
+// ScholarImportUserDecision says what a run did with one user and why.
+type ScholarImportUserDecision struct {
+	UserID   uint   `json:"user_id"`
+	Decision string `json:"decision"`
+	Reason   string `json:"reason,omitempty"`
 }
 
 type ScholarImportUserSummary struct {
-	PublicationsFetched int `json:"fetched"`
-	PublicationsCreated int `json:"created"`
-	PublicationsUpdated int `json:"updated"`
-	PublicationsFailed  int `json:"failed"`
+	PublicationsFetched   int `json:"fetched"`
+	PublicationsCreated   int `json:"created"`
+	PublicationsUpdated   int `json:"updated"`
+	PublicationsUnchanged int `json:"unchanged"`
+	PublicationsMerged    int `json:"merged"`
+	PublicationsFailed    int `json:"failed"`
 }
 
 type ScholarImportUserInput struct {
@@ -47,6 +70,11 @@ type ScholarImportAllInput struct {
 	LockName      string
 	DryRun        bool
 	RecordRun     bool
+
+	// Incremental skips users processed within MinInterval and leaves
+	// publications whose content hash is unchanged untouched.
+	Incremental bool
+	MinInterval time.Duration
 }
 
 type ScholarImportJobService struct {
@@ -79,7 +107,7 @@ func (s *ScholarImportJobService) RunForUser(ctx context.Context, input *Scholar
 		return nil, errors.New("author_id is required")
 	}
 
-	res, err := s.processUser(ctx, input.UserID, input.AuthorID, input.DryRun)
+	res, err := s.processUser(ctx, input.UserID, input.AuthorID, input.DryRun, false)
 	if err != nil {
 		return nil, err
 	}
@@ -91,7 +119,7 @@ func (s *ScholarImportJobService) RunForAll(ctx context.Context, input *ScholarI
 		return nil, errors.New("input is nil")
 	}
 	recordRun := input.RecordRun
-	summary := &ScholarImportSummary{}
+	summary := &ScholarImportSummary{Incremental: input.Incremental}
 
 	release, err := s.acquireLock(ctx, input.LockName)
 	if err != nil {
@@ -114,9 +142,18 @@ func (s *ScholarImportJobService) RunForAll(ctx context.Context, input *ScholarI
 	}
 
 	var finalErr error
+	cancelled := false
+	var stopCtx context.Context
 	if run != nil {
+		var unregister func()
+		stopCtx, unregister = registerImportRun(ImportRunSourceScholar, uint64(run.ID))
+		defer unregister()
 		defer func() {
-			if finalErr != nil {
+			if cancelled {
+				if err := s.runService.MarkCancelled(run.ID, summary); err != nil {
+					log.Printf("failed to mark scholar import run cancelled: %v", err)
+				}
+			} else if finalErr != nil {
 				if err := s.runService.MarkFailure(run.ID, summary, finalErr); err != nil {
 					log.Printf("failed to mark scholar import run failure: %v", err)
 				}
@@ -150,24 +187,112 @@ func (s *ScholarImportJobService) RunForAll(ctx context.Context, input *ScholarI
 		return nil, err
 	}
 
+	var lastProcessed map[uint]time.Time
+	if input.Incremental && len(users) > 0 {
+		userIDs := make([]uint, len(users))
+		for i, u := range users {
+			userIDs[i] = u.UserID
+		}
+		if lastProcessed, err = s.runService.LastProcessedAt(ctx, userIDs); err != nil {
+			finalErr = err
+			return nil, err
+		}
+	}
+	now := time.Now()
+
+	record := func(decision ScholarImportUserDecision) {
+		summary.Decisions = append(summary.Decisions, decision)
+		if run == nil {
+			return
+		}
+		if err := s.runService.RecordUserDecision(run.ID, decision); err != nil {
+			log.Printf("failed to record scholar import decision for user %d: %v", decision.UserID, err)
+		}
+	}
+
 	for _, u := range users {
-		res, err := s.processUser(ctx, u.UserID, u.ScholarAuthorID, input.DryRun)
+		if ctx.Err() != nil || (stopCtx != nil && stopCtx.Err() != nil) {
+			cancelled = true
+			log.Printf("scholar import run cancelled after %d users", summary.UsersProcessed+summary.UsersWithErrors+summary.UsersSkipped)
+			return summary, ErrImportRunCancelled
+		}
+
+		if input.Incremental {
+			if last, ok := lastProcessed[u.UserID]; ok && !scholarImportDue(last, now, input.MinInterval) {
+				summary.UsersSkipped++
+				record(ScholarImportUserDecision{
+					UserID:   u.UserID,
+					Decision: models.ScholarImportUserSkipped,
+					Reason:   fmt.Sprintf("imported %s, within %s", last.Format(time.RFC3339), input.MinInterval),
+				})
+				continue
+			}
+		}
+
+		res, err := s.processUser(ctx, u.UserID, u.ScholarAuthorID, input.DryRun, input.Incremental)
 		if err != nil {
 			summary.UsersWithErrors++
 			log.Printf("scholar import failed for user %d: %v", u.UserID, err)
-			continue
+			record(ScholarImportUserDecision{UserID: u.UserID, Decision: models.ScholarImportUserFailed, Reason: err.Error()})
+		} else {
+			record(ScholarImportUserDecision{UserID: u.UserID, Decision: models.ScholarImportUserProcessed})
+			summary.UsersProcessed++
+			summary.PublicationsFetched += res.PublicationsFetched
+			summary.PublicationsCreated += res.PublicationsCreated
+			summary.PublicationsUpdated += res.PublicationsUpdated
+			summary.PublicationsUnchanged += res.PublicationsUnchanged
+			summary.PublicationsMerged += res.PublicationsMerged
+			summary.PublicationsFailed += res.PublicationsFailed
+		}
+
+		if run != nil {
+			if err := s.runService.UpdateProgress(run.ID, summary); err != nil {
+				log.Printf("failed to update scholar import run progress: %v", err)
+			}
 		}
-		summary.UsersProcessed++
-		summary.PublicationsFetched += res.PublicationsFetched
-		summary.PublicationsCreated += res.PublicationsCreated
-		summary.PublicationsUpdated += res.PublicationsUpdated
-		summary.PublicationsFailed += res.PublicationsFailed
 	}
 
 	return summary, nil
 }
 
-func (s *ScholarImportJobService) processUser(ctx context.Context, userID uint, authorID string, dryRun bool) (*ScholarImportUserSummary, error) {
+// scholarImportDue reports whether a user last processed at last should be
+// imported again at now. A non-positive interval makes every user due.
+func scholarImportDue(last, now time.Time, interval time.Duration) bool {
+	if interval <= 0 || last.IsZero() {
+		return true
+	}
+	return !last.Add(interval).After(now)
+}
+
+// scholarPublicationHash fingerprints the fields the scholar import writes, so
+// a publication fetched again with the same content can be left as it is.
+func scholarPublicationHash(pub *models.UserPublication) string {
+	str := func(v *string) string {
+		if v == nil {
+			return ""
+		}
+		return *v
+	}
+	year, citedBy := "", ""
+	if pub.PublicationYear != nil {
+		year = strconv.Itoa(int(*pub.PublicationYear))
+	}
+	if pub.CitedBy != nil {
+		citedBy = strconv.FormatUint(uint64(*pub.CitedBy), 10)
+	}
+
+	h := sha256.New()
+	for _, field := range []string{
+		pub.Title, str(pub.Authors), str(pub.Journal), year, str(pub.DOI), str(pub.URL),
+		citedBy, str(pub.CitedByURL), str(pub.CitationHistory), str(pub.Source), str(pub.ExternalIDs),
+	} {
+		h.Write([]byte(field))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (s *ScholarImportJobService) processUser(ctx context.Context, userID uint, authorID string, dryRun, skipUnchanged bool) (*ScholarImportUserSummary, error) {
 	pubs, err := FetchScholarOnce(authorID)
 	if err != nil {
 		return nil, &ScholarScriptError{AuthorID: authorID, Err: err}
@@ -175,6 +300,19 @@ func (s *ScholarImportJobService) processUser(ctx context.Context, userID uint,
 
 	res := &ScholarImportUserSummary{PublicationsFetched: len(pubs)}
 
+	knownHashes := map[string]bool{}
+	if skipUnchanged {
+		var hashes []string
+		if err := s.db.WithContext(ctx).Model(&models.UserPublication{}).
+			Where("user_id = ? AND content_hash IS NOT NULL", userID).
+			Pluck("content_hash", &hashes).Error; err != nil {
+			return nil, err
+		}
+		for _, hash := range hashes {
+			knownHashes[hash] = true
+		}
+	}
+
 	if !dryRun {
 		if err := s.updateAuthorMetrics(userID, authorID); err != nil {
 			log.Printf("failed to update scholar metrics for user %d: %v", userID, err)
@@ -234,19 +372,34 @@ func (s *ScholarImportJobService) processUser(ctx context.Context, userID uint,
 			CitationHistory: citationHistory,
 		}
 
-		if dryRun {
+		contentHash := scholarPublicationHash(pub)
+		pub.ContentHash = &contentHash
+		if knownHashes[contentHash] {
+			res.PublicationsUnchanged++
 			continue
 		}
 
-		created, _, e := s.pubs.Upsert(pub)
+		var outcome PublicationUpsertOutcome
+		var e error
+		if dryRun {
+			// Count what the import would do without writing it.
+			outcome, e = s.pubs.PreviewUpsert(pub)
+		} else {
+			outcome, _, e = s.pubs.UpsertWithOutcome(pub)
+		}
 		if e != nil {
 			res.PublicationsFailed++
 			log.Printf("failed to upsert publication for user %d: %v", userID, e)
 			continue
 		}
-		if created {
+		switch outcome {
+		case PublicationCreated:
 			res.PublicationsCreated++
-		} else {
+		case PublicationMerged:
+			res.PublicationsMerged++
+		case PublicationUnchanged:
+			res.PublicationsUnchanged++
+		default:
 			res.PublicationsUpdated++
 		}
 	}
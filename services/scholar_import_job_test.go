@@ -13,6 +13,8 @@ import (
 	"testing"
 	"time"
 
+	"fund-management-api/models"
+
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 )
@@ -293,3 +295,46 @@ func TestRunForAllReleasesLockWhenContextCanceled(t *testing.T) {
 		t.Fatalf("%v", err)
 	}
 }
+
+func TestScholarImportDue(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		name     string
+		last     time.Time
+		interval time.Duration
+		want     bool
+	}{
+		{"never imported", time.Time{}, 72 * time.Hour, true},
+		{"within interval", now.Add(-24 * time.Hour), 72 * time.Hour, false},
+		{"exactly at interval", now.Add(-72 * time.Hour), 72 * time.Hour, true},
+		{"older than interval", now.Add(-96 * time.Hour), 72 * time.Hour, true},
+		{"no interval", now.Add(-time.Minute), 0, true},
+	} {
+		if got := scholarImportDue(tc.last, now, tc.interval); got != tc.want {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestScholarPublicationHashTracksImportedFields(t *testing.T) {
+	authors := "A. Author, B. Author"
+	year := uint16(2024)
+	cited := uint(10)
+	pub := &models.UserPublication{UserID: 7, Title: "Deep Learning", Authors: &authors, PublicationYear: &year, CitedBy: &cited}
+
+	base := scholarPublicationHash(pub)
+	if again := scholarPublicationHash(pub); again != base {
+		t.Fatalf("hash is not stable: %s vs %s", base, again)
+	}
+
+	pub.UserID = 8
+	if got := scholarPublicationHash(pub); got != base {
+		t.Fatalf("hash should depend only on imported content")
+	}
+
+	moreCitations := uint(11)
+	pub.CitedBy = &moreCitations
+	if got := scholarPublicationHash(pub); got == base {
+		t.Fatalf("expected a new hash when the citation count changes")
+	}
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
@@ -52,18 +53,87 @@ func (s *ScholarImportRunService) MarkFailure(runID uint, summary *ScholarImport
 	return s.finish(runID, models.ScholarImportRunStatusFailed, summary, &msg)
 }
 
+func (s *ScholarImportRunService) MarkCancelled(runID uint, summary *ScholarImportSummary) error {
+	msg := ErrImportRunCancelled.Error()
+	return s.finish(runID, models.ScholarImportRunStatusCancelled, summary, &msg)
+}
+
+// UpdateProgress stores the running counters so callers polling the run can
+// follow its progress before it finishes.
+func (s *ScholarImportRunService) UpdateProgress(runID uint, summary *ScholarImportSummary) error {
+	if summary == nil {
+		return nil
+	}
+	return s.db.Model(&models.ScholarImportRun{}).
+		Where("id = ? AND status = ?", runID, models.ScholarImportRunStatusRunning).
+		Updates(scholarSummaryUpdates(summary)).Error
+}
+
+// RecordUserDecision logs whether the run processed, skipped or failed a user.
+func (s *ScholarImportRunService) RecordUserDecision(runID uint, decision ScholarImportUserDecision) error {
+	entry := &models.ScholarImportRunUser{
+		RunID:    runID,
+		UserID:   decision.UserID,
+		Decision: decision.Decision,
+	}
+	if decision.Reason != "" {
+		reason := decision.Reason
+		if len(reason) > 255 {
+			reason = reason[:252] + "..."
+		}
+		entry.Reason = &reason
+	}
+	return s.db.Create(entry).Error
+}
+
+// LastProcessedAt returns when each of userIDs was last processed by a run.
+// Users never processed are absent from the map.
+func (s *ScholarImportRunService) LastProcessedAt(ctx context.Context, userIDs []uint) (map[uint]time.Time, error) {
+	result := make(map[uint]time.Time, len(userIDs))
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		UserID         uint
+		LastImportedAt time.Time
+	}
+	if err := s.db.WithContext(ctx).Model(&models.ScholarImportRunUser{}).
+		Select("user_id, MAX(created_at) AS last_imported_at").
+		Where("decision = ? AND user_id IN ?", models.ScholarImportUserProcessed, userIDs).
+		Group("user_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		result[row.UserID] = row.LastImportedAt
+	}
+	return result, nil
+}
+
+func scholarSummaryUpdates(summary *ScholarImportSummary) map[string]interface{} {
+	return map[string]interface{}{
+		"users_processed":        summary.UsersProcessed,
+		"users_with_errors":      summary.UsersWithErrors,
+		"users_skipped":          summary.UsersSkipped,
+		"publications_fetched":   summary.PublicationsFetched,
+		"publications_created":   summary.PublicationsCreated,
+		"publications_updated":   summary.PublicationsUpdated,
+		"publications_unchanged": summary.PublicationsUnchanged,
+		"publications_merged":    summary.PublicationsMerged,
+		"publications_failed":    summary.PublicationsFailed,
+	}
+}
+
 func (s *ScholarImportRunService) finish(runID uint, status string, summary *ScholarImportSummary, errMsg *string) error {
 	updates := map[string]interface{}{
 		"status":      status,
 		"finished_at": time.Now(),
 	}
 	if summary != nil {
-		updates["users_processed"] = summary.UsersProcessed
-		updates["users_with_errors"] = summary.UsersWithErrors
-		updates["publications_fetched"] = summary.PublicationsFetched
-		updates["publications_created"] = summary.PublicationsCreated
-		updates["publications_updated"] = summary.PublicationsUpdated
-		updates["publications_failed"] = summary.PublicationsFailed
+		for key, value := range scholarSummaryUpdates(summary) {
+			updates[key] = value
+		}
 	}
 	if errMsg != nil {
 		if len(*errMsg) > 1000 {
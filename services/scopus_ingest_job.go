@@ -31,10 +31,12 @@ type ScopusIngestAllInput struct {
 type ScopusIngestJobSummary struct {
 	UsersProcessed      int `json:"users_processed"`
 	UsersWithErrors     int `json:"users_with_errors"`
+	Retries             int `json:"retries"`
 	DocumentsFetched    int `json:"documents_fetched"`
 	DocumentsCreated    int `json:"documents_created"`
 	DocumentsUpdated    int `json:"documents_updated"`
 	DocumentsFailed     int `json:"documents_failed"`
+	PublicationsMerged  int `json:"publications_merged"`
 	AuthorsCreated      int `json:"authors_created"`
 	AuthorsUpdated      int `json:"authors_updated"`
 	AffiliationsCreated int `json:"affiliations_created"`
@@ -140,31 +142,23 @@ func (s *ScopusIngestJobService) RunForAll(ctx context.Context, input *ScopusIng
 		return nil, err
 	}
 
+	stopCtx, unregister := registerImportRun(ImportRunSourceScopus, run.ID)
+	defer unregister()
+
 	startedAt := time.Now()
 	var runErr error
 	defer func() {
 		status := "success"
-		if runErr != nil {
+		if errors.Is(runErr, ErrImportRunCancelled) {
+			status = "cancelled"
+		} else if runErr != nil {
 			status = "failed"
 		}
 
-		updates := map[string]interface{}{
-			"status":               status,
-			"finished_at":          time.Now(),
-			"duration_seconds":     time.Since(startedAt).Seconds(),
-			"users_processed":      summary.UsersProcessed,
-			"users_with_errors":    summary.UsersWithErrors,
-			"documents_fetched":    summary.DocumentsFetched,
-			"documents_created":    summary.DocumentsCreated,
-			"documents_updated":    summary.DocumentsUpdated,
-			"documents_failed":     summary.DocumentsFailed,
-			"authors_created":      summary.AuthorsCreated,
-			"authors_updated":      summary.AuthorsUpdated,
-			"affiliations_created": summary.AffiliationsCreated,
-			"affiliations_updated": summary.AffiliationsUpdated,
-			"links_inserted":       summary.LinksInserted,
-			"links_updated":        summary.LinksUpdated,
-		}
+		updates := summary.runUpdates()
+		updates["status"] = status
+		updates["finished_at"] = time.Now()
+		updates["duration_seconds"] = time.Since(startedAt).Seconds()
 
 		if runErr != nil {
 			errMsg := runErr.Error()
@@ -202,28 +196,63 @@ func (s *ScopusIngestJobService) RunForAll(ctx context.Context, input *ScopusIng
 	}
 
 	for _, user := range users {
+		if ctx.Err() != nil || stopCtx.Err() != nil {
+			runErr = ErrImportRunCancelled
+			log.Printf("scopus batch import run %d cancelled after %d users", run.ID, summary.UsersProcessed+summary.UsersWithErrors)
+			return summary, runErr
+		}
+
 		res, err := s.ingest.RunForAuthor(ctx, user.ScopusID)
+		if res != nil {
+			summary.Retries += res.Retries
+		}
 		if err != nil {
 			summary.UsersWithErrors++
 			log.Printf("scopus ingest failed for user %d: %v", user.UserID, err)
-			continue
+		} else {
+			summary.UsersProcessed++
+			summary.DocumentsFetched += res.DocumentsFetched
+			summary.DocumentsCreated += res.DocumentsCreated
+			summary.DocumentsUpdated += res.DocumentsUpdated
+			summary.DocumentsFailed += res.DocumentsFailed
+			summary.PublicationsMerged += res.PublicationsMerged
+			summary.AuthorsCreated += res.AuthorsCreated
+			summary.AuthorsUpdated += res.AuthorsUpdated
+			summary.AffiliationsCreated += res.AffiliationsCreated
+			summary.AffiliationsUpdated += res.AffiliationsUpdated
+			summary.LinksInserted += res.DocumentAuthorsInserted
+			summary.LinksUpdated += res.DocumentAuthorsUpdated
+		}
+
+		// Persist running counters so the run can be polled while in progress.
+		if err := s.db.WithContext(ctx).Model(run).Updates(summary.runUpdates()).Error; err != nil {
+			log.Printf("failed to update scopus batch import run %d progress: %v", run.ID, err)
 		}
-		summary.UsersProcessed++
-		summary.DocumentsFetched += res.DocumentsFetched
-		summary.DocumentsCreated += res.DocumentsCreated
-		summary.DocumentsUpdated += res.DocumentsUpdated
-		summary.DocumentsFailed += res.DocumentsFailed
-		summary.AuthorsCreated += res.AuthorsCreated
-		summary.AuthorsUpdated += res.AuthorsUpdated
-		summary.AffiliationsCreated += res.AffiliationsCreated
-		summary.AffiliationsUpdated += res.AffiliationsUpdated
-		summary.LinksInserted += res.DocumentAuthorsInserted
-		summary.LinksUpdated += res.DocumentAuthorsUpdated
 	}
 
 	return summary, nil
 }
 
+// runUpdates maps the summary counters onto scopus_batch_import_runs columns.
+func (summary *ScopusIngestJobSummary) runUpdates() map[string]interface{} {
+	return map[string]interface{}{
+		"users_processed":      summary.UsersProcessed,
+		"users_with_errors":    summary.UsersWithErrors,
+		"retries":              summary.Retries,
+		"documents_fetched":    summary.DocumentsFetched,
+		"documents_created":    summary.DocumentsCreated,
+		"documents_updated":    summary.DocumentsUpdated,
+		"documents_failed":     summary.DocumentsFailed,
+		"publications_merged":  summary.PublicationsMerged,
+		"authors_created":      summary.AuthorsCreated,
+		"authors_updated":      summary.AuthorsUpdated,
+		"affiliations_created": summary.AffiliationsCreated,
+		"affiliations_updated": summary.AffiliationsUpdated,
+		"links_inserted":       summary.LinksInserted,
+		"links_updated":        summary.LinksUpdated,
+	}
+}
+
 func (s *ScopusIngestJobService) acquireBatchRunLock(ctx context.Context) (func() error, error) {
 	lockCtx := persistentContext(ctx)
 
@@ -17,6 +17,7 @@ import (
 	"fund-management-api/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 const (
@@ -40,6 +41,8 @@ type ScopusIngestResult struct {
 	DocumentAuthorsInserted int `json:"document_authors_inserted"`
 	DocumentAuthorsUpdated  int `json:"document_authors_updated"`
 	DocumentsFailed         int `json:"documents_failed"`
+	PublicationsMerged      int `json:"publications_merged"`
+	Retries                 int `json:"retries"`
 }
 
 // ScopusIngestService fetches and stores publications from the Scopus API.
@@ -48,6 +51,7 @@ type ScopusIngestService struct {
 	client     *http.Client
 	metrics    *CiteScoreMetricsService
 	conference *ScopusConferenceService
+	retry      scopusRetryPolicy
 }
 
 // NewScopusIngestService constructs a ScopusIngestService.
@@ -63,6 +67,7 @@ func NewScopusIngestService(db *gorm.DB, client *http.Client) *ScopusIngestServi
 		client:     client,
 		metrics:    NewCiteScoreMetricsService(db, client),
 		conference: NewScopusConferenceService(db, client),
+		retry:      scopusRetryPolicyFromEnv(),
 	}
 }
 
@@ -125,7 +130,13 @@ func (s *ScopusIngestService) ingestAuthor(ctx context.Context, scopusAuthorID,
 	}()
 
 	for {
-		resp, err := s.fetchPage(ctx, apiKey, scopusAuthorID, start, job.ID)
+		var resp *scopusResponse
+		retries, err := withScopusRetry(ctx, s.retry, func() error {
+			var fetchErr error
+			resp, fetchErr = s.fetchPage(ctx, apiKey, scopusAuthorID, start, job.ID)
+			return fetchErr
+		})
+		result.Retries += retries
 		if err != nil {
 			ingestErr = err
 			break
@@ -160,12 +171,19 @@ func (s *ScopusIngestService) ingestAuthor(ctx context.Context, scopusAuthorID,
 	}
 
 	if ingestErr != nil {
-		return nil, ingestErr
+		// The partial result still carries the retry count for the caller's summary.
+		return result, ingestErr
 	}
 
 	return result, nil
 }
 
+// scopusDecodeError is an unreadable 200 response; retrying will not help.
+type scopusDecodeError struct{ err error }
+
+func (e *scopusDecodeError) Error() string { return fmt.Sprintf("decode scopus response: %v", e.err) }
+func (e *scopusDecodeError) Unwrap() error { return e.err }
+
 type scopusResponse struct {
 	TotalResults int
 	Entries      []json.RawMessage
@@ -209,7 +227,11 @@ func (s *ScopusIngestService) fetchPage(ctx context.Context, apiKey, scopusAutho
 
 		if resp.StatusCode != http.StatusOK {
 			body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
-			requestErr = fmt.Errorf("scopus api error: status %d body %s", resp.StatusCode, string(body))
+			requestErr = &ScopusAPIError{
+				StatusCode: resp.StatusCode,
+				Body:       string(body),
+				RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+			}
 		} else {
 			var decoded struct {
 				SearchResults struct {
@@ -219,7 +241,7 @@ func (s *ScopusIngestService) fetchPage(ctx context.Context, apiKey, scopusAutho
 			}
 
 			if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
-				requestErr = fmt.Errorf("decode scopus response: %w", err)
+				requestErr = &scopusDecodeError{err: err}
 			} else {
 				payload.TotalResults = parseIntSafe(decoded.SearchResults.TotalResults)
 				payload.Entries = decoded.SearchResults.Entries
@@ -309,27 +331,57 @@ func (s *ScopusIngestService) processEntry(ctx context.Context, raw json.RawMess
 		docModel.RawJSON = cloneJSON(raw)
 
 		var doc models.ScopusDocument
-		if err := tx.Where("eid = ?", docModel.EID).First(&doc).Error; err != nil {
-			if !errors.Is(err, gorm.ErrRecordNotFound) {
-				return err
-			}
-			if err := tx.Create(docModel).Error; err != nil {
+		err := tx.Where("eid = ?", docModel.EID).First(&doc).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err == nil {
+			docModel.ID = doc.ID
+			if err := tx.Save(docModel).Error; err != nil {
 				return err
 			}
-			result.DocumentsCreated++
+			result.DocumentsUpdated++
 			doc = *docModel
-			created = true
 		} else {
-			docModel.ID = doc.ID
-			if err := tx.Save(docModel).Error; err != nil {
+			duplicate, aliased, err := findDuplicateScopusDocument(tx, docModel)
+			if err != nil {
 				return err
 			}
-			result.DocumentsUpdated++
+			if duplicate != nil {
+				// Same paper under another EID: update it in place and keep
+				// its EID so links and metrics stay attached. The incoming EID
+				// is recorded as an alias so the next import finds it directly.
+				incomingEID := docModel.EID
+				docModel.ID = duplicate.ID
+				docModel.EID = duplicate.EID
+				if err := tx.Save(docModel).Error; err != nil {
+					return err
+				}
+				if aliased {
+					result.DocumentsUpdated++
+				} else {
+					alias := models.ScopusDocumentAlias{EID: incomingEID, DocumentID: duplicate.ID}
+					if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&alias).Error; err != nil {
+						return err
+					}
+					result.PublicationsMerged++
+				}
+			} else {
+				if err := tx.Create(docModel).Error; err != nil {
+					return err
+				}
+				result.DocumentsCreated++
+				created = true
+			}
 			doc = *docModel
 		}
 
 		persisted = doc
 
+		if err := linkPublicationsToScopusDocument(tx, &doc); err != nil {
+			return err
+		}
+
 		affiliationMap, err := s.upsertAffiliations(tx, entry, result)
 		if err != nil {
 			return err
@@ -345,6 +397,73 @@ func (s *ScopusIngestService) processEntry(ctx context.Context, raw json.RawMess
 	return &persisted, created, nil
 }
 
+// findDuplicateScopusDocument finds a stored document that is the same paper
+// as doc under a different EID: one doc's EID was already merged into, one with
+// the same normalized DOI or, when doc has no DOI, the same normalized title and
+// cover year. aliased reports a match through a recorded alias.
+func findDuplicateScopusDocument(tx *gorm.DB, doc *models.ScopusDocument) (duplicate *models.ScopusDocument, aliased bool, err error) {
+	var alias models.ScopusDocumentAlias
+	err = tx.Where("eid = ?", doc.EID).First(&alias).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, err
+	}
+	if err == nil {
+		var target models.ScopusDocument
+		err = tx.Select("id", "eid").Where("id = ?", alias.DocumentID).First(&target).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, err
+		}
+		if err == nil {
+			return &target, true, nil
+		}
+	}
+
+	var found models.ScopusDocument
+	if doc.DOINormalized != nil {
+		err := tx.Select("id", "eid").
+			Where("doi_normalized = ?", *doc.DOINormalized).
+			Order("id ASC").
+			First(&found).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		return &found, false, nil
+	}
+
+	if doc.TitleKey == nil || doc.CoverDate == nil {
+		return nil, false, nil
+	}
+	yearStart := time.Date(doc.CoverDate.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	err = tx.Select("id", "eid").
+		Where("title_key = ? AND doi_normalized IS NULL AND cover_date >= ? AND cover_date < ?",
+			*doc.TitleKey, yearStart, yearStart.AddDate(1, 0, 0)).
+		Order("id ASC").
+		First(&found).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return &found, false, nil
+}
+
+// linkPublicationsToScopusDocument records the document's EID in the
+// external_ids of imported publications with the same normalized DOI, so the
+// Scholar and Scopus records of one paper point at each other.
+func linkPublicationsToScopusDocument(tx *gorm.DB, doc *models.ScopusDocument) error {
+	if doc.DOINormalized == nil {
+		return nil
+	}
+	return tx.Model(&models.UserPublication{}).
+		Where("doi_normalized = ? AND deleted_at IS NULL", *doc.DOINormalized).
+		Where("external_ids IS NULL OR JSON_EXTRACT(external_ids, '$.scopus_eid') IS NULL").
+		Update("external_ids", gorm.Expr("JSON_SET(COALESCE(external_ids, '{}'), '$.scopus_eid', ?)", doc.EID)).Error
+}
+
 func (s *ScopusIngestService) enqueueMetricFetch(ctx context.Context, doc *models.ScopusDocument, seen map[string]struct{}) {
 	if doc == nil {
 		return
@@ -693,6 +812,7 @@ func buildScopusDocument(entry *scopusEntry) *models.ScopusDocument {
 		ScopusID:           optionalString(entry.Identifier),
 		ScopusLink:         extractScopusLink(entry),
 		Title:              optionalString(entry.Title),
+		TitleKey:           titleKeyPtr(entry.Title),
 		Abstract:           optionalString(entry.Description),
 		AggregationType:    optionalString(entry.AggregationType),
 		Subtype:            optionalString(entry.Subtype),
@@ -706,6 +826,7 @@ func buildScopusDocument(entry *scopusEntry) *models.ScopusDocument {
 		ArticleNumber:      optionalString(entry.ArticleNumber),
 		CoverDisplayDate:   optionalString(entry.CoverDisplayDate),
 		DOI:                optionalString(entry.DOI),
+		DOINormalized:      normalizedDOIPtr(optionalString(entry.DOI)),
 		PII:                optionalString(entry.PII),
 		FundAcr:            optionalString(entry.FundAcr),
 		FundSponsor:        optionalString(entry.FundSponsor),
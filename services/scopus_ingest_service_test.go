@@ -4,6 +4,9 @@ import (
 	"database/sql/driver"
 	"regexp"
 	"testing"
+	"time"
+
+	"fund-management-api/models"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -98,3 +101,71 @@ func TestUpsertAuthorsAndLinks_PreservesExistingORCID(t *testing.T) {
 		t.Fatalf("unexpected remaining steps: %v", err)
 	}
 }
+
+func TestFindDuplicateScopusDocument_PrefersRecordedAlias(t *testing.T) {
+	steps := []*queryStep{
+		{
+			kind:    kindQuery,
+			pattern: regexp.MustCompile("SELECT .* FROM `scopus_document_aliases` WHERE eid = "),
+			args:    []driver.Value{"2-s2.0-2", int64(1)},
+			columns: []string{"eid", "document_id", "created_at"},
+			rows:    [][]driver.Value{{"2-s2.0-2", int64(7), time.Now()}},
+		},
+		{
+			kind:    kindQuery,
+			pattern: regexp.MustCompile("SELECT `id`,`eid` FROM `scopus_documents` WHERE id = "),
+			args:    []driver.Value{int64(7), int64(1)},
+			columns: []string{"id", "eid"},
+			rows:    [][]driver.Value{{int64(7), "2-s2.0-1"}},
+		},
+	}
+	db, state, cleanup := newScriptedGormDB(t, steps)
+	defer cleanup()
+
+	duplicate, aliased, err := findDuplicateScopusDocument(db, &models.ScopusDocument{EID: "2-s2.0-2", DOINormalized: stringPtr("10.1000/abc")})
+	if err != nil {
+		t.Fatalf("findDuplicateScopusDocument: %v", err)
+	}
+	if duplicate == nil || duplicate.ID != 7 || duplicate.EID != "2-s2.0-1" || !aliased {
+		t.Fatalf("expected the aliased document 7, got %+v (aliased=%v)", duplicate, aliased)
+	}
+	if err := state.verifyComplete(); err != nil {
+		t.Fatalf("unexpected remaining steps: %v", err)
+	}
+}
+
+func TestFindDuplicateScopusDocument_MatchesTitleKeyInSQL(t *testing.T) {
+	title := "Deep Learning for Rice-Disease Detection: A Survey"
+	coverDate := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	yearStart := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	steps := []*queryStep{
+		{
+			kind:    kindQuery,
+			pattern: regexp.MustCompile("SELECT .* FROM `scopus_document_aliases` WHERE eid = "),
+			args:    []driver.Value{"2-s2.0-2", int64(1)},
+			columns: []string{"eid", "document_id", "created_at"},
+			rows:    [][]driver.Value{},
+		},
+		{
+			kind:    kindQuery,
+			pattern: regexp.MustCompile("SELECT `id`,`eid` FROM `scopus_documents` WHERE title_key = \\? AND doi_normalized IS NULL"),
+			args:    []driver.Value{"deep learning for rice disease detection a survey", yearStart, yearStart.AddDate(1, 0, 0), int64(1)},
+			columns: []string{"id", "eid"},
+			rows:    [][]driver.Value{{int64(3), "2-s2.0-1"}},
+		},
+	}
+	db, state, cleanup := newScriptedGormDB(t, steps)
+	defer cleanup()
+
+	doc := &models.ScopusDocument{EID: "2-s2.0-2", Title: &title, TitleKey: titleKeyPtr(title), CoverDate: &coverDate}
+	duplicate, aliased, err := findDuplicateScopusDocument(db, doc)
+	if err != nil {
+		t.Fatalf("findDuplicateScopusDocument: %v", err)
+	}
+	if duplicate == nil || duplicate.ID != 3 || aliased {
+		t.Fatalf("expected a title match on document 3, got %+v (aliased=%v)", duplicate, aliased)
+	}
+	if err := state.verifyComplete(); err != nil {
+		t.Fatalf("unexpected remaining steps: %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultScopusRetryMaxAttempts = 4
+	defaultScopusRetryBaseDelay   = time.Second
+	scopusRetryMaxDelay           = 30 * time.Second
+	scopusRetryAfterCap           = 2 * time.Minute
+)
+
+// ScopusAPIError is a non-200 response from the Scopus API.
+type ScopusAPIError struct {
+	StatusCode int
+	Body       string
+	RetryAfter time.Duration
+}
+
+func (e *ScopusAPIError) Error() string {
+	return fmt.Sprintf("scopus api error: status %d body %s", e.StatusCode, e.Body)
+}
+
+// scopusRetryPolicy controls how Scopus page fetches are retried. Attempts
+// counts the first try, so MaxAttempts 1 disables retries.
+type scopusRetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// scopusRetryPolicyFromEnv reads SCOPUS_RETRY_MAX_ATTEMPTS (default 4) and
+// SCOPUS_RETRY_BASE_DELAY_MS (default 1000).
+func scopusRetryPolicyFromEnv() scopusRetryPolicy {
+	policy := scopusRetryPolicy{
+		MaxAttempts: defaultScopusRetryMaxAttempts,
+		BaseDelay:   defaultScopusRetryBaseDelay,
+		MaxDelay:    scopusRetryMaxDelay,
+	}
+	if n, err := strconv.Atoi(strings.TrimSpace(os.Getenv("SCOPUS_RETRY_MAX_ATTEMPTS"))); err == nil && n > 0 {
+		policy.MaxAttempts = n
+	}
+	if ms, err := strconv.Atoi(strings.TrimSpace(os.Getenv("SCOPUS_RETRY_BASE_DELAY_MS"))); err == nil && ms >= 0 {
+		policy.BaseDelay = time.Duration(ms) * time.Millisecond
+	}
+	return policy
+}
+
+// backoff returns the wait before retry number retry (1-based): the base delay
+// doubled per retry, capped at MaxDelay, with jitter in its upper half.
+func (p scopusRetryPolicy) backoff(retry int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < retry && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
+// scopusRetryable reports whether err is worth retrying: 429, 5xx and network
+// failures are; other 4xx responses, decode errors and cancellation are not.
+// The returned duration is the server's Retry-After, if any.
+func scopusRetryable(err error) (bool, time.Duration) {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false, 0
+	}
+	var apiErr *ScopusAPIError
+	if errors.As(err, &apiErr) {
+		if apiErr.StatusCode == http.StatusTooManyRequests {
+			return true, apiErr.RetryAfter
+		}
+		return apiErr.StatusCode >= 500, 0
+	}
+	var decodeErr *scopusDecodeError
+	return !errors.As(err, &decodeErr), 0
+}
+
+// withScopusRetry calls fn until it succeeds, fails with a non-retryable
+// error, or runs out of attempts, waiting between attempts. It returns the
+// number of retries made. Waiting stops as soon as ctx is done.
+func withScopusRetry(ctx context.Context, policy scopusRetryPolicy, fn func() error) (int, error) {
+	attempts := policy.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	retries := 0
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return retries, nil
+		}
+		retryable, retryAfter := scopusRetryable(err)
+		if !retryable || attempt >= attempts {
+			return retries, err
+		}
+
+		wait := policy.backoff(attempt)
+		if retryAfter > 0 {
+			wait = min(retryAfter, scopusRetryAfterCap)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return retries, ctx.Err()
+		case <-timer.C:
+		}
+		retries++
+	}
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestWithScopusRetry_RetriesTransientErrorsOnly(t *testing.T) {
+	policy := scopusRetryPolicy{MaxAttempts: 4, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+	for _, tc := range []struct {
+		name        string
+		err         error
+		wantCalls   int
+		wantRetries int
+	}{
+		{"server error", &ScopusAPIError{StatusCode: 503}, 4, 3},
+		{"rate limited", &ScopusAPIError{StatusCode: 429}, 4, 3},
+		{"network error", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, 4, 3},
+		{"bad request", &ScopusAPIError{StatusCode: 400}, 1, 0},
+		{"unauthorized", &ScopusAPIError{StatusCode: 401}, 1, 0},
+		{"decode error", &scopusDecodeError{err: errors.New("unexpected EOF")}, 1, 0},
+	} {
+		calls := 0
+		retries, err := withScopusRetry(context.Background(), policy, func() error {
+			calls++
+			return tc.err
+		})
+		if !errors.Is(err, tc.err) {
+			t.Fatalf("%s: expected the last error back, got %v", tc.name, err)
+		}
+		if calls != tc.wantCalls || retries != tc.wantRetries {
+			t.Fatalf("%s: expected %d calls and %d retries, got %d and %d", tc.name, tc.wantCalls, tc.wantRetries, calls, retries)
+		}
+	}
+}
+
+func TestWithScopusRetry_StopsRetryingOnSuccess(t *testing.T) {
+	policy := scopusRetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond}
+	calls := 0
+	retries, err := withScopusRetry(context.Background(), policy, func() error {
+		calls++
+		if calls < 3 {
+			return &ScopusAPIError{StatusCode: 502}
+		}
+		return nil
+	})
+	if err != nil || calls != 3 || retries != 2 {
+		t.Fatalf("expected success after 2 retries, got calls=%d retries=%d err=%v", calls, retries, err)
+	}
+}
+
+func TestWithScopusRetry_HonoursRetryAfterAndCancellation(t *testing.T) {
+	policy := scopusRetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	calls := 0
+	_, err := withScopusRetry(ctx, policy, func() error {
+		calls++
+		return &ScopusAPIError{StatusCode: 429, RetryAfter: time.Minute}
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the context error while waiting for Retry-After, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected no retry before Retry-After elapsed, got %d calls", calls)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Fatalf("cancellation should interrupt the wait, took %s", elapsed)
+	}
+}
+
+func TestScopusRetryBackoffGrowsWithJitter(t *testing.T) {
+	policy := scopusRetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for retry, upper := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 400 * time.Millisecond, 6: time.Second} {
+		for i := 0; i < 20; i++ {
+			got := policy.backoff(retry)
+			if got < upper/2 || got > upper {
+				t.Fatalf("retry %d: expected a delay in [%s, %s], got %s", retry, upper/2, upper, got)
+			}
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	for value, want := range map[string]time.Duration{
+		"":                              0,
+		"7":                             7 * time.Second,
+		"-3":                            0,
+		"Thu, 15 Oct 2026 12:00:30 GMT": 30 * time.Second,
+		"Thu, 15 Oct 2026 11:00:00 GMT": 0,
+		"soon":                          0,
+	} {
+		if got := parseRetryAfter(value, now); got != want {
+			t.Fatalf("parseRetryAfter(%q): expected %s, got %s", value, want, got)
+		}
+	}
+}
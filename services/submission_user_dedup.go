@@ -0,0 +1,111 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"fund-management-api/models"
+
+	"gorm.io/gorm"
+)
+
+// SubmissionUserDedupSummary reports what DedupeSubmissionUsers found and,
+// unless it was a dry run, changed.
+type SubmissionUserDedupSummary struct {
+	DuplicateGroups    int   `json:"duplicate_groups"`
+	RowsDeleted        int   `json:"rows_deleted"`
+	DeletedIDs         []int `json:"deleted_ids,omitempty"`
+	ApplicantsInserted int   `json:"applicants_inserted"`
+	DryRun             bool  `json:"dry_run"`
+}
+
+// submissionUserDuplicateIDs returns the rows to delete so each
+// (submission_id, user_id) pair keeps one row: the one with the lowest
+// display_order, then the lowest id.
+func submissionUserDuplicateIDs(rows []models.SubmissionUser) (groups int, ids []int) {
+	type key struct{ submissionID, userID int }
+	byPair := make(map[key][]models.SubmissionUser)
+	for _, row := range rows {
+		k := key{row.SubmissionID, row.UserID}
+		byPair[k] = append(byPair[k], row)
+	}
+
+	for _, group := range byPair {
+		if len(group) < 2 {
+			continue
+		}
+		groups++
+		sort.Slice(group, func(i, j int) bool {
+			if group[i].DisplayOrder != group[j].DisplayOrder {
+				return group[i].DisplayOrder < group[j].DisplayOrder
+			}
+			return group[i].ID < group[j].ID
+		})
+		for _, row := range group[1:] {
+			ids = append(ids, row.ID)
+		}
+	}
+	sort.Ints(ids)
+	return groups, ids
+}
+
+// DedupeSubmissionUsers is the one-time cleanup that precedes the
+// (submission_id, user_id) unique key: it deletes duplicate submission_users
+// rows and adds the missing owner row for submissions whose applicant is not
+// listed. With dryRun it only counts.
+func DedupeSubmissionUsers(db *gorm.DB, dryRun bool) (*SubmissionUserDedupSummary, error) {
+	summary := &SubmissionUserDedupSummary{DryRun: dryRun}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var rows []models.SubmissionUser
+		if err := tx.Select("id", "submission_id", "user_id", "display_order").
+			Where("(submission_id, user_id) IN (?)",
+				tx.Model(&models.SubmissionUser{}).
+					Select("submission_id, user_id").
+					Group("submission_id, user_id").
+					Having("COUNT(*) > 1")).
+			Find(&rows).Error; err != nil {
+			return err
+		}
+		summary.DuplicateGroups, summary.DeletedIDs = submissionUserDuplicateIDs(rows)
+		summary.RowsDeleted = len(summary.DeletedIDs)
+
+		var missing []models.Submission
+		if err := tx.Select("submission_id", "user_id").
+			Where("deleted_at IS NULL AND user_id > 0").
+			Where("NOT EXISTS (SELECT 1 FROM submission_users su WHERE su.submission_id = submissions.submission_id AND su.user_id = submissions.user_id)").
+			Find(&missing).Error; err != nil {
+			return err
+		}
+		summary.ApplicantsInserted = len(missing)
+
+		if dryRun {
+			return nil
+		}
+
+		if len(summary.DeletedIDs) > 0 {
+			if err := tx.Where("id IN ?", summary.DeletedIDs).Delete(&models.SubmissionUser{}).Error; err != nil {
+				return err
+			}
+		}
+		now := time.Now()
+		for _, submission := range missing {
+			owner := models.SubmissionUser{
+				SubmissionID: submission.SubmissionID,
+				UserID:       submission.UserID,
+				Role:         "owner",
+				IsPrimary:    true,
+				DisplayOrder: 1,
+				CreatedAt:    now,
+			}
+			if err := tx.Create(&owner).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
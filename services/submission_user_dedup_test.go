@@ -0,0 +1,35 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"fund-management-api/models"
+)
+
+func TestSubmissionUserDuplicateIDsKeepsLowestDisplayOrder(t *testing.T) {
+	groups, ids := submissionUserDuplicateIDs([]models.SubmissionUser{
+		{ID: 10, SubmissionID: 1, UserID: 5, DisplayOrder: 3},
+		{ID: 11, SubmissionID: 1, UserID: 5, DisplayOrder: 1},
+		{ID: 12, SubmissionID: 1, UserID: 5, DisplayOrder: 1},
+		{ID: 20, SubmissionID: 2, UserID: 5, DisplayOrder: 2},
+		{ID: 30, SubmissionID: 2, UserID: 6, DisplayOrder: 4},
+		{ID: 31, SubmissionID: 2, UserID: 6, DisplayOrder: 2},
+	})
+	if groups != 2 {
+		t.Fatalf("expected 2 duplicate groups, got %d", groups)
+	}
+	if want := []int{10, 12, 30}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("expected to delete %v, got %v", want, ids)
+	}
+}
+
+func TestSubmissionUserDuplicateIDsWithoutDuplicates(t *testing.T) {
+	groups, ids := submissionUserDuplicateIDs([]models.SubmissionUser{
+		{ID: 1, SubmissionID: 1, UserID: 1},
+		{ID: 2, SubmissionID: 1, UserID: 2},
+	})
+	if groups != 0 || len(ids) != 0 {
+		t.Fatalf("expected nothing to delete, got %d groups %v", groups, ids)
+	}
+}
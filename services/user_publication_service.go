@@ -3,7 +3,9 @@ package services
 import (
 	"crypto/sha1"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
@@ -77,14 +79,138 @@ func makeFingerprint(title string, year *uint16) string {
 	return hex.EncodeToString(h.Sum(nil))
 }
 
-// Upsert by (user_id, doi) first; fallback to (user_id, fingerprint).
+// PublicationUpsertOutcome says how UpsertWithOutcome stored a publication.
+type PublicationUpsertOutcome string
+
+const (
+	PublicationCreated PublicationUpsertOutcome = "created"
+	PublicationUpdated PublicationUpsertOutcome = "updated"
+	// PublicationMerged means the record matched a publication from another
+	// source, or one keyed differently (DOI formatting, title punctuation),
+	// and was merged into it instead of being inserted as a duplicate.
+	PublicationMerged PublicationUpsertOutcome = "merged"
+	// PublicationUnchanged means the source sent the stored record again with
+	// the same content hash, so nothing was written.
+	PublicationUnchanged PublicationUpsertOutcome = "unchanged"
+)
+
+// Upsert by normalized DOI first; fallback to fingerprint, then to the
+// normalized title and year for records without a DOI.
 // Returns (created?, record, error).
 func (s *PublicationService) Upsert(pub *models.UserPublication) (bool, models.UserPublication, error) {
+	outcome, record, err := s.UpsertWithOutcome(pub)
+	return outcome == PublicationCreated, record, err
+}
+
+// UpsertWithOutcome is Upsert reporting whether the record was created,
+// updated in place, merged into a publication it duplicates, or left alone
+// because it is unchanged.
+func (s *PublicationService) UpsertWithOutcome(pub *models.UserPublication) (PublicationUpsertOutcome, models.UserPublication, error) {
 	var empty models.UserPublication
 	if pub == nil {
-		return false, empty, errors.New("publication is nil")
+		return "", empty, errors.New("publication is nil")
+	}
+
+	match, err := s.prepareAndMatch(pub)
+	if err != nil {
+		return "", empty, err
 	}
 
+	// 4) Update (match found)
+	if match != nil {
+		existing := *match
+		if unchangedSourceRecord(&existing, pub) {
+			return PublicationUnchanged, existing, nil
+		}
+		outcome := PublicationUpdated
+		if !sameSourceRecord(&existing, pub) {
+			outcome = PublicationMerged
+		}
+
+		updates := map[string]interface{}{
+			"title":            pub.Title,
+			"authors":          pub.Authors,
+			"journal":          pub.Journal,
+			"publication_type": pub.PublicationType,
+			"publication_date": pub.PublicationDate,
+			"publication_year": pub.PublicationYear,
+			"doi":              pub.DOI, // if DOI appears later, save it
+			"doi_normalized":   pub.DOINormalized,
+			"url":              pub.URL,
+			"source":           pub.Source,
+			"external_ids":     mergeExternalIDs(existing.ExternalIDs, pub.ExternalIDs),
+			"fingerprint":      pub.Fingerprint, // keep current fingerprint
+			"content_hash":     pub.ContentHash,
+			"is_verified":      pub.IsVerified,
+			// Optional new fields (safe even if columns don't exist—remove if not added):
+			"cited_by":         pub.CitedBy,
+			"cited_by_url":     pub.CitedByURL,
+			"citation_history": pub.CitationHistory,
+			"updated_at":       time.Now(),
+		}
+		if outcome == PublicationMerged {
+			// Keep what the other source knew when this one has nothing, and
+			// keep the stored DOI and fingerprint as the record's keys.
+			for column, value := range updates {
+				if isNilValue(value) {
+					delete(updates, column)
+				}
+			}
+			if existing.DOI != nil && *existing.DOI != "" {
+				delete(updates, "doi")
+				delete(updates, "doi_normalized")
+			}
+			if existing.Fingerprint != nil && *existing.Fingerprint != "" {
+				delete(updates, "fingerprint")
+			}
+			if existing.Source != nil {
+				delete(updates, "source")
+			}
+		}
+
+		if err := s.db.Model(&existing).Updates(updates).Error; err != nil {
+			return "", empty, err
+		}
+		// Return the fresh record
+		if err := s.db.First(&existing, existing.ID).Error; err != nil {
+			return "", empty, err
+		}
+		return outcome, existing, nil
+	}
+
+	// 5) Create new
+	if err := s.db.Create(pub).Error; err != nil {
+		return "", empty, err
+	}
+	return PublicationCreated, *pub, nil
+}
+
+// PreviewUpsert reports what UpsertWithOutcome would do with pub without
+// writing anything, for dry runs.
+func (s *PublicationService) PreviewUpsert(pub *models.UserPublication) (PublicationUpsertOutcome, error) {
+	if pub == nil {
+		return "", errors.New("publication is nil")
+	}
+	match, err := s.prepareAndMatch(pub)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case match == nil:
+		return PublicationCreated, nil
+	case unchangedSourceRecord(match, pub):
+		return PublicationUnchanged, nil
+	case sameSourceRecord(match, pub):
+		return PublicationUpdated, nil
+	default:
+		return PublicationMerged, nil
+	}
+}
+
+// prepareAndMatch fills pub's derived fields (year, normalized DOI,
+// fingerprint, Scopus link) and returns the stored publication it duplicates,
+// or nil when it is new.
+func (s *PublicationService) prepareAndMatch(pub *models.UserPublication) (*models.UserPublication, error) {
 	// Derive year from date if needed
 	if pub.PublicationYear == nil && pub.PublicationDate != nil {
 		yy := uint16(pub.PublicationDate.Year())
@@ -96,6 +222,7 @@ func (s *PublicationService) Upsert(pub *models.UserPublication) (bool, models.U
 		d := strings.TrimSpace(*pub.DOI)
 		pub.DOI = &d
 	}
+	pub.DOINormalized = normalizedDOIPtr(pub.DOI)
 
 	// Ensure we have a fingerprint if none provided
 	if (pub.Fingerprint == nil || *pub.Fingerprint == "") && pub.Title != "" {
@@ -103,68 +230,106 @@ func (s *PublicationService) Upsert(pub *models.UserPublication) (bool, models.U
 		pub.Fingerprint = &fp
 	}
 
+	// Record the Scopus document the publication duplicates, if any.
+	if pub.DOINormalized != nil {
+		var eids []string
+		if err := s.db.Model(&models.ScopusDocument{}).
+			Where("doi_normalized = ?", *pub.DOINormalized).
+			Order("id ASC").Limit(1).
+			Pluck("eid", &eids).Error; err != nil {
+			return nil, err
+		}
+		if len(eids) > 0 {
+			link, _ := json.Marshal(map[string]string{"scopus_eid": eids[0]})
+			pub.ExternalIDs = mergeExternalIDs(pub.ExternalIDs, stringPtr(string(link)))
+		}
+	}
+
 	var existing models.UserPublication
-	var found bool
 
 	// 1) Prefer DOI
-	if pub.DOI != nil && *pub.DOI != "" {
-		if err := s.db.Where("user_id = ? AND doi = ? AND deleted_at IS NULL",
-			pub.UserID, *pub.DOI).
+	if pub.DOINormalized != nil {
+		if err := s.db.Where("user_id = ? AND doi_normalized = ? AND deleted_at IS NULL",
+			pub.UserID, *pub.DOINormalized).
 			First(&existing).Error; err == nil {
-			found = true
+			return &existing, nil
 		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
-			return false, empty, err
+			return nil, err
 		}
 	}
 
 	// 2) Fallback fingerprint
-	if !found && pub.Fingerprint != nil && *pub.Fingerprint != "" {
+	if pub.Fingerprint != nil && *pub.Fingerprint != "" {
 		if err := s.db.Where("user_id = ? AND fingerprint = ? AND deleted_at IS NULL",
 			pub.UserID, *pub.Fingerprint).
 			First(&existing).Error; err == nil {
-			found = true
+			return &existing, nil
 		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
-			return false, empty, err
+			return nil, err
 		}
 	}
 
-	// 3) Update (match found)
-	if found {
-		updates := map[string]interface{}{
-			"title":            pub.Title,
-			"authors":          pub.Authors,
-			"journal":          pub.Journal,
-			"publication_type": pub.PublicationType,
-			"publication_date": pub.PublicationDate,
-			"publication_year": pub.PublicationYear,
-			"doi":              pub.DOI, // if DOI appears later, save it
-			"url":              pub.URL,
-			"source":           pub.Source,
-			"external_ids":     pub.ExternalIDs,
-			"fingerprint":      pub.Fingerprint, // keep current fingerprint
-			"is_verified":      pub.IsVerified,
-			// Optional new fields (safe even if columns don't exist—remove if not added):
-			"cited_by":         pub.CitedBy,
-			"cited_by_url":     pub.CitedByURL,
-			"citation_history": pub.CitationHistory,
-			"updated_at":       time.Now(),
-		}
+	// 3) Fallback normalized title + year, for records without a DOI
+	if pub.DOINormalized == nil {
+		return s.findByTitleYear(pub)
+	}
+	return nil, nil
+}
 
-		if err := s.db.Model(&existing).Updates(updates).Error; err != nil {
-			return false, empty, err
-		}
-		// Return the fresh record
-		if err := s.db.First(&existing, existing.ID).Error; err != nil {
-			return false, empty, err
+// findByTitleYear looks for one of the user's publications with the same
+// normalized title and publication year.
+func (s *PublicationService) findByTitleYear(pub *models.UserPublication) (*models.UserPublication, error) {
+	key := publicationTitleKey(pub.Title)
+	if key == "" {
+		return nil, nil
+	}
+
+	q := s.db.Where("user_id = ? AND deleted_at IS NULL", pub.UserID)
+	if pub.PublicationYear != nil {
+		q = q.Where("publication_year = ?", *pub.PublicationYear)
+	} else {
+		q = q.Where("publication_year IS NULL")
+	}
+
+	var candidates []models.UserPublication
+	if err := q.Order("id ASC").Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+	for i := range candidates {
+		if publicationTitleKey(candidates[i].Title) == key {
+			return &candidates[i], nil
 		}
-		return false, existing, nil
 	}
+	return nil, nil
+}
 
-	// 4) Create new
-	if err := s.db.Create(pub).Error; err != nil {
-		return false, empty, err
+// sameSourceRecord reports whether existing is the incoming record itself: the
+// same source keyed by the same DOI or fingerprint.
+func sameSourceRecord(existing, incoming *models.UserPublication) bool {
+	if stringOrEmpty(existing.Source) != stringOrEmpty(incoming.Source) {
+		return false
+	}
+	if existing.DOI != nil && incoming.DOI != nil && *existing.DOI != "" && *existing.DOI == *incoming.DOI {
+		return true
+	}
+	return existing.Fingerprint != nil && incoming.Fingerprint != nil && *existing.Fingerprint == *incoming.Fingerprint
+}
+
+// unchangedSourceRecord reports whether incoming is the stored record sent
+// again by its source with the same content hash.
+func unchangedSourceRecord(existing, incoming *models.UserPublication) bool {
+	if existing.ContentHash == nil || incoming.ContentHash == nil || *existing.ContentHash == "" {
+		return false
+	}
+	return *existing.ContentHash == *incoming.ContentHash && sameSourceRecord(existing, incoming)
+}
+
+func isNilValue(value interface{}) bool {
+	if value == nil {
+		return true
 	}
-	return true, *pub, nil
+	rv := reflect.ValueOf(value)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
 }
 
 func (s *PublicationService) SoftDelete(id uint, userID uint) error {
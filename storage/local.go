@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Local stores objects as files under Root. Keys are paths relative to Root;
+// paths recorded before keys existed (absolute, or already starting with
+// Root) are accepted as they are.
+type Local struct {
+	Root string
+}
+
+// NewLocal returns a local-disk backend rooted at root.
+func NewLocal(root string) *Local {
+	return &Local{Root: root}
+}
+
+// LocalPath maps key to its file path.
+func (l *Local) LocalPath(key string) (string, error) {
+	trimmed := strings.TrimSpace(key)
+	if trimmed == "" {
+		return "", fmt.Errorf("storage: empty key")
+	}
+	cleaned := filepath.Clean(filepath.FromSlash(strings.ReplaceAll(trimmed, "\\", "/")))
+	if filepath.IsAbs(cleaned) || filepath.VolumeName(cleaned) != "" {
+		return cleaned, nil
+	}
+
+	root := filepath.Clean(l.Root)
+	if root != "." && (cleaned == root || strings.HasPrefix(cleaned, root+string(filepath.Separator))) {
+		return cleaned, nil
+	}
+	if cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("storage: key %q escapes the storage root", key)
+	}
+	return filepath.Join(root, cleaned), nil
+}
+
+func (l *Local) Save(_ context.Context, key string, r io.Reader, _ int64, _ string) error {
+	path, err := l.LocalPath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, r); err != nil {
+		out.Close()
+		os.Remove(path)
+		return err
+	}
+	return out.Close()
+}
+
+func (l *Local) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := l.LocalPath(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotExist
+	}
+	return f, err
+}
+
+func (l *Local) Delete(_ context.Context, key string) error {
+	path, err := l.LocalPath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (l *Local) Stat(_ context.Context, key string) (ObjectInfo, error) {
+	path, err := l.LocalPath(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && info.IsDir()) {
+		return ObjectInfo{}, ErrNotExist
+	}
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	return ObjectInfo{
+		Key:         key,
+		Size:        info.Size(),
+		ModTime:     info.ModTime(),
+		ContentType: mime.TypeByExtension(filepath.Ext(path)),
+	}, nil
+}
+
+// URL returns "": local files are served through the API.
+func (l *Local) URL(context.Context, string, time.Duration) (string, error) {
+	return "", nil
+}
+
+// Rename moves the file at from to to, creating to's directory.
+func (l *Local) Rename(from, to string) error {
+	src, err := l.LocalPath(from)
+	if err != nil {
+		return err
+	}
+	dst, err := l.LocalPath(to)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	return os.Rename(src, dst)
+}
@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalPath_AcceptsKeysAndLegacyPaths(t *testing.T) {
+	l := NewLocal("uploads")
+	for key, want := range map[string]string{
+		"users/7/a.pdf":          filepath.Join("uploads", "users", "7", "a.pdf"),
+		"uploads/users/7/a.pdf":  filepath.Join("uploads", "users", "7", "a.pdf"),
+		"./uploads/temp/b.pdf":   filepath.Join("uploads", "temp", "b.pdf"),
+		"/var/data/legacy/c.pdf": filepath.Join("/var/data/legacy", "c.pdf"),
+	} {
+		got, err := l.LocalPath(key)
+		if err != nil || got != want {
+			t.Fatalf("LocalPath(%q): expected %s, got %s (%v)", key, want, got, err)
+		}
+	}
+
+	for _, key := range []string{"", "../secret", "users/../../secret"} {
+		if _, err := l.LocalPath(key); err == nil {
+			t.Fatalf("LocalPath(%q): expected an error", key)
+		}
+	}
+}
+
+func TestLocal_SaveStatMoveDelete(t *testing.T) {
+	root := t.TempDir()
+	l := NewLocal(root)
+	ctx := context.Background()
+
+	if err := l.Save(ctx, "users/7/temp/a.pdf", strings.NewReader("hello"), 5, "application/pdf"); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	info, err := l.Stat(ctx, "users/7/temp/a.pdf")
+	if err != nil || info.Size != 5 {
+		t.Fatalf("Stat: expected size 5, got %+v (%v)", info, err)
+	}
+
+	if err := Move(ctx, l, "users/7/temp/a.pdf", "users/7/submissions/b.pdf"); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "users", "7", "submissions", "b.pdf")); err != nil {
+		t.Fatalf("expected the moved file on disk: %v", err)
+	}
+	if _, err := l.Open(ctx, "users/7/temp/a.pdf"); !errors.Is(err, ErrNotExist) {
+		t.Fatalf("expected ErrNotExist for the old key, got %v", err)
+	}
+
+	r, err := l.Open(ctx, "users/7/submissions/b.pdf")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	body, _ := io.ReadAll(r)
+	r.Close()
+	if string(body) != "hello" {
+		t.Fatalf("unexpected content %q", body)
+	}
+
+	if err := l.Delete(ctx, "users/7/submissions/b.pdf"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := l.Delete(ctx, "users/7/submissions/b.pdf"); err != nil {
+		t.Fatalf("Delete of a missing key should succeed, got %v", err)
+	}
+}
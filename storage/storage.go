@@ -0,0 +1,137 @@
+// Package storage abstracts where uploaded files live, so API pods can share
+// files through an object store instead of a common disk.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNotExist is returned by Open and Stat for keys that have no object.
+var ErrNotExist = errors.New("storage: object does not exist")
+
+// ObjectInfo describes a stored object.
+type ObjectInfo struct {
+	Key         string
+	Size        int64
+	ModTime     time.Time
+	ContentType string
+}
+
+// Storage stores files under backend-relative keys.
+type Storage interface {
+	// Save writes r to key, replacing any existing object.
+	Save(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Open returns the object's content; it returns ErrNotExist when missing.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes key; deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+	// Stat describes key; it returns ErrNotExist when missing.
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+	// URL returns a time-limited URL clients can fetch the object from
+	// directly, or "" when the backend has no such URL.
+	URL(ctx context.Context, key string, expires time.Duration) (string, error)
+}
+
+// LocalPather is implemented by backends whose objects are files on this
+// host, so callers can hand paths to tools that need a file.
+type LocalPather interface {
+	LocalPath(key string) (string, error)
+}
+
+// Values of STORAGE_BACKEND.
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+var (
+	defaultStorage Storage
+	defaultErr     error
+	defaultOnce    sync.Once
+)
+
+// Init selects the process-wide backend from STORAGE_BACKEND (local, the
+// default, or s3). Call it at startup so a bad S3 configuration fails fast.
+func Init() error {
+	defaultOnce.Do(func() {
+		defaultStorage, defaultErr = newFromEnv()
+	})
+	return defaultErr
+}
+
+// Default returns the backend chosen by Init, initializing it on first use.
+// If the configured backend cannot be built it falls back to local disk.
+func Default() Storage {
+	if err := Init(); err != nil {
+		return NewLocal(uploadRoot())
+	}
+	return defaultStorage
+}
+
+// IsLocal reports whether s keeps objects on this host's disk.
+func IsLocal(s Storage) bool {
+	_, ok := s.(LocalPather)
+	return ok
+}
+
+func newFromEnv() (Storage, error) {
+	switch backend := strings.ToLower(strings.TrimSpace(os.Getenv("STORAGE_BACKEND"))); backend {
+	case "", BackendLocal:
+		return NewLocal(uploadRoot()), nil
+	case BackendS3:
+		cfg, err := S3ConfigFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		return NewS3(cfg)
+	default:
+		return nil, fmt.Errorf("storage: unknown STORAGE_BACKEND %q", backend)
+	}
+}
+
+func uploadRoot() string {
+	if root := strings.TrimSpace(os.Getenv("UPLOAD_PATH")); root != "" {
+		return root
+	}
+	return "./uploads"
+}
+
+// Move renames from to to. Local files are renamed in place; other backends
+// copy the object and delete the original.
+func Move(ctx context.Context, s Storage, from, to string) error {
+	if local, ok := s.(*Local); ok {
+		return local.Rename(from, to)
+	}
+
+	info, err := s.Stat(ctx, from)
+	if err != nil {
+		return err
+	}
+	r, err := s.Open(ctx, from)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	if err := s.Save(ctx, to, r, info.Size, info.ContentType); err != nil {
+		return err
+	}
+	return s.Delete(ctx, from)
+}
+
+// Exists reports whether key has an object.
+func Exists(ctx context.Context, s Storage, key string) (bool, error) {
+	if _, err := s.Stat(ctx, key); err != nil {
+		if errors.Is(err, ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
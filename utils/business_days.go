@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"fund-management-api/config"
+	"fund-management-api/models"
+)
+
+const holidayDateLayout = "2006-01-02"
+
+// holidayCacheTTL bounds how long public_holidays edits take to be picked up.
+const holidayCacheTTL = 10 * time.Minute
+
+type holidayCache struct {
+	sync.RWMutex
+	dates    map[string]bool
+	loadedAt time.Time
+}
+
+var publicHolidayCache holidayCache
+
+// PublicHolidays returns the configured public holidays keyed by YYYY-MM-DD.
+// The public_holidays table is cached; failures fall back to the last known set.
+func PublicHolidays() map[string]bool {
+	publicHolidayCache.RLock()
+	if publicHolidayCache.dates != nil && time.Since(publicHolidayCache.loadedAt) < holidayCacheTTL {
+		dates := publicHolidayCache.dates
+		publicHolidayCache.RUnlock()
+		return dates
+	}
+	publicHolidayCache.RUnlock()
+
+	publicHolidayCache.Lock()
+	defer publicHolidayCache.Unlock()
+
+	if publicHolidayCache.dates != nil && time.Since(publicHolidayCache.loadedAt) < holidayCacheTTL {
+		return publicHolidayCache.dates
+	}
+
+	var holidays []models.PublicHoliday
+	if config.DB == nil {
+		return publicHolidayCache.dates
+	}
+	if err := config.DB.Where("deleted_at IS NULL").Find(&holidays).Error; err != nil {
+		log.Printf("[business_days] load public holidays: %v", err)
+		publicHolidayCache.loadedAt = time.Now()
+		if publicHolidayCache.dates == nil {
+			publicHolidayCache.dates = map[string]bool{}
+		}
+		return publicHolidayCache.dates
+	}
+
+	dates := make(map[string]bool, len(holidays))
+	for _, holiday := range holidays {
+		dates[holiday.HolidayDate.Format(holidayDateLayout)] = true
+	}
+	publicHolidayCache.dates = dates
+	publicHolidayCache.loadedAt = time.Now()
+	return dates
+}
+
+// ResetPublicHolidayCache forces the next PublicHolidays call to reload the table.
+func ResetPublicHolidayCache() {
+	publicHolidayCache.Lock()
+	publicHolidayCache.dates = nil
+	publicHolidayCache.Unlock()
+}
+
+// BusinessDaysBetween counts working days (Mon–Fri, excluding configured public
+// holidays) after start's date up to and including end's date. The result is
+// negative when end falls before start.
+func BusinessDaysBetween(start, end time.Time) int {
+	return CountBusinessDays(start, end, PublicHolidays())
+}
+
+// CountBusinessDays is BusinessDaysBetween with an explicit holiday set.
+func CountBusinessDays(start, end time.Time, holidays map[string]bool) int {
+	end = end.In(start.Location())
+	startDay := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	endDay := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, start.Location())
+
+	sign := 1
+	if endDay.Before(startDay) {
+		startDay, endDay = endDay, startDay
+		sign = -1
+	}
+
+	count := 0
+	for day := startDay.AddDate(0, 0, 1); !day.After(endDay); day = day.AddDate(0, 0, 1) {
+		if isBusinessDay(day, holidays) {
+			count++
+		}
+	}
+	return sign * count
+}
+
+func isBusinessDay(day time.Time, holidays map[string]bool) bool {
+	switch day.Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+	return !holidays[day.Format(holidayDateLayout)]
+}
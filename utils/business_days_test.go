@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCountBusinessDays(t *testing.T) {
+	bangkok := time.FixedZone("ICT", 7*60*60)
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2026, month, d, 9, 30, 0, 0, bangkok)
+	}
+	// 2026-10-23 (Chulalongkorn Day) is a Friday; 2026-12-31 is a Thursday.
+	holidays := map[string]bool{"2026-10-23": true, "2026-12-31": true}
+
+	for _, tc := range []struct {
+		name       string
+		start, end time.Time
+		want       int
+	}{
+		{"same day", day(time.October, 12), day(time.October, 12), 0},
+		{"next weekday", day(time.October, 12), day(time.October, 13), 1},
+		{"monday to friday", day(time.October, 12), day(time.October, 16), 4},
+		{"friday to saturday", day(time.October, 16), day(time.October, 17), 0},
+		{"friday to monday skips the weekend", day(time.October, 16), day(time.October, 19), 1},
+		{"saturday to sunday", day(time.October, 17), day(time.October, 18), 0},
+		{"sunday to monday", day(time.October, 18), day(time.October, 19), 1},
+		{"holiday at the end is not counted", day(time.October, 22), day(time.October, 23), 0},
+		{"holiday friday then weekend", day(time.October, 22), day(time.October, 26), 1},
+		{"start on a holiday", day(time.October, 23), day(time.October, 26), 1},
+		{"two full weeks with one holiday", day(time.October, 16), day(time.October, 30), 9},
+		{"across the year end holiday", day(time.December, 30), day(time.December, 31).AddDate(0, 0, 1), 1},
+		{"end before start is negative", day(time.October, 19), day(time.October, 16), -1},
+	} {
+		if got := CountBusinessDays(tc.start, tc.end, holidays); got != tc.want {
+			t.Errorf("%s: CountBusinessDays(%s, %s) = %d, want %d",
+				tc.name, tc.start.Format(holidayDateLayout), tc.end.Format(holidayDateLayout), got, tc.want)
+		}
+	}
+}
+
+func TestCountBusinessDays_UsesStartLocationForDates(t *testing.T) {
+	bangkok := time.FixedZone("ICT", 7*60*60)
+	// 2026-10-16 20:00 UTC is already Saturday in Bangkok.
+	start := time.Date(2026, time.October, 16, 8, 0, 0, 0, bangkok)
+	end := time.Date(2026, time.October, 16, 20, 0, 0, 0, time.UTC)
+	if got := CountBusinessDays(start, end, nil); got != 0 {
+		t.Fatalf("expected Friday to Saturday (Bangkok) to count 0 days, got %d", got)
+	}
+}
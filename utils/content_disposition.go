@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// ContentDisposition builds a Content-Disposition header value for filename.
+// Browsers that understand RFC 5987 use the UTF-8 filename* parameter, so
+// Thai names survive; older clients fall back to an ASCII-only filename.
+func ContentDisposition(dispositionType, filename string) string {
+	return fmt.Sprintf(`%s; filename="%s"; filename*=UTF-8''%s`,
+		dispositionType, asciiFilenameFallback(filename), encodeRFC5987(filename))
+}
+
+// asciiFilenameFallback replaces characters that cannot appear in a quoted
+// ASCII filename parameter; a name left with nothing readable becomes
+// "download" with its extension.
+func asciiFilenameFallback(filename string) string {
+	var b strings.Builder
+	for _, r := range filename {
+		if r < 0x20 || r > 0x7e || r == '"' || r == '\\' {
+			b.WriteByte('_')
+			continue
+		}
+		b.WriteRune(r)
+	}
+	fallback := b.String()
+	ext := filepath.Ext(fallback)
+	if base := strings.TrimSuffix(fallback, ext); strings.Trim(base, "_. ") == "" {
+		return "download" + ext
+	}
+	return fallback
+}
+
+// encodeRFC5987 percent-encodes value as an RFC 5987 ext-value, keeping only
+// attr-char unescaped.
+func encodeRFC5987(value string) string {
+	const hex = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9',
+			strings.IndexByte("!#$&+-.^_`|~", c) >= 0:
+			b.WriteByte(c)
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hex[c>>4])
+			b.WriteByte(hex[c&0x0f])
+		}
+	}
+	return b.String()
+}
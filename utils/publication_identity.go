@@ -0,0 +1,70 @@
+// utils/publication_identity.go - Normalised identifiers for matching publications
+package utils
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// doiPattern matches a normalised DOI: the "10." directory indicator, a numeric
+// registrant code and a non-empty suffix without whitespace.
+var doiPattern = regexp.MustCompile(`^10\.\d{4,9}(\.\d+)*/\S+$`)
+
+var doiPrefixes = []string{
+	"https://doi.org/",
+	"http://doi.org/",
+	"https://dx.doi.org/",
+	"http://dx.doi.org/",
+	"doi.org/",
+	"doi:",
+}
+
+// NormalizeDOI lower-cases a DOI and strips resolver URLs and the "doi:" prefix
+// so the same DOI typed in different forms compares equal.
+func NormalizeDOI(raw string) string {
+	doi := strings.ToLower(strings.TrimSpace(raw))
+	for _, prefix := range doiPrefixes {
+		if strings.HasPrefix(doi, prefix) {
+			doi = strings.TrimSpace(strings.TrimPrefix(doi, prefix))
+			break
+		}
+	}
+	return strings.TrimRight(doi, ". ")
+}
+
+// IsValidDOI reports whether an already normalised DOI has the 10.xxxx/suffix form.
+func IsValidDOI(doi string) bool {
+	return doiPattern.MatchString(doi)
+}
+
+// NormalizeISSN reduces an ISSN to the canonical "1234-567X" form, or returns ""
+// when it does not hold exactly eight digits (the last may be X).
+func NormalizeISSN(raw string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(raw) {
+		if unicode.IsDigit(r) || r == 'X' {
+			b.WriteRune(r)
+		}
+	}
+	compact := b.String()
+	if len(compact) != 8 || strings.ContainsRune(compact[:7], 'X') {
+		return ""
+	}
+	return compact[:4] + "-" + compact[4:]
+}
+
+// NormalizePublicationTitle lower-cases a title, drops punctuation and collapses
+// whitespace, keeping letters (including Thai) and digits.
+func NormalizePublicationTitle(raw string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(raw) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), unicode.Is(unicode.Mn, r):
+			b.WriteRune(r)
+		default:
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
@@ -0,0 +1,52 @@
+package utils
+
+import "testing"
+
+func TestNormalizeDOI(t *testing.T) {
+	for _, tc := range []struct {
+		name, raw, want string
+	}{
+		{"bare", "10.1000/xyz123", "10.1000/xyz123"},
+		{"upper case", "10.1000/ABC.Def", "10.1000/abc.def"},
+		{"surrounding spaces", "  10.1000/xyz123 ", "10.1000/xyz123"},
+		{"https resolver", "https://doi.org/10.1000/xyz123", "10.1000/xyz123"},
+		{"http resolver", "http://doi.org/10.1000/xyz123", "10.1000/xyz123"},
+		{"dx resolver", "https://dx.doi.org/10.1000/xyz123", "10.1000/xyz123"},
+		{"upper case resolver", "HTTPS://DOI.ORG/10.1000/XYZ123", "10.1000/xyz123"},
+		{"resolver without scheme", "doi.org/10.1000/xyz123", "10.1000/xyz123"},
+		{"doi prefix", "doi:10.1000/xyz123", "10.1000/xyz123"},
+		{"doi prefix with space", "DOI: 10.1000/xyz123", "10.1000/xyz123"},
+		{"trailing dot", "10.1000/xyz123.", "10.1000/xyz123"},
+		{"trailing dots and space", "https://doi.org/10.1000/xyz123. .", "10.1000/xyz123"},
+		{"inner dots kept", "10.1000/j.jcs.2020.01", "10.1000/j.jcs.2020.01"},
+		{"empty", "   ", ""},
+	} {
+		if got := NormalizeDOI(tc.raw); got != tc.want {
+			t.Errorf("%s: NormalizeDOI(%q) = %q, want %q", tc.name, tc.raw, got, tc.want)
+		}
+	}
+}
+
+func TestIsValidDOI(t *testing.T) {
+	for _, tc := range []struct {
+		doi  string
+		want bool
+	}{
+		{"10.1000/xyz123", true},
+		{"10.1016/j.jcs.2020.01.005", true},
+		{"10.1000.10/abc", true},
+		{NormalizeDOI("https://doi.org/10.1000/XYZ123."), true},
+		{NormalizeDOI("doi:10.1000/xyz123"), true},
+		{"", false},
+		{"10.1000/", false},
+		{"10.12/abc", false},
+		{"11.1000/abc", false},
+		{"10.1000/has space", false},
+		{"https://doi.org/10.1000/xyz123", false},
+		{"doi:10.1000/xyz123", false},
+	} {
+		if got := IsValidDOI(tc.doi); got != tc.want {
+			t.Errorf("IsValidDOI(%q) = %v, want %v", tc.doi, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,533 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// Minimal QR Code encoder (ISO/IEC 18004) used to stamp verification links on
+// generated forms. It only supports byte mode for versions 1-10; EncodeQR uses
+// error-correction level M, which comfortably fits a verification URL (up to
+// 213 bytes).
+
+// ErrQRDataTooLong is returned when the payload does not fit in version 10 at
+// the requested error-correction level.
+var ErrQRDataTooLong = errors.New("qr: data too long")
+
+type qrECLevel int
+
+const (
+	qrLevelL qrECLevel = iota
+	qrLevelM
+	qrLevelQ
+	qrLevelH
+)
+
+// formatBits is the level's two-bit code in the format information.
+func (l qrECLevel) formatBits() int {
+	return [...]int{1, 0, 3, 2}[l]
+}
+
+// Per-version tables from ISO/IEC 18004 for versions 1-10, indexed by level
+// (L, M, Q, H) and then version-1.
+var (
+	qrTotalCodewords      = []int{26, 44, 70, 100, 134, 172, 196, 242, 292, 346}
+	qrECCodewordsPerBlock = [4][]int{
+		{7, 10, 15, 20, 26, 18, 20, 24, 30, 18},
+		{10, 16, 26, 18, 24, 16, 18, 22, 22, 26},
+		{13, 22, 18, 26, 18, 24, 18, 22, 20, 24},
+		{17, 28, 22, 16, 22, 28, 26, 26, 24, 28},
+	}
+	qrECBlocks = [4][]int{
+		{1, 1, 1, 1, 1, 2, 2, 2, 2, 4},
+		{1, 1, 1, 2, 2, 4, 4, 4, 5, 5},
+		{1, 1, 2, 2, 4, 4, 6, 6, 8, 8},
+		{1, 1, 2, 4, 4, 4, 5, 6, 8, 8},
+	}
+	qrAlignmentPositions = [][]int{
+		nil, {6, 18}, {6, 22}, {6, 26}, {6, 30}, {6, 34},
+		{6, 22, 38}, {6, 24, 42}, {6, 26, 46}, {6, 28, 50},
+	}
+)
+
+type qrVersionSpec struct {
+	ecPerBlock int
+	blocks     [][2]int // {block count, data codewords per block}
+	alignment  []int
+}
+
+// qrSpec splits the version's codewords into error-correction blocks; when
+// they do not divide evenly the later blocks carry one extra data codeword.
+func qrSpec(version int, level qrECLevel) qrVersionSpec {
+	total := qrTotalCodewords[version-1]
+	ecPerBlock := qrECCodewordsPerBlock[level][version-1]
+	blockCount := qrECBlocks[level][version-1]
+
+	longBlocks := total % blockCount
+	shortData := total/blockCount - ecPerBlock
+	blocks := [][2]int{{blockCount - longBlocks, shortData}}
+	if longBlocks > 0 {
+		blocks = append(blocks, [2]int{longBlocks, shortData + 1})
+	}
+	return qrVersionSpec{ecPerBlock: ecPerBlock, blocks: blocks, alignment: qrAlignmentPositions[version-1]}
+}
+
+func (s qrVersionSpec) dataCodewords() int {
+	total := 0
+	for _, group := range s.blocks {
+		total += group[0] * group[1]
+	}
+	return total
+}
+
+// EncodeQR returns the module matrix (true = dark) for data at level M,
+// indexed [y][x].
+func EncodeQR(data []byte) ([][]bool, error) {
+	return encodeQR(data, qrLevelM)
+}
+
+func encodeQR(data []byte, level qrECLevel) ([][]bool, error) {
+	version := 0
+	for v := 1; v <= len(qrTotalCodewords); v++ {
+		countBits := 8
+		if v >= 10 {
+			countBits = 16
+		}
+		if 4+countBits+len(data)*8 <= qrSpec(v, level).dataCodewords()*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, ErrQRDataTooLong
+	}
+	spec := qrSpec(version, level)
+
+	codewords := qrInterleave(spec, qrDataCodewords(data, version, spec.dataCodewords()))
+
+	q := newQRMatrix(version, spec)
+	q.drawCodewords(codewords)
+
+	bestMask, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		q.applyMask(mask)
+		q.drawFormatBits(level, mask)
+		if penalty := q.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			bestMask, bestPenalty = mask, penalty
+		}
+		q.applyMask(mask)
+	}
+	q.applyMask(bestMask)
+	q.drawFormatBits(level, bestMask)
+
+	return q.modules, nil
+}
+
+// QRCodePNG renders data as a PNG with scale pixels per module and the
+// standard four-module quiet zone.
+func QRCodePNG(data []byte, scale int) ([]byte, error) {
+	modules, err := EncodeQR(data)
+	if err != nil {
+		return nil, err
+	}
+	if scale < 1 {
+		scale = 1
+	}
+
+	const border = 4
+	size := len(modules)
+	dim := (size + border*2) * scale
+	img := image.NewGray(image.Rect(0, 0, dim, dim))
+	for i := range img.Pix {
+		img.Pix[i] = 0xFF
+	}
+	for y, row := range modules {
+		for x, dark := range row {
+			if !dark {
+				continue
+			}
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetGray((x+border)*scale+dx, (y+border)*scale+dy, color.Gray{Y: 0})
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func qrDataCodewords(data []byte, version, capacity int) []byte {
+	var bits []bool
+	appendBits := func(value, length int) {
+		for i := length - 1; i >= 0; i-- {
+			bits = append(bits, (value>>uint(i))&1 == 1)
+		}
+	}
+
+	countBits := 8
+	if version >= 10 {
+		countBits = 16
+	}
+	appendBits(0x4, 4) // byte mode
+	appendBits(len(data), countBits)
+	for _, b := range data {
+		appendBits(int(b), 8)
+	}
+
+	capacityBits := capacity * 8
+	terminator := capacityBits - len(bits)
+	if terminator > 4 {
+		terminator = 4
+	}
+	appendBits(0, terminator)
+	appendBits(0, (8-len(bits)%8)%8)
+
+	out := make([]byte, 0, capacity)
+	for i := 0; i < len(bits); i += 8 {
+		var b byte
+		for j := 0; j < 8; j++ {
+			if bits[i+j] {
+				b |= 1 << uint(7-j)
+			}
+		}
+		out = append(out, b)
+	}
+	for pad := byte(0xEC); len(out) < capacity; pad ^= 0xEC ^ 0x11 {
+		out = append(out, pad)
+	}
+	return out
+}
+
+func qrInterleave(spec qrVersionSpec, data []byte) []byte {
+	divisor := qrReedSolomonDivisor(spec.ecPerBlock)
+
+	var dataBlocks, ecBlocks [][]byte
+	offset := 0
+	for _, group := range spec.blocks {
+		for i := 0; i < group[0]; i++ {
+			block := data[offset : offset+group[1]]
+			offset += group[1]
+			dataBlocks = append(dataBlocks, block)
+			ecBlocks = append(ecBlocks, qrReedSolomonRemainder(block, divisor))
+		}
+	}
+
+	longest := 0
+	for _, block := range dataBlocks {
+		if len(block) > longest {
+			longest = len(block)
+		}
+	}
+
+	result := make([]byte, 0, len(data)+len(ecBlocks)*spec.ecPerBlock)
+	for i := 0; i < longest; i++ {
+		for _, block := range dataBlocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < spec.ecPerBlock; i++ {
+		for _, block := range ecBlocks {
+			result = append(result, block[i])
+		}
+	}
+	return result
+}
+
+func qrGFMultiply(x, y byte) byte {
+	var z int
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>uint(i))&1) * int(x)
+	}
+	return byte(z)
+}
+
+func qrReedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = qrGFMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = qrGFMultiply(root, 0x02)
+	}
+	return result
+}
+
+func qrReedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, coef := range divisor {
+			result[i] ^= qrGFMultiply(coef, factor)
+		}
+	}
+	return result
+}
+
+type qrMatrix struct {
+	size       int
+	modules    [][]bool
+	isFunction [][]bool
+}
+
+func newQRMatrix(version int, spec qrVersionSpec) *qrMatrix {
+	size := version*4 + 17
+	q := &qrMatrix{size: size}
+	q.modules = make([][]bool, size)
+	q.isFunction = make([][]bool, size)
+	for i := range q.modules {
+		q.modules[i] = make([]bool, size)
+		q.isFunction[i] = make([]bool, size)
+	}
+
+	for i := 0; i < size; i++ {
+		q.setFunction(6, i, i%2 == 0)
+		q.setFunction(i, 6, i%2 == 0)
+	}
+
+	q.drawFinder(3, 3)
+	q.drawFinder(size-4, 3)
+	q.drawFinder(3, size-4)
+
+	last := len(spec.alignment) - 1
+	for i, y := range spec.alignment {
+		for j, x := range spec.alignment {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			q.drawAlignment(x, y)
+		}
+	}
+
+	// Reserve the format areas; real bits are written once a mask is chosen.
+	q.drawFormatBits(qrLevelM, 0)
+
+	if version >= 7 {
+		rem := version
+		for i := 0; i < 12; i++ {
+			rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+		}
+		bits := version<<12 | rem
+		for i := 0; i < 18; i++ {
+			dark := (bits>>uint(i))&1 == 1
+			a, b := size-11+i%3, i/3
+			q.setFunction(a, b, dark)
+			q.setFunction(b, a, dark)
+		}
+	}
+
+	return q
+}
+
+func (q *qrMatrix) setFunction(x, y int, dark bool) {
+	q.modules[y][x] = dark
+	q.isFunction[y][x] = true
+}
+
+func (q *qrMatrix) drawFinder(cx, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := cx+dx, cy+dy
+			if x < 0 || x >= q.size || y < 0 || y >= q.size {
+				continue
+			}
+			dist := qrMaxAbs(dx, dy)
+			q.setFunction(x, y, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (q *qrMatrix) drawAlignment(cx, cy int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			q.setFunction(cx+dx, cy+dy, qrMaxAbs(dx, dy) != 1)
+		}
+	}
+}
+
+func qrMaxAbs(a, b int) int {
+	if a < 0 {
+		a = -a
+	}
+	if b < 0 {
+		b = -b
+	}
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// drawFormatBits writes both copies of the format information.
+func (q *qrMatrix) drawFormatBits(level qrECLevel, mask int) {
+	data := level.formatBits()<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+	bit := func(i int) bool { return (bits>>uint(i))&1 == 1 }
+
+	for i := 0; i <= 5; i++ {
+		q.setFunction(8, i, bit(i))
+	}
+	q.setFunction(8, 7, bit(6))
+	q.setFunction(8, 8, bit(7))
+	q.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		q.setFunction(14-i, 8, bit(i))
+	}
+
+	for i := 0; i < 8; i++ {
+		q.setFunction(q.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		q.setFunction(8, q.size-15+i, bit(i))
+	}
+	q.setFunction(8, q.size-8, true)
+}
+
+func (q *qrMatrix) drawCodewords(data []byte) {
+	i := 0
+	for right := q.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < q.size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = q.size - 1 - vert
+				}
+				if q.isFunction[y][x] || i >= len(data)*8 {
+					continue
+				}
+				q.modules[y][x] = (data[i>>3]>>uint(7-(i&7)))&1 == 1
+				i++
+			}
+		}
+	}
+}
+
+func (q *qrMatrix) applyMask(mask int) {
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if q.isFunction[y][x] {
+				continue
+			}
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert {
+				q.modules[y][x] = !q.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty scores the symbol using the four mask evaluation rules.
+func (q *qrMatrix) penalty() int {
+	size := q.size
+	result := 0
+	at := func(x, y int, horizontal bool) bool {
+		if horizontal {
+			return q.modules[y][x]
+		}
+		return q.modules[x][y]
+	}
+
+	for _, horizontal := range []bool{true, false} {
+		for y := 0; y < size; y++ {
+			run := 1
+			for x := 1; x < size; x++ {
+				if at(x, y, horizontal) == at(x-1, y, horizontal) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					result += 3 + run - 5
+				}
+				run = 1
+			}
+			if run >= 5 {
+				result += 3 + run - 5
+			}
+
+			for x := 0; x+6 < size; x++ {
+				if !(at(x, y, horizontal) && !at(x+1, y, horizontal) && at(x+2, y, horizontal) &&
+					at(x+3, y, horizontal) && at(x+4, y, horizontal) && !at(x+5, y, horizontal) && at(x+6, y, horizontal)) {
+					continue
+				}
+				lightBefore, lightAfter := true, true
+				for k := 1; k <= 4; k++ {
+					if x-k >= 0 && at(x-k, y, horizontal) {
+						lightBefore = false
+					}
+					if x+6+k < size && at(x+6+k, y, horizontal) {
+						lightAfter = false
+					}
+				}
+				if lightBefore || lightAfter {
+					result += 40
+				}
+			}
+		}
+	}
+
+	dark := 0
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			if q.modules[y][x] {
+				dark++
+			}
+			if x+1 < size && y+1 < size {
+				c := q.modules[y][x]
+				if c == q.modules[y][x+1] && c == q.modules[y+1][x] && c == q.modules[y+1][x+1] {
+					result += 3
+				}
+			}
+		}
+	}
+	total := size * size
+	deviation := dark*20 - total*10
+	if deviation < 0 {
+		deviation = -deviation
+	}
+	result += deviation / total * 10
+
+	return result
+}
@@ -0,0 +1,418 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image/png"
+	"testing"
+)
+
+// The decoder below reads symbols back using only ISO/IEC 18004, not the
+// encoder's helpers, so a mistake in the encoder cannot cancel itself out.
+
+var (
+	testQRTotalCodewords = []int{26, 44, 70, 100, 134, 172, 196, 242, 292, 346}
+	testQRDataCodewords  = map[qrECLevel][]int{
+		qrLevelL: {19, 34, 55, 80, 108, 136, 156, 194, 232, 274},
+		qrLevelM: {16, 28, 44, 64, 86, 108, 124, 154, 182, 216},
+		qrLevelQ: {13, 22, 34, 48, 62, 76, 88, 110, 132, 154},
+		qrLevelH: {9, 16, 26, 36, 46, 60, 66, 86, 100, 122},
+	}
+	testQRBlocks = map[qrECLevel][]int{
+		qrLevelL: {1, 1, 1, 1, 1, 2, 2, 2, 2, 4},
+		qrLevelM: {1, 1, 1, 2, 2, 4, 4, 4, 5, 5},
+		qrLevelQ: {1, 1, 2, 2, 4, 4, 6, 6, 8, 8},
+		qrLevelH: {1, 1, 2, 4, 4, 4, 5, 6, 8, 8},
+	}
+	testQRAlignment = [][]int{nil, {6, 18}, {6, 22}, {6, 26}, {6, 30}, {6, 34}, {6, 22, 38}, {6, 24, 42}, {6, 26, 46}, {6, 28, 50}}
+	testQRLevelBits = map[int]qrECLevel{1: qrLevelL, 0: qrLevelM, 3: qrLevelQ, 2: qrLevelH}
+	testQRLevelName = map[qrECLevel]string{qrLevelL: "L", qrLevelM: "M", qrLevelQ: "Q", qrLevelH: "H"}
+)
+
+// testQRByteCapacity is the largest byte-mode payload for the version.
+func testQRByteCapacity(version int, level qrECLevel) int {
+	countBits := 8
+	if version >= 10 {
+		countBits = 16
+	}
+	return (testQRDataCodewords[level][version-1]*8 - 4 - countBits) / 8
+}
+
+var testGFExp, testGFLog = func() ([512]byte, [256]int) {
+	var exp [512]byte
+	var log [256]int
+	x := 1
+	for i := 0; i < 255; i++ {
+		exp[i] = byte(x)
+		log[x] = i
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11D
+		}
+	}
+	for i := 255; i < 512; i++ {
+		exp[i] = exp[i-255]
+	}
+	return exp, log
+}()
+
+func testGFMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return testGFExp[testGFLog[a]+testGFLog[b]]
+}
+
+// testBCHRemainder is the remainder of value<<shift divided by poly.
+func testBCHRemainder(value, shift, poly int) int {
+	value <<= shift
+	degree := 0
+	for p := poly; p > 1; p >>= 1 {
+		degree++
+	}
+	for bit := shift + 12; bit >= degree; bit-- {
+		if value&(1<<bit) != 0 {
+			value ^= poly << (bit - degree)
+		}
+	}
+	return value
+}
+
+func testQRIsFunction(version, size, x, y int) bool {
+	switch {
+	case x <= 8 && y <= 8, x >= size-8 && y <= 8, x <= 8 && y >= size-8:
+		return true // finders, separators and format information
+	case x == 6 || y == 6:
+		return true // timing patterns
+	}
+	if version >= 7 && ((x >= size-11 && x <= size-9 && y <= 5) || (y >= size-11 && y <= size-9 && x <= 5)) {
+		return true
+	}
+	positions := testQRAlignment[version-1]
+	for _, cy := range positions {
+		for _, cx := range positions {
+			if (cx == 6 && cy == 6) || (cx == 6 && cy == positions[len(positions)-1]) || (cy == 6 && cx == positions[len(positions)-1]) {
+				continue
+			}
+			if x >= cx-2 && x <= cx+2 && y >= cy-2 && y <= cy+2 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func testQRMask(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (y+x)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (y+x)%3 == 0
+	case 4:
+		return (y/2+x/3)%2 == 0
+	case 5:
+		return (y*x)%2+(y*x)%3 == 0
+	case 6:
+		return ((y*x)%2+(y*x)%3)%2 == 0
+	default:
+		return ((y+x)%2+(y*x)%3)%2 == 0
+	}
+}
+
+// decodeTestQR reads a symbol produced by encodeQR back into its payload.
+func decodeTestQR(t *testing.T, modules [][]bool) ([]byte, qrECLevel, int) {
+	t.Helper()
+
+	size := len(modules)
+	if (size-17)%4 != 0 || size < 21 {
+		t.Fatalf("invalid symbol size %d", size)
+	}
+	version := (size - 17) / 4
+	at := func(x, y int) bool { return modules[y][x] }
+
+	for _, corner := range [][2]int{{0, 0}, {size - 7, 0}, {0, size - 7}} {
+		for dy := 0; dy < 7; dy++ {
+			for dx := 0; dx < 7; dx++ {
+				ax, ay := dx-3, dy-3
+				if ax < 0 {
+					ax = -ax
+				}
+				if ay < 0 {
+					ay = -ay
+				}
+				ring := ax
+				if ay > ring {
+					ring = ay
+				}
+				if at(corner[0]+dx, corner[1]+dy) != (ring != 2) {
+					t.Fatalf("finder pattern at %v is wrong at (%d,%d)", corner, dx, dy)
+				}
+			}
+		}
+	}
+	for i := 8; i < size-8; i++ {
+		if at(i, 6) != (i%2 == 0) || at(6, i) != (i%2 == 0) {
+			t.Fatalf("timing pattern is wrong at %d", i)
+		}
+	}
+	if !at(8, size-8) {
+		t.Fatalf("dark module missing")
+	}
+
+	var format1, format2 int
+	for i := 0; i < 15; i++ {
+		var x, y int
+		switch {
+		case i <= 5:
+			x, y = 8, i
+		case i == 6:
+			x, y = 8, 7
+		case i == 7:
+			x, y = 8, 8
+		case i == 8:
+			x, y = 7, 8
+		default:
+			x, y = 14-i, 8
+		}
+		if at(x, y) {
+			format1 |= 1 << i
+		}
+		if i < 8 {
+			x, y = size-1-i, 8
+		} else {
+			x, y = 8, size-15+i
+		}
+		if at(x, y) {
+			format2 |= 1 << i
+		}
+	}
+	if format1 != format2 {
+		t.Fatalf("format copies differ: %015b vs %015b", format1, format2)
+	}
+	format := format1 ^ 0x5412
+	data := format >> 10
+	if format&0x3FF != testBCHRemainder(data, 10, 0x537) {
+		t.Fatalf("format information %015b fails its BCH check", format1)
+	}
+	level, mask := testQRLevelBits[data>>3], data&7
+
+	if version >= 7 {
+		var info1, info2 int
+		for i := 0; i < 18; i++ {
+			if at(size-11+i%3, i/3) {
+				info1 |= 1 << i
+			}
+			if at(i/3, size-11+i%3) {
+				info2 |= 1 << i
+			}
+		}
+		if info1 != info2 || info1>>12 != version || info1&0xFFF != testBCHRemainder(version, 12, 0x1F25) {
+			t.Fatalf("version information %018b is invalid for version %d", info1, version)
+		}
+	}
+
+	var bits []bool
+	for right := size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < size; vert++ {
+			for j := 0; j < 2; j++ {
+				x, y := right-j, vert
+				if (right+1)&2 == 0 {
+					y = size - 1 - vert
+				}
+				if testQRIsFunction(version, size, x, y) {
+					continue
+				}
+				bits = append(bits, at(x, y) != testQRMask(mask, x, y))
+			}
+		}
+	}
+	total := testQRTotalCodewords[version-1]
+	if len(bits) < total*8 {
+		t.Fatalf("symbol has %d data bits, need %d", len(bits), total*8)
+	}
+	codewords := make([]byte, total)
+	for i := range codewords {
+		for j := 0; j < 8; j++ {
+			if bits[i*8+j] {
+				codewords[i] |= 1 << (7 - j)
+			}
+		}
+	}
+
+	blockCount := testQRBlocks[level][version-1]
+	dataTotal := testQRDataCodewords[level][version-1]
+	ec := (total - dataTotal) / blockCount
+	shortBlocks := blockCount - total%blockCount
+	blocks := make([][]byte, blockCount)
+	dataLen := func(b int) int {
+		if b < shortBlocks {
+			return total/blockCount - ec
+		}
+		return total/blockCount - ec + 1
+	}
+	next := 0
+	for i := 0; i <= total/blockCount-ec; i++ {
+		for b := range blocks {
+			if i < dataLen(b) {
+				blocks[b] = append(blocks[b], codewords[next])
+				next++
+			}
+		}
+	}
+	for i := 0; i < ec; i++ {
+		for b := range blocks {
+			blocks[b] = append(blocks[b], codewords[next])
+			next++
+		}
+	}
+
+	var payload []byte
+	for b, block := range blocks {
+		for k := 0; k < ec; k++ {
+			var syndrome byte
+			for n, c := range block {
+				syndrome ^= testGFMul(c, testGFExp[(k*(len(block)-1-n))%255])
+			}
+			if syndrome != 0 {
+				t.Fatalf("block %d fails Reed-Solomon syndrome %d", b, k)
+			}
+		}
+		payload = append(payload, block[:dataLen(b)]...)
+	}
+
+	pos := 0
+	read := func(n int) int {
+		v := 0
+		for i := 0; i < n; i++ {
+			v <<= 1
+			if payload[(pos+i)/8]&(1<<(7-(pos+i)%8)) != 0 {
+				v |= 1
+			}
+		}
+		pos += n
+		return v
+	}
+	if mode := read(4); mode != 0x4 {
+		t.Fatalf("expected byte mode, got %04b", mode)
+	}
+	countBits := 8
+	if version >= 10 {
+		countBits = 16
+	}
+	out := make([]byte, read(countBits))
+	for i := range out {
+		out[i] = byte(read(8))
+	}
+	for i := 0; i < 4 && pos < len(payload)*8; i++ {
+		if read(1) != 0 {
+			t.Fatalf("terminator is not zero")
+		}
+	}
+	pos = (pos + 7) / 8
+	for i, pad := 0, byte(0xEC); pos+i < len(payload); i, pad = i+1, pad^0xEC^0x11 {
+		if payload[pos+i] != pad {
+			t.Fatalf("pad codeword %d is %#x, want %#x", i, payload[pos+i], pad)
+		}
+	}
+	return out, level, version
+}
+
+func testQRPayload(n int) []byte {
+	base := []byte("https://fund.example.ac.th/verify/")
+	out := make([]byte, n)
+	for i := range out {
+		if i < len(base) {
+			out[i] = base[i]
+		} else {
+			out[i] = byte(i*37 + 11)
+		}
+	}
+	return out
+}
+
+func TestEncodeQR_DecodesAtEveryVersionAndLevel(t *testing.T) {
+	for _, level := range []qrECLevel{qrLevelL, qrLevelM, qrLevelQ, qrLevelH} {
+		for version := 1; version <= 10; version++ {
+			capacity := testQRByteCapacity(version, level)
+			lengths := []int{capacity}
+			if version == 1 {
+				lengths = append(lengths, 0, 1)
+			} else {
+				lengths = append(lengths, testQRByteCapacity(version-1, level)+1)
+			}
+			for _, n := range lengths {
+				t.Run(fmt.Sprintf("%s-%d-%dB", testQRLevelName[level], version, n), func(t *testing.T) {
+					payload := testQRPayload(n)
+					modules, err := encodeQR(payload, level)
+					if err != nil {
+						t.Fatalf("encodeQR: %v", err)
+					}
+					got, gotLevel, gotVersion := decodeTestQR(t, modules)
+					if gotVersion != version || gotLevel != level {
+						t.Fatalf("expected version %d-%s, got %d-%s", version, testQRLevelName[level], gotVersion, testQRLevelName[gotLevel])
+					}
+					if !bytes.Equal(got, payload) {
+						t.Fatalf("decoded %q, want %q", got, payload)
+					}
+				})
+			}
+		}
+
+		if _, err := encodeQR(testQRPayload(testQRByteCapacity(10, level)+1), level); !errors.Is(err, ErrQRDataTooLong) {
+			t.Fatalf("level %s: expected ErrQRDataTooLong past version 10, got %v", testQRLevelName[level], err)
+		}
+	}
+}
+
+func TestEncodeQR_UsesLevelM(t *testing.T) {
+	url := []byte("https://fund.example.ac.th/api/v1/public/verify/3f1c9a2b7d4e4f60a1b2c3d4e5f60718")
+	modules, err := EncodeQR(url)
+	if err != nil {
+		t.Fatalf("EncodeQR: %v", err)
+	}
+	got, level, _ := decodeTestQR(t, modules)
+	if level != qrLevelM || !bytes.Equal(got, url) {
+		t.Fatalf("expected %q at level M, got %q at level %s", url, got, testQRLevelName[level])
+	}
+}
+
+func TestQRCodePNG_MatchesModules(t *testing.T) {
+	data := []byte("https://fund.example.ac.th/verify/abc")
+	const scale = 3
+	content, err := QRCodePNG(data, scale)
+	if err != nil {
+		t.Fatalf("QRCodePNG: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("decode png: %v", err)
+	}
+	modules, _ := EncodeQR(data)
+	size := len(modules) + 8
+	if b := img.Bounds(); b.Dx() != size*scale || b.Dy() != size*scale {
+		t.Fatalf("expected %dx%d image, got %v", size*scale, size*scale, b)
+	}
+
+	sampled := make([][]bool, len(modules))
+	for y := range sampled {
+		sampled[y] = make([]bool, len(modules))
+		for x := range sampled[y] {
+			r, _, _, _ := img.At((x+4)*scale+scale/2, (y+4)*scale+scale/2).RGBA()
+			sampled[y][x] = r < 0x8000
+		}
+	}
+	if got, _, _ := decodeTestQR(t, sampled); !bytes.Equal(got, data) {
+		t.Fatalf("decoded %q from PNG, want %q", got, data)
+	}
+	if r, _, _, _ := img.At(0, 0).RGBA(); r < 0x8000 {
+		t.Fatalf("quiet zone is not light")
+	}
+}
@@ -43,9 +43,17 @@ package utils
 
 // ErrorResponse represents an error response
 type ErrorResponse struct {
-	Success bool   `json:"success"`
-	Error   string `json:"error"`
-	Code    string `json:"code,omitempty"`
+	Success bool         `json:"success"`
+	Error   string       `json:"error"`
+	Code    string       `json:"code,omitempty"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
+
+// FieldError describes a validation failure on a single request field
+type FieldError struct {
+	Field     string `json:"field"`
+	Message   string `json:"message"`
+	MaxLength int    `json:"max_length,omitempty"`
 }
 
 // SuccessResponse represents a success response
@@ -78,6 +86,21 @@ func NewErrorResponse(error string, code ...string) ErrorResponse {
 	return resp
 }
 
+// NewFieldErrorResponse creates an error response listing per-field failures;
+// Error carries the first message for clients that only read that key
+func NewFieldErrorResponse(code string, fields []FieldError) ErrorResponse {
+	resp := ErrorResponse{
+		Success: false,
+		Error:   "validation failed",
+		Code:    code,
+		Fields:  fields,
+	}
+	if len(fields) > 0 {
+		resp.Error = fields[0].Message
+	}
+	return resp
+}
+
 // NewSuccessResponse creates a new success response
 func NewSuccessResponse(message string, data interface{}, count ...int) SuccessResponse {
 	resp := SuccessResponse{
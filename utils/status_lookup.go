@@ -102,6 +102,12 @@ func canonicalStatusCode(code string) string {
 	return normalized
 }
 
+// CanonicalStatusCode maps a status code or alias ("approved", "ร่าง", ...) to
+// its canonical StatusCode* value. Unknown codes are returned normalised.
+func CanonicalStatusCode(code string) string {
+	return canonicalStatusCode(code)
+}
+
 func codeCandidates(code string) []string {
 	canonical := canonicalStatusCode(code)
 	seen := make(map[string]struct{})
@@ -160,6 +166,15 @@ func cacheStatus(status models.ApplicationStatus) {
 	}
 }
 
+// ResetStatusCache drops every cached application status so the next lookup
+// reads the table again.
+func ResetStatusCache() {
+	applicationStatusCache.Lock()
+	applicationStatusCache.byCode = make(map[string]models.ApplicationStatus)
+	applicationStatusCache.byID = make(map[int]models.ApplicationStatus)
+	applicationStatusCache.Unlock()
+}
+
 func getCachedStatusByCode(code string) (models.ApplicationStatus, bool) {
 	key := normalizeStatusCode(code)
 	if key == "" {
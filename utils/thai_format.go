@@ -22,21 +22,33 @@ var thaiMonths = []string{
 	"ธันวาคม",
 }
 
-// FormatThaiDate returns the date formatted using Thai month names and Buddhist Era year.
+// FormatThaiDate returns the date in the application timezone, formatted using
+// Thai month names and Buddhist Era year.
 func FormatThaiDate(t time.Time) string {
 	if t.IsZero() {
 		return ""
 	}
+	return formatThaiDateParts(t.In(AppLocation()))
+}
+
+// FormatThaiCalendarDate formats a date-only value (a DATE column) by its own
+// year, month and day, so it is never shifted by a timezone conversion.
+func FormatThaiCalendarDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return formatThaiDateParts(t)
+}
 
-	localTime := t.In(time.Local)
-	monthIndex := int(localTime.Month()) - 1
+func formatThaiDateParts(t time.Time) string {
+	monthIndex := int(t.Month()) - 1
 	if monthIndex < 0 || monthIndex >= len(thaiMonths) {
-		return localTime.Format("02/01/2006")
+		return t.Format("02/01/2006")
 	}
 
-	day := localTime.Day()
+	day := t.Day()
 	monthName := thaiMonths[monthIndex]
-	year := localTime.Year() + 543
+	year := t.Year() + 543
 
 	return strconv.Itoa(day) + " " + monthName + " " + strconv.Itoa(year)
 }
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultAppTimezone is used when APP_TIMEZONE is unset or cannot be loaded.
+const DefaultAppTimezone = "Asia/Bangkok"
+
+var appLocation struct {
+	sync.Mutex
+	loc *time.Location
+}
+
+// AppLocation returns the timezone business dates are interpreted in: cutoff
+// days, date filters and dates shown to users. It is read from APP_TIMEZONE
+// (default Asia/Bangkok). When the zone database is unavailable Asia/Bangkok
+// falls back to a fixed UTC+7 zone, which has no DST to miss.
+func AppLocation() *time.Location {
+	appLocation.Lock()
+	defer appLocation.Unlock()
+
+	if appLocation.loc == nil {
+		appLocation.loc = loadAppLocation(strings.TrimSpace(os.Getenv("APP_TIMEZONE")))
+	}
+	return appLocation.loc
+}
+
+// ResetAppLocation forces the next AppLocation call to re-read APP_TIMEZONE.
+func ResetAppLocation() {
+	appLocation.Lock()
+	appLocation.loc = nil
+	appLocation.Unlock()
+}
+
+func loadAppLocation(name string) *time.Location {
+	if name != "" {
+		loc, err := time.LoadLocation(name)
+		if err == nil {
+			return loc
+		}
+		log.Printf("[timezone] invalid APP_TIMEZONE %q, using %s: %v", name, DefaultAppTimezone, err)
+	}
+	if loc, err := time.LoadLocation(DefaultAppTimezone); err == nil {
+		return loc
+	}
+	return time.FixedZone("ICT", 7*60*60)
+}
+
+// EndOfDayInAppLocation returns the last instant of t's calendar date in the
+// application timezone. The date is taken as stored, so a DATE column read as
+// midnight in any zone keeps its day.
+func EndOfDayInAppLocation(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 23, 59, 59, 999999999, AppLocation())
+}
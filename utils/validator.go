@@ -2,8 +2,12 @@
 package utils
 
 import (
+	"fmt"
+	"os"
 	"regexp"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 // ValidateEmail checks if email is valid
@@ -31,3 +35,78 @@ func SanitizeInput(input string) string {
 
 	return input
 }
+
+// textColumnLimit mirrors a column's size in the schema: MaxChars for
+// VARCHAR(n), MaxBytes for TEXT (65,535 bytes, roughly 21k Thai characters).
+type textColumnLimit struct {
+	MaxChars int
+	MaxBytes int
+}
+
+const textColumnMaxBytes = 65535
+
+// textColumnLimits lists free-text columns keyed by "table.column".
+var textColumnLimits = map[string]textColumnLimit{
+	"fund_application_details.project_title":       {MaxChars: 255},
+	"fund_application_details.project_description": {MaxBytes: textColumnMaxBytes},
+	"publication_reward_details.paper_title":       {MaxChars: 500},
+	"publication_reward_details.journal_name":      {MaxChars: 255},
+	"publication_reward_details.author_name_list":  {MaxBytes: textColumnMaxBytes},
+	"publication_reward_details.signature":         {MaxChars: 255},
+	"fund_subcategories.fund_condition":            {MaxBytes: textColumnMaxBytes},
+}
+
+// TextMaxLength returns the character limit enforced for column. It can be
+// lowered (never raised past the schema) with TEXT_MAX_LENGTH_<COLUMN>, e.g.
+// TEXT_MAX_LENGTH_PROJECT_TITLE=200. Zero means only the byte limit applies.
+func TextMaxLength(table, column string) int {
+	limit := textColumnLimits[table+"."+column]
+	maxChars := limit.MaxChars
+
+	raw := strings.TrimSpace(os.Getenv("TEXT_MAX_LENGTH_" + strings.ToUpper(column)))
+	if configured, err := strconv.Atoi(raw); err == nil && configured > 0 {
+		if maxChars == 0 || configured < maxChars {
+			maxChars = configured
+		}
+	}
+	return maxChars
+}
+
+// TextField is a request value checked against a column's limit. Field is the
+// JSON key reported back to the client; it defaults to Column.
+type TextField struct {
+	Column string
+	Field  string
+	Value  string
+}
+
+// ValidateTextLengths checks fields against the limits for table and returns
+// one FieldError per oversized field, in the order given.
+func ValidateTextLengths(table string, fields ...TextField) []FieldError {
+	var fieldErrors []FieldError
+	for _, f := range fields {
+		if f.Value == "" {
+			continue
+		}
+		name := f.Field
+		if name == "" {
+			name = f.Column
+		}
+
+		if maxChars := TextMaxLength(table, f.Column); maxChars > 0 && utf8.RuneCountInString(f.Value) > maxChars {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:     name,
+				Message:   fmt.Sprintf("%s must be at most %d characters", name, maxChars),
+				MaxLength: maxChars,
+			})
+			continue
+		}
+		if maxBytes := textColumnLimits[table+"."+f.Column].MaxBytes; maxBytes > 0 && len(f.Value) > maxBytes {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:   name,
+				Message: fmt.Sprintf("%s is too long (at most %d bytes)", name, maxBytes),
+			})
+		}
+	}
+	return fieldErrors
+}